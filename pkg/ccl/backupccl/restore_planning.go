@@ -265,6 +265,54 @@ func maybeFilterMissingViews(
 	return filteredTablesByID, nil
 }
 
+// checkNoIncrementalViews returns an error if the set of tables to restore
+// contains a table that an incrementally maintained view depends on. The
+// restored data of the table would not be maintained into the view, and the
+// query that maintains the view refers to the table by its name at backup
+// time. Since a view cannot be restored without its tables, this also rejects
+// the views.
+func checkNoIncrementalViews(tablesByID map[descpb.ID]*tabledesc.Mutable) error {
+	for _, table := range tablesByID {
+		for _, ref := range table.DependedOnBy {
+			if ref.Incremental {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot restore table %q because an incrementally maintained view depends on it",
+					table.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// unrestoredFunctionIDs returns the IDs of the user-defined functions that the
+// restored descriptors refer to. Function descriptors are not included in
+// backups, so these functions are not restored, and the references to them are
+// removed when the descriptors are rewritten.
+func unrestoredFunctionIDs(
+	databases []*dbdesc.Mutable, schemas []*schemadesc.Mutable, tables []*tabledesc.Mutable,
+) catalog.DescriptorIDSet {
+	var ids catalog.DescriptorIDSet
+	addOverloads := func(functions map[string]descpb.SchemaDescriptor_Function) {
+		for _, fn := range functions {
+			for _, o := range fn.Overloads {
+				ids.Add(o.ID)
+			}
+		}
+	}
+	for _, db := range databases {
+		addOverloads(db.PublicSchemaFunctions)
+	}
+	for _, sc := range schemas {
+		addOverloads(sc.Functions)
+	}
+	for _, table := range tables {
+		for _, id := range table.DependedOnByFunctions {
+			ids.Add(id)
+		}
+	}
+	return ids
+}
+
 func synthesizePGTempSchema(
 	ctx context.Context, p sql.PlanHookState, schemaName string, dbID descpb.ID,
 ) (descpb.ID, error) {
@@ -717,15 +765,18 @@ func allocateDescriptorRewrites(
 					// Create a rewrite entry for the type.
 					descriptorRewrites[typ.ID] = &jobspb.RestoreDetails_DescriptorRewrite{ParentID: parentID}
 
-					// Ensure that there isn't a collision with the array type name.
-					arrTyp := typesByID[typ.ArrayTypeID]
-					typeName := tree.NewUnqualifiedTypeName(arrTyp.GetName())
-					err = catalogkv.CheckObjectCollision(ctx, txn, p.ExecCfg().Codec, parentID, getParentSchemaID(typ), typeName)
-					if err != nil {
-						return errors.Wrapf(err, "name collision for %q's array type", typ.Name)
+					// Domains have no array type, so there is nothing more to do.
+					if typ.ArrayTypeID != descpb.InvalidID {
+						// Ensure that there isn't a collision with the array type name.
+						arrTyp := typesByID[typ.ArrayTypeID]
+						typeName := tree.NewUnqualifiedTypeName(arrTyp.GetName())
+						err = catalogkv.CheckObjectCollision(ctx, txn, p.ExecCfg().Codec, parentID, getParentSchemaID(typ), typeName)
+						if err != nil {
+							return errors.Wrapf(err, "name collision for %q's array type", typ.Name)
+						}
+						// Create the rewrite entry for the array type as well.
+						descriptorRewrites[arrTyp.ID] = &jobspb.RestoreDetails_DescriptorRewrite{ParentID: parentID}
 					}
-					// Create the rewrite entry for the array type as well.
-					descriptorRewrites[arrTyp.ID] = &jobspb.RestoreDetails_DescriptorRewrite{ParentID: parentID}
 				} else {
 					// If there was a name collision, we'll try to see if we can remap
 					// this type to the type existing in the cluster.
@@ -753,10 +804,12 @@ func allocateDescriptorRewrites(
 						ID:         existingType.GetID(),
 						ToExisting: true,
 					}
-					descriptorRewrites[typ.ArrayTypeID] = &jobspb.RestoreDetails_DescriptorRewrite{
-						ParentID:   existingType.GetParentID(),
-						ID:         existingType.GetArrayTypeID(),
-						ToExisting: true,
+					if typ.ArrayTypeID != descpb.InvalidID {
+						descriptorRewrites[typ.ArrayTypeID] = &jobspb.RestoreDetails_DescriptorRewrite{
+							ParentID:   existingType.GetParentID(),
+							ID:         existingType.GetArrayTypeID(),
+							ToExisting: true,
+						}
 					}
 				}
 				// If we're restoring to a public schema of database that already exists
@@ -766,7 +819,9 @@ func allocateDescriptorRewrites(
 					typ.GetParentSchemaID() == descpb.InvalidID {
 					publicSchemaID := parentDB.GetSchemaID(tree.PublicSchema)
 					descriptorRewrites[typ.ID].ParentSchemaID = publicSchemaID
-					descriptorRewrites[typ.ArrayTypeID].ParentSchemaID = publicSchemaID
+					if typ.ArrayTypeID != descpb.InvalidID {
+						descriptorRewrites[typ.ArrayTypeID].ParentSchemaID = publicSchemaID
+					}
 				}
 			}
 		}
@@ -1072,6 +1127,8 @@ func rewriteDatabaseDescs(databases []*dbdesc.Mutable, descriptorRewrites DescRe
 			return err
 		}
 		db.Schemas = newSchemas
+		// Functions are not restored, see unrestoredFunctionIDs.
+		db.PublicSchemaFunctions = nil
 	}
 	return nil
 }
@@ -1142,6 +1199,27 @@ func rewriteTypeDescs(types []*typedesc.Mutable, descriptorRewrites DescRewriteM
 			if err := rewriteIDsInTypesT(typ.Alias, descriptorRewrites); err != nil {
 				return err
 			}
+		case descpb.TypeDescriptor_DOMAIN:
+			// Rewrite the ID's present in the base type and in the serialized
+			// expressions of the domain.
+			config := typ.DomainConfig
+			if err := rewriteIDsInTypesT(config.BaseType, descriptorRewrites); err != nil {
+				return err
+			}
+			if config.DefaultExpr != nil {
+				newExpr, err := rewriteTypesInExpr(*config.DefaultExpr, descriptorRewrites)
+				if err != nil {
+					return err
+				}
+				config.DefaultExpr = &newExpr
+			}
+			for i := range config.Checks {
+				newExpr, err := rewriteTypesInExpr(config.Checks[i].Expr, descriptorRewrites)
+				if err != nil {
+					return err
+				}
+				config.Checks[i].Expr = newExpr
+			}
 		default:
 			return errors.AssertionFailedf("unknown type kind %s", t.String())
 		}
@@ -1163,6 +1241,8 @@ func rewriteSchemaDescs(schemas []*schemadesc.Mutable, descriptorRewrites DescRe
 
 		sc.ID = rewrite.ID
 		sc.ParentID = rewrite.ParentID
+		// Functions are not restored, see unrestoredFunctionIDs.
+		sc.Functions = nil
 	}
 	return nil
 }
@@ -1289,6 +1369,8 @@ func RewriteTableDescs(
 				table.DependedOnBy = append(table.DependedOnBy, ref)
 			}
 		}
+		// Functions are not restored, see unrestoredFunctionIDs.
+		table.DependedOnByFunctions = nil
 
 		if table.IsSequence() && table.SequenceOpts.HasOwner() {
 			if ownerRewrite, ok := descriptorRewrites[table.SequenceOpts.SequenceOwner.OwnerTableID]; ok {
@@ -1891,7 +1973,7 @@ func doRestorePlan(
 	for _, m := range mainBackupManifests {
 		spans := roachpb.Spans(m.Spans)
 		for i := range m.Descriptors {
-			table, _, _, _ := descpb.FromDescriptor(&m.Descriptors[i])
+			table, _, _, _, _ := descpb.FromDescriptor(&m.Descriptors[i])
 			if table == nil {
 				continue
 			}
@@ -2023,6 +2105,10 @@ func doRestorePlan(
 		return err
 	}
 
+	if err := checkNoIncrementalViews(filteredTablesByID); err != nil {
+		return err
+	}
+
 	// When running a full cluster restore, we drop the defaultdb and postgres
 	// databases that are present in a new cluster.
 	// This is done so that they can be restored the same way any other user
@@ -2074,6 +2160,12 @@ func doRestorePlan(
 		types = append(types, desc)
 	}
 
+	if fns := unrestoredFunctionIDs(databases, schemas, tables); fns.Len() > 0 {
+		p.BufferClientNotice(ctx, errors.WithDetailf(
+			pgnotice.Newf("%d user-defined functions will not be restored", fns.Len()),
+			"user-defined functions are not included in backups and must be recreated after the restore"))
+	}
+
 	// We attempt to rewrite ID's in the collected type and table descriptors
 	// to catch errors during this process here, rather than in the job itself.
 	if err := RewriteTableDescs(tables, descriptorRewrites, intoDB); err != nil {
@@ -81,6 +81,11 @@ type changeAggregator struct {
 	eventProducer kvevent.Reader
 	// eventConsumer consumes the event.
 	eventConsumer kvEventConsumer
+	// txnBuffer, if non-nil, holds the rows emitted by eventConsumer until they
+	// can be emitted grouped by transaction (the transaction_boundaries option).
+	txnBuffer *txnGroupingBuffer
+	// selector is nil if the changefeed emits every row in full.
+	selector *changefeedSelect
 
 	// lastFlush and flushFrequency keep track of the flush frequency.
 	lastFlush      time.Time
@@ -102,6 +107,9 @@ type timestampLowerBoundOracle interface {
 type changeAggregatorLowerBoundOracle struct {
 	sf                         *span.Frontier
 	initialInclusiveLowerBound hlc.Timestamp
+	// txnBuffer, if non-nil, holds rows which are only emitted once the local
+	// span frontier has reached their timestamp.
+	txnBuffer *txnGroupingBuffer
 }
 
 // inclusiveLowerBoundTs is used to generate a representative timestamp to name
@@ -110,6 +118,15 @@ type changeAggregatorLowerBoundOracle struct {
 // the local span frontier. This convention is chosen to preserve CDC's ordering
 // guarantees. See comment on cloudStorageSink for more details.
 func (o *changeAggregatorLowerBoundOracle) inclusiveLowerBoundTS() hlc.Timestamp {
+	if o.txnBuffer != nil {
+		// Rows held by the txnBuffer are emitted after the frontier has passed
+		// them.
+		if min := o.txnBuffer.minTimestamp(); !min.IsEmpty() {
+			if frontier := o.sf.Frontier(); frontier.IsEmpty() || min.LessEq(frontier) {
+				return min
+			}
+		}
+	}
 	if frontier := o.sf.Frontier(); !frontier.IsEmpty() {
 		// We call `Next()` here on the frontier because this allows us
 		// to name files using a timestamp that is an inclusive lower bound
@@ -271,6 +288,30 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 
 	ca.sink = &errorWrapperSink{wrapped: ca.sink}
 
+	if _, ok := ca.spec.Feed.Opts[changefeedbase.OptTransactionBoundaries]; ok {
+		e, ok := ca.encoder.(*jsonEncoder)
+		if !ok {
+			ca.MoveToDraining(errors.AssertionFailedf(
+				`%s requires a JSON encoder, got %T`, changefeedbase.OptTransactionBoundaries, ca.encoder))
+			ca.cancel()
+			return
+		}
+		ca.txnBuffer = newTxnGroupingBuffer(ca.sink, e)
+		timestampOracle.txnBuffer = ca.txnBuffer
+	}
+
+	// The selector is parsed before the kvfeed is started, since filtering the
+	// rows requires their previous values.
+	if ca.spec.Feed.Opts[changefeedbase.OptFormat] != string(changefeedbase.OptFormatNative) {
+		_, withDiff := ca.spec.Feed.Opts[changefeedbase.OptDiff]
+		ca.selector, err = parseChangefeedSelect(ca.spec.Feed.Select, withDiff, ca.flowCtx.NewEvalCtx())
+		if err != nil {
+			ca.MoveToDraining(err)
+			ca.cancel()
+			return
+		}
+	}
+
 	ca.eventProducer, err = ca.startKVFeed(ctx, spans, initialHighWater, needsInitialScan, ca.sliMetrics)
 	if err != nil {
 		// Early abort in the case that there is an error creating the sink.
@@ -284,7 +325,7 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	} else {
 		ca.eventConsumer = newKVEventToRowConsumer(
 			ctx, ca.flowCtx.Cfg, ca.frontier.SpanFrontier(), initialHighWater,
-			ca.sink, ca.encoder, ca.spec.Feed, ca.knobs)
+			ca.sink, ca.encoder, ca.selector, ca.txnBuffer, ca.spec.Feed, ca.knobs)
 	}
 }
 
@@ -340,6 +381,7 @@ func (ca *changeAggregator) makeKVFeedCfg(
 	schemaChangePolicy := changefeedbase.SchemaChangePolicy(
 		ca.spec.Feed.Opts[changefeedbase.OptSchemaChangePolicy])
 	_, withDiff := ca.spec.Feed.Opts[changefeedbase.OptDiff]
+	withDiff = withDiff || ca.selector.needsPrevValues()
 	cfg := ca.flowCtx.Cfg
 
 	var sf schemafeed.SchemaFeed
@@ -452,6 +494,9 @@ func (ca *changeAggregator) close() {
 	if ca.kvFeedDoneCh != nil {
 		<-ca.kvFeedDoneCh
 	}
+	if ca.txnBuffer != nil {
+		ca.txnBuffer.close(ca.Ctx)
+	}
 	if ca.sink != nil {
 		if err := ca.sink.Close(); err != nil {
 			log.Warningf(ca.Ctx, `error closing sink. goroutines may have leaked: %v`, err)
@@ -544,6 +589,15 @@ func (ca *changeAggregator) noteResolvedSpan(resolved *jobspb.ResolvedSpan) erro
 		return err
 	}
 
+	// Every transaction at or below the frontier has been fully seen by this
+	// changeAggregator, so it can be emitted. This has to happen before the
+	// resolved spans are sent to the changeFrontier.
+	if advanced && ca.txnBuffer != nil {
+		if err := ca.txnBuffer.flush(ca.Ctx, ca.frontier.Frontier()); err != nil {
+			return err
+		}
+	}
+
 	forceFlush := resolved.BoundaryType != jobspb.ResolvedSpan_NONE
 
 	checkpointFrontier := advanced &&
@@ -634,6 +688,10 @@ type kvEventToRowConsumer struct {
 	rfCache   *rowFetcherCache
 	details   jobspb.ChangefeedDetails
 	kvFetcher row.SpanKVFetcher
+	// selector is nil if the changefeed emits every row in full.
+	selector *changefeedSelect
+	// txnBuffer, if non-nil, receives the rows instead of the sink.
+	txnBuffer *txnGroupingBuffer
 }
 
 var _ kvEventConsumer = &kvEventToRowConsumer{}
@@ -645,6 +703,8 @@ func newKVEventToRowConsumer(
 	cursor hlc.Timestamp,
 	sink Sink,
 	encoder Encoder,
+	selector *changefeedSelect,
+	txnBuffer *txnGroupingBuffer,
 	details jobspb.ChangefeedDetails,
 	knobs TestingKnobs,
 ) kvEventConsumer {
@@ -657,13 +717,15 @@ func newKVEventToRowConsumer(
 	)
 
 	return &kvEventToRowConsumer{
-		frontier: frontier,
-		encoder:  encoder,
-		sink:     sink,
-		cursor:   cursor,
-		rfCache:  rfCache,
-		details:  details,
-		knobs:    knobs,
+		frontier:  frontier,
+		encoder:   encoder,
+		sink:      sink,
+		cursor:    cursor,
+		rfCache:   rfCache,
+		details:   details,
+		knobs:     knobs,
+		selector:  selector,
+		txnBuffer: txnBuffer,
 	}
 }
 
@@ -695,13 +757,28 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 			"or equal to the local frontier %s.", r.updated, c.frontier.Frontier())
 		return nil
 	}
+	// The key is always encoded from the full row, so that rows stay keyed by
+	// their primary key no matter how they are projected.
+	valueRow := r
+	if c.selector != nil {
+		var matches bool
+		valueRow, matches, err = c.selector.apply(ctx, r)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			a := ev.DetachAlloc()
+			a.Release(ctx)
+			return nil
+		}
+	}
 	var keyCopy, valueCopy []byte
 	encodedKey, err := c.encoder.EncodeKey(ctx, r)
 	if err != nil {
 		return err
 	}
 	c.scratch, keyCopy = c.scratch.Copy(encodedKey, 0 /* extraCap */)
-	encodedValue, err := c.encoder.EncodeValue(ctx, r)
+	encodedValue, err := c.encoder.EncodeValue(ctx, valueRow)
 	if err != nil {
 		return err
 	}
@@ -712,7 +789,10 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 			return err
 		}
 	}
-	if err := c.sink.EmitRow(
+	if c.txnBuffer != nil {
+		c.txnBuffer.add(tableDescriptorTopic{r.tableDesc},
+			keyCopy, valueCopy, r.updated, r.mvccTimestamp, r.txnID, ev.DetachAlloc())
+	} else if err := c.sink.EmitRow(
 		ctx, tableDescriptorTopic{r.tableDesc},
 		keyCopy, valueCopy, r.updated, r.mvccTimestamp, ev.DetachAlloc(),
 	); err != nil {
@@ -767,6 +847,7 @@ func (c *kvEventToRowConsumer) eventToRow(
 	r.deleted = rf.RowIsDeleted()
 	r.updated = schemaTimestamp
 	r.mvccTimestamp = mvccTimestamp
+	r.txnID = event.TxnID()
 
 	// Assert that we don't get a second row from the row.Fetcher. We
 	// fed it a single KV, so that would be surprising.
@@ -781,7 +862,7 @@ func (c *kvEventToRowConsumer) eventToRow(
 
 	// Get prev value, if necessary.
 	_, withDiff := c.details.Opts[changefeedbase.OptDiff]
-	if withDiff {
+	if withDiff || c.selector.needsPrevValues() {
 		prevRF := rf
 		if prevSchemaTimestamp != schemaTimestamp {
 			// If the previous value is being interpreted under a different
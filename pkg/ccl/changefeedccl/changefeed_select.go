@@ -0,0 +1,418 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// changefeedSelect applies the projection and filter of a
+// `CREATE CHANGEFEED ... AS SELECT` (or `CREATE CHANGEFEED FOR t WHERE`)
+// statement to the rows decoded by the rowFetcherCache, before they are
+// encoded.
+//
+// Each changed row is filtered using its new value. A row whose new value
+// does not match the filter is emitted as a deletion if its previous value
+// matched, so that consumers stop seeing a row which left the filter.
+// Deletions are filtered using the previous value of the row. The previous
+// values are always fetched when there is a filter, and are only emitted with
+// the `diff` option. If the previous value of a row is not known, the row is
+// assumed to have matched.
+//
+// The projection is implemented by handing the encoders a synthesized table
+// descriptor whose public columns are the expressions in the SELECT list.
+// Plain column references keep the ID of the column they refer to, so the
+// primary key can still be found in projected rows.
+type changefeedSelect struct {
+	clause  *tree.SelectClause
+	evalCtx *tree.EvalContext
+	// withDiff is true if the previous values of the rows are emitted (the
+	// `diff` option).
+	withDiff bool
+
+	// TODO(dan): Bound the size of this cache.
+	evaluators map[tableIDAndVersion]*selectEvaluator
+}
+
+// parseChangefeedSelect parses the SELECT statement stored in the changefeed
+// details. It returns nil if the changefeed has no SELECT statement.
+func parseChangefeedSelect(
+	selectStmt string, withDiff bool, evalCtx *tree.EvalContext,
+) (*changefeedSelect, error) {
+	if selectStmt == `` {
+		return nil, nil
+	}
+	clause, err := parseSelectClause(selectStmt)
+	if err != nil {
+		return nil, err
+	}
+	return &changefeedSelect{
+		clause:     clause,
+		evalCtx:    evalCtx,
+		withDiff:   withDiff,
+		evaluators: make(map[tableIDAndVersion]*selectEvaluator),
+	}, nil
+}
+
+// needsPrevValues returns true if the previous values of the rows are needed
+// to filter them, in which case the changefeed must fetch them even without
+// the `diff` option. It can be called on a nil changefeedSelect.
+func (s *changefeedSelect) needsPrevValues() bool {
+	return s != nil && s.clause.Where != nil
+}
+
+func parseSelectClause(selectStmt string) (*tree.SelectClause, error) {
+	stmt, err := parser.ParseOne(selectStmt)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, errors.AssertionFailedf(`expected *tree.Select got %T`, stmt.AST)
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok {
+		return nil, errors.AssertionFailedf(`expected *tree.SelectClause got %T`, sel.Select)
+	}
+	return clause, nil
+}
+
+// apply applies the filter and projection to the given row. It returns false
+// if the row should not be emitted. Otherwise it returns the row to be used to
+// encode the value, which is a deletion if the row left the filter; the key
+// should still be encoded using the original row.
+func (s *changefeedSelect) apply(ctx context.Context, r encodeRow) (encodeRow, bool, error) {
+	e, err := s.evaluatorFor(ctx, r.tableDesc)
+	if err != nil {
+		return encodeRow{}, false, err
+	}
+	var prevE *selectEvaluator
+	if r.prevTableDesc != nil {
+		if prevE, err = s.evaluatorFor(ctx, r.prevTableDesc); err != nil {
+			return encodeRow{}, false, err
+		}
+	}
+
+	deleted := r.deleted
+	if !deleted {
+		matches, err := e.matches(s.evalCtx, r.datums)
+		if err != nil {
+			return encodeRow{}, false, err
+		}
+		if !matches {
+			// The row is emitted as a deletion if it left the filter.
+			if prevMatches, err := s.prevMatches(prevE, r); err != nil || !prevMatches {
+				return encodeRow{}, false, err
+			}
+			deleted = true
+		}
+	} else if prevMatches, err := s.prevMatches(prevE, r); err != nil || !prevMatches {
+		return encodeRow{}, false, err
+	}
+
+	projected := r
+	projected.deleted = deleted
+	projected.tableDesc = e.desc
+	if projected.datums, err = e.project(s.evalCtx, r.datums, deleted); err != nil {
+		return encodeRow{}, false, err
+	}
+	if !s.withDiff {
+		// The previous value was only fetched to filter the row.
+		projected.prevDatums, projected.prevDeleted, projected.prevTableDesc = nil, false, nil
+	} else if prevE != nil {
+		projected.prevTableDesc = prevE.desc
+		if r.prevDatums != nil {
+			projected.prevDatums, err = prevE.project(s.evalCtx, r.prevDatums, r.prevDeleted)
+			if err != nil {
+				return encodeRow{}, false, err
+			}
+		}
+	}
+	return projected, true, nil
+}
+
+// prevMatches returns whether the previous value of the given row passes the
+// filter. It returns true if the previous value is not known, since the row
+// could have matched.
+func (s *changefeedSelect) prevMatches(prevE *selectEvaluator, r encodeRow) (bool, error) {
+	if prevE == nil || r.prevDatums == nil {
+		return true, nil
+	}
+	if r.prevDeleted {
+		return false, nil
+	}
+	return prevE.matches(s.evalCtx, r.prevDatums)
+}
+
+func (s *changefeedSelect) evaluatorFor(
+	ctx context.Context, tableDesc catalog.TableDescriptor,
+) (*selectEvaluator, error) {
+	cacheKey := makeTableIDAndVersion(tableDesc.GetID(), tableDesc.GetVersion())
+	if e, ok := s.evaluators[cacheKey]; ok {
+		return e, nil
+	}
+	e, err := makeSelectEvaluator(ctx, s.evalCtx, s.clause, tableDesc)
+	if err != nil {
+		return nil, err
+	}
+	s.evaluators[cacheKey] = e
+	return e, nil
+}
+
+// selectEvaluator is the projection and filter of a changefeed bound to one
+// version of the target table.
+type selectEvaluator struct {
+	// desc is the projected table descriptor.
+	desc catalog.TableDescriptor
+	// exprs has one expression per public column of desc.
+	exprs []tree.TypedExpr
+	// filter is nil if the SELECT statement has no WHERE clause.
+	filter    tree.TypedExpr
+	container rowContainer
+}
+
+// makeSelectEvaluator type checks the given SELECT clause against the columns
+// of tableDesc. It is used both to validate the statement when the changefeed
+// is created and to evaluate it in the processors.
+func makeSelectEvaluator(
+	ctx context.Context,
+	evalCtx *tree.EvalContext,
+	clause *tree.SelectClause,
+	tableDesc catalog.TableDescriptor,
+) (*selectEvaluator, error) {
+	tn, err := selectTableName(clause)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &selectEvaluator{
+		container: rowContainer{cols: tableDesc.PublicColumns()},
+	}
+	source := colinfo.NewSourceInfoForSingleTable(
+		*tn, colinfo.ResultColumnsFromColumns(tableDesc.GetID(), e.container.cols),
+	)
+	ivarHelper := tree.MakeIndexedVarHelper(&e.container, len(e.container.cols))
+	semaCtx := tree.MakeSemaContext()
+	semaCtx.IVarContainer = &e.container
+	// Rows must be filtered the same way no matter when and where they are
+	// processed, so only immutable expressions are allowed.
+	semaCtx.Properties.Require(`CHANGEFEED`,
+		tree.RejectSpecial|tree.RejectVolatileFunctions|tree.RejectStableOperators)
+
+	resolve := func(expr tree.Expr) (tree.Expr, error) {
+		var v schemaexpr.NameResolutionVisitor
+		return schemaexpr.ResolveNamesUsingVisitor(
+			&v, expr, source, ivarHelper, evalCtx.SessionData().SearchPath,
+		)
+	}
+	typeCheck := func(expr tree.Expr) (tree.TypedExpr, error) {
+		resolved, err := resolve(expr)
+		if err != nil {
+			return nil, err
+		}
+		return tree.TypeCheck(ctx, resolved, &semaCtx, types.Any)
+	}
+
+	if clause.Where != nil {
+		resolved, err := resolve(clause.Where.Expr)
+		if err != nil {
+			return nil, err
+		}
+		filter, err := tree.TypeCheckAndRequire(ctx, resolved, &semaCtx, types.Bool, `WHERE`)
+		if err != nil {
+			return nil, err
+		}
+		e.filter = filter
+	}
+
+	projected := protoutil.Clone(tableDesc.TableDesc()).(*descpb.TableDescriptor)
+	projected.Columns = nil
+	projected.Indexes = nil
+	projected.Mutations = nil
+	projected.Checks = nil
+	nextColumnID := tableDesc.GetNextColumnID()
+	var usedIDs catalog.TableColSet
+	usedNames := make(map[string]struct{})
+	addColumn := func(name string, id descpb.ColumnID, expr tree.TypedExpr) error {
+		if _, ok := usedNames[name]; ok {
+			return pgerror.Newf(pgcode.DuplicateColumn,
+				`column %q specified more than once in changefeed SELECT`, name)
+		}
+		usedNames[name] = struct{}{}
+		if id == 0 || usedIDs.Contains(id) {
+			id = nextColumnID
+			nextColumnID++
+		}
+		usedIDs.Add(id)
+		projected.Columns = append(projected.Columns, descpb.ColumnDescriptor{
+			Name:     name,
+			ID:       id,
+			Type:     expr.ResolvedType(),
+			Nullable: true,
+		})
+		e.exprs = append(e.exprs, expr)
+		return nil
+	}
+
+	for i := range clause.Exprs {
+		selectExpr := clause.Exprs[i]
+		if err := selectExpr.NormalizeTopLevelVarName(); err != nil {
+			return nil, err
+		}
+		switch t := selectExpr.Expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			for colIdx, col := range e.container.cols {
+				if err := addColumn(col.GetName(), col.GetID(), ivarHelper.IndexedVar(colIdx)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		case *tree.ColumnItem:
+			expr, err := typeCheck(t)
+			if err != nil {
+				return nil, err
+			}
+			ivar := expr.(*tree.IndexedVar)
+			col := e.container.cols[ivar.Idx]
+			name := col.GetName()
+			if selectExpr.As != `` {
+				name = string(selectExpr.As)
+			}
+			if err := addColumn(name, col.GetID(), expr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if selectExpr.As == `` {
+			return nil, pgerror.Newf(pgcode.InvalidColumnReference,
+				`expression %s in changefeed SELECT must be named with AS`, tree.AsString(selectExpr.Expr))
+		}
+		expr, err := typeCheck(selectExpr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		if err := addColumn(string(selectExpr.As), 0 /* id */, expr); err != nil {
+			return nil, err
+		}
+	}
+	e.desc = tabledesc.NewBuilder(projected).BuildImmutableTable()
+	return e, nil
+}
+
+// selectTableName returns the name of the single table in the FROM clause of a
+// changefeed SELECT.
+func selectTableName(clause *tree.SelectClause) (*tree.TableName, error) {
+	if len(clause.From.Tables) != 1 {
+		return nil, errors.AssertionFailedf(`expected a single table in changefeed SELECT`)
+	}
+	expr := clause.From.Tables[0]
+	if aliased, ok := expr.(*tree.AliasedTableExpr); ok {
+		expr = aliased.Expr
+	}
+	switch t := expr.(type) {
+	case *tree.TableName:
+		return t, nil
+	case *tree.UnresolvedObjectName:
+		tn := t.ToTableName()
+		return &tn, nil
+	default:
+		return nil, errors.AssertionFailedf(`unexpected %T in changefeed SELECT`, expr)
+	}
+}
+
+// matches returns whether the given row, which has one datum for each public
+// column of the unprojected table, passes the filter.
+func (e *selectEvaluator) matches(evalCtx *tree.EvalContext, row rowenc.EncDatumRow) (bool, error) {
+	if e.filter == nil {
+		return true, nil
+	}
+	d, err := e.eval(evalCtx, e.filter, row)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
+
+// project returns the projected row. For deleted rows, only the primary key
+// columns are guaranteed to be set, so only plain column references are
+// projected and every other expression is NULL.
+func (e *selectEvaluator) project(
+	evalCtx *tree.EvalContext, row rowenc.EncDatumRow, deleted bool,
+) (rowenc.EncDatumRow, error) {
+	projected := make(rowenc.EncDatumRow, len(e.exprs))
+	for i, expr := range e.exprs {
+		if ivar, ok := expr.(*tree.IndexedVar); ok {
+			projected[i] = row[ivar.Idx]
+			continue
+		}
+		if deleted {
+			projected[i] = rowenc.EncDatum{Datum: tree.DNull}
+			continue
+		}
+		d, err := e.eval(evalCtx, expr, row)
+		if err != nil {
+			return nil, err
+		}
+		projected[i] = rowenc.EncDatum{Datum: d}
+	}
+	return projected, nil
+}
+
+func (e *selectEvaluator) eval(
+	evalCtx *tree.EvalContext, expr tree.TypedExpr, row rowenc.EncDatumRow,
+) (tree.Datum, error) {
+	e.container.row = row
+	evalCtx.PushIVarContainer(&e.container)
+	defer evalCtx.PopIVarContainer()
+	return expr.Eval(evalCtx)
+}
+
+// rowContainer is the tree.IndexedVarContainer used to type check and evaluate
+// expressions over the public columns of a table. Datums are only decoded
+// when an expression references them.
+type rowContainer struct {
+	cols  []catalog.Column
+	row   rowenc.EncDatumRow
+	alloc rowenc.DatumAlloc
+}
+
+var _ tree.IndexedVarContainer = &rowContainer{}
+
+// IndexedVarEval implements the tree.IndexedVarContainer interface.
+func (c *rowContainer) IndexedVarEval(idx int, _ *tree.EvalContext) (tree.Datum, error) {
+	if err := c.row[idx].EnsureDecoded(c.cols[idx].GetType(), &c.alloc); err != nil {
+		return nil, err
+	}
+	return c.row[idx].Datum, nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (c *rowContainer) IndexedVarResolvedType(idx int) *types.T {
+	return c.cols[idx].GetType()
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (c *rowContainer) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	n := tree.Name(c.cols[idx].GetName())
+	return &n
+}
@@ -0,0 +1,153 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeedSelect(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := tree.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(ctx)
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT)`)
+	require.NoError(t, err)
+	rows, err := parseValues(tableDesc, `VALUES (1, 'one', 10), (2, 'two', 20)`)
+	require.NoError(t, err)
+
+	datumStrings := func(row rowenc.EncDatumRow) []string {
+		var strs []string
+		for _, d := range row {
+			strs = append(strs, d.Datum.String())
+		}
+		return strs
+	}
+	columnNames := func(r encodeRow) []string {
+		var names []string
+		for _, col := range r.tableDesc.PublicColumns() {
+			names = append(names, col.GetName())
+		}
+		return names
+	}
+
+	t.Run("filter and projection", func(t *testing.T) {
+		s, err := parseChangefeedSelect(`SELECT a, c * 2 AS d FROM foo WHERE c > 15`, false /* withDiff */, evalCtx)
+		require.NoError(t, err)
+
+		_, matches, err := s.apply(ctx, encodeRow{
+			datums: rows[0], tableDesc: tableDesc, prevDeleted: true,
+			prevDatums: rowenc.EncDatumRow{}, prevTableDesc: tableDesc,
+		})
+		require.NoError(t, err)
+		require.False(t, matches)
+
+		projected, matches, err := s.apply(ctx, encodeRow{datums: rows[1], tableDesc: tableDesc})
+		require.NoError(t, err)
+		require.True(t, matches)
+		require.Equal(t, []string{`a`, `d`}, columnNames(projected))
+		require.Equal(t, []string{`2`, `40`}, datumStrings(projected.datums))
+		// Plain column references keep their column IDs.
+		require.Equal(t, tableDesc.PublicColumns()[0].GetID(), projected.tableDesc.PublicColumns()[0].GetID())
+	})
+
+	t.Run("star", func(t *testing.T) {
+		s, err := parseChangefeedSelect(`SELECT * FROM foo WHERE b = 'one'`, false /* withDiff */, evalCtx)
+		require.NoError(t, err)
+		projected, matches, err := s.apply(ctx, encodeRow{datums: rows[0], tableDesc: tableDesc})
+		require.NoError(t, err)
+		require.True(t, matches)
+		require.Equal(t, []string{`a`, `b`, `c`}, columnNames(projected))
+	})
+
+	t.Run("deletes", func(t *testing.T) {
+		s, err := parseChangefeedSelect(`SELECT a, c + 1 AS d FROM foo WHERE c > 15`, true /* withDiff */, evalCtx)
+		require.NoError(t, err)
+		deleted := rowenc.EncDatumRow{rows[0][0], {Datum: tree.DNull}, {Datum: tree.DNull}}
+
+		// Without the previous row, deletes are always emitted.
+		projected, matches, err := s.apply(ctx, encodeRow{
+			datums: deleted, deleted: true, tableDesc: tableDesc,
+		})
+		require.NoError(t, err)
+		require.True(t, matches)
+		require.Equal(t, []string{`1`, `NULL`}, datumStrings(projected.datums))
+
+		// With the previous row, deletes are filtered on it.
+		_, matches, err = s.apply(ctx, encodeRow{
+			datums: deleted, deleted: true, tableDesc: tableDesc,
+			prevDatums: rows[0], prevTableDesc: tableDesc,
+		})
+		require.NoError(t, err)
+		require.False(t, matches)
+	})
+
+	t.Run("updates", func(t *testing.T) {
+		s, err := parseChangefeedSelect(`SELECT a, c FROM foo WHERE c > 15`, false /* withDiff */, evalCtx)
+		require.NoError(t, err)
+		left := rowenc.EncDatumRow{rows[1][0], rows[1][1], {Datum: tree.NewDInt(5)}}
+
+		// A row which leaves the filter is emitted as a deletion.
+		projected, matches, err := s.apply(ctx, encodeRow{
+			datums: left, tableDesc: tableDesc,
+			prevDatums: rows[1], prevTableDesc: tableDesc,
+		})
+		require.NoError(t, err)
+		require.True(t, matches)
+		require.True(t, projected.deleted)
+		require.Equal(t, []string{`2`, `5`}, datumStrings(projected.datums))
+		// The previous value is not emitted without the diff option.
+		require.Nil(t, projected.prevDatums)
+
+		// A row which did not match before and does not match now is filtered.
+		_, matches, err = s.apply(ctx, encodeRow{
+			datums: left, tableDesc: tableDesc,
+			prevDatums: rows[0], prevTableDesc: tableDesc,
+		})
+		require.NoError(t, err)
+		require.False(t, matches)
+
+		// A row which enters the filter is emitted.
+		projected, matches, err = s.apply(ctx, encodeRow{
+			datums: rows[1], tableDesc: tableDesc,
+			prevDatums: rows[0], prevTableDesc: tableDesc,
+		})
+		require.NoError(t, err)
+		require.True(t, matches)
+		require.False(t, projected.deleted)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for stmt, expected := range map[string]string{
+			`SELECT a + 1 FROM foo`:                  `must be named with AS`,
+			`SELECT a, c AS a FROM foo`:              `specified more than once`,
+			`SELECT a FROM foo WHERE b`:              `argument of WHERE must be type bool`,
+			`SELECT a FROM foo WHERE random() > 0.5`: `volatile functions are not allowed in CHANGEFEED`,
+			`SELECT now() AS n FROM foo`:             `not allowed in CHANGEFEED`,
+			`SELECT missing FROM foo`:                `column "missing" does not exist`,
+		} {
+			clause, err := parseSelectClause(stmt)
+			require.NoError(t, err)
+			_, err = makeSelectEvaluator(ctx, evalCtx, clause, tableDesc)
+			require.Error(t, err, stmt)
+			require.Regexp(t, expected, err.Error(), stmt)
+		}
+	})
+}
@@ -319,12 +319,35 @@ func changefeedPlanHook(
 			return err
 		}
 
-		if isCloudStorageSink(parsedSink) || isWebhookSink(parsedSink) {
+		if isCloudStorageSink(parsedSink) || isWebhookSink(parsedSink) || isNATSSink(parsedSink) {
 			details.Opts[changefeedbase.OptKeyInValue] = ``
 		}
 		if isWebhookSink(parsedSink) {
 			details.Opts[changefeedbase.OptTopicInValue] = ``
 		}
+		// The postgres sink applies the changes to the target tables when a
+		// resolved timestamp is emitted, so it always needs them.
+		if _, ok := details.Opts[changefeedbase.OptResolvedTimestamps]; !ok && isPostgresSink(parsedSink) {
+			details.Opts[changefeedbase.OptResolvedTimestamps] = ``
+		}
+
+		if changefeedStmt.Select != nil || changefeedStmt.Where != nil {
+			if details.Select, err = validateChangefeedSelect(
+				ctx, p, changefeedStmt, targetDescs, details.Opts,
+			); err != nil {
+				return err
+			}
+		}
+
+		if changefeedbase.FormatType(details.Opts[changefeedbase.OptFormat]) == changefeedbase.OptFormatParquet {
+			if !isCloudStorageSink(parsedSink) {
+				return errors.Errorf(`%s=%s is only usable with cloud storage sinks`,
+					changefeedbase.OptFormat, changefeedbase.OptFormatParquet)
+			}
+			if err := validateParquetTargets(targetDescs); err != nil {
+				return err
+			}
+		}
 
 		if !unspecifiedSink && p.ExecCfg().ExternalIODirConfig.DisableOutbound {
 			return errors.Errorf("Outbound IO is disabled by configuration, cannot create changefeed into %s", parsedSink.Scheme)
@@ -499,6 +522,8 @@ func changefeedJobDescription(
 	c := &tree.CreateChangefeed{
 		Targets: changefeed.Targets,
 		SinkURI: tree.NewDString(cleanedSinkURI),
+		Select:  changefeed.Select,
+		Where:   changefeed.Where,
 	}
 	for k, v := range opts {
 		if k == changefeedbase.OptWebhookAuthHeader {
@@ -515,6 +540,65 @@ func changefeedJobDescription(
 	return tree.AsStringWithFQNames(c, ann), nil
 }
 
+// validateChangefeedSelect checks the projection and filter of a changefeed
+// against its target table and returns the SELECT statement to be stored in
+// the job details.
+func validateChangefeedSelect(
+	ctx context.Context,
+	p sql.PlanHookState,
+	changefeedStmt *tree.CreateChangefeed,
+	targetDescs []catalog.Descriptor,
+	opts map[string]string,
+) (string, error) {
+	if len(changefeedStmt.Targets.Tables) != 1 || len(targetDescs) != 1 {
+		return ``, errors.Errorf(`CHANGEFEED with a projection or filter must target exactly one table`)
+	}
+	switch format := changefeedbase.FormatType(opts[changefeedbase.OptFormat]); format {
+	case changefeedbase.OptFormatNative, changefeedbase.OptFormatParquet:
+		return ``, errors.Errorf(`%s=%s does not support a projection or filter`,
+			changefeedbase.OptFormat, format)
+	}
+	table, ok := targetDescs[0].(catalog.TableDescriptor)
+	if !ok {
+		return ``, errors.Errorf(`CHANGEFEED cannot target %s`, tree.AsString(&changefeedStmt.Targets))
+	}
+	pattern, err := changefeedStmt.Targets.Tables[0].NormalizeTablePattern()
+	if err != nil {
+		return ``, err
+	}
+	tn, ok := pattern.(*tree.TableName)
+	if !ok {
+		return ``, errors.Errorf(`CHANGEFEED cannot target %s`, tree.AsString(pattern))
+	}
+
+	clause := &tree.SelectClause{
+		Exprs: changefeedStmt.Select,
+		From:  tree.From{Tables: tree.TableExprs{tn}},
+		Where: changefeedStmt.Where,
+	}
+	if len(clause.Exprs) == 0 {
+		clause.Exprs = tree.SelectExprs{tree.StarSelectExpr()}
+	}
+	e, err := makeSelectEvaluator(ctx, &p.ExtendedEvalContext().EvalContext, clause, table)
+	if err != nil {
+		return ``, err
+	}
+
+	// The key of every row is encoded from the unprojected row, but the value
+	// can only carry the key if all of the primary key columns are projected.
+	if _, ok := opts[changefeedbase.OptKeyInValue]; ok {
+		for _, colID := range table.GetPrimaryIndex().IndexDesc().KeyColumnIDs {
+			if _, err := e.desc.FindColumnWithID(colID); err != nil {
+				col, _ := table.FindColumnWithID(colID)
+				return ``, errors.Errorf(
+					`primary key column %q must be projected when using option %s`,
+					col.GetName(), changefeedbase.OptKeyInValue)
+			}
+		}
+	}
+	return tree.AsString(&tree.Select{Select: clause}), nil
+}
+
 // validateNonNegativeDuration returns a nil error if optValue can be
 // parsed as a duration and is non-negative; otherwise, an error is
 // returned.
@@ -604,13 +688,22 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 		switch v := changefeedbase.FormatType(details.Opts[opt]); v {
 		case ``, changefeedbase.OptFormatJSON:
 			details.Opts[opt] = string(changefeedbase.OptFormatJSON)
-		case changefeedbase.OptFormatAvro, changefeedbase.DeprecatedOptFormatAvro:
+		case changefeedbase.OptFormatAvro, changefeedbase.DeprecatedOptFormatAvro,
+			changefeedbase.OptFormatProtobuf, changefeedbase.OptFormatParquet:
 			// No-op.
 		default:
 			return jobspb.ChangefeedDetails{}, errors.Errorf(
 				`unknown %s: %s`, opt, v)
 		}
 	}
+	{
+		const opt = changefeedbase.OptTransactionBoundaries
+		if _, ok := details.Opts[opt]; ok &&
+			changefeedbase.FormatType(details.Opts[changefeedbase.OptFormat]) != changefeedbase.OptFormatJSON {
+			return jobspb.ChangefeedDetails{}, errors.Errorf(
+				`%s is only usable with %s=%s`, opt, changefeedbase.OptFormat, changefeedbase.OptFormatJSON)
+		}
+	}
 	{
 		const opt = changefeedbase.OptOnError
 		switch v := changefeedbase.OnErrorType(details.Opts[opt]); v {
@@ -1522,7 +1522,7 @@ func fetchDescVersionModificationTime(
 			if err := value.GetProto(&desc); err != nil {
 				t.Fatal(err)
 			}
-			if tableDesc, _, _, _ := descpb.FromDescriptorWithMVCCTimestamp(&desc, k.Timestamp); tableDesc != nil {
+			if tableDesc, _, _, _, _ := descpb.FromDescriptorWithMVCCTimestamp(&desc, k.Timestamp); tableDesc != nil {
 				if int(tableDesc.Version) == version {
 					return tableDesc.ModificationTime
 				}
@@ -3169,6 +3169,13 @@ func TestChangefeedErrors(t *testing.T) {
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH topic_in_value, format='experimental_avro'`,
 		`kafka://nope`,
 	)
+	// Kafka spreads the rows of a topic across partitions, so their boundary
+	// markers cannot surround them.
+	sqlDB.ExpectErr(
+		t, `this sink is incompatible with option transaction_boundaries`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH transaction_boundaries`,
+		`kafka://nope`,
+	)
 
 	// The cloudStorageSink is particular about the options it will work with.
 	sqlDB.ExpectErr(
@@ -50,6 +50,7 @@ const (
 	OptWebhookClientTimeout     = `webhook_client_timeout`
 	OptOnError                  = `on_error`
 	OptMetricsScope             = `metrics_label`
+	OptTransactionBoundaries    = `transaction_boundaries`
 
 	// OptSchemaChangeEventClassColumnChange corresponds to all schema change
 	// events which add or remove any column.
@@ -90,8 +91,10 @@ const (
 	OptEnvelopeDeprecatedRow EnvelopeType = `deprecated_row`
 	OptEnvelopeWrapped       EnvelopeType = `wrapped`
 
-	OptFormatJSON FormatType = `json`
-	OptFormatAvro FormatType = `avro`
+	OptFormatJSON     FormatType = `json`
+	OptFormatAvro     FormatType = `avro`
+	OptFormatProtobuf FormatType = `protobuf`
+	OptFormatParquet  FormatType = `parquet`
 
 	OptFormatNative FormatType = `native`
 
@@ -109,6 +112,10 @@ const (
 	// OptKafkaSinkConfig is a JSON configuration for kafka sink (kafkaSinkConfig).
 	OptKafkaSinkConfig   = `kafka_sink_config`
 	OptWebhookSinkConfig = `webhook_sink_config`
+	// OptPulsarSinkConfig is a JSON configuration for pulsar sink (pulsarSinkConfig).
+	OptPulsarSinkConfig = `pulsar_sink_config`
+	// OptNATSSinkConfig is a JSON configuration for nats sink (natsSinkConfig).
+	OptNATSSinkConfig = `nats_sink_config`
 
 	SinkParamCACert                 = `ca_cert`
 	SinkParamClientCert             = `client_cert`
@@ -130,7 +137,12 @@ const (
 	SinkSchemeHTTP                  = `http`
 	SinkSchemeHTTPS                 = `https`
 	SinkSchemeKafka                 = `kafka`
+	SinkSchemeNATS                  = `nats`
 	SinkSchemeNull                  = `null`
+	SinkSchemePostgres              = `postgres`
+	SinkSchemePostgresql            = `postgresql`
+	SinkSchemePulsar                = `pulsar`
+	SinkSchemePulsarTLS             = `pulsar+ssl`
 	SinkSchemeWebhookHTTP           = `webhook-http`
 	SinkSchemeWebhookHTTPS          = `webhook-https`
 	SinkParamSASLEnabled            = `sasl_enabled`
@@ -166,10 +178,13 @@ var ChangefeedOptionExpectValues = map[string]sql.KVStringOptValidate{
 	OptProtectDataFromGCOnPause: sql.KVStringOptRequireNoValue,
 	OptKafkaSinkConfig:          sql.KVStringOptRequireValue,
 	OptWebhookSinkConfig:        sql.KVStringOptRequireValue,
+	OptPulsarSinkConfig:         sql.KVStringOptRequireValue,
+	OptNATSSinkConfig:           sql.KVStringOptRequireValue,
 	OptWebhookAuthHeader:        sql.KVStringOptRequireValue,
 	OptWebhookClientTimeout:     sql.KVStringOptRequireValue,
 	OptOnError:                  sql.KVStringOptRequireValue,
 	OptMetricsScope:             sql.KVStringOptRequireValue,
+	OptTransactionBoundaries:    sql.KVStringOptRequireNoValue,
 }
 
 func makeStringSet(opts ...string) map[string]struct{} {
@@ -194,11 +209,19 @@ var CommonOptions = makeStringSet(OptCursor, OptEnvelope,
 // SQLValidOptions is options exclusive to SQL sink
 var SQLValidOptions map[string]struct{} = nil
 
+// PostgresValidOptions is options exclusive to postgres sink
+var PostgresValidOptions = makeStringSet()
+
 // KafkaValidOptions is options exclusive to Kafka sink
 var KafkaValidOptions = makeStringSet(OptAvroSchemaPrefix, OptConfluentSchemaRegistry, OptKafkaSinkConfig)
 
 // CloudStorageValidOptions is options exclusive to cloud storage sink
-var CloudStorageValidOptions = makeStringSet(OptCompression)
+//
+// OptTransactionBoundaries is only valid for the sinks which keep the order of
+// the rows of a topic, so that the rows of a transaction stay between its
+// boundary markers. Sinks such as Kafka distribute the rows of a topic across
+// partitions by key.
+var CloudStorageValidOptions = makeStringSet(OptCompression, OptTransactionBoundaries)
 
 // WebhookValidOptions is options exclusive to webhook sink
 var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTimeout, OptWebhookSinkConfig)
@@ -206,6 +229,12 @@ var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTi
 // PubsubValidOptions is options exclusice to pubsub sink
 var PubsubValidOptions = makeStringSet()
 
+// PulsarValidOptions is options exclusive to pulsar sink
+var PulsarValidOptions = makeStringSet(OptPulsarSinkConfig)
+
+// NATSValidOptions is options exclusive to nats sink
+var NATSValidOptions = makeStringSet(OptNATSSinkConfig)
+
 // CaseInsensitiveOpts options which supports case Insensitive value
 var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents, OptSchemaChangePolicy, OptOnError)
 
@@ -11,6 +11,7 @@ package changefeeddist
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -50,9 +51,17 @@ func StartDistChangefeed(
 		execCtx.ExecCfg().Codec.ForSystemTenant() /* distribute */)
 
 	var spanPartitions []sql.SpanPartition
+	_, groupByTxn := details.Opts[changefeedbase.OptTransactionBoundaries]
 	if details.SinkURI == `` {
 		// Sinkless feeds get one ChangeAggregator on the gateway.
 		spanPartitions = []sql.SpanPartition{{Node: dsp.GatewayID(), Spans: trackedSpans}}
+	} else if groupByTxn {
+		// Feeds that group rows by transaction also get one ChangeAggregator on
+		// the gateway, next to the ChangeFrontier. It sees every row of each
+		// transaction, and its local frontier is the frontier of the feed, so it
+		// can group the rows that the ChangeFrontier would otherwise receive from
+		// several aggregators.
+		spanPartitions = []sql.SpanPartition{{Node: dsp.GatewayID(), Spans: trackedSpans}}
 	} else {
 		// All other feeds get a ChangeAggregator local on the leaseholder.
 		var err error
@@ -26,7 +26,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 const (
@@ -60,6 +64,9 @@ type encodeRow struct {
 	// prevTableDesc is a TableDescriptor for the table containing `prevDatums`.
 	// It's valid for interpreting the row at `updated.Prev()`.
 	prevTableDesc catalog.TableDescriptor
+	// txnID is the ID of the transaction which committed the row, if it is
+	// known. See kvevent.Event.TxnID.
+	txnID uuid.UUID
 }
 
 // Encoder turns a row into a serialized changefeed key, value, or resolved
@@ -87,6 +94,10 @@ func getEncoder(opts map[string]string, targets jobspb.ChangefeedTargets) (Encod
 		return makeJSONEncoder(opts, targets)
 	case changefeedbase.OptFormatAvro, changefeedbase.DeprecatedOptFormatAvro:
 		return newConfluentAvroEncoder(opts, targets)
+	case changefeedbase.OptFormatProtobuf:
+		return newProtobufEncoder(opts, targets)
+	case changefeedbase.OptFormatParquet:
+		return newParquetEncoder(opts, targets)
 	case changefeedbase.OptFormatNative:
 		return &nativeEncoder{}, nil
 	default:
@@ -99,7 +110,7 @@ func getEncoder(opts map[string]string, targets jobspb.ChangefeedTargets) (Encod
 // to its value. Updated timestamps in rows and resolved timestamp payloads are
 // stored in a sub-object under the `__crdb__` key in the top-level JSON object.
 type jsonEncoder struct {
-	updatedField, mvccTimestampField, beforeField, wrapped, keyOnly, keyInValue, topicInValue bool
+	updatedField, mvccTimestampField, beforeField, wrapped, keyOnly, keyInValue, topicInValue, txnField bool
 
 	targets jobspb.ChangefeedTargets
 	alloc   rowenc.DatumAlloc
@@ -133,6 +144,11 @@ func makeJSONEncoder(
 		return nil, errors.Errorf(`%s is only usable with %s=%s`,
 			changefeedbase.OptTopicInValue, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
 	}
+	_, e.txnField = opts[changefeedbase.OptTransactionBoundaries]
+	if e.txnField && !e.wrapped {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			changefeedbase.OptTransactionBoundaries, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
 	return e, nil
 }
 
@@ -265,6 +281,9 @@ func (e *jsonEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, err
 			}
 			jsonEntries[`topic`] = topicEntry
 		}
+		if e.txnField {
+			jsonEntries[`txn_id`] = txnIDToJSON(row.txnID)
+		}
 	} else {
 		jsonEntries = after
 	}
@@ -312,6 +331,41 @@ func (e *jsonEncoder) EncodeResolvedTimestamp(
 	return gojson.Marshal(jsonEntries)
 }
 
+// EncodeTransactionBoundary encodes the key and value of a transaction
+// boundary marker, which is emitted to every topic touched by a transaction
+// before and after its rows when the transaction_boundaries option is used.
+// The returned bytes are only valid until the next call to Encode*.
+func (e *jsonEncoder) EncodeTransactionBoundary(
+	_ context.Context, boundary txnBoundary,
+) (key []byte, value []byte, err error) {
+	txnID := txnIDToJSON(boundary.txnID)
+	mvccTimestamp := boundary.mvccTimestamp.AsOfSystemTime()
+	if key, err = gojson.Marshal([]interface{}{mvccTimestamp, txnID}); err != nil {
+		return nil, nil, err
+	}
+	txn := map[string]interface{}{
+		`boundary`:       boundary.typ,
+		`id`:             txnID,
+		`mvcc_timestamp`: mvccTimestamp,
+	}
+	if boundary.typ == txnBoundaryCommit {
+		txn[`rows`] = boundary.rows
+	}
+	if value, err = gojson.Marshal(map[string]interface{}{`transaction`: txn}); err != nil {
+		return nil, nil, err
+	}
+	return key, value, nil
+}
+
+// txnIDToJSON returns the JSON representation of a transaction ID, which is
+// null if the ID is not known.
+func txnIDToJSON(txnID uuid.UUID) interface{} {
+	if txnID == (uuid.UUID{}) {
+		return nil
+	}
+	return txnID.String()
+}
+
 // confluentAvroEncoder encodes changefeed entries as Avro's binary or textual
 // JSON format. Keys are the primary key columns in a record. Values are all
 // columns in a record.
@@ -557,6 +611,194 @@ func (e *confluentAvroEncoder) register(
 	return e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, schema.codec.Schema())
 }
 
+// protobufEncoder encodes changefeed entries as protobuf messages, built from
+// the table descriptors as described in protobuf.go. Keys are a message with
+// the primary key columns. Values are either a message with every column
+// (envelope=row) or an envelope message wrapping it along with the requested
+// metadata (envelope=wrapped).
+type protobufEncoder struct {
+	updatedField, mvccTimestampField, beforeField, wrapped, keyOnly, keyInValue, topicInValue bool
+
+	targets jobspb.ChangefeedTargets
+	alloc   rowenc.DatumAlloc
+	buf     []byte
+
+	keyCache      map[tableIDAndVersion]*protobufDataRecord
+	rowCache      map[tableIDAndVersion]*protobufDataRecord
+	envelopeCache map[tableIDAndVersionPair]*protobufEnvelopeRecord
+	resolved      protoreflect.MessageDescriptor
+}
+
+var _ Encoder = &protobufEncoder{}
+
+func newProtobufEncoder(
+	opts map[string]string, targets jobspb.ChangefeedTargets,
+) (*protobufEncoder, error) {
+	e := &protobufEncoder{
+		targets: targets,
+		keyOnly: changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]) == changefeedbase.OptEnvelopeKeyOnly,
+		wrapped: changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]) == changefeedbase.OptEnvelopeWrapped,
+	}
+	_, e.updatedField = opts[changefeedbase.OptUpdatedTimestamps]
+	_, e.mvccTimestampField = opts[changefeedbase.OptMVCCTimestamps]
+	if (e.updatedField || e.mvccTimestampField) && !e.wrapped {
+		// Unlike JSON, there is no room for a metadata field in the row
+		// message itself.
+		opt := changefeedbase.OptUpdatedTimestamps
+		if !e.updatedField {
+			opt = changefeedbase.OptMVCCTimestamps
+		}
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			opt, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+	_, e.beforeField = opts[changefeedbase.OptDiff]
+	if e.beforeField && !e.wrapped {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			changefeedbase.OptDiff, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+	_, e.keyInValue = opts[changefeedbase.OptKeyInValue]
+	if e.keyInValue && !e.wrapped {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			changefeedbase.OptKeyInValue, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+	_, e.topicInValue = opts[changefeedbase.OptTopicInValue]
+	if e.topicInValue && !e.wrapped {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			changefeedbase.OptTopicInValue, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+
+	resolved, err := resolvedToProtobufMessage()
+	if err != nil {
+		return nil, err
+	}
+	e.resolved = resolved
+	e.keyCache = make(map[tableIDAndVersion]*protobufDataRecord)
+	e.rowCache = make(map[tableIDAndVersion]*protobufDataRecord)
+	e.envelopeCache = make(map[tableIDAndVersionPair]*protobufEnvelopeRecord)
+	return e, nil
+}
+
+func (e *protobufEncoder) marshal(msg protoreflect.ProtoMessage) ([]byte, error) {
+	var err error
+	e.buf, err = proto.MarshalOptions{Deterministic: true}.MarshalAppend(e.buf[:0], msg)
+	return e.buf, err
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *protobufEncoder) EncodeKey(_ context.Context, row encodeRow) ([]byte, error) {
+	cacheKey := makeTableIDAndVersion(row.tableDesc.GetID(), row.tableDesc.GetVersion())
+	record, ok := e.keyCache[cacheKey]
+	if !ok {
+		var err error
+		name := SQLNameToAvroName(row.tableDesc.GetName()) + `_key`
+		record, err = indexToProtobufRecord(row.tableDesc, row.tableDesc.GetPrimaryIndex(), name)
+		if err != nil {
+			return nil, err
+		}
+		// TODO(dan): Bound the size of this cache.
+		e.keyCache[cacheKey] = record
+	}
+	msg := record.newMessage()
+	if err := record.fillFromRow(msg, row.datums, &e.alloc); err != nil {
+		return nil, err
+	}
+	return e.marshal(msg)
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *protobufEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, error) {
+	if e.keyOnly || (!e.wrapped && row.deleted) {
+		return nil, nil
+	}
+
+	if !e.wrapped {
+		cacheKey := makeTableIDAndVersion(row.tableDesc.GetID(), row.tableDesc.GetVersion())
+		record, ok := e.rowCache[cacheKey]
+		if !ok {
+			var err error
+			record, err = tableToProtobufRecord(row.tableDesc, SQLNameToAvroName(row.tableDesc.GetName()))
+			if err != nil {
+				return nil, err
+			}
+			// TODO(dan): Bound the size of this cache.
+			e.rowCache[cacheKey] = record
+		}
+		msg := record.newMessage()
+		if err := record.fillFromRow(msg, row.datums, &e.alloc); err != nil {
+			return nil, err
+		}
+		return e.marshal(msg)
+	}
+
+	var cacheKey tableIDAndVersionPair
+	if e.beforeField && row.prevTableDesc != nil {
+		cacheKey[0] = makeTableIDAndVersion(row.prevTableDesc.GetID(), row.prevTableDesc.GetVersion())
+	}
+	cacheKey[1] = makeTableIDAndVersion(row.tableDesc.GetID(), row.tableDesc.GetVersion())
+	record, ok := e.envelopeCache[cacheKey]
+	if !ok {
+		opts := protobufEnvelopeOpts{
+			beforeField:        e.beforeField && row.prevTableDesc != nil,
+			updatedField:       e.updatedField,
+			mvccTimestampField: e.mvccTimestampField,
+			keyField:           e.keyInValue,
+			topicField:         e.topicInValue,
+		}
+		var err error
+		record, err = envelopeToProtobufRecord(row.tableDesc, row.prevTableDesc, opts)
+		if err != nil {
+			return nil, err
+		}
+		// TODO(dan): Bound the size of this cache.
+		e.envelopeCache[cacheKey] = record
+	}
+
+	msg := dynamicpb.NewMessage(record.desc)
+	if !row.deleted {
+		after := msg.Mutable(record.afterFD).Message()
+		if err := record.after.fillFromRow(after, row.datums, &e.alloc); err != nil {
+			return nil, err
+		}
+	}
+	if record.before != nil && row.prevDatums != nil && !row.prevDeleted {
+		before := msg.Mutable(record.beforeFD).Message()
+		if err := record.before.fillFromRow(before, row.prevDatums, &e.alloc); err != nil {
+			return nil, err
+		}
+	}
+	if record.opts.updatedField {
+		msg.Set(record.updatedFD, protoreflect.ValueOfString(row.updated.AsOfSystemTime()))
+	}
+	if record.opts.mvccTimestampField {
+		msg.Set(record.mvccFD, protoreflect.ValueOfString(row.mvccTimestamp.AsOfSystemTime()))
+	}
+	if record.key != nil {
+		key := msg.Mutable(record.keyFD).Message()
+		if err := record.key.fillFromRow(key, row.datums, &e.alloc); err != nil {
+			return nil, err
+		}
+	}
+	if record.opts.topicField {
+		topic, ok := e.targets[row.tableDesc.GetID()]
+		if !ok {
+			return nil, fmt.Errorf("table with name %s and descriptor ID %d not found in changefeed target list",
+				row.tableDesc.GetName(), row.tableDesc.GetID())
+		}
+		msg.Set(record.topicFD, protoreflect.ValueOfString(topic.StatementTimeName))
+	}
+	return e.marshal(msg)
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *protobufEncoder) EncodeResolvedTimestamp(
+	_ context.Context, _ string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	msg := dynamicpb.NewMessage(e.resolved)
+	msg.Set(e.resolved.Fields().ByNumber(1),
+		protoreflect.ValueOfString(tree.TimestampToDecimalDatum(resolved).Decimal.String()))
+	return e.marshal(msg)
+}
+
 // nativeEncoder only implements EncodeResolvedTimestamp.
 // Unfortunately, the encoder assumes that it operates with encodeRow -- something
 // that's just not the case when emitting raw KVs.
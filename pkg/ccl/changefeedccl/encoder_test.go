@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach-go/v2/crdb"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
@@ -29,6 +30,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/workload/ledger"
 	"github.com/cockroachdb/cockroach/pkg/workload/workloadsql"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 func TestEncoders(t *testing.T) {
@@ -826,3 +830,137 @@ func TestAvroLedger(t *testing.T) {
 
 	t.Run(`kafka`, kafkaTest(testFn))
 }
+
+func TestProtobufEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (
+		a INT PRIMARY KEY, b STRING, c DECIMAL, d INTERVAL, e INT[], f TIMESTAMPTZ, g JSONB
+	)`)
+	require.NoError(t, err)
+	rows, err := parseValues(tableDesc, `VALUES
+		(1, 'bar', 1.2300, '1 mon 2 days 00:00:03', ARRAY[4, 5], '2021-01-02 03:04:05.678+00', '{"x": 1}'),
+		(2, NULL, NULL, NULL, NULL, NULL, NULL)`)
+	require.NoError(t, err)
+	targets := jobspb.ChangefeedTargets{
+		tableDesc.GetID(): jobspb.ChangefeedTarget{StatementTimeName: tableDesc.GetName()},
+	}
+	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
+	cacheKey := makeTableIDAndVersion(tableDesc.GetID(), tableDesc.GetVersion())
+
+	decode := func(desc protoreflect.MessageDescriptor, buf []byte) *dynamicpb.Message {
+		msg := dynamicpb.NewMessage(desc)
+		require.NoError(t, proto.Unmarshal(buf, msg))
+		return msg
+	}
+	field := func(msg protoreflect.Message, name string) protoreflect.Value {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		require.NotNil(t, fd, name)
+		return msg.Get(fd)
+	}
+	has := func(msg protoreflect.Message, name string) bool {
+		return msg.Has(msg.Descriptor().Fields().ByName(protoreflect.Name(name)))
+	}
+
+	t.Run(`row`, func(t *testing.T) {
+		e, err := getEncoder(map[string]string{
+			changefeedbase.OptFormat:   string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeRow),
+		}, targets)
+		require.NoError(t, err)
+		pe := e.(*protobufEncoder)
+
+		row := encodeRow{datums: rows[0], updated: ts, tableDesc: tableDesc}
+		keyBuf, err := e.EncodeKey(context.Background(), row)
+		require.NoError(t, err)
+		key := decode(pe.keyCache[cacheKey].desc, keyBuf)
+		require.Equal(t, int64(1), field(key, `a`).Int())
+
+		valueBuf, err := e.EncodeValue(context.Background(), row)
+		require.NoError(t, err)
+		value := decode(pe.rowCache[cacheKey].desc, valueBuf)
+		require.Equal(t, `bar`, field(value, `b`).String())
+		require.Equal(t, `1.2300`, field(value, `c`).String())
+		interval := field(value, `d`).Message()
+		require.Equal(t, int64(1), field(interval, `months`).Int())
+		require.Equal(t, int64(2), field(interval, `days`).Int())
+		require.Equal(t, int64(3*time.Second), field(interval, `nanos`).Int())
+		arr := field(value, `e`).List()
+		require.Equal(t, 2, arr.Len())
+		require.Equal(t, int64(5), arr.Get(1).Int())
+		timestamp := field(value, `f`).Message()
+		require.Equal(t, int64(1609556645), field(timestamp, `seconds`).Int())
+		require.Equal(t, int64(678000000), field(timestamp, `nanos`).Int())
+		require.Equal(t, `{"x": 1}`, field(value, `g`).String())
+
+		row = encodeRow{datums: rows[1], updated: ts, tableDesc: tableDesc}
+		valueBuf, err = e.EncodeValue(context.Background(), row)
+		require.NoError(t, err)
+		value = decode(pe.rowCache[cacheKey].desc, valueBuf)
+		require.True(t, has(value, `a`))
+		for _, name := range []string{`b`, `c`, `d`, `e`, `f`, `g`} {
+			require.False(t, has(value, name), name)
+		}
+
+		row.deleted = true
+		valueBuf, err = e.EncodeValue(context.Background(), row)
+		require.NoError(t, err)
+		require.Nil(t, valueBuf)
+	})
+
+	t.Run(`wrapped`, func(t *testing.T) {
+		e, err := getEncoder(map[string]string{
+			changefeedbase.OptFormat:            string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope:          string(changefeedbase.OptEnvelopeWrapped),
+			changefeedbase.OptDiff:              ``,
+			changefeedbase.OptUpdatedTimestamps: ``,
+			changefeedbase.OptKeyInValue:        ``,
+			changefeedbase.OptTopicInValue:      ``,
+		}, targets)
+		require.NoError(t, err)
+		pe := e.(*protobufEncoder)
+
+		row := encodeRow{
+			datums:        rows[0],
+			deleted:       true,
+			prevDatums:    rows[0],
+			updated:       ts,
+			tableDesc:     tableDesc,
+			prevTableDesc: tableDesc,
+		}
+		valueBuf, err := e.EncodeValue(context.Background(), row)
+		require.NoError(t, err)
+		value := decode(pe.envelopeCache[tableIDAndVersionPair{cacheKey, cacheKey}].desc, valueBuf)
+		require.False(t, has(value, `after`))
+		require.Equal(t, `bar`, field(field(value, `before`).Message(), `b`).String())
+		require.Equal(t, int64(1), field(field(value, `key`).Message(), `a`).Int())
+		require.Equal(t, `1.0000000002`, field(value, `updated`).String())
+		require.Equal(t, `foo`, field(value, `topic`).String())
+		require.Nil(t, value.Descriptor().Fields().ByName(`mvcc_timestamp`))
+
+		resolvedBuf, err := e.EncodeResolvedTimestamp(context.Background(), `foo`, ts)
+		require.NoError(t, err)
+		resolved := decode(pe.resolved, resolvedBuf)
+		require.Equal(t, `1.0000000002`, field(resolved, `resolved`).String())
+	})
+
+	t.Run(`key_only`, func(t *testing.T) {
+		_, err := getEncoder(map[string]string{
+			changefeedbase.OptFormat:            string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope:          string(changefeedbase.OptEnvelopeKeyOnly),
+			changefeedbase.OptUpdatedTimestamps: ``,
+		}, targets)
+		require.EqualError(t, err, `updated is only usable with envelope=wrapped`)
+
+		e, err := getEncoder(map[string]string{
+			changefeedbase.OptFormat:   string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeKeyOnly),
+		}, targets)
+		require.NoError(t, err)
+		row := encodeRow{datums: rows[0], updated: ts, tableDesc: tableDesc}
+		valueBuf, err := e.EncodeValue(context.Background(), row)
+		require.NoError(t, err)
+		require.Nil(t, valueBuf)
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 )
 
@@ -94,6 +95,7 @@ type Event struct {
 	flush              bool
 	resolved           *jobspb.ResolvedSpan
 	backfillTimestamp  hlc.Timestamp
+	txnID              uuid.UUID
 	bufferAddTimestamp time.Time
 	approxSize         int
 	alloc              Alloc
@@ -146,6 +148,14 @@ func (b *Event) BackfillTimestamp() hlc.Timestamp {
 	return b.backfillTimestamp
 }
 
+// TxnID returns the ID of the transaction which committed the KV, if it is
+// known. It is empty for backfills, for non-transactional writes, and for
+// values emitted by rangefeed catch-up scans (see
+// roachpb.RangeFeedValue.TxnID).
+func (b *Event) TxnID() uuid.UUID {
+	return b.txnID
+}
+
 // BufferAddTimestamp is the time this event came into  the buffer.
 func (b *Event) BufferAddTimestamp() time.Time {
 	return b.bufferAddTimestamp
@@ -214,12 +224,13 @@ func MakeResolvedEvent(
 
 // MakeKVEvent returns KV event.
 func MakeKVEvent(
-	kv roachpb.KeyValue, prevVal roachpb.Value, backfillTimestamp hlc.Timestamp,
+	kv roachpb.KeyValue, prevVal roachpb.Value, backfillTimestamp hlc.Timestamp, txnID uuid.UUID,
 ) Event {
 	return Event{
 		kv:                kv,
 		prevVal:           prevVal,
 		backfillTimestamp: backfillTimestamp,
-		approxSize:        kv.Size() + prevVal.Size() + backfillTimestamp.Size(),
+		txnID:             txnID,
+		approxSize:        kv.Size() + prevVal.Size() + backfillTimestamp.Size() + txnID.Size(),
 	}
 }
@@ -91,7 +91,7 @@ func (p *rangefeed) addEventsToBuffer(ctx context.Context) error {
 				}
 				if err := p.memBuf.Add(
 					ctx,
-					kvevent.MakeKVEvent(kv, prevVal, backfillTimestamp),
+					kvevent.MakeKVEvent(kv, prevVal, backfillTimestamp, t.TxnID),
 				); err != nil {
 					return err
 				}
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/limit"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 )
 
@@ -236,7 +237,10 @@ func slurpScanResponse(
 				// change. This is handled in kvsToRows.
 				prevVal = kv.Value
 			}
-			if err = sink.Add(ctx, kvevent.MakeKVEvent(kv, prevVal, ts)); err != nil {
+			// The scan returns a snapshot of the span rather than the writes of
+			// transactions, and the values it reads do not record the transactions
+			// which wrote them, so the KVs have no transaction ID.
+			if err = sink.Add(ctx, kvevent.MakeKVEvent(kv, prevVal, ts, uuid.UUID{} /* txnID */)); err != nil {
 				return errors.Wrapf(err, `buffering changes for %s`, span)
 			}
 		}
@@ -0,0 +1,199 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/importccl"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// The file contains the support for format=parquet, which is only available
+// with cloud storage sinks. Each file written by the sink holds the rows of one
+// version of one table, and has one column per column of the table, using the
+// same SQL to parquet type mapping as EXPORT (see importccl.ParquetWriter),
+// followed by the metadata columns below.
+//
+// Since a parquet file can only be built once all of its rows are known, the
+// parquetEncoder does not produce parquet: it encodes the datums of each row
+// using the column value encoding, and the cloud storage sink decodes them
+// using the table descriptor of the row's topic and adds them to the file.
+
+const (
+	// parquetDeletedColumn is true for rows that were deleted, in which case
+	// only their primary key columns are set.
+	parquetDeletedColumn = `__crdb__deleted`
+	// parquetUpdatedColumn holds the updated timestamp of the rows. It is only
+	// present with the updated option.
+	parquetUpdatedColumn = `__crdb__updated`
+	// parquetMVCCTimestampColumn holds the MVCC timestamp of the rows. It is
+	// only present with the mvcc_timestamp option.
+	parquetMVCCTimestampColumn = `__crdb__mvcc_timestamp`
+)
+
+const (
+	parquetRowFlagDeleted byte = 1 << iota
+)
+
+// parquetEncoder encodes the values of rows for the cloud storage sink, which
+// writes them to parquet files. Keys and resolved timestamps are encoded as
+// JSON.
+type parquetEncoder struct {
+	json    *jsonEncoder
+	alloc   rowenc.DatumAlloc
+	buf     []byte
+	scratch []byte
+}
+
+var _ Encoder = &parquetEncoder{}
+
+func newParquetEncoder(
+	opts map[string]string, targets jobspb.ChangefeedTargets,
+) (*parquetEncoder, error) {
+	if changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]) != changefeedbase.OptEnvelopeWrapped {
+		return nil, errors.Errorf(`%s=%s is only usable with %s=%s`,
+			changefeedbase.OptFormat, changefeedbase.OptFormatParquet,
+			changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+	if _, ok := opts[changefeedbase.OptDiff]; ok {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptDiff, changefeedbase.OptFormat, changefeedbase.OptFormatParquet)
+	}
+	j, err := makeJSONEncoder(opts, targets)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetEncoder{json: j}, nil
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *parquetEncoder) EncodeKey(ctx context.Context, row encodeRow) ([]byte, error) {
+	return e.json.EncodeKey(ctx, row)
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *parquetEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, error) {
+	var flags byte
+	if row.deleted {
+		flags |= parquetRowFlagDeleted
+	}
+	e.buf = append(e.buf[:0], flags)
+	for i, col := range row.tableDesc.PublicColumns() {
+		datum := row.datums[i]
+		if err := datum.EnsureDecoded(col.GetType(), &e.alloc); err != nil {
+			return nil, err
+		}
+		var err error
+		e.buf, err = rowenc.EncodeTableValue(
+			e.buf, descpb.ColumnID(encoding.NoColumnID), datum.Datum, e.scratch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e.buf, nil
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *parquetEncoder) EncodeResolvedTimestamp(
+	ctx context.Context, topic string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	return e.json.EncodeResolvedTimestamp(ctx, topic, resolved)
+}
+
+// parquetFileSchema describes the columns of the parquet files written for
+// one version of a table.
+type parquetFileSchema struct {
+	desc                   catalog.TableDescriptor
+	updated, mvccTimestamp bool
+}
+
+func (s parquetFileSchema) columns() (names []string, typs []*types.T) {
+	for _, col := range s.desc.PublicColumns() {
+		names = append(names, col.GetName())
+		typs = append(typs, col.GetType())
+	}
+	names = append(names, parquetDeletedColumn)
+	typs = append(typs, types.Bool)
+	if s.updated {
+		names = append(names, parquetUpdatedColumn)
+		typs = append(typs, types.String)
+	}
+	if s.mvccTimestamp {
+		names = append(names, parquetMVCCTimestampColumn)
+		typs = append(typs, types.String)
+	}
+	return names, typs
+}
+
+func (s parquetFileSchema) newWriter() (*importccl.ParquetWriter, error) {
+	names, typs := s.columns()
+	w, err := importccl.NewParquetWriter(names, typs)
+	if err != nil {
+		return nil, errors.Wrapf(err, `table %s`, s.desc.GetName())
+	}
+	return w, nil
+}
+
+// decodeRow decodes a value encoded by the parquetEncoder into the datums of a
+// row of the file.
+func (s parquetFileSchema) decodeRow(
+	alloc *rowenc.DatumAlloc, value []byte, updated, mvcc hlc.Timestamp,
+) (tree.Datums, error) {
+	if len(value) == 0 {
+		return nil, errors.AssertionFailedf(`empty parquet row`)
+	}
+	flags, b := value[0], value[1:]
+	cols := s.desc.PublicColumns()
+	row := make(tree.Datums, 0, len(cols)+3)
+	for _, col := range cols {
+		var d tree.Datum
+		var err error
+		d, b, err = rowenc.DecodeTableValue(alloc, col.GetType(), b)
+		if err != nil {
+			return nil, errors.Wrapf(err, `decoding column %s`, col.GetName())
+		}
+		row = append(row, d)
+	}
+	if len(b) != 0 {
+		return nil, errors.AssertionFailedf(`%d trailing bytes in parquet row`, len(b))
+	}
+	row = append(row, tree.MakeDBool(flags&parquetRowFlagDeleted != 0))
+	if s.updated {
+		row = append(row, tree.NewDString(tree.TimestampToDecimalDatum(updated).Decimal.String()))
+	}
+	if s.mvccTimestamp {
+		row = append(row, tree.NewDString(tree.TimestampToDecimalDatum(mvcc).Decimal.String()))
+	}
+	return row, nil
+}
+
+// validateParquetTargets checks that the columns of the target tables can be
+// written to parquet files.
+func validateParquetTargets(descs []catalog.Descriptor) error {
+	for _, desc := range descs {
+		table, ok := desc.(catalog.TableDescriptor)
+		if !ok {
+			continue
+		}
+		if _, err := (parquetFileSchema{desc: table}).newWriter(); err != nil {
+			return errors.Wrapf(err, `%s=%s`, changefeedbase.OptFormat, changefeedbase.OptFormatParquet)
+		}
+	}
+	return nil
+}
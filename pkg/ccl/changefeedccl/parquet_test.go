@@ -0,0 +1,196 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/blobs"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParquetEncoderOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		opts     map[string]string
+		expected string
+	}{
+		{
+			opts:     map[string]string{changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeKeyOnly)},
+			expected: `format=parquet is only usable with envelope=wrapped`,
+		},
+		{
+			opts: map[string]string{
+				changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeWrapped),
+				changefeedbase.OptDiff:     ``,
+			},
+			expected: `diff is not supported with format=parquet`,
+		},
+	} {
+		_, err := newParquetEncoder(tc.opts, jobspb.ChangefeedTargets{})
+		require.EqualError(t, err, tc.expected)
+	}
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b DECIMAL)`)
+	require.NoError(t, err)
+	require.Regexp(t, `format=parquet: table foo: parquet export does not support the DecimalFamily type`,
+		validateParquetTargets([]catalog.Descriptor{tableDesc}))
+}
+
+func TestParquetCloudStorageSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	dir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+
+	settings := cluster.MakeTestingClusterSettings()
+	settings.ExternalIODir = dir
+	clientFactory := blobs.TestBlobServiceClient(settings.ExternalIODir)
+	externalStorageFromURI := func(ctx context.Context, uri string, user security.SQLUsername) (cloud.ExternalStorage,
+		error) {
+		return cloud.ExternalStorageFromURI(ctx, uri, base.ExternalIODirConfig{}, settings,
+			clientFactory, user, nil, nil)
+	}
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c BOOL)`)
+	require.NoError(t, err)
+	rows, err := parseValues(tableDesc, `VALUES (1, 'one', true), (2, NULL, false), (3, NULL, NULL)`)
+	require.NoError(t, err)
+	topic := tableDescriptorTopic{tableDesc}
+
+	opts := map[string]string{
+		changefeedbase.OptFormat:            string(changefeedbase.OptFormatParquet),
+		changefeedbase.OptEnvelope:          string(changefeedbase.OptEnvelopeWrapped),
+		changefeedbase.OptKeyInValue:        ``,
+		changefeedbase.OptUpdatedTimestamps: ``,
+	}
+	e, err := newParquetEncoder(opts, jobspb.ChangefeedTargets{})
+	require.NoError(t, err)
+
+	// readParquetFiles returns the rows of every parquet file under sinkDir
+	// (relative to the temp dir created above), sorted by the name of the file.
+	readParquetFiles := func(t *testing.T, sinkDir string) [][]map[string]interface{} {
+		paths, err := filepath.Glob(filepath.Join(dir, sinkDir, `*`, `*.parquet`))
+		require.NoError(t, err)
+		sort.Strings(paths)
+		var files [][]map[string]interface{}
+		for _, path := range paths {
+			contents, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			fr, err := goparquet.NewFileReader(bytes.NewReader(contents))
+			require.NoError(t, err)
+			var fileRows []map[string]interface{}
+			for {
+				row, err := fr.NextRow()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				fileRows = append(fileRows, row)
+			}
+			files = append(files, fileRows)
+		}
+		return files
+	}
+
+	testSpan := roachpb.Span{Key: []byte("a"), EndKey: []byte("b")}
+	sf, err := span.MakeFrontier(testSpan)
+	require.NoError(t, err)
+	timestampOracle := &changeAggregatorLowerBoundOracle{sf: sf}
+
+	for _, fileSize := range []int64{0, 1} {
+		t.Run(fmt.Sprintf("file_size=%d", fileSize), func(t *testing.T) {
+			sinkDir := fmt.Sprintf(`parquet%d`, fileSize)
+			uri := `nodelocal://0/` + sinkDir
+			if fileSize > 0 {
+				uri += fmt.Sprintf("?%s=%d", changefeedbase.SinkParamFileSize, fileSize)
+			}
+			u, err := url.Parse(uri)
+			require.NoError(t, err)
+			s, err := makeCloudStorageSink(
+				ctx, sinkURL{URL: u}, 1, settings, opts, timestampOracle,
+				externalStorageFromURI, security.RootUserName(), nil,
+			)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, s.Close()) }()
+
+			for i, row := range rows {
+				ts := hlc.Timestamp{WallTime: int64(i + 1)}
+				value, err := e.EncodeValue(ctx, encodeRow{
+					datums:    row,
+					updated:   ts,
+					deleted:   i == 2,
+					tableDesc: tableDesc,
+				})
+				require.NoError(t, err)
+				require.NoError(t, s.EmitRow(ctx, topic, nil, value, ts, ts, zeroAlloc))
+			}
+			require.NoError(t, s.Flush(ctx))
+
+			expected := []map[string]interface{}{
+				{`a`: int64(1), `b`: []byte(`one`), `c`: true, `__crdb__deleted`: false,
+					`__crdb__updated`: []byte(`1.0000000000`)},
+				{`a`: int64(2), `c`: false, `__crdb__deleted`: false,
+					`__crdb__updated`: []byte(`2.0000000000`)},
+				{`a`: int64(3), `__crdb__deleted`: true,
+					`__crdb__updated`: []byte(`3.0000000000`)},
+			}
+			files := readParquetFiles(t, sinkDir)
+			if fileSize == 0 {
+				// All the rows fit in a single file.
+				require.Equal(t, [][]map[string]interface{}{expected}, files)
+			} else {
+				// Every row rolls over to a new file.
+				require.Equal(t, [][]map[string]interface{}{
+					expected[:1], expected[1:2], expected[2:],
+				}, files)
+			}
+		})
+	}
+
+	t.Run("compression", func(t *testing.T) {
+		u, err := url.Parse(`nodelocal://0/compression`)
+		require.NoError(t, err)
+		compressionOpts := map[string]string{changefeedbase.OptCompression: `gzip`}
+		for k, v := range opts {
+			compressionOpts[k] = v
+		}
+		_, err = makeCloudStorageSink(
+			ctx, sinkURL{URL: u}, 1, settings, compressionOpts, timestampOracle,
+			externalStorageFromURI, security.RootUserName(), nil,
+		)
+		require.EqualError(t, err, `compression is not supported with format=parquet`)
+	})
+}
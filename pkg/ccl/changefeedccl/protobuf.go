@@ -0,0 +1,491 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	// Registers google/protobuf/timestamp.proto, which the generated
+	// descriptors depend on, with protoregistry.GlobalFiles.
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The file contains the mapping between our SQL schemas and protobuf
+// messages, in the same spirit as avro.go does for avro records. It's not
+// intended to be a general purpose protobuf utility.
+//
+// A SQL table schema is mapped to a protobuf message, built at runtime, with a
+// 1:1 mapping between table columns and message fields. The number of each
+// field is the ID of its column. Column IDs are never reused within a table, so
+// the messages generated for every version of a table are wire compatible with
+// each other: dropped columns stop appearing and added columns get fresh field
+// numbers. All fields use proto2 optional semantics, which lets consumers tell
+// a SQL NULL apart from a zero value.
+//
+// Column types are mapped to protobuf types as faithfully as possible. Types
+// without a native protobuf equivalent are mapped so that no information is
+// lost: DECIMAL is encoded as its exact string representation, TIMESTAMP and
+// TIMESTAMPTZ as google.protobuf.Timestamp, INTERVAL as a message holding its
+// months, days and nanos separately, and arrays as repeated fields of their
+// element type. Every other type is encoded as its SQL string representation.
+
+const (
+	protobufPackage         = `cockroach.changefeed`
+	protobufIntervalMessage = `Interval`
+	protobufResolvedMessage = `Resolved`
+	protobufTimestampProto  = `google/protobuf/timestamp.proto`
+	protobufTimestampType   = `.google.protobuf.Timestamp`
+)
+
+// Field numbers of the envelope message. These are part of the wire format and
+// must never change.
+const (
+	protobufEnvelopeAfterField         = 1
+	protobufEnvelopeBeforeField        = 2
+	protobufEnvelopeUpdatedField       = 3
+	protobufEnvelopeMVCCTimestampField = 4
+	protobufEnvelopeKeyField           = 5
+	protobufEnvelopeTopicField         = 6
+)
+
+// protobufDataRecord is a message with one field per column of a table, or of
+// the key columns of one of its indexes.
+type protobufDataRecord struct {
+	desc protoreflect.MessageDescriptor
+	// colIdxByFieldIdx and colTypeByFieldIdx are indexed by the position of
+	// the field in desc.Fields().
+	colIdxByFieldIdx  []int
+	colTypeByFieldIdx []*types.T
+}
+
+// protobufEnvelopeOpts controls which fields are present in an envelope
+// message.
+type protobufEnvelopeOpts struct {
+	beforeField, updatedField, mvccTimestampField, keyField, topicField bool
+}
+
+// protobufEnvelopeRecord is the message for `envelope=wrapped` values. It
+// contains the row as of after the change and, optionally, the row as of
+// before it and some changefeed metadata.
+type protobufEnvelopeRecord struct {
+	desc                     protoreflect.MessageDescriptor
+	opts                     protobufEnvelopeOpts
+	before, after, key       *protobufDataRecord
+	beforeFD, afterFD, keyFD protoreflect.FieldDescriptor
+	updatedFD, mvccFD        protoreflect.FieldDescriptor
+	topicFD                  protoreflect.FieldDescriptor
+}
+
+// protobufDataMessage is a protobuf message definition with one field per
+// column that has not yet been resolved into a descriptor.
+type protobufDataMessage struct {
+	proto             *descriptorpb.DescriptorProto
+	colIdxByFieldIdx  []int
+	colTypeByFieldIdx []*types.T
+}
+
+func (m *protobufDataMessage) record(fd protoreflect.FileDescriptor) *protobufDataRecord {
+	return &protobufDataRecord{
+		desc:              fd.Messages().ByName(protoreflect.Name(m.proto.GetName())),
+		colIdxByFieldIdx:  m.colIdxByFieldIdx,
+		colTypeByFieldIdx: m.colTypeByFieldIdx,
+	}
+}
+
+// typeToProtobufField returns the protobuf field (without name and number) used
+// to encode a column of the given type.
+func typeToProtobufField(typ *types.T) (*descriptorpb.FieldDescriptorProto, error) {
+	field := &descriptorpb.FieldDescriptorProto{
+		Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if typ.Family() == types.ArrayFamily {
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		typ = typ.ArrayContents()
+		if typ.Family() == types.ArrayFamily {
+			return nil, errors.Errorf(`type %s not yet supported with protobuf`, typ.SQLString())
+		}
+	}
+	switch typ.Family() {
+	case types.BoolFamily:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()
+	case types.IntFamily:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+	case types.FloatFamily:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+	case types.BytesFamily:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
+	case types.TimestampFamily, types.TimestampTZFamily:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		field.TypeName = proto.String(protobufTimestampType)
+	case types.IntervalFamily:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		field.TypeName = proto.String(`.` + protobufPackage + `.` + protobufIntervalMessage)
+	default:
+		// DECIMAL, STRING, UUID, JSONB, and all the other types are sent as
+		// strings, which is lossless.
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	}
+	return field, nil
+}
+
+// columnsToProtobufMessage builds a message with one field for each of the
+// given columns of tableDesc, in order.
+func columnsToProtobufMessage(
+	tableDesc catalog.TableDescriptor, name string, colIdxs []int,
+) (*protobufDataMessage, error) {
+	m := &protobufDataMessage{
+		proto: &descriptorpb.DescriptorProto{Name: proto.String(name)},
+	}
+	cols := tableDesc.PublicColumns()
+	for _, colIdx := range colIdxs {
+		col := cols[colIdx]
+		fieldNumber := protoreflect.FieldNumber(col.GetID())
+		if !fieldNumber.IsValid() {
+			return nil, errors.Errorf(`column %s: id %d cannot be used as a protobuf field number`,
+				col.GetName(), col.GetID())
+		}
+		field, err := typeToProtobufField(col.GetType())
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %s", col.GetName())
+		}
+		// Protobuf identifiers have the same restrictions as avro names.
+		field.Name = proto.String(SQLNameToAvroName(col.GetName()))
+		field.Number = proto.Int32(int32(fieldNumber))
+		m.proto.Field = append(m.proto.Field, field)
+		m.colIdxByFieldIdx = append(m.colIdxByFieldIdx, colIdx)
+		m.colTypeByFieldIdx = append(m.colTypeByFieldIdx, col.GetType())
+	}
+	return m, nil
+}
+
+// indexToProtobufMessage builds a message with one field per key column of the
+// given index. The fields are kept in the same order as the columns in the
+// index.
+func indexToProtobufMessage(
+	tableDesc catalog.TableDescriptor, index catalog.Index, name string,
+) (*protobufDataMessage, error) {
+	colIdxByID := catalog.ColumnIDToOrdinalMap(tableDesc.PublicColumns())
+	colIdxs := make([]int, index.NumKeyColumns())
+	for i := range colIdxs {
+		colID := index.GetKeyColumnID(i)
+		colIdx, ok := colIdxByID.Get(colID)
+		if !ok {
+			return nil, errors.Errorf(`unknown column id: %d`, colID)
+		}
+		colIdxs[i] = colIdx
+	}
+	return columnsToProtobufMessage(tableDesc, name, colIdxs)
+}
+
+// tableToProtobufMessage builds a message with one field per column of the
+// table. The fields are kept in the same order as `tableDesc.PublicColumns()`.
+func tableToProtobufMessage(
+	tableDesc catalog.TableDescriptor, name string,
+) (*protobufDataMessage, error) {
+	colIdxs := make([]int, len(tableDesc.PublicColumns()))
+	for i := range colIdxs {
+		colIdxs[i] = i
+	}
+	return columnsToProtobufMessage(tableDesc, name, colIdxs)
+}
+
+// makeProtobufFile resolves the given messages, along with the helper messages
+// they may reference, into a file descriptor. The file is not registered
+// anywhere.
+func makeProtobufFile(
+	name string, msgs ...*descriptorpb.DescriptorProto,
+) (protoreflect.FileDescriptor, error) {
+	optionalInt64 := func(name string, number int32) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(),
+		}
+	}
+	interval := &descriptorpb.DescriptorProto{
+		Name: proto.String(protobufIntervalMessage),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			optionalInt64(`months`, 1),
+			optionalInt64(`days`, 2),
+			optionalInt64(`nanos`, 3),
+		},
+	}
+	file := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(name),
+		Package:     proto.String(protobufPackage),
+		Syntax:      proto.String(`proto2`),
+		Dependency:  []string{protobufTimestampProto},
+		MessageType: append([]*descriptorpb.DescriptorProto{interval}, msgs...),
+	}
+	return protodesc.NewFile(file, protoregistry.GlobalFiles)
+}
+
+// protobufFileName returns a name for the file holding the messages of the
+// given table version. It only needs to be unique among the files built by a
+// single encoder.
+func protobufFileName(tableDesc catalog.TableDescriptor, suffix string) string {
+	return fmt.Sprintf(`%s_%d_%d_%s.proto`,
+		SQLNameToAvroName(tableDesc.GetName()), tableDesc.GetID(), tableDesc.GetVersion(), suffix)
+}
+
+// indexToProtobufRecord returns the record for the key columns of the given
+// index.
+func indexToProtobufRecord(
+	tableDesc catalog.TableDescriptor, index catalog.Index, name string,
+) (*protobufDataRecord, error) {
+	key, err := indexToProtobufMessage(tableDesc, index, name)
+	if err != nil {
+		return nil, err
+	}
+	fd, err := makeProtobufFile(protobufFileName(tableDesc, `key`), key.proto)
+	if err != nil {
+		return nil, err
+	}
+	return key.record(fd), nil
+}
+
+// tableToProtobufRecord returns the record for all columns of the table.
+func tableToProtobufRecord(
+	tableDesc catalog.TableDescriptor, name string,
+) (*protobufDataRecord, error) {
+	row, err := tableToProtobufMessage(tableDesc, name)
+	if err != nil {
+		return nil, err
+	}
+	fd, err := makeProtobufFile(protobufFileName(tableDesc, `value`), row.proto)
+	if err != nil {
+		return nil, err
+	}
+	return row.record(fd), nil
+}
+
+// envelopeToProtobufRecord returns the envelope message for the given table.
+// prevTableDesc must be set iff opts.beforeField is.
+func envelopeToProtobufRecord(
+	tableDesc, prevTableDesc catalog.TableDescriptor, opts protobufEnvelopeOpts,
+) (*protobufEnvelopeRecord, error) {
+	name := SQLNameToAvroName(tableDesc.GetName())
+	envelope := &descriptorpb.DescriptorProto{Name: proto.String(name + `_envelope`)}
+	addField := func(
+		name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string,
+	) {
+		field := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   typ.Enum(),
+		}
+		if typeName != `` {
+			field.TypeName = proto.String(`.` + protobufPackage + `.` + typeName)
+		}
+		envelope.Field = append(envelope.Field, field)
+	}
+
+	after, err := tableToProtobufMessage(tableDesc, name)
+	if err != nil {
+		return nil, err
+	}
+	msgs := []*descriptorpb.DescriptorProto{after.proto}
+	addField(`after`, protobufEnvelopeAfterField,
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, after.proto.GetName())
+
+	var before *protobufDataMessage
+	if opts.beforeField {
+		before, err = tableToProtobufMessage(prevTableDesc, name+`_before`)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, before.proto)
+		addField(`before`, protobufEnvelopeBeforeField,
+			descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, before.proto.GetName())
+	}
+	if opts.updatedField {
+		addField(`updated`, protobufEnvelopeUpdatedField,
+			descriptorpb.FieldDescriptorProto_TYPE_STRING, ``)
+	}
+	if opts.mvccTimestampField {
+		addField(`mvcc_timestamp`, protobufEnvelopeMVCCTimestampField,
+			descriptorpb.FieldDescriptorProto_TYPE_STRING, ``)
+	}
+	var key *protobufDataMessage
+	if opts.keyField {
+		key, err = indexToProtobufMessage(tableDesc, tableDesc.GetPrimaryIndex(), name+`_key`)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, key.proto)
+		addField(`key`, protobufEnvelopeKeyField,
+			descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, key.proto.GetName())
+	}
+	if opts.topicField {
+		addField(`topic`, protobufEnvelopeTopicField,
+			descriptorpb.FieldDescriptorProto_TYPE_STRING, ``)
+	}
+	msgs = append(msgs, envelope)
+
+	fd, err := makeProtobufFile(protobufFileName(tableDesc, `envelope`), msgs...)
+	if err != nil {
+		return nil, err
+	}
+	r := &protobufEnvelopeRecord{
+		desc:  fd.Messages().ByName(protoreflect.Name(envelope.GetName())),
+		opts:  opts,
+		after: after.record(fd),
+	}
+	fields := r.desc.Fields()
+	r.afterFD = fields.ByNumber(protobufEnvelopeAfterField)
+	if before != nil {
+		r.before = before.record(fd)
+		r.beforeFD = fields.ByNumber(protobufEnvelopeBeforeField)
+	}
+	if key != nil {
+		r.key = key.record(fd)
+		r.keyFD = fields.ByNumber(protobufEnvelopeKeyField)
+	}
+	r.updatedFD = fields.ByNumber(protobufEnvelopeUpdatedField)
+	r.mvccFD = fields.ByNumber(protobufEnvelopeMVCCTimestampField)
+	r.topicFD = fields.ByNumber(protobufEnvelopeTopicField)
+	return r, nil
+}
+
+// resolvedToProtobufMessage returns the message used for resolved timestamp
+// payloads.
+func resolvedToProtobufMessage() (protoreflect.MessageDescriptor, error) {
+	resolved := &descriptorpb.DescriptorProto{
+		Name: proto.String(protobufResolvedMessage),
+		Field: []*descriptorpb.FieldDescriptorProto{{
+			Name:   proto.String(`resolved`),
+			Number: proto.Int32(1),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}},
+	}
+	fd, err := makeProtobufFile(`resolved.proto`, resolved)
+	if err != nil {
+		return nil, err
+	}
+	return fd.Messages().ByName(protobufResolvedMessage), nil
+}
+
+// newMessage returns an empty message for this record.
+func (r *protobufDataRecord) newMessage() *dynamicpb.Message {
+	return dynamicpb.NewMessage(r.desc)
+}
+
+// fillFromRow sets the fields of msg, which must be a message of this record,
+// from the given row. Fields of NULL columns are left unset.
+func (r *protobufDataRecord) fillFromRow(
+	msg protoreflect.Message, row rowenc.EncDatumRow, alloc *rowenc.DatumAlloc,
+) error {
+	fields := r.desc.Fields()
+	for fieldIdx, colIdx := range r.colIdxByFieldIdx {
+		fd := fields.Get(fieldIdx)
+		datum := row[colIdx]
+		if err := datum.EnsureDecoded(r.colTypeByFieldIdx[fieldIdx], alloc); err != nil {
+			return err
+		}
+		if datum.Datum == tree.DNull {
+			continue
+		}
+		if fd.IsList() {
+			arr, ok := datum.Datum.(*tree.DArray)
+			if !ok {
+				return errors.AssertionFailedf(`expected array for field %s, got %T`, fd.Name(), datum.Datum)
+			}
+			list := msg.Mutable(fd).List()
+			for _, elem := range arr.Array {
+				if elem == tree.DNull {
+					return errors.Errorf(`field %s: arrays with NULL elements are not supported with protobuf`,
+						fd.Name())
+				}
+				v, err := datumToProtobufValue(elem, fd, list.NewElement)
+				if err != nil {
+					return err
+				}
+				list.Append(v)
+			}
+			continue
+		}
+		v, err := datumToProtobufValue(datum.Datum, fd, func() protoreflect.Value {
+			return msg.NewField(fd)
+		})
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+// datumToProtobufValue converts a non-NULL datum into the value of the given
+// field (or of one element of it, for repeated fields). newMessage is used to
+// allocate the value of message fields.
+func datumToProtobufValue(
+	d tree.Datum, fd protoreflect.FieldDescriptor, newMessage func() protoreflect.Value,
+) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(bool(*d.(*tree.DBool))), nil
+	case protoreflect.Int64Kind:
+		return protoreflect.ValueOfInt64(int64(*d.(*tree.DInt))), nil
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float64(*d.(*tree.DFloat))), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(*d.(*tree.DBytes))), nil
+	case protoreflect.MessageKind:
+		v := newMessage()
+		msg := v.Message()
+		fields := msg.Descriptor().Fields()
+		setTimestamp := func(t time.Time) {
+			msg.Set(fields.ByName(`seconds`), protoreflect.ValueOfInt64(t.Unix()))
+			msg.Set(fields.ByName(`nanos`), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+		}
+		switch t := d.(type) {
+		case *tree.DTimestamp:
+			setTimestamp(t.Time)
+		case *tree.DTimestampTZ:
+			setTimestamp(t.Time)
+		case *tree.DInterval:
+			msg.Set(fields.ByName(`months`), protoreflect.ValueOfInt64(t.Months))
+			msg.Set(fields.ByName(`days`), protoreflect.ValueOfInt64(t.Days))
+			msg.Set(fields.ByName(`nanos`), protoreflect.ValueOfInt64(t.Nanos()))
+		default:
+			return protoreflect.Value{}, errors.AssertionFailedf(
+				`unexpected datum %T for protobuf message field %s`, d, fd.Name())
+		}
+		return v, nil
+	case protoreflect.StringKind:
+		switch t := d.(type) {
+		case *tree.DString:
+			return protoreflect.ValueOfString(string(*t)), nil
+		case *tree.DCollatedString:
+			return protoreflect.ValueOfString(t.Contents), nil
+		case *tree.DDecimal:
+			return protoreflect.ValueOfString(t.Decimal.String()), nil
+		default:
+			return protoreflect.ValueOfString(tree.AsStringWithFlags(d, tree.FmtBareStrings)), nil
+		}
+	default:
+		return protoreflect.Value{}, errors.AssertionFailedf(
+			`unexpected protobuf kind %s for field %s`, fd.Kind(), fd.Name())
+	}
+}
@@ -104,6 +104,14 @@ func getSink(
 			return validateOptionsAndMakeSink(changefeedbase.KafkaValidOptions, func() (Sink, error) {
 				return makeKafkaSink(ctx, sinkURL{URL: u}, feedCfg.Targets, feedCfg.Opts, m)
 			})
+		case isPulsarSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.PulsarValidOptions, func() (Sink, error) {
+				return makePulsarSink(ctx, sinkURL{URL: u}, feedCfg.Targets, feedCfg.Opts, m)
+			})
+		case isNATSSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.NATSValidOptions, func() (Sink, error) {
+				return makeNATSSink(ctx, sinkURL{URL: u}, feedCfg.Targets, feedCfg.Opts, m)
+			})
 		case isWebhookSink(u):
 			return validateOptionsAndMakeSink(changefeedbase.WebhookValidOptions, func() (Sink, error) {
 				return makeWebhookSink(ctx, sinkURL{URL: u}, feedCfg.Opts,
@@ -121,6 +129,10 @@ func getSink(
 					feedCfg.Opts, timestampOracle, serverCfg.ExternalStorageFromURI, user, m,
 				)
 			})
+		case isPostgresSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.PostgresValidOptions, func() (Sink, error) {
+				return makePostgresSink(sinkURL{URL: u}, jobID, feedCfg.Opts, m)
+			})
 		case u.Scheme == changefeedbase.SinkSchemeExperimentalSQL:
 			return validateOptionsAndMakeSink(changefeedbase.SQLValidOptions, func() (Sink, error) {
 				return makeSQLSink(sinkURL{URL: u}, sqlSinkTableName, feedCfg.Targets, m)
@@ -22,11 +22,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/importccl"
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -64,6 +66,11 @@ type cloudStorageSinkFile struct {
 	alloc         kvevent.Alloc
 	oldestMVCC    hlc.Timestamp
 	recordMetrics recordEmittedMessagesCallback
+
+	// parquet and parquetSchema are only set with format=parquet, in which case
+	// the rows are written to parquet instead of buf until the file is flushed.
+	parquet       *importccl.ParquetWriter
+	parquetSchema parquetFileSchema
 }
 
 var _ io.Writer = &cloudStorageSinkFile{}
@@ -77,6 +84,16 @@ func (f *cloudStorageSinkFile) Write(p []byte) (int, error) {
 	return f.buf.Write(p)
 }
 
+// size returns the size of the file so far. With format=parquet, the file is
+// only written when it is flushed, so its size is estimated by the size of the
+// encoded rows.
+func (f *cloudStorageSinkFile) size() int {
+	if f.parquet != nil {
+		return f.rawSize
+	}
+	return f.buf.Len()
+}
+
 // cloudStorageSink writes changefeed output to files in a cloud storage bucket
 // (S3/GCS/HTTP) maintaining CDC's ordering guarantees (see below) for each
 // row through lexicographical filename ordering.
@@ -285,6 +302,11 @@ type cloudStorageSink struct {
 
 	compression string
 
+	// parquet is set with format=parquet. parquetUpdated and
+	// parquetMVCCTimestamp control the metadata columns of the parquet files.
+	parquet, parquetUpdated, parquetMVCCTimestamp bool
+	parquetAlloc                                  rowenc.DatumAlloc
+
 	es cloud.ExternalStorage
 
 	// These are fields to track information needed to output files based on the naming
@@ -372,6 +394,11 @@ func makeCloudStorageSink(
 		// would require a bit of refactoring.
 		s.ext = `.ndjson`
 		s.rowDelimiter = []byte{'\n'}
+	case changefeedbase.OptFormatParquet:
+		s.ext = `.parquet`
+		s.parquet = true
+		_, s.parquetUpdated = opts[changefeedbase.OptUpdatedTimestamps]
+		_, s.parquetMVCCTimestamp = opts[changefeedbase.OptMVCCTimestamps]
 	default:
 		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
 			changefeedbase.OptFormat, opts[changefeedbase.OptFormat])
@@ -389,7 +416,11 @@ func makeCloudStorageSink(
 	}
 
 	if codec, ok := opts[changefeedbase.OptCompression]; ok && codec != "" {
-		if strings.EqualFold(codec, "gzip") {
+		if s.parquet {
+			// Parquet files are compressed internally.
+			return nil, errors.Errorf(`%s is not supported with %s=%s`,
+				changefeedbase.OptCompression, changefeedbase.OptFormat, changefeedbase.OptFormatParquet)
+		} else if strings.EqualFold(codec, "gzip") {
 			s.compression = sinkCompressionGzip
 			s.ext = s.ext + ".gz"
 		} else {
@@ -407,14 +438,14 @@ func makeCloudStorageSink(
 
 func (s *cloudStorageSink) getOrCreateFile(
 	topic TopicDescriptor, eventMVCC hlc.Timestamp,
-) *cloudStorageSinkFile {
+) (*cloudStorageSinkFile, error) {
 	key := cloudStorageSinkKey{topic.GetName(), int64(topic.GetVersion())}
 	if item := s.files.Get(key); item != nil {
 		f := item.(*cloudStorageSinkFile)
 		if eventMVCC.Less(f.oldestMVCC) {
 			f.oldestMVCC = eventMVCC
 		}
-		return f
+		return f, nil
 	}
 	f := &cloudStorageSinkFile{
 		cloudStorageSinkKey: key,
@@ -425,8 +456,23 @@ func (s *cloudStorageSink) getOrCreateFile(
 	case sinkCompressionGzip:
 		f.codec = gzip.NewWriter(&f.buf)
 	}
+	if s.parquet {
+		table, ok := topic.(tableDescriptorTopic)
+		if !ok {
+			return nil, errors.AssertionFailedf(`unexpected topic descriptor %T`, topic)
+		}
+		f.parquetSchema = parquetFileSchema{
+			desc:          table.TableDescriptor,
+			updated:       s.parquetUpdated,
+			mvccTimestamp: s.parquetMVCCTimestamp,
+		}
+		var err error
+		if f.parquet, err = f.parquetSchema.newWriter(); err != nil {
+			return nil, err
+		}
+	}
 	s.files.ReplaceOrInsert(f)
-	return f
+	return f, nil
 }
 
 // EmitRow implements the Sink interface.
@@ -441,17 +487,32 @@ func (s *cloudStorageSink) EmitRow(
 		return errors.New(`cannot EmitRow on a closed sink`)
 	}
 
-	file := s.getOrCreateFile(topic, mvcc)
-	file.alloc.Merge(&alloc)
-
-	if _, err := file.Write(value); err != nil {
+	file, err := s.getOrCreateFile(topic, mvcc)
+	if err != nil {
 		return err
 	}
-	if _, err := file.Write(s.rowDelimiter); err != nil {
-		return err
+	file.alloc.Merge(&alloc)
+
+	if file.parquet != nil {
+		row, err := file.parquetSchema.decodeRow(&s.parquetAlloc, value, updated, mvcc)
+		if err != nil {
+			return err
+		}
+		if err := file.parquet.AddRow(row); err != nil {
+			return err
+		}
+		file.rawSize += len(value)
+		file.numMessages++
+	} else {
+		if _, err := file.Write(value); err != nil {
+			return err
+		}
+		if _, err := file.Write(s.rowDelimiter); err != nil {
+			return err
+		}
 	}
 
-	if int64(file.buf.Len()) > s.targetMaxFileSize {
+	if int64(file.size()) > s.targetMaxFileSize {
 		if err := s.flushTopicVersions(ctx, file.topic, file.schemaID); err != nil {
 			return err
 		}
@@ -561,6 +622,13 @@ func (s *cloudStorageSink) flushFile(ctx context.Context, file *cloudStorageSink
 			return err
 		}
 	}
+	if file.parquet != nil {
+		contents, err := file.parquet.Finish()
+		if err != nil {
+			return err
+		}
+		file.buf.Write(contents)
+	}
 
 	// We use this monotonically increasing fileID to ensure correct ordering
 	// among files emitted at the same timestamp during the same job session.
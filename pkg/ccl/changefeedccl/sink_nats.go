@@ -0,0 +1,307 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+)
+
+func isNATSSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeNATS
+}
+
+// natsPublisher is the subset of nats.JetStreamContext used by natsSink. It
+// exists for testing.
+type natsPublisher interface {
+	PublishMsgAsync(m *nats.Msg, opts ...nats.PubOpt) (nats.PubAckFuture, error)
+}
+
+// natsSinkConfig is the configuration of the nats sink, which can be
+// overridden with the nats_sink_config option.
+type natsSinkConfig struct {
+	// Flush describes how many messages may be awaiting an acknowledgement.
+	Flush struct {
+		// MaxPending is the maximum number of messages awaiting an
+		// acknowledgement from the server. Emitting more messages blocks until
+		// some of them are acknowledged.
+		MaxPending int `json:",omitempty"`
+	}
+	// Timeout bounds the time spent connecting to the server.
+	Timeout jsonDuration `json:",omitempty"`
+}
+
+func defaultNATSSinkConfig() *natsSinkConfig {
+	cfg := &natsSinkConfig{}
+	cfg.Flush.MaxPending = 4000
+	cfg.Timeout = jsonDuration(10 * time.Second)
+	return cfg
+}
+
+func getNATSSinkConfig(opts map[string]string) (*natsSinkConfig, error) {
+	cfg := defaultNATSSinkConfig()
+	if configStr, haveOverride := opts[changefeedbase.OptNATSSinkConfig]; haveOverride {
+		if err := json.Unmarshal([]byte(configStr), cfg); err != nil {
+			return nil, errors.Wrapf(err,
+				"failed to parse nats config; check %s option", changefeedbase.OptNATSSinkConfig)
+		}
+	}
+	if cfg.Flush.MaxPending <= 0 {
+		return nil, errors.New("invalid nats configuration: Flush.MaxPending must be > 0")
+	}
+	return cfg, nil
+}
+
+// natsPendingMessage is a message that has been published but not yet
+// acknowledged.
+type natsPendingMessage struct {
+	ack  nats.PubAckFuture
+	size int
+	m    messageMetadata
+}
+
+// natsSink emits to NATS JetStream. Every subject the changefeed emits to must
+// be bound to a JetStream stream, otherwise publishing fails. NATS messages
+// have no key, so the key is always included in the value.
+//
+// natsSink is not concurrency-safe; all calls to Emit and Flush should be from
+// the same goroutine.
+type natsSink struct {
+	ctx      context.Context
+	url      string
+	opts     []nats.Option
+	cfg      *natsSinkConfig
+	subjects map[descpb.ID]string
+	metrics  *sliMetrics
+
+	conn *nats.Conn
+	// js is the publisher used to emit messages. It is replaced in tests.
+	js      natsPublisher
+	pending []natsPendingMessage
+}
+
+var _ Sink = (*natsSink)(nil)
+
+func makeNATSSink(
+	ctx context.Context,
+	u sinkURL,
+	targets jobspb.ChangefeedTargets,
+	opts map[string]string,
+	m *sliMetrics,
+) (Sink, error) {
+	subjectPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
+	subjectName := u.consumeParam(changefeedbase.SinkParamTopicName)
+	if schemaTopic := u.consumeParam(changefeedbase.SinkParamSchemaTopic); schemaTopic != `` {
+		return nil, errors.Errorf(`%s is not yet supported`, changefeedbase.SinkParamSchemaTopic)
+	}
+
+	var tlsEnabled, tlsSkipVerify bool
+	var caCert, clientCert, clientKey []byte
+	if _, err := u.consumeBool(changefeedbase.SinkParamTLSEnabled, &tlsEnabled); err != nil {
+		return nil, err
+	}
+	if _, err := u.consumeBool(changefeedbase.SinkParamSkipTLSVerify, &tlsSkipVerify); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamCACert, &caCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientCert, &clientCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientKey, &clientKey); err != nil {
+		return nil, err
+	}
+
+	cfg, err := getNATSSinkConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	connOpts := []nats.Option{
+		nats.Name(`CockroachDB`),
+		nats.Timeout(time.Duration(cfg.Timeout)),
+	}
+	if tlsEnabled {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: tlsSkipVerify,
+		}
+		if caCert != nil {
+			caCertPool := x509.NewCertPool()
+			caCertPool.AppendCertsFromPEM(caCert)
+			tlsConfig.RootCAs = caCertPool
+		}
+		if clientCert != nil && clientKey == nil {
+			return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey)
+		} else if clientKey != nil && clientCert == nil {
+			return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientKey, changefeedbase.SinkParamClientCert)
+		}
+		if clientCert != nil && clientKey != nil {
+			cert, err := tls.X509KeyPair(clientCert, clientKey)
+			if err != nil {
+				return nil, errors.Wrap(err, `invalid client certificate data provided`)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		connOpts = append(connOpts, nats.Secure(tlsConfig))
+	} else {
+		if caCert != nil {
+			return nil, errors.Errorf(`%s requires %s=true`, changefeedbase.SinkParamCACert, changefeedbase.SinkParamTLSEnabled)
+		}
+		if clientCert != nil {
+			return nil, errors.Errorf(`%s requires %s=true`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamTLSEnabled)
+		}
+	}
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown nats sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	// The query parameters have all been consumed above; the user info, if
+	// any, is used by the nats client to authenticate.
+	serverURL := url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host}
+	return &natsSink{
+		ctx:      ctx,
+		url:      serverURL.String(),
+		opts:     connOpts,
+		cfg:      cfg,
+		subjects: makeTopicsMap(subjectPrefix, subjectName, targets),
+		metrics:  m,
+	}, nil
+}
+
+// Dial implements the Sink interface.
+func (s *natsSink) Dial() error {
+	conn, err := nats.Connect(s.url, s.opts...)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.CannotConnectNow, `connecting to nats`)
+	}
+	js, err := conn.JetStream(nats.PublishAsyncMaxPending(s.cfg.Flush.MaxPending))
+	if err != nil {
+		conn.Close()
+		return pgerror.Wrapf(err, pgcode.CannotConnectNow, `connecting to nats`)
+	}
+	s.conn = conn
+	s.js = js
+	return nil
+}
+
+// Close implements the Sink interface.
+func (s *natsSink) Close() error {
+	// If we're shutting down, we don't care what happens to the outstanding
+	// messages.
+	for _, p := range s.pending {
+		p.m.alloc.Release(s.ctx)
+	}
+	s.pending = nil
+	// s.conn is only nil in tests.
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	return nil
+}
+
+// EmitRow implements the Sink interface.
+func (s *natsSink) EmitRow(
+	ctx context.Context,
+	topicDescr TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+) error {
+	subject, isKnownTopic := s.subjects[topicDescr.GetID()]
+	if !isKnownTopic {
+		return errors.Errorf(`cannot emit to undeclared topic: %s`, topicDescr.GetName())
+	}
+	return s.publish(ctx, subject, value, messageMetadata{
+		alloc: alloc, mvcc: mvcc, updateMetrics: s.metrics.recordEmittedMessages(),
+	})
+}
+
+// EmitResolvedTimestamp implements the Sink interface.
+func (s *natsSink) EmitResolvedTimestamp(
+	ctx context.Context, encoder Encoder, resolved hlc.Timestamp,
+) error {
+	defer s.metrics.recordResolvedCallback()()
+
+	for _, subject := range s.subjects {
+		payload, err := encoder.EncodeResolvedTimestamp(ctx, subject, resolved)
+		if err != nil {
+			return err
+		}
+		// The encoder may reuse the payload's buffer.
+		payload = append([]byte(nil), payload...)
+		if err := s.publish(ctx, subject, payload, messageMetadata{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *natsSink) publish(
+	ctx context.Context, subject string, data []byte, m messageMetadata,
+) error {
+	ack, err := s.js.PublishMsgAsync(&nats.Msg{Subject: subject, Data: data})
+	if err != nil {
+		m.alloc.Release(ctx)
+		return errors.Wrapf(err, `publishing to nats subject %s`, subject)
+	}
+	s.pending = append(s.pending, natsPendingMessage{ack: ack, size: len(data), m: m})
+	if log.V(2) {
+		log.Infof(ctx, "emitting %d inflight records to nats", len(s.pending))
+	}
+	return nil
+}
+
+// Flush implements the Sink interface.
+func (s *natsSink) Flush(ctx context.Context) error {
+	defer s.metrics.recordFlushRequestCallback()()
+
+	if log.V(1) && len(s.pending) > 0 {
+		log.Infof(ctx, "flush waiting for %d inflight messages", len(s.pending))
+	}
+	var flushErr error
+	for len(s.pending) > 0 {
+		p := s.pending[0]
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.ack.Ok():
+			if p.m.updateMetrics != nil {
+				p.m.updateMetrics(1, p.m.mvcc, p.size, sinkDoesNotCompress)
+			}
+		case err := <-p.ack.Err():
+			if flushErr == nil {
+				flushErr = errors.Wrapf(err, `publishing to nats subject %s`, p.ack.Msg().Subject)
+			}
+		}
+		p.m.alloc.Release(ctx)
+		s.pending[0] = natsPendingMessage{}
+		s.pending = s.pending[1:]
+	}
+	s.pending = nil
+	return flushErr
+}
@@ -0,0 +1,592 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	gosql "database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	postgresSinkStagingTable  = `_crdb_changefeed_staging`
+	postgresSinkResolvedTable = `_crdb_changefeed_resolved`
+
+	postgresSinkCreateStagingTableStmt = `CREATE TABLE IF NOT EXISTS ` + postgresSinkStagingTable + ` (
+		job_id BIGINT NOT NULL,
+		mvcc_wall BIGINT NOT NULL,
+		mvcc_logical INT NOT NULL,
+		table_name TEXT NOT NULL,
+		key_json TEXT NOT NULL,
+		value_json TEXT,
+		PRIMARY KEY (job_id, mvcc_wall, mvcc_logical, table_name, key_json)
+	)`
+	postgresSinkCreateResolvedTableStmt = `CREATE TABLE IF NOT EXISTS ` + postgresSinkResolvedTable + ` (
+		job_id BIGINT PRIMARY KEY,
+		resolved_wall BIGINT NOT NULL,
+		resolved_logical INT NOT NULL
+	)`
+	postgresSinkStageStmt = `INSERT INTO ` + postgresSinkStagingTable +
+		` (job_id, mvcc_wall, mvcc_logical, table_name, key_json, value_json)`
+	postgresSinkStageCols = 6
+	// postgresSinkStageBatchSize is the maximum number of rows staged by a
+	// single statement.
+	postgresSinkStageBatchSize = 100
+	// The staged rows are read in pages of postgresSinkApplyPageSize rows, in
+	// the order of the primary key of the staging table. The rows after the
+	// first page are read starting after the last row of the previous page.
+	postgresSinkSelectStagedStmt = `SELECT mvcc_wall, mvcc_logical, table_name, key_json, value_json
+		FROM ` + postgresSinkStagingTable + ` WHERE job_id = $1 AND (mvcc_wall, mvcc_logical) <= ($2, $3)`
+	postgresSinkSelectStagedAfterClause = ` AND (mvcc_wall, mvcc_logical, table_name, key_json) > ($4, $5, $6, $7)`
+	postgresSinkSelectStagedOrderClause = ` ORDER BY mvcc_wall, mvcc_logical, table_name, key_json LIMIT `
+	postgresSinkApplyPageSize           = 1000
+	// postgresSinkApplyBatchSize is the maximum number of rows applied to a
+	// mirror table by a single statement.
+	postgresSinkApplyBatchSize   = 100
+	postgresSinkDeleteStagedStmt = `DELETE FROM ` + postgresSinkStagingTable +
+		` WHERE job_id = $1 AND (mvcc_wall, mvcc_logical) <= ($2, $3)`
+	postgresSinkResolvedStmt = `INSERT INTO ` + postgresSinkResolvedTable +
+		` (job_id, resolved_wall, resolved_logical) VALUES ($1, $2, $3)
+		ON CONFLICT (job_id) DO UPDATE
+		SET resolved_wall = excluded.resolved_wall, resolved_logical = excluded.resolved_logical`
+)
+
+func isPostgresSink(u *url.URL) bool {
+	switch u.Scheme {
+	case changefeedbase.SinkSchemePostgres, changefeedbase.SinkSchemePostgresql:
+		return true
+	default:
+		return false
+	}
+}
+
+// postgresSink applies the changes of a changefeed to mirror tables in a
+// PostgreSQL-compatible database. Each watched table is mirrored into a table
+// of the same name, which must already exist in the target database with (at
+// least) the same columns and primary key.
+//
+// Changes are applied in two steps so that the mirror tables stay
+// transactionally consistent:
+//   - The sink of each changeAggregator writes the changed rows, encoded as
+//     JSON, into a staging table in the target database when it is flushed.
+//   - When the changeFrontier emits a resolved timestamp, its sink applies
+//     every staged row at or below that timestamp to the mirror tables, in
+//     MVCC timestamp order, and records the resolved timestamp, all in a
+//     single transaction. The mirror tables therefore always reflect the
+//     source tables as of the last resolved timestamp.
+//
+// The changefeed must use format=json and envelope=wrapped. The resolved
+// option is enabled automatically for this sink.
+type postgresSink struct {
+	db    *gosql.DB
+	uri   string
+	jobID jobspb.JobID
+
+	// rowBuf holds the staging table rows that have been emitted but not yet
+	// flushed.
+	rowBuf  []interface{}
+	metrics *sliMetrics
+}
+
+var _ Sink = (*postgresSink)(nil)
+
+func makePostgresSink(
+	u sinkURL, jobID jobspb.JobID, opts map[string]string, m *sliMetrics,
+) (Sink, error) {
+	if u.Path == `` || u.Path == `/` {
+		return nil, errors.Errorf(`must specify database`)
+	}
+	if format := changefeedbase.FormatType(opts[changefeedbase.OptFormat]); format != changefeedbase.OptFormatJSON {
+		return nil, errors.Errorf(`this sink requires %s=%s`,
+			changefeedbase.OptFormat, changefeedbase.OptFormatJSON)
+	}
+	if envelope := changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]); envelope != changefeedbase.OptEnvelopeWrapped {
+		return nil, errors.Errorf(`this sink requires %s=%s`,
+			changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+
+	uri := u.String()
+	u.consumeParam(`sslcert`)
+	u.consumeParam(`sslkey`)
+	u.consumeParam(`sslmode`)
+	u.consumeParam(`sslrootcert`)
+	u.consumeParam(`application_name`)
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown postgres sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	return &postgresSink{
+		uri:     uri,
+		jobID:   jobID,
+		metrics: m,
+	}, nil
+}
+
+// Dial implements the Sink interface.
+func (s *postgresSink) Dial() error {
+	db, err := gosql.Open(`postgres`, s.uri)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range []string{
+		postgresSinkCreateStagingTableStmt, postgresSinkCreateResolvedTableStmt,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return pgerror.Wrap(err, pgcode.CannotConnectNow, `connecting to postgres sink`)
+		}
+	}
+	s.db = db
+	return nil
+}
+
+// EmitRow implements the Sink interface.
+func (s *postgresSink) EmitRow(
+	ctx context.Context,
+	topicDescr TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+) error {
+	defer alloc.Release(ctx)
+	defer s.metrics.recordEmittedMessages()(1, mvcc, len(key)+len(value), sinkDoesNotCompress)
+
+	table, ok := topicDescr.(tableDescriptorTopic)
+	if !ok {
+		return errors.AssertionFailedf(`unexpected topic descriptor %T`, topicDescr)
+	}
+
+	// The key is a JSON array of the primary key values, which is turned into
+	// an object so that the rows can be applied without the table descriptor.
+	var keyVals []json.RawMessage
+	if err := json.Unmarshal(key, &keyVals); err != nil {
+		return errors.Wrap(err, `decoding key`)
+	}
+	idx := table.GetPrimaryIndex()
+	if len(keyVals) != idx.NumKeyColumns() {
+		return errors.AssertionFailedf(`expected %d key columns, got %d`,
+			idx.NumKeyColumns(), len(keyVals))
+	}
+	keyObj := make(map[string]json.RawMessage, len(keyVals))
+	for i, v := range keyVals {
+		keyObj[idx.GetKeyColumnName(i)] = v
+	}
+	keyJSON, err := json.Marshal(keyObj)
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		After json.RawMessage `json:"after"`
+	}
+	if err := json.Unmarshal(value, &envelope); err != nil {
+		return errors.Wrap(err, `decoding value`)
+	}
+	var valueJSON interface{}
+	if len(envelope.After) > 0 && !bytes.Equal(envelope.After, []byte(`null`)) {
+		valueJSON = string(envelope.After)
+	}
+
+	s.rowBuf = append(s.rowBuf,
+		int64(s.jobID), mvcc.WallTime, mvcc.Logical, table.GetName(), string(keyJSON), valueJSON)
+	if len(s.rowBuf)/postgresSinkStageCols >= postgresSinkStageBatchSize {
+		return s.stage(ctx)
+	}
+	return nil
+}
+
+// stage writes the buffered rows to the staging table.
+func (s *postgresSink) stage(ctx context.Context) error {
+	if len(s.rowBuf) == 0 {
+		return nil
+	}
+
+	var stmt strings.Builder
+	stmt.WriteString(postgresSinkStageStmt)
+	for i := 0; i < len(s.rowBuf); i++ {
+		if i == 0 {
+			stmt.WriteString(` VALUES (`)
+		} else if i%postgresSinkStageCols == 0 {
+			stmt.WriteString(`),(`)
+		} else {
+			stmt.WriteString(`,`)
+		}
+		fmt.Fprintf(&stmt, `$%d`, i+1)
+	}
+	// Rows may be emitted more than once; the duplicates are identical.
+	stmt.WriteString(`) ON CONFLICT DO NOTHING`)
+	if _, err := s.db.ExecContext(ctx, stmt.String(), s.rowBuf...); err != nil {
+		return err
+	}
+	s.rowBuf = s.rowBuf[:0]
+	return nil
+}
+
+// EmitResolvedTimestamp implements the Sink interface.
+func (s *postgresSink) EmitResolvedTimestamp(
+	ctx context.Context, _ Encoder, resolved hlc.Timestamp,
+) error {
+	defer s.metrics.recordResolvedCallback()()
+
+	// Rows emitted by this sink must be staged before they can be applied.
+	if err := s.stage(ctx); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil /* opts */)
+	if err != nil {
+		return err
+	}
+	if err := s.applyStaged(ctx, tx, resolved); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type postgresStagedRow struct {
+	table     string
+	keyJSON   string
+	key       map[string]interface{}
+	value     map[string]interface{}
+	isDeleted bool
+}
+
+// applyStaged applies the rows staged by this changefeed at or below the
+// resolved timestamp to the mirror tables and removes them from the staging
+// table. The staged rows are read and applied a page at a time so that they
+// never all need to be held in memory.
+func (s *postgresSink) applyStaged(ctx context.Context, tx *gosql.Tx, resolved hlc.Timestamp) error {
+	var after []interface{}
+	for {
+		page, last, err := s.readStagedPage(ctx, tx, resolved, after)
+		if err != nil {
+			return err
+		}
+		if err := applyStagedPage(ctx, tx, page); err != nil {
+			return err
+		}
+		if len(page) < postgresSinkApplyPageSize {
+			break
+		}
+		after = last
+	}
+
+	if _, err := tx.ExecContext(ctx, postgresSinkDeleteStagedStmt,
+		int64(s.jobID), resolved.WallTime, resolved.Logical); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, postgresSinkResolvedStmt,
+		int64(s.jobID), resolved.WallTime, resolved.Logical)
+	return err
+}
+
+// readStagedPage reads the next page of staged rows at or below the resolved
+// timestamp, starting after the staging table primary key given in after (or
+// from the first row if it is nil). It also returns the primary key of the
+// last row read, from which the following page starts.
+func (s *postgresSink) readStagedPage(
+	ctx context.Context, tx *gosql.Tx, resolved hlc.Timestamp, after []interface{},
+) (_ []postgresStagedRow, last []interface{}, _ error) {
+	stmt := postgresSinkSelectStagedStmt
+	args := []interface{}{int64(s.jobID), resolved.WallTime, resolved.Logical}
+	if after != nil {
+		stmt += postgresSinkSelectStagedAfterClause
+		args = append(args, after...)
+	}
+	stmt += postgresSinkSelectStagedOrderClause + fmt.Sprint(postgresSinkApplyPageSize)
+
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var staged []postgresStagedRow
+	var wall, logical int64
+	for rows.Next() {
+		var r postgresStagedRow
+		var valueJSON gosql.NullString
+		if err := rows.Scan(&wall, &logical, &r.table, &r.keyJSON, &valueJSON); err != nil {
+			_ = rows.Close()
+			return nil, nil, err
+		}
+		if err := decodeJSONObject(r.keyJSON, &r.key); err != nil {
+			_ = rows.Close()
+			return nil, nil, err
+		}
+		if r.isDeleted = !valueJSON.Valid; !r.isDeleted {
+			if err := decodeJSONObject(valueJSON.String, &r.value); err != nil {
+				_ = rows.Close()
+				return nil, nil, err
+			}
+		}
+		staged = append(staged, r)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(staged) > 0 {
+		r := &staged[len(staged)-1]
+		last = []interface{}{wall, logical, r.table, r.keyJSON}
+	}
+	return staged, last, nil
+}
+
+// applyStagedPage applies a page of staged rows, in MVCC timestamp order, to
+// the mirror tables.
+func applyStagedPage(ctx context.Context, tx *gosql.Tx, page []postgresStagedRow) error {
+	for _, b := range makePostgresApplyBatches(page) {
+		stmt, args, err := b.stmt()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, stmt, args...); err != nil {
+			return errors.Wrapf(err, `applying changes to %s`, b.table)
+		}
+	}
+	return nil
+}
+
+// postgresApplyBatch is a set of staged rows of a mirror table that are
+// applied by a single multi-row statement: either upserts of rows with the
+// same columns, or deletes.
+type postgresApplyBatch struct {
+	table     string
+	keyCols   []string
+	isDeleted bool
+	// cols are the columns of the upserted rows.
+	cols []string
+	rows []*postgresStagedRow
+}
+
+// makePostgresApplyBatches groups rows, which are in MVCC timestamp order, into
+// batches. Since all the rows are applied in the same transaction, only the
+// last change to each row determines the state of the mirror table, so the
+// earlier changes are skipped. Each row then appears at most once, which both
+// makes the order in which the batches are applied irrelevant and is required
+// by the upserts, which cannot change the same row twice.
+func makePostgresApplyBatches(rows []postgresStagedRow) []*postgresApplyBatch {
+	type rowKey struct{ table, key string }
+	latest := make(map[rowKey]int, len(rows))
+	for i := range rows {
+		latest[rowKey{rows[i].table, rows[i].keyJSON}] = i
+	}
+
+	var batches []*postgresApplyBatch
+	open := make(map[string]*postgresApplyBatch)
+	for i := range rows {
+		r := &rows[i]
+		if latest[rowKey{r.table, r.keyJSON}] != i {
+			continue
+		}
+		keyCols := sortedKeys(r.key)
+		var cols []string
+		if !r.isDeleted {
+			cols = sortedKeys(r.value)
+		}
+		// The names are quoted so that the batch key is unambiguous.
+		batchKey := fmt.Sprintf(`%s %t (%s) (%s)`, tree.NameString(r.table), r.isDeleted,
+			quotedNames(keyCols), quotedNames(cols))
+		b, ok := open[batchKey]
+		if !ok || len(b.rows) >= postgresSinkApplyBatchSize {
+			b = &postgresApplyBatch{table: r.table, keyCols: keyCols, isDeleted: r.isDeleted, cols: cols}
+			open[batchKey] = b
+			batches = append(batches, b)
+		}
+		b.rows = append(b.rows, r)
+	}
+	return batches
+}
+
+// stmt returns the statement that applies the batch to its mirror table: an
+// upsert for changed rows, or a delete for deleted rows.
+func (b *postgresApplyBatch) stmt() (string, []interface{}, error) {
+	var stmt strings.Builder
+	var args []interface{}
+	addTuple := func(cols []string, vals map[string]interface{}) error {
+		stmt.WriteString(`(`)
+		for i, col := range cols {
+			if i > 0 {
+				stmt.WriteString(`, `)
+			}
+			arg, err := jsonToSQLArg(vals[col])
+			if err != nil {
+				return err
+			}
+			args = append(args, arg)
+			fmt.Fprintf(&stmt, `$%d`, len(args))
+		}
+		stmt.WriteString(`)`)
+		return nil
+	}
+
+	if b.isDeleted {
+		fmt.Fprintf(&stmt, `DELETE FROM %s WHERE (%s) IN (`, tree.NameString(b.table), quotedNames(b.keyCols))
+		for i, r := range b.rows {
+			if i > 0 {
+				stmt.WriteString(`, `)
+			}
+			if err := addTuple(b.keyCols, r.key); err != nil {
+				return ``, nil, err
+			}
+		}
+		stmt.WriteString(`)`)
+		return stmt.String(), args, nil
+	}
+
+	fmt.Fprintf(&stmt, `INSERT INTO %s (%s) VALUES `, tree.NameString(b.table), quotedNames(b.cols))
+	for i, r := range b.rows {
+		if i > 0 {
+			stmt.WriteString(`, `)
+		}
+		if err := addTuple(b.cols, r.value); err != nil {
+			return ``, nil, err
+		}
+	}
+	fmt.Fprintf(&stmt, ` ON CONFLICT (%s) DO `, quotedNames(b.keyCols))
+	var updates []string
+	isKey := make(map[string]bool, len(b.keyCols))
+	for _, col := range b.keyCols {
+		isKey[col] = true
+	}
+	for _, col := range b.cols {
+		if !isKey[col] {
+			updates = append(updates, fmt.Sprintf(`%[1]s = excluded.%[1]s`, tree.NameString(col)))
+		}
+	}
+	if len(updates) == 0 {
+		stmt.WriteString(`NOTHING`)
+	} else {
+		stmt.WriteString(`UPDATE SET `)
+		stmt.WriteString(strings.Join(updates, `, `))
+	}
+	return stmt.String(), args, nil
+}
+
+func decodeJSONObject(s string, dest *map[string]interface{}) error {
+	dec := json.NewDecoder(strings.NewReader(s))
+	// Keep numbers as their original text to preserve their precision.
+	dec.UseNumber()
+	return errors.Wrap(dec.Decode(dest), `decoding staged row`)
+}
+
+func quotedNames(names []string) string {
+	q := make([]string, len(names))
+	for i, name := range names {
+		q[i] = tree.NameString(name)
+	}
+	return strings.Join(q, `, `)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonToSQLArg converts a value decoded from the JSON encoding of a datum (see
+// tree.AsJSON) into a statement argument. Scalars are passed as text and
+// converted by the target database to the type of the column they are written
+// to. Arrays are passed as array literals, and objects as JSON text.
+func jsonToSQLArg(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	case bool:
+		return t, nil
+	case []interface{}:
+		var buf strings.Builder
+		if err := writeArrayLiteral(&buf, t); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	case map[string]interface{}:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	default:
+		return nil, errors.AssertionFailedf(`unexpected JSON value %T`, v)
+	}
+}
+
+// writeArrayLiteral writes the array in the text format of arrays, e.g.
+// {1,"a b",NULL}.
+func writeArrayLiteral(buf *strings.Builder, arr []interface{}) error {
+	buf.WriteByte('{')
+	for i, elem := range arr {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		switch t := elem.(type) {
+		case nil:
+			buf.WriteString(`NULL`)
+		case []interface{}:
+			if err := writeArrayLiteral(buf, t); err != nil {
+				return err
+			}
+		default:
+			arg, err := jsonToSQLArg(t)
+			if err != nil {
+				return err
+			}
+			buf.WriteByte('"')
+			for _, c := range fmt.Sprint(arg) {
+				if c == '"' || c == '\\' {
+					buf.WriteByte('\\')
+				}
+				buf.WriteRune(c)
+			}
+			buf.WriteByte('"')
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// Flush implements the Sink interface.
+func (s *postgresSink) Flush(ctx context.Context) error {
+	defer s.metrics.recordFlushRequestCallback()()
+	return s.stage(ctx)
+}
+
+// Close implements the Sink interface.
+func (s *postgresSink) Close() error {
+	// s.db is only nil if Dial was not called or failed.
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresSinkApplyBatches(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	staged := func(table, keyJSON, valueJSON string) postgresStagedRow {
+		r := postgresStagedRow{table: table, keyJSON: keyJSON, isDeleted: valueJSON == ``}
+		require.NoError(t, decodeJSONObject(keyJSON, &r.key))
+		if !r.isDeleted {
+			require.NoError(t, decodeJSONObject(valueJSON, &r.value))
+		}
+		return r
+	}
+	rows := []postgresStagedRow{
+		staged(`t`, `{"a":1}`, `{"a":1,"b":"one"}`),
+		staged(`t`, `{"a":2}`, `{"a":2,"b":"two"}`),
+		staged(`t`, `{"a":3}`, `{"a":3,"b":"three"}`),
+		staged(`t`, `{"a":4}`, ``),
+		// Only the last change to a row is applied.
+		staged(`t`, `{"a":1}`, `{"a":1,"b":"ONE"}`),
+		staged(`t`, `{"a":2}`, ``),
+		staged(`u`, `{"k1":"x","k2":5}`, `{"k1":"x","k2":5}`),
+		staged(`u`, `{"k1":"y","k2":6}`, ``),
+		staged(`t`, `{"a":5}`, ``),
+	}
+
+	type stmt struct {
+		sql  string
+		args []interface{}
+	}
+	var stmts []stmt
+	for _, b := range makePostgresApplyBatches(rows) {
+		sql, args, err := b.stmt()
+		require.NoError(t, err)
+		stmts = append(stmts, stmt{sql, args})
+	}
+	require.Equal(t, []stmt{
+		{
+			sql:  `INSERT INTO t (a, b) VALUES ($1, $2), ($3, $4) ON CONFLICT (a) DO UPDATE SET b = excluded.b`,
+			args: []interface{}{`3`, `three`, `1`, `ONE`},
+		},
+		{
+			sql:  `DELETE FROM t WHERE (a) IN (($1), ($2), ($3))`,
+			args: []interface{}{`4`, `2`, `5`},
+		},
+		{
+			sql:  `INSERT INTO u (k1, k2) VALUES ($1, $2) ON CONFLICT (k1, k2) DO NOTHING`,
+			args: []interface{}{`x`, `5`},
+		},
+		{
+			sql:  `DELETE FROM u WHERE (k1, k2) IN (($1, $2))`,
+			args: []interface{}{`y`, `6`},
+		},
+	}, stmts)
+
+	// Batches are split once they reach the maximum number of rows.
+	rows = rows[:0]
+	for i := 0; i < postgresSinkApplyBatchSize+1; i++ {
+		rows = append(rows, staged(`t`, fmt.Sprintf(`{"a":%d}`, i), ``))
+	}
+	batches := makePostgresApplyBatches(rows)
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0].rows, postgresSinkApplyBatchSize)
+	require.Len(t, batches[1].rows, 1)
+}
@@ -0,0 +1,478 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+func isPulsarSink(u *url.URL) bool {
+	switch u.Scheme {
+	case changefeedbase.SinkSchemePulsar, changefeedbase.SinkSchemePulsarTLS:
+		return true
+	default:
+		return false
+	}
+}
+
+// pulsarProducer is the subset of pulsar.Producer used by pulsarSink. It
+// exists for testing.
+type pulsarProducer interface {
+	SendAsync(context.Context, *pulsar.ProducerMessage, func(pulsar.MessageID, *pulsar.ProducerMessage, error))
+	Flush() error
+	Close()
+}
+
+// pulsarClient is the subset of pulsar.Client used by pulsarSink. It exists
+// for testing.
+type pulsarClient interface {
+	createProducer(topic string) (pulsarProducer, error)
+	topicPartitions(topic string) ([]string, error)
+	Close()
+}
+
+type pulsarClientAdapter struct {
+	client pulsar.Client
+	cfg    *pulsarSinkConfig
+}
+
+var _ pulsarClient = (*pulsarClientAdapter)(nil)
+
+func (a *pulsarClientAdapter) createProducer(topic string) (pulsarProducer, error) {
+	return a.client.CreateProducer(a.cfg.producerOptions(topic))
+}
+
+func (a *pulsarClientAdapter) topicPartitions(topic string) ([]string, error) {
+	return a.client.TopicPartitions(topic)
+}
+
+func (a *pulsarClientAdapter) Close() {
+	a.client.Close()
+}
+
+// pulsarSinkConfig is the configuration of the pulsar sink, which can be
+// overridden with the pulsar_sink_config option.
+type pulsarSinkConfig struct {
+	// Flush describes the batching of messages by the producers. See
+	// pulsar.ProducerOptions.
+	Flush struct {
+		// Messages is the maximum number of messages in a batch.
+		Messages int `json:",omitempty"`
+		// Bytes is the maximum size of a batch.
+		Bytes int `json:",omitempty"`
+		// Frequency is the maximum amount of time a message waits to be sent.
+		Frequency jsonDuration `json:",omitempty"`
+	}
+	// Compression is one of NONE, LZ4, ZLIB or ZSTD.
+	Compression string `json:",omitempty"`
+}
+
+func (c *pulsarSinkConfig) compressionType() (pulsar.CompressionType, error) {
+	switch strings.ToUpper(c.Compression) {
+	case ``, `NONE`:
+		return pulsar.NoCompression, nil
+	case `LZ4`:
+		return pulsar.LZ4, nil
+	case `ZLIB`:
+		return pulsar.ZLib, nil
+	case `ZSTD`:
+		return pulsar.ZSTD, nil
+	default:
+		return pulsar.NoCompression, errors.Errorf(
+			`invalid compression %q, must be one of NONE, LZ4, ZLIB or ZSTD`, c.Compression)
+	}
+}
+
+func (c *pulsarSinkConfig) Validate() error {
+	if c.Flush.Messages < 0 || c.Flush.Bytes < 0 || c.Flush.Frequency < 0 {
+		return errors.New("Flush settings must be >= 0")
+	}
+	_, err := c.compressionType()
+	return err
+}
+
+func (c *pulsarSinkConfig) producerOptions(topic string) pulsar.ProducerOptions {
+	// The configuration is validated when the sink is created.
+	compression, _ := c.compressionType()
+	// The default message router sends messages with the same key to the same
+	// partition, which preserves the per-key ordering guarantees of changefeeds.
+	opts := pulsar.ProducerOptions{
+		Topic:           topic,
+		CompressionType: compression,
+	}
+	if c.Flush.Messages > 0 {
+		opts.BatchingMaxMessages = uint(c.Flush.Messages)
+	}
+	if c.Flush.Bytes > 0 {
+		opts.BatchingMaxSize = uint(c.Flush.Bytes)
+	}
+	if c.Flush.Frequency > 0 {
+		opts.BatchingMaxPublishDelay = time.Duration(c.Flush.Frequency)
+	}
+	return opts
+}
+
+func getPulsarSinkConfig(opts map[string]string) (*pulsarSinkConfig, error) {
+	cfg := &pulsarSinkConfig{}
+	if configStr, haveOverride := opts[changefeedbase.OptPulsarSinkConfig]; haveOverride {
+		if err := json.Unmarshal([]byte(configStr), cfg); err != nil {
+			return nil, errors.Wrapf(err,
+				"failed to parse pulsar config; check %s option", changefeedbase.OptPulsarSinkConfig)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid pulsar configuration")
+	}
+	return cfg, nil
+}
+
+// pulsarSink emits to Apache Pulsar asynchronously. It is not concurrency-safe;
+// all calls to Emit and Flush should be from the same goroutine.
+type pulsarSink struct {
+	ctx        context.Context
+	clientOpts pulsar.ClientOptions
+	cfg        *pulsarSinkConfig
+	topics     map[descpb.ID]string
+	metrics    *sliMetrics
+
+	// caCertFile is a temporary file holding the CA certificate, since the
+	// pulsar client only reads trusted certificates from a file.
+	caCertFile string
+
+	// newClient is replaced in tests.
+	newClient func() (pulsarClient, error)
+	client    pulsarClient
+	producers map[string]pulsarProducer
+
+	mu struct {
+		syncutil.Mutex
+		inflight int64
+		flushErr error
+		flushCh  chan struct{}
+	}
+}
+
+var _ Sink = (*pulsarSink)(nil)
+
+func makePulsarSink(
+	ctx context.Context,
+	u sinkURL,
+	targets jobspb.ChangefeedTargets,
+	opts map[string]string,
+	m *sliMetrics,
+) (_ Sink, retErr error) {
+	topicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
+	topicName := u.consumeParam(changefeedbase.SinkParamTopicName)
+	if schemaTopic := u.consumeParam(changefeedbase.SinkParamSchemaTopic); schemaTopic != `` {
+		return nil, errors.Errorf(`%s is not yet supported`, changefeedbase.SinkParamSchemaTopic)
+	}
+
+	var tlsSkipVerify bool
+	var caCert, clientCert, clientKey []byte
+	if _, err := u.consumeBool(changefeedbase.SinkParamSkipTLSVerify, &tlsSkipVerify); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamCACert, &caCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientCert, &clientCert); err != nil {
+		return nil, err
+	}
+	if err := u.decodeBase64(changefeedbase.SinkParamClientKey, &clientKey); err != nil {
+		return nil, err
+	}
+
+	cfg, err := getPulsarSinkConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &pulsarSink{
+		ctx:       ctx,
+		cfg:       cfg,
+		topics:    makeTopicsMap(topicPrefix, topicName, targets),
+		metrics:   m,
+		producers: make(map[string]pulsarProducer),
+	}
+	defer func() {
+		if retErr != nil {
+			sink.removeCACertFile()
+		}
+	}()
+
+	// Only the scheme and host are passed on to the pulsar client, which uses
+	// the scheme to decide whether to use TLS.
+	serviceURL := url.URL{Scheme: u.Scheme, Host: u.Host}
+	sink.clientOpts = pulsar.ClientOptions{
+		URL:                        serviceURL.String(),
+		TLSAllowInsecureConnection: tlsSkipVerify,
+	}
+
+	tlsEnabled := u.Scheme == changefeedbase.SinkSchemePulsarTLS
+	if !tlsEnabled {
+		if caCert != nil {
+			return nil, errors.Errorf(`%s requires the %s scheme`,
+				changefeedbase.SinkParamCACert, changefeedbase.SinkSchemePulsarTLS)
+		}
+		if clientCert != nil {
+			return nil, errors.Errorf(`%s requires the %s scheme`,
+				changefeedbase.SinkParamClientCert, changefeedbase.SinkSchemePulsarTLS)
+		}
+	}
+	if clientCert != nil && clientKey == nil {
+		return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey)
+	} else if clientKey != nil && clientCert == nil {
+		return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientKey, changefeedbase.SinkParamClientCert)
+	}
+	if clientCert != nil {
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, `invalid client certificate data provided`)
+		}
+		sink.clientOpts.Authentication = pulsar.NewAuthenticationFromTLSCertSupplier(
+			func() (*tls.Certificate, error) { return &cert, nil })
+	}
+	if caCert != nil {
+		f, err := ioutil.TempFile(``, `changefeed-pulsar-ca`)
+		if err != nil {
+			return nil, err
+		}
+		sink.caCertFile = f.Name()
+		_, err = f.Write(caCert)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		sink.clientOpts.TLSTrustCertsFilePath = sink.caCertFile
+	}
+
+	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown pulsar sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	sink.newClient = func() (pulsarClient, error) {
+		client, err := pulsar.NewClient(sink.clientOpts)
+		if err != nil {
+			return nil, err
+		}
+		return &pulsarClientAdapter{client: client, cfg: sink.cfg}, nil
+	}
+	return sink, nil
+}
+
+func (s *pulsarSink) removeCACertFile() {
+	if s.caCertFile == `` {
+		return
+	}
+	if err := os.Remove(s.caCertFile); err != nil {
+		log.Warningf(s.ctx, `failed to remove pulsar CA certificate file: %v`, err)
+	}
+	s.caCertFile = ``
+}
+
+// Dial implements the Sink interface.
+func (s *pulsarSink) Dial() error {
+	client, err := s.newClient()
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.CannotConnectNow,
+			`connecting to pulsar: %s`, s.clientOpts.URL)
+	}
+	s.client = client
+	// Create the producers eagerly so that connection errors surface here.
+	for _, topic := range s.topics {
+		if _, err := s.producer(topic); err != nil {
+			return pgerror.Wrapf(err, pgcode.CannotConnectNow,
+				`connecting to pulsar: %s`, s.clientOpts.URL)
+		}
+	}
+	return nil
+}
+
+// producer returns the producer for the given topic, creating it if needed.
+func (s *pulsarSink) producer(topic string) (pulsarProducer, error) {
+	if p, ok := s.producers[topic]; ok {
+		return p, nil
+	}
+	p, err := s.client.createProducer(topic)
+	if err != nil {
+		return nil, errors.Wrapf(err, `creating pulsar producer for topic %s`, topic)
+	}
+	s.producers[topic] = p
+	return p, nil
+}
+
+// Close implements the Sink interface.
+func (s *pulsarSink) Close() error {
+	// If we're shutting down, we don't care what happens to the outstanding
+	// messages.
+	for topic, p := range s.producers {
+		p.Close()
+		delete(s.producers, topic)
+	}
+	if s.client != nil {
+		s.client.Close()
+	}
+	s.removeCACertFile()
+	return nil
+}
+
+// EmitRow implements the Sink interface.
+func (s *pulsarSink) EmitRow(
+	ctx context.Context,
+	topicDescr TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	alloc kvevent.Alloc,
+) error {
+	topic, isKnownTopic := s.topics[topicDescr.GetID()]
+	if !isKnownTopic {
+		return errors.Errorf(`cannot emit to undeclared topic: %s`, topicDescr.GetName())
+	}
+	msg := &pulsar.ProducerMessage{
+		Key:     string(key),
+		Payload: value,
+	}
+	return s.emitMessage(ctx, topic, msg, messageMetadata{
+		alloc: alloc, mvcc: mvcc, updateMetrics: s.metrics.recordEmittedMessages(),
+	})
+}
+
+// EmitResolvedTimestamp implements the Sink interface.
+func (s *pulsarSink) EmitResolvedTimestamp(
+	ctx context.Context, encoder Encoder, resolved hlc.Timestamp,
+) error {
+	defer s.metrics.recordResolvedCallback()()
+
+	for _, topic := range s.topics {
+		payload, err := encoder.EncodeResolvedTimestamp(ctx, topic, resolved)
+		if err != nil {
+			return err
+		}
+		// The encoder may reuse the payload's buffer.
+		payload = append([]byte(nil), payload...)
+		// Resolved timestamps are sent to every partition of the topic
+		// directly, since keyless messages are otherwise spread across
+		// partitions. A partitioned topic is made of one topic per partition,
+		// and a non-partitioned topic is its own single partition.
+		partitions, err := s.client.topicPartitions(topic)
+		if err != nil {
+			return err
+		}
+		for _, partition := range partitions {
+			msg := &pulsar.ProducerMessage{Payload: payload}
+			if err := s.emitMessage(ctx, partition, msg, messageMetadata{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *pulsarSink) emitMessage(
+	ctx context.Context, topic string, msg *pulsar.ProducerMessage, m messageMetadata,
+) error {
+	p, err := s.producer(topic)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.mu.inflight++
+	if log.V(2) {
+		log.Infof(ctx, "emitting %d inflight records to pulsar", s.mu.inflight)
+	}
+	s.mu.Unlock()
+
+	p.SendAsync(ctx, msg, func(_ pulsar.MessageID, msg *pulsar.ProducerMessage, err error) {
+		if m.updateMetrics != nil && err == nil {
+			m.updateMetrics(1, m.mvcc, len(msg.Key)+len(msg.Payload), sinkDoesNotCompress)
+		}
+		m.alloc.Release(s.ctx)
+		s.finishInflightMessage(err)
+	})
+	return nil
+}
+
+func (s *pulsarSink) finishInflightMessage(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.inflight--
+	if s.mu.flushErr == nil && err != nil {
+		s.mu.flushErr = err
+	}
+	if s.mu.inflight == 0 && s.mu.flushCh != nil {
+		s.mu.flushCh <- struct{}{}
+		s.mu.flushCh = nil
+	}
+}
+
+// Flush implements the Sink interface.
+func (s *pulsarSink) Flush(ctx context.Context) error {
+	defer s.metrics.recordFlushRequestCallback()()
+
+	// Ask the producers to send their batches right away rather than waiting
+	// for Flush.Frequency to elapse.
+	for topic, p := range s.producers {
+		if err := p.Flush(); err != nil {
+			return errors.Wrapf(err, `flushing pulsar topic %s`, topic)
+		}
+	}
+
+	flushCh := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	inflight := s.mu.inflight
+	flushErr := s.mu.flushErr
+	s.mu.flushErr = nil
+	immediateFlush := inflight == 0 || flushErr != nil
+	if !immediateFlush {
+		s.mu.flushCh = flushCh
+	}
+	s.mu.Unlock()
+
+	if immediateFlush {
+		return flushErr
+	}
+
+	if log.V(1) {
+		log.Infof(ctx, "flush waiting for %d inflight messages", inflight)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-flushCh:
+		s.mu.Lock()
+		flushErr := s.mu.flushErr
+		s.mu.flushErr = nil
+		s.mu.Unlock()
+		return flushErr
+	}
+}
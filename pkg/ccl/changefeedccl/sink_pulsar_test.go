@@ -0,0 +1,217 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type pulsarSentMessage struct {
+	topic    string
+	msg      *pulsar.ProducerMessage
+	callback func(pulsar.MessageID, *pulsar.ProducerMessage, error)
+}
+
+type pulsarClientMock struct {
+	syncutil.Mutex
+	sent       []pulsarSentMessage
+	partitions map[string][]string
+	flushes    int
+}
+
+var _ pulsarClient = (*pulsarClientMock)(nil)
+
+func (c *pulsarClientMock) createProducer(topic string) (pulsarProducer, error) {
+	return &pulsarProducerMock{topic: topic, client: c}, nil
+}
+
+func (c *pulsarClientMock) topicPartitions(topic string) ([]string, error) {
+	if partitions, ok := c.partitions[topic]; ok {
+		return partitions, nil
+	}
+	return []string{topic}, nil
+}
+
+func (c *pulsarClientMock) Close() {}
+
+// acknowledge acknowledges every sent message, failing the ones whose payload
+// is in fail.
+func (c *pulsarClientMock) acknowledge(fail ...string) {
+	c.Lock()
+	sent := c.sent
+	c.sent = nil
+	c.Unlock()
+	for _, m := range sent {
+		var err error
+		for _, f := range fail {
+			if string(m.msg.Payload) == f {
+				err = errors.New(f)
+			}
+		}
+		m.callback(nil, m.msg, err)
+	}
+}
+
+func (c *pulsarClientMock) sentPayloads() map[string][]string {
+	c.Lock()
+	defer c.Unlock()
+	payloads := make(map[string][]string)
+	for _, m := range c.sent {
+		payloads[m.topic] = append(payloads[m.topic], string(m.msg.Payload))
+	}
+	return payloads
+}
+
+type pulsarProducerMock struct {
+	topic  string
+	client *pulsarClientMock
+}
+
+func (p *pulsarProducerMock) SendAsync(
+	_ context.Context,
+	msg *pulsar.ProducerMessage,
+	callback func(pulsar.MessageID, *pulsar.ProducerMessage, error),
+) {
+	p.client.Lock()
+	defer p.client.Unlock()
+	p.client.sent = append(p.client.sent, pulsarSentMessage{
+		topic: p.topic, msg: msg, callback: callback,
+	})
+}
+
+func (p *pulsarProducerMock) Flush() error {
+	p.client.Lock()
+	defer p.client.Unlock()
+	p.client.flushes++
+	return nil
+}
+
+func (p *pulsarProducerMock) Close() {}
+
+func TestPulsarSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	u, err := url.Parse(`pulsar://localhost:6650?topic_prefix=p_`)
+	require.NoError(t, err)
+	s, err := makePulsarSink(ctx, sinkURL{URL: u}, makeChangefeedTargets(`t`), nil, nil)
+	require.NoError(t, err)
+	sink := s.(*pulsarSink)
+	client := &pulsarClientMock{
+		partitions: map[string][]string{`p_t`: {`p_t-partition-0`, `p_t-partition-1`}},
+	}
+	sink.newClient = func() (pulsarClient, error) { return client, nil }
+	require.NoError(t, sink.Dial())
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	// No inflight
+	require.NoError(t, sink.Flush(ctx))
+
+	var pool testAllocPool
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`k`), []byte(`1`), zeroTS, zeroTS, pool.alloc()))
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`k`), []byte(`2`), zeroTS, zeroTS, pool.alloc()))
+	require.Equal(t, map[string][]string{`p_t`: {`1`, `2`}}, client.sentPayloads())
+	require.Error(t, sink.EmitRow(ctx, topic(`u`), nil, []byte(`3`), zeroTS, zeroTS, zeroAlloc))
+
+	// Flush waits for the acknowledgements.
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if err := sink.Flush(timeoutCtx); !testutils.IsError(err, `context deadline exceeded`) {
+		t.Fatalf(`expected "context deadline exceeded" error got: %+v`, err)
+	}
+	client.acknowledge(`2`)
+	if err := sink.Flush(ctx); !testutils.IsError(err, `2`) {
+		t.Fatalf(`expected "2" error got: %+v`, err)
+	}
+	require.NoError(t, sink.Flush(ctx))
+	require.EqualValues(t, 0, pool.used())
+	// Flush asks the producers to send their batches right away.
+	require.NotZero(t, client.flushes)
+
+	// Resolved timestamps are sent to every partition.
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, testEncoder{}, hlc.Timestamp{WallTime: 1}))
+	require.Equal(t, map[string][]string{
+		`p_t-partition-0`: {`0.000000001,0`},
+		`p_t-partition-1`: {`0.000000001,0`},
+	}, client.sentPayloads())
+	client.acknowledge()
+	require.NoError(t, sink.Flush(ctx))
+}
+
+func TestPulsarSinkConfig(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	makeSink := func(uri string, opts map[string]string) (*pulsarSink, error) {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+		s, err := makePulsarSink(ctx, sinkURL{URL: u}, makeChangefeedTargets(`t`), opts, nil)
+		if err != nil {
+			return nil, err
+		}
+		return s.(*pulsarSink), nil
+	}
+
+	t.Run("batching", func(t *testing.T) {
+		s, err := makeSink(`pulsar://localhost:6650`, map[string]string{
+			changefeedbase.OptPulsarSinkConfig: `{"Flush": {"Messages": 100, "Bytes": 1000, "Frequency": "1s"}, "Compression": "lz4"}`,
+		})
+		require.NoError(t, err)
+		defer func() { require.NoError(t, s.Close()) }()
+		opts := s.cfg.producerOptions(`t`)
+		require.EqualValues(t, 100, opts.BatchingMaxMessages)
+		require.EqualValues(t, 1000, opts.BatchingMaxSize)
+		require.Equal(t, time.Second, opts.BatchingMaxPublishDelay)
+		require.Equal(t, pulsar.LZ4, opts.CompressionType)
+		require.Equal(t, `pulsar://localhost:6650`, s.clientOpts.URL)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		caCert := base64.StdEncoding.EncodeToString([]byte(`ca`))
+		s, err := makeSink(`pulsar+ssl://localhost:6651?ca_cert=`+url.QueryEscape(caCert), nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, s.clientOpts.TLSTrustCertsFilePath)
+		require.Equal(t, `pulsar+ssl://localhost:6651`, s.clientOpts.URL)
+		require.NoError(t, s.Close())
+		require.Empty(t, s.caCertFile)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		caCert := url.QueryEscape(base64.StdEncoding.EncodeToString([]byte(`ca`)))
+		for uri, expected := range map[string]string{
+			`pulsar://localhost:6650?ca_cert=` + caCert:         `ca_cert requires the pulsar\+ssl scheme`,
+			`pulsar+ssl://localhost:6650?client_cert=` + caCert: `client_cert requires client_key to be set`,
+			`pulsar://localhost:6650?foo=bar`:                   `unknown pulsar sink query parameters: foo`,
+		} {
+			_, err := makeSink(uri, nil)
+			require.Error(t, err, uri)
+			require.Regexp(t, expected, err.Error(), uri)
+		}
+		_, err := makeSink(`pulsar://localhost:6650`, map[string]string{
+			changefeedbase.OptPulsarSinkConfig: `{"Compression": "snappy"}`,
+		})
+		require.Regexp(t, `invalid compression "snappy"`, err)
+	})
+}
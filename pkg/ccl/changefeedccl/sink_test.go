@@ -506,6 +506,74 @@ func TestSQLSink(t *testing.T) {
 	)
 }
 
+func TestPostgresSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDBRaw, _ := serverutils.StartServer(t, base.TestServerArgs{UseDatabase: "d"})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(sqlDBRaw)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	const createFoo = `CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT[])`
+	sqlDB.Exec(t, createFoo)
+	fooDesc, err := parseTableDesc(createFoo)
+	require.NoError(t, err)
+	fooTopic := tableDescriptorTopic{fooDesc}
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), t.Name(), url.User(security.RootUser))
+	defer cleanup()
+	pgURL.Path = `d`
+
+	opts := map[string]string{
+		changefeedbase.OptFormat:   string(changefeedbase.OptFormatJSON),
+		changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeWrapped),
+	}
+	sink, err := makePostgresSink(sinkURL{URL: &pgURL}, jobspb.JobID(1), opts, nil)
+	require.NoError(t, err)
+	require.NoError(t, sink.Dial())
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	var pool testAllocPool
+	emit := func(key, value string, mvcc hlc.Timestamp) {
+		require.NoError(t, sink.EmitRow(ctx, fooTopic, []byte(key), []byte(value), mvcc, mvcc, pool.alloc()))
+	}
+	emit(`[1]`, `{"after": {"a": 1, "b": "one", "c": [1, 2]}}`, ts(1))
+	emit(`[2]`, `{"after": {"a": 2, "b": "two \"quoted\"", "c": null}}`, ts(1))
+	emit(`[1]`, `{"after": {"a": 1, "b": "uno", "c": [3]}}`, ts(2))
+	emit(`[1]`, `{"after": null}`, ts(3))
+	require.EqualValues(t, 0, pool.used())
+
+	// Nothing is applied until a resolved timestamp is emitted.
+	require.NoError(t, sink.Flush(ctx))
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM `+postgresSinkStagingTable, [][]string{{`4`}})
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM foo`, [][]string{{`0`}})
+
+	var e testEncoder
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, e, ts(2)))
+	sqlDB.CheckQueryResults(t, `SELECT a, b, c FROM foo ORDER BY a`, [][]string{
+		{`1`, `uno`, `{3}`},
+		{`2`, `two "quoted"`, `NULL`},
+	})
+	sqlDB.CheckQueryResults(t,
+		`SELECT job_id, resolved_wall, resolved_logical FROM `+postgresSinkResolvedTable,
+		[][]string{{`1`, `2`, `0`}})
+
+	require.NoError(t, sink.EmitResolvedTimestamp(ctx, e, ts(3)))
+	sqlDB.CheckQueryResults(t, `SELECT a, b, c FROM foo ORDER BY a`, [][]string{
+		{`2`, `two "quoted"`, `NULL`},
+	})
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM `+postgresSinkStagingTable, [][]string{{`0`}})
+
+	// Only format=json and envelope=wrapped are supported.
+	_, err = makePostgresSink(sinkURL{URL: &pgURL}, jobspb.JobID(1), map[string]string{
+		changefeedbase.OptFormat:   string(changefeedbase.OptFormatJSON),
+		changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeRow),
+	}, nil)
+	require.EqualError(t, err, `this sink requires envelope=wrapped`)
+}
+
 func TestSaramaConfigOptionParsing(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -0,0 +1,236 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+const (
+	txnBoundaryBegin  = `begin`
+	txnBoundaryCommit = `commit`
+)
+
+// txnBoundary is a marker emitted to each topic touched by a transaction
+// before (txnBoundaryBegin) and after (txnBoundaryCommit) the transaction's
+// rows in that topic.
+type txnBoundary struct {
+	typ           string
+	txnID         uuid.UUID
+	mvccTimestamp hlc.Timestamp
+	// rows is the number of rows of the transaction emitted to the topic. It is
+	// only set for txnBoundaryCommit markers.
+	rows int
+}
+
+// txnGroupKey identifies the rows of one transaction. Rows whose transaction
+// is not known (see kvevent.Event.TxnID) are grouped by their MVCC timestamp
+// alone. Since every row in such a group was committed at the same timestamp,
+// applying the group atomically is still consistent, even though it may
+// combine several transactions (see txnGroupingBuffer.flush).
+type txnGroupKey struct {
+	mvccTimestamp hlc.Timestamp
+	txnID         uuid.UUID
+}
+
+func (k txnGroupKey) less(o txnGroupKey) bool {
+	if !k.mvccTimestamp.Equal(o.mvccTimestamp) {
+		return k.mvccTimestamp.Less(o.mvccTimestamp)
+	}
+	return bytes.Compare(k.txnID.GetBytes(), o.txnID.GetBytes()) < 0
+}
+
+type bufferedRow struct {
+	topic      TopicDescriptor
+	key, value []byte
+	updated    hlc.Timestamp
+	alloc      kvevent.Alloc
+}
+
+type txnGroup struct {
+	key  txnGroupKey
+	rows []bufferedRow
+}
+
+// txnGroupingBuffer implements the transaction_boundaries option. It holds
+// the encoded rows of a changeAggregator until its local frontier reaches their
+// MVCC timestamp, at which point no more rows of their transactions can be
+// emitted. The rows are then emitted one transaction at a time, in MVCC
+// timestamp order, each surrounded by txnBoundary markers.
+//
+// A changefeed with this option is planned with a single changeAggregator,
+// next to the changeFrontier, which watches every span of the changefeed (see
+// changefeeddist.StartDistChangefeed). Its local frontier is therefore the
+// frontier of the changefeed, and each group holds every row of its
+// transaction.
+//
+// The rows whose transaction is not known are those of the initial scan and
+// of rangefeed catch-up scans, which happen when a changefeed resumes or a
+// rangefeed is restarted. Such a row may belong to any transaction committed at
+// its timestamp, so every row at that timestamp is emitted in a single group
+// with a null transaction ID. The group may combine several transactions, but
+// never splits one.
+//
+// The memory of the buffered rows stays reserved (via their kvevent.Alloc)
+// until they are emitted, which throttles the kvfeed if the frontier lags.
+type txnGroupingBuffer struct {
+	sink    Sink
+	encoder *jsonEncoder
+	groups  map[txnGroupKey]*txnGroup
+}
+
+func newTxnGroupingBuffer(sink Sink, encoder *jsonEncoder) *txnGroupingBuffer {
+	return &txnGroupingBuffer{
+		sink:    sink,
+		encoder: encoder,
+		groups:  make(map[txnGroupKey]*txnGroup),
+	}
+}
+
+// add buffers a row. The key and value must remain valid until the row is
+// emitted.
+func (b *txnGroupingBuffer) add(
+	topic TopicDescriptor,
+	key, value []byte,
+	updated, mvcc hlc.Timestamp,
+	txnID uuid.UUID,
+	alloc kvevent.Alloc,
+) {
+	groupKey := txnGroupKey{mvccTimestamp: mvcc, txnID: txnID}
+	g, ok := b.groups[groupKey]
+	if !ok {
+		g = &txnGroup{key: groupKey}
+		b.groups[groupKey] = g
+	}
+	g.rows = append(g.rows, bufferedRow{
+		topic: topic, key: key, value: value, updated: updated, alloc: alloc,
+	})
+}
+
+// minTimestamp returns the lowest MVCC timestamp of the buffered rows, or the
+// empty timestamp if nothing is buffered.
+func (b *txnGroupingBuffer) minTimestamp() hlc.Timestamp {
+	var min hlc.Timestamp
+	for k := range b.groups {
+		if min.IsEmpty() || k.mvccTimestamp.Less(min) {
+			min = k.mvccTimestamp
+		}
+	}
+	return min
+}
+
+// flush emits every transaction with an MVCC timestamp at or below the given
+// frontier.
+func (b *txnGroupingBuffer) flush(ctx context.Context, frontier hlc.Timestamp) error {
+	var ready []*txnGroup
+	for k, g := range b.groups {
+		if k.mvccTimestamp.LessEq(frontier) {
+			ready = append(ready, g)
+		}
+	}
+	// Merge the groups at the timestamps of the rows whose transaction is not
+	// known into the groups of these rows.
+	unknown := make(map[hlc.Timestamp]*txnGroup)
+	for _, g := range ready {
+		if g.key.txnID == (uuid.UUID{}) {
+			unknown[g.key.mvccTimestamp] = g
+		}
+	}
+	if len(unknown) > 0 {
+		merged := ready[:0]
+		for _, g := range ready {
+			if u, ok := unknown[g.key.mvccTimestamp]; ok && u != g {
+				u.rows = append(u.rows, g.rows...)
+				delete(b.groups, g.key)
+				continue
+			}
+			merged = append(merged, g)
+		}
+		ready = merged
+	}
+	sort.Slice(ready, func(i, j int) bool { return ready[i].key.less(ready[j].key) })
+	for _, g := range ready {
+		if err := b.emitGroup(ctx, g); err != nil {
+			return err
+		}
+		// The group is only removed once it has been emitted, so that
+		// minTimestamp stays a lower bound on the timestamps of the rows being
+		// written (see changeAggregatorLowerBoundOracle).
+		delete(b.groups, g.key)
+	}
+	return nil
+}
+
+func (b *txnGroupingBuffer) emitGroup(ctx context.Context, g *txnGroup) error {
+	var topics []TopicDescriptor
+	rowsByTopic := make(map[string]int)
+	for _, r := range g.rows {
+		name := r.topic.GetName()
+		if _, ok := rowsByTopic[name]; !ok {
+			topics = append(topics, r.topic)
+		}
+		rowsByTopic[name]++
+	}
+
+	emitBoundary := func(topic TopicDescriptor, typ string) error {
+		boundary := txnBoundary{
+			typ:           typ,
+			txnID:         g.key.txnID,
+			mvccTimestamp: g.key.mvccTimestamp,
+		}
+		if typ == txnBoundaryCommit {
+			boundary.rows = rowsByTopic[topic.GetName()]
+		}
+		key, value, err := b.encoder.EncodeTransactionBoundary(ctx, boundary)
+		if err != nil {
+			return err
+		}
+		return b.sink.EmitRow(
+			ctx, topic, key, value, g.key.mvccTimestamp, g.key.mvccTimestamp, kvevent.Alloc{},
+		)
+	}
+
+	for _, topic := range topics {
+		if err := emitBoundary(topic, txnBoundaryBegin); err != nil {
+			return err
+		}
+	}
+	for i := range g.rows {
+		r := &g.rows[i]
+		alloc := r.alloc
+		r.alloc = kvevent.Alloc{}
+		if err := b.sink.EmitRow(
+			ctx, r.topic, r.key, r.value, r.updated, g.key.mvccTimestamp, alloc,
+		); err != nil {
+			return err
+		}
+	}
+	for _, topic := range topics {
+		if err := emitBoundary(topic, txnBoundaryCommit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close releases the memory of every row that has not been emitted.
+func (b *txnGroupingBuffer) close(ctx context.Context) {
+	for k, g := range b.groups {
+		for i := range g.rows {
+			g.rows[i].alloc.Release(ctx)
+		}
+		delete(b.groups, k)
+	}
+}
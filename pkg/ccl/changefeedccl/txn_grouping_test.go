@@ -0,0 +1,111 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxnGroupingBuffer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	foo, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY)`)
+	require.NoError(t, err)
+	bar, err := parseTableDesc(`CREATE TABLE bar (a INT PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	encoder, err := makeJSONEncoder(map[string]string{
+		changefeedbase.OptEnvelope:              string(changefeedbase.OptEnvelopeWrapped),
+		changefeedbase.OptTransactionBoundaries: ``,
+	}, nil /* targets */)
+	require.NoError(t, err)
+	sink := &bufferSink{}
+	b := newTxnGroupingBuffer(sink, encoder)
+
+	txn1, txn2, txn3 := uuid.MakeV4(), uuid.MakeV4(), uuid.MakeV4()
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	add := func(topic TopicDescriptor, value string, mvcc hlc.Timestamp, txnID uuid.UUID) {
+		b.add(topic, []byte(`[]`), []byte(value), mvcc, mvcc, txnID, kvevent.Alloc{})
+	}
+	add(tableDescriptorTopic{foo}, `foo-1`, ts(2), txn1)
+	add(tableDescriptorTopic{bar}, `bar-1`, ts(2), txn1)
+	add(tableDescriptorTopic{foo}, `foo-2`, ts(1), txn2)
+	add(tableDescriptorTopic{foo}, `foo-3`, ts(2), txn1)
+	add(tableDescriptorTopic{bar}, `bar-2`, ts(3), uuid.UUID{})
+	add(tableDescriptorTopic{foo}, `foo-4`, ts(3), txn3)
+	require.Equal(t, ts(1), b.minTimestamp())
+
+	popAll := func() []string {
+		var msgs []string
+		for !sink.buf.IsEmpty() {
+			row := sink.buf.Pop()
+			msgs = append(msgs, fmt.Sprintf(`%s: %s`,
+				tree.MustBeDString(row[1].Datum), tree.MustBeDBytes(row[3].Datum)))
+		}
+		return msgs
+	}
+	marker := func(topic, typ string, txnID uuid.UUID, wallTime int64, rows int) string {
+		id := `null`
+		if txnID != (uuid.UUID{}) {
+			id = fmt.Sprintf(`"%s"`, txnID)
+		}
+		var rowsField string
+		if typ == txnBoundaryCommit {
+			rowsField = fmt.Sprintf(`,"rows":%d`, rows)
+		}
+		return fmt.Sprintf(
+			`%s: {"transaction":{"boundary":"%s","id":%s,"mvcc_timestamp":"%d.0000000000"%s}}`,
+			topic, typ, id, wallTime, rowsField)
+	}
+
+	// Nothing is emitted until the frontier reaches the transactions.
+	require.NoError(t, b.flush(ctx, ts(0)))
+	require.Empty(t, popAll())
+
+	require.NoError(t, b.flush(ctx, ts(2)))
+	require.Equal(t, []string{
+		marker(`foo`, txnBoundaryBegin, txn2, 1, 0),
+		`foo: foo-2`,
+		marker(`foo`, txnBoundaryCommit, txn2, 1, 1),
+		marker(`foo`, txnBoundaryBegin, txn1, 2, 0),
+		marker(`bar`, txnBoundaryBegin, txn1, 2, 0),
+		`foo: foo-1`,
+		`bar: bar-1`,
+		`foo: foo-3`,
+		marker(`foo`, txnBoundaryCommit, txn1, 2, 2),
+		marker(`bar`, txnBoundaryCommit, txn1, 2, 1),
+	}, popAll())
+	require.Equal(t, ts(3), b.minTimestamp())
+
+	// A row without a known transaction is grouped with every row at its
+	// timestamp.
+	require.NoError(t, b.flush(ctx, ts(5)))
+	require.Equal(t, []string{
+		marker(`bar`, txnBoundaryBegin, uuid.UUID{}, 3, 0),
+		marker(`foo`, txnBoundaryBegin, uuid.UUID{}, 3, 0),
+		`bar: bar-2`,
+		`foo: foo-4`,
+		marker(`bar`, txnBoundaryCommit, uuid.UUID{}, 3, 1),
+		marker(`foo`, txnBoundaryCommit, uuid.UUID{}, 3, 1),
+	}, popAll())
+	require.True(t, b.minTimestamp().IsEmpty())
+}
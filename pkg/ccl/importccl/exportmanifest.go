@@ -0,0 +1,305 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package importccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// exportManifest is the manifest written by incremental EXPORTs next to the
+// files they export.
+type exportManifest struct {
+	// StartTime and EndTime bound the time range covered by the export: the
+	// files hold the rows that changed in (StartTime, EndTime]. The timestamps
+	// are formatted like cluster_logical_timestamp(), so that EndTime can be
+	// used as the incremental_from option of the next export.
+	StartTime string               `json:"start_time"`
+	EndTime   string               `json:"end_time"`
+	Files     []exportManifestFile `json:"files"`
+	// PrimaryKey contains the names of the primary key columns of the table,
+	// and Deletes the primary keys of the rows that changed in the time range
+	// but are not exported, because they were deleted or no longer pass the
+	// filter of the export. Those rows must be removed from the rows of the
+	// previous exports.
+	PrimaryKey []string   `json:"primary_key"`
+	Deletes    [][]string `json:"deletes"`
+}
+
+type exportManifestFile struct {
+	Name  string `json:"name"`
+	Rows  int64  `json:"rows"`
+	Bytes int64  `json:"bytes"`
+}
+
+func exportManifestTimestamp(ts hlc.Timestamp) string {
+	return tree.TimestampToDecimalDatum(ts).Decimal.String()
+}
+
+func newExportManifestWriterProcessor(
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ExportManifestWriterSpec,
+	input execinfra.RowSource,
+	output execinfra.RowReceiver,
+) (execinfra.Processor, error) {
+	w := &exportManifestWriter{
+		flowCtx:     flowCtx,
+		processorID: processorID,
+		spec:        spec,
+		input:       input,
+		output:      output,
+	}
+	semaCtx := tree.MakeSemaContext()
+	if err := w.out.Init(&execinfrapb.PostProcessSpec{}, w.OutputTypes(), &semaCtx, flowCtx.NewEvalCtx()); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+type exportManifestWriter struct {
+	flowCtx     *execinfra.FlowCtx
+	processorID int32
+	spec        execinfrapb.ExportManifestWriterSpec
+	input       execinfra.RowSource
+	out         execinfra.ProcOutputHelper
+	output      execinfra.RowReceiver
+}
+
+var _ execinfra.Processor = &exportManifestWriter{}
+
+func (w *exportManifestWriter) OutputTypes() []*types.T {
+	res := make([]*types.T, len(colinfo.ExportColumns))
+	for i := range res {
+		res[i] = colinfo.ExportColumns[i].Typ
+	}
+	return res
+}
+
+func (w *exportManifestWriter) MustBeStreaming() bool {
+	return false
+}
+
+func (w *exportManifestWriter) Run(ctx context.Context) {
+	ctx, span := tracing.ChildSpan(ctx, "exportManifestWriter")
+	defer span.Finish()
+
+	err := func() error {
+		typs := w.input.OutputTypes()
+		w.input.Start(ctx)
+		input := execinfra.MakeNoMetadataRowSource(w.input, w.output)
+
+		alloc := &rowenc.DatumAlloc{}
+		manifest := exportManifest{
+			StartTime: exportManifestTimestamp(w.spec.StartTime),
+			EndTime:   exportManifestTimestamp(w.flowCtx.Txn.ReadTimestamp()),
+			Files:     []exportManifestFile{},
+			Deletes:   [][]string{},
+		}
+		for {
+			row, err := input.NextRow()
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				break
+			}
+			for i := range row {
+				if err := row[i].EnsureDecoded(typs[i], alloc); err != nil {
+					return err
+				}
+			}
+			manifest.Files = append(manifest.Files, exportManifestFile{
+				Name:  string(tree.MustBeDString(row[0].Datum)),
+				Rows:  int64(tree.MustBeDInt(row[1].Datum)),
+				Bytes: int64(tree.MustBeDInt(row[2].Datum)),
+			})
+
+			cs, err := w.out.EmitRow(ctx, row, w.output)
+			if err != nil {
+				return err
+			}
+			if cs != execinfra.NeedMoreRows {
+				return errors.New("unexpected closure of consumer")
+			}
+		}
+		sort.Slice(manifest.Files, func(i, j int) bool {
+			return manifest.Files[i].Name < manifest.Files[j].Name
+		})
+		if err := w.addDeletes(ctx, &manifest); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		conf, err := cloud.ExternalStorageConfFromURI(w.spec.Destination, w.spec.User())
+		if err != nil {
+			return err
+		}
+		es, err := w.flowCtx.Cfg.ExternalStorage(ctx, conf)
+		if err != nil {
+			return err
+		}
+		defer es.Close()
+		return cloud.WriteFile(ctx, es, w.spec.Name, bytes.NewReader(data))
+	}()
+
+	execinfra.DrainAndClose(
+		ctx, w.output, err, func(context.Context) {} /* pushTrailingMeta */, w.input)
+}
+
+// addDeletes adds the primary keys of the rows to delete to the manifest. They
+// are found by scanning the changes of the exported spans again: the keys
+// that were deleted are read from their tombstones, and the rows that are
+// still live but don't pass the filter are decoded to evaluate it.
+func (w *exportManifestWriter) addDeletes(ctx context.Context, manifest *exportManifest) error {
+	table := tabledesc.NewBuilder(&w.spec.Table).BuildImmutableTable()
+	index := table.GetPrimaryIndex()
+
+	var colIdxMap catalog.TableColMap
+	var valNeededForCol util.FastIntSet
+	cols := make([]catalog.Column, len(w.spec.ColumnIDs))
+	colTypes := make([]*types.T, len(cols))
+	for i, id := range w.spec.ColumnIDs {
+		col, err := table.FindColumnWithID(id)
+		if err != nil {
+			return err
+		}
+		cols[i] = col
+		colTypes[i] = col.GetType()
+		colIdxMap.Set(id, i)
+		valNeededForCol.Add(i)
+	}
+
+	pkOrds := make([]int, index.NumKeyColumns())
+	pkTypes := make([]*types.T, len(pkOrds))
+	pkDirs := make([]descpb.IndexDescriptor_Direction, len(pkOrds))
+	manifest.PrimaryKey = make([]string, len(pkOrds))
+	for i := range pkOrds {
+		ord, ok := colIdxMap.Get(index.GetKeyColumnID(i))
+		if !ok {
+			return errors.AssertionFailedf(
+				"primary key column %s is not read", index.GetKeyColumnName(i))
+		}
+		pkOrds[i] = ord
+		pkTypes[i] = colTypes[ord]
+		pkDirs[i] = index.GetKeyColumnDirection(i)
+		manifest.PrimaryKey[i] = index.GetKeyColumnName(i)
+	}
+
+	alloc := &rowenc.DatumAlloc{}
+	f := tree.NewFmtCtx(tree.FmtExport)
+	defer f.Close()
+	addDelete := func(key rowenc.EncDatumRow) error {
+		values := make([]string, len(key))
+		for i := range key {
+			if err := key[i].EnsureDecoded(pkTypes[i], alloc); err != nil {
+				return err
+			}
+			key[i].Datum.Format(f)
+			values[i] = f.String()
+			f.Reset()
+		}
+		manifest.Deletes = append(manifest.Deletes, values)
+		return nil
+	}
+
+	codec := w.flowCtx.Codec()
+	onDelete := func(k roachpb.Key) error {
+		key := make(rowenc.EncDatumRow, len(pkTypes))
+		if _, _, _, err := rowenc.DecodeIndexKey(codec, pkTypes, key, pkDirs, k); err != nil {
+			return err
+		}
+		return addDelete(key)
+	}
+
+	var rf row.Fetcher
+	if err := rf.Init(
+		ctx,
+		codec,
+		false, /* reverse */
+		descpb.ScanLockingStrength_FOR_NONE,
+		descpb.ScanLockingWaitPolicy_BLOCK,
+		0,     /* lockTimeout */
+		false, /* isCheck */
+		alloc,
+		nil, /* memMonitor */
+		row.FetcherTableArgs{
+			Desc:            table,
+			Index:           index,
+			ColIdxMap:       colIdxMap,
+			Cols:            cols,
+			ValNeededForCol: valNeededForCol,
+		},
+	); err != nil {
+		return err
+	}
+	fetcher := row.NewIncrementalKVFetcher(
+		w.flowCtx.Cfg.DB, w.spec.Spans, w.spec.StartTime, w.flowCtx.Txn.ReadTimestamp(), onDelete,
+	)
+	if err := rf.StartScanFrom(ctx, fetcher, false /* traceKV */); err != nil {
+		return err
+	}
+
+	var filter execinfrapb.ExprHelper
+	semaCtx := tree.MakeSemaContext()
+	if err := filter.Init(w.spec.Filter, colTypes, &semaCtx, w.flowCtx.NewEvalCtx()); err != nil {
+		return err
+	}
+	for {
+		r, _, _, err := rf.NextRow(ctx)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			return nil
+		}
+		if w.spec.Filter.Empty() {
+			continue
+		}
+		if pass, err := filter.EvalFilter(r); err != nil {
+			return err
+		} else if pass {
+			continue
+		}
+		key := make(rowenc.EncDatumRow, len(pkOrds))
+		for i, ord := range pkOrds {
+			key[i] = r[ord]
+		}
+		if err := addDelete(key); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	rowexec.NewExportManifestWriterProcessor = newExportManifestWriterProcessor
+}
@@ -107,6 +107,72 @@ func newParquetExporter(
 	return exporter, nil
 }
 
+// ParquetWriter writes rows of datums into an in-memory parquet file, using the
+// same mapping between SQL and parquet types as EXPORT. It is used by
+// changefeeds that emit parquet files to cloud storage.
+type ParquetWriter struct {
+	exporter *parquetExporter
+	row      map[string]interface{}
+	numRows  int
+}
+
+// NewParquetWriter returns a ParquetWriter for rows with the given column
+// names and types. It returns an error if one of the types cannot be written
+// to parquet.
+func NewParquetWriter(colNames []string, typs []*types.T) (*ParquetWriter, error) {
+	// Every column is nullable, since the rows written by changefeeds for
+	// deletes only have values for their primary key columns.
+	nullability := make([]bool, len(colNames))
+	for i := range nullability {
+		nullability[i] = true
+	}
+	exporter, err := newParquetExporter(execinfrapb.ParquetWriterSpec{
+		ColNames:       colNames,
+		ColNullability: nullability,
+	}, typs)
+	if err != nil {
+		return nil, err
+	}
+	exporter.ResetBuffer()
+	return &ParquetWriter{
+		exporter: exporter,
+		row:      make(map[string]interface{}, len(colNames)),
+	}, nil
+}
+
+// AddRow appends a row to the file. The datums must be in the order of the
+// columns passed to NewParquetWriter.
+func (w *ParquetWriter) AddRow(datums tree.Datums) error {
+	for i, d := range datums {
+		col := &w.exporter.parquetColumns[i]
+		if d == tree.DNull {
+			w.row[col.name] = nil
+			continue
+		}
+		v, err := col.encodeFn(d)
+		if err != nil {
+			return err
+		}
+		w.row[col.name] = v
+	}
+	w.numRows++
+	return w.exporter.Write(w.row)
+}
+
+// NumRows returns the number of rows added to the file.
+func (w *ParquetWriter) NumRows() int {
+	return w.numRows
+}
+
+// Finish closes the file and returns its contents. The writer must not be used
+// afterwards.
+func (w *ParquetWriter) Finish() ([]byte, error) {
+	if err := w.exporter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close parquet writer")
+	}
+	return w.exporter.Bytes(), nil
+}
+
 // parquetColumn contains the relevant data to map a crdb table column to a parquet table column.
 type parquetColumn struct {
 	name     string
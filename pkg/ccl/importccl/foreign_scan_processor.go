@@ -0,0 +1,240 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package importccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+const foreignScanProcessorName = "foreignScanProcessor"
+
+// foreignScanProcessor is a processor that does not take any inputs and reads
+// the rows of a foreign table from the files in its spec. It starts a worker
+// goroutine in Start(), which reads the files one after the other with the
+// readers of IMPORT, and emits the rows over an internally maintained channel.
+// Next() reads from this channel until exhausted.
+type foreignScanProcessor struct {
+	execinfra.ProcessorBase
+
+	flowCtx *execinfra.FlowCtx
+	spec    execinfrapb.ForeignScanSpec
+	table   catalog.TableDescriptor
+
+	rowCh chan rowenc.EncDatumRow
+	// cancelAndWait cancels the worker goroutine and waits for it to finish.
+	// It cannot be called concurrently with Next(), as it consumes from rowCh.
+	cancelAndWait func()
+
+	scanErr error
+}
+
+var _ execinfra.Processor = &foreignScanProcessor{}
+var _ execinfra.RowSource = &foreignScanProcessor{}
+
+func newForeignScanProcessor(
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ForeignScanSpec,
+	post *execinfrapb.PostProcessSpec,
+	output execinfra.RowReceiver,
+) (execinfra.Processor, error) {
+	fs := &foreignScanProcessor{
+		flowCtx: flowCtx,
+		spec:    spec,
+		table:   tabledesc.NewBuilder(&spec.Table).BuildImmutableTable(),
+		rowCh:   make(chan rowenc.EncDatumRow),
+	}
+	cols := fs.table.PublicColumns()
+	outputTypes := make([]*types.T, len(cols))
+	for i, col := range cols {
+		outputTypes[i] = col.GetType()
+	}
+	if err := fs.Init(fs, post, outputTypes, flowCtx, processorID, output, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			// This processor doesn't have any inputs to drain.
+			InputsToDrain: nil,
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				fs.close()
+				return nil
+			},
+		}); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// Start is part of the RowSource interface.
+func (fs *foreignScanProcessor) Start(ctx context.Context) {
+	ctx = fs.StartInternal(ctx, foreignScanProcessorName)
+	ctx, cancel := context.WithCancel(ctx)
+	fs.cancelAndWait = func() {
+		cancel()
+		// Wait until rowCh is closed by the goroutine below.
+		for range fs.rowCh {
+		}
+	}
+	go func() {
+		defer close(fs.rowCh)
+		fs.scanErr = fs.scan(ctx)
+	}()
+}
+
+// Next is part of the RowSource interface.
+func (fs *foreignScanProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for fs.State == execinfra.StateRunning {
+		r, ok := <-fs.rowCh
+		if !ok {
+			fs.MoveToDraining(fs.scanErr)
+			break
+		}
+		if outRow := fs.ProcessRowHelper(r); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, fs.DrainHelper()
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (fs *foreignScanProcessor) ConsumerClosed() {
+	fs.close()
+}
+
+func (fs *foreignScanProcessor) close() {
+	if fs.Closed {
+		return
+	}
+	if fs.cancelAndWait != nil {
+		fs.cancelAndWait()
+	}
+	fs.InternalClose()
+}
+
+// scan reads the files of the spec and sends their rows to rowCh.
+func (fs *foreignScanProcessor) scan(ctx context.Context) error {
+	evalCtx := fs.flowCtx.NewEvalCtx()
+	cols := fs.table.PublicColumns()
+	conv := &row.DatumRowConverter{
+		Datums:          make(tree.Datums, len(cols)),
+		VisibleCols:     cols,
+		VisibleColTypes: make([]*types.T, len(cols)),
+		EvalCtx:         evalCtx,
+	}
+	for i, col := range cols {
+		conv.VisibleColTypes[i] = col.GetType()
+		conv.TargetColOrds.Add(i)
+	}
+	importCtx := &parallelImportContext{
+		evalCtx:   evalCtx,
+		tableDesc: fs.table,
+	}
+
+	readFile := func(
+		ctx context.Context, input *fileReader, _ int32, _ int64, _ chan string,
+	) error {
+		producer, consumer, cleanup, err := newForeignScanPipeline(ctx, importCtx, fs.spec.Format, input)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		var skip int64
+		if fs.spec.Format.Format == roachpb.IOFileFormat_CSV {
+			skip = int64(fs.spec.Format.Csv.Skip)
+		}
+		for rowNum := int64(1); producer.Scan(); rowNum++ {
+			if rowNum <= skip {
+				if err := producer.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			data, err := producer.Row()
+			if err != nil {
+				return err
+			}
+			// The consumers only set the datums of the columns present in the
+			// record.
+			for i := range conv.Datums {
+				conv.Datums[i] = nil
+			}
+			if err := consumer.FillDatums(data, rowNum, conv); err != nil {
+				return err
+			}
+			encRow := make(rowenc.EncDatumRow, len(conv.Datums))
+			for i, datum := range conv.Datums {
+				if datum == nil {
+					datum = tree.DNull
+				}
+				if datum == tree.DNull && !cols[i].IsNullable() {
+					return sqlerrors.NewNonNullViolationError(cols[i].GetName())
+				}
+				encRow[i] = rowenc.DatumToEncDatum(conv.VisibleColTypes[i], datum)
+			}
+			select {
+			case fs.rowCh <- encRow:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return producer.Err()
+	}
+
+	return readInputFiles(ctx, fs.spec.Uri, nil /* resumePos */, fs.spec.Format, readFile,
+		fs.flowCtx.Cfg.ExternalStorage, fs.spec.User())
+}
+
+// newForeignScanPipeline returns the producer and consumer of the IMPORT
+// reader of the given format, which read the rows of a foreign table. The
+// returned cleanup function must be called once the rows have been read.
+func newForeignScanPipeline(
+	ctx context.Context,
+	importCtx *parallelImportContext,
+	format roachpb.IOFileFormat,
+	input *fileReader,
+) (importRowProducer, importRowConsumer, func(), error) {
+	switch format.Format {
+	case roachpb.IOFileFormat_CSV:
+		producer, consumer := newCSVPipeline(&csvInputReader{
+			importCtx:           importCtx,
+			numExpectedDataCols: len(importCtx.tableDesc.VisibleColumns()),
+			opts:                format.Csv,
+		}, input)
+		return producer, consumer, func() {}, nil
+	case roachpb.IOFileFormat_Avro:
+		producer, consumer, err := newImportAvroPipeline(&avroInputReader{
+			importContext: importCtx,
+			opts:          format.Avro,
+		}, input)
+		return producer, consumer, func() {}, err
+	case roachpb.IOFileFormat_Parquet:
+		return newImportParquetPipeline(ctx, &parquetInputReader{
+			importContext: importCtx,
+			opts:          format.Parquet,
+		}, input)
+	default:
+		return nil, nil, nil, errors.AssertionFailedf(
+			"unsupported foreign table format %s", format.Format.String())
+	}
+}
+
+func init() {
+	rowexec.NewForeignScanProcessor = newForeignScanProcessor
+}
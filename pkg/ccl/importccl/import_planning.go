@@ -92,6 +92,12 @@ const (
 	avroSchema    = "schema"
 	avroSchemaURI = "schema_uri"
 
+	// Split column names into paths into nested objects when importing
+	// newline delimited JSON.
+	ndjsonPathSeparator = "path_separator"
+	// Store whole newline delimited JSON objects in a JSONB column.
+	ndjsonJSONColumn = "json_column"
+
 	pgDumpIgnoreAllUnsupported     = "ignore_unsupported_statements"
 	pgDumpIgnoreShuntFileDest      = "log_ignored_statements"
 	pgDumpUnsupportedSchemaStmtLog = "unsupported_schema_stmts"
@@ -133,6 +139,9 @@ var importOptionExpectValues = map[string]sql.KVStringOptValidate{
 	avroBinRecords:         sql.KVStringOptRequireNoValue,
 	avroJSONRecords:        sql.KVStringOptRequireNoValue,
 
+	ndjsonPathSeparator: sql.KVStringOptRequireValue,
+	ndjsonJSONColumn:    sql.KVStringOptRequireValue,
+
 	pgDumpIgnoreAllUnsupported: sql.KVStringOptRequireNoValue,
 	pgDumpIgnoreShuntFileDest:  sql.KVStringOptRequireValue,
 }
@@ -165,6 +174,10 @@ var avroAllowedOptions = makeStringSet(
 	avroStrict, avroBinRecords, avroJSONRecords,
 	avroRecordsSeparatedBy, avroSchema, avroSchemaURI, optMaxRowSize, csvRowLimit,
 )
+var parquetAllowedOptions = makeStringSet(avroStrict, csvRowLimit)
+var ndjsonAllowedOptions = makeStringSet(
+	avroStrict, csvRowLimit, optMaxRowSize, ndjsonPathSeparator, ndjsonJSONColumn,
+)
 var csvAllowedOptions = makeStringSet(
 	csvDelimiter, csvComment, csvNullIf, csvSkip, csvStrictQuotes, csvRowLimit,
 )
@@ -187,6 +200,8 @@ var allowedIntoFormats = map[string]struct{}{
 	"AVRO":      {},
 	"DELIMITED": {},
 	"PGCOPY":    {},
+	"PARQUET":   {},
+	"NDJSON":    {},
 }
 
 // featureImportEnabled is used to enable and disable the IMPORT feature.
@@ -723,6 +738,29 @@ func importPlanHook(
 			if err != nil {
 				return err
 			}
+		case "PARQUET":
+			if err = validateFormatOptions(importStmt.FileFormat, opts, parquetAllowedOptions); err != nil {
+				return err
+			}
+			format.Format = roachpb.IOFileFormat_Parquet
+			_, format.Parquet.StrictMode = opts[avroStrict]
+			if override, ok := opts[csvRowLimit]; ok {
+				rowLimit, err := strconv.Atoi(override)
+				if err != nil {
+					return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+				}
+				if rowLimit <= 0 {
+					return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+				}
+				format.Parquet.RowLimit = int64(rowLimit)
+			}
+		case "NDJSON":
+			if err = validateFormatOptions(importStmt.FileFormat, opts, ndjsonAllowedOptions); err != nil {
+				return err
+			}
+			if err := parseNDJSONOptions(opts, &format); err != nil {
+				return err
+			}
 		default:
 			return unimplemented.Newf("import.format", "unsupported import format: %q", importStmt.FileFormat)
 		}
@@ -789,6 +827,16 @@ func importPlanHook(
 				return err
 			}
 
+			// IMPORT INTO ingests the data directly, so it would not maintain the
+			// incrementally maintained views that depend on the table.
+			for _, ref := range found.DependedOnBy {
+				if ref.Incremental {
+					return pgerror.Newf(pgcode.FeatureNotSupported,
+						"cannot IMPORT INTO table %q because an incrementally maintained view depends on it",
+						found.Name)
+				}
+			}
+
 			// Validate target columns.
 			var intoCols []string
 			var isTargetCol = make(map[string]bool)
@@ -834,6 +882,13 @@ func importPlanHook(
 				}
 			}
 
+			if format.Format == roachpb.IOFileFormat_NDJSON {
+				// Check that the table can hold the objects of the files.
+				if _, err := newNDJSONConsumer(found, format.NDJSON); err != nil {
+					return err
+				}
+			}
+
 			tableDetails = []jobspb.ImportDetails_Table{{Desc: &found.TableDescriptor, IsNew: false, TargetCols: intoCols}}
 		} else if importStmt.Bundle {
 			// If we target a single table, populate details with one entry of tableName.
@@ -1081,6 +1136,40 @@ func parseAvroOptions(
 	return nil
 }
 
+func parseNDJSONOptions(opts map[string]string, format *roachpb.IOFileFormat) error {
+	format.Format = roachpb.IOFileFormat_NDJSON
+	_, format.NDJSON.StrictMode = opts[avroStrict]
+	format.NDJSON.PathSeparator = opts[ndjsonPathSeparator]
+	format.NDJSON.JsonColumn = opts[ndjsonJSONColumn]
+	if _, ok := opts[importOptionSaveRejected]; ok {
+		format.SaveRejected = true
+	}
+
+	if override, ok := opts[csvRowLimit]; ok {
+		rowLimit, err := strconv.Atoi(override)
+		if err != nil {
+			return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+		}
+		if rowLimit <= 0 {
+			return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+		}
+		format.NDJSON.RowLimit = int64(rowLimit)
+	}
+
+	format.NDJSON.MaxRowSize = int32(defaultScanBuffer)
+	if override, ok := opts[optMaxRowSize]; ok {
+		sz, err := humanizeutil.ParseBytes(override)
+		if err != nil {
+			return err
+		}
+		if sz < 1 || sz > math.MaxInt32 {
+			return errors.Errorf("%s out of range: %d", override, sz)
+		}
+		format.NDJSON.MaxRowSize = int32(sz)
+	}
+	return nil
+}
+
 type loggerKind int
 
 const (
@@ -2769,6 +2769,13 @@ func TestExportImportRoundTrip(t *testing.T) {
 			tbl:      "t",
 			expected: `SELECT 'dog' COLLATE en`,
 		},
+		{
+			stmts: `EXPORT INTO PARQUET 'nodelocal://0/%[1]s' FROM SELECT 1::INT8 AS i, 'a' AS s, 1.5::FLOAT8 AS f, true AS b, NULL::STRING AS n;
+							CREATE TABLE t (i INT8, s STRING, f FLOAT8, b BOOL, n STRING);
+							IMPORT INTO t PARQUET DATA ('nodelocal://0/%[1]s/export*-n*.0.parquet')`,
+			tbl:      "t",
+			expected: `SELECT 1::INT8, 'a', 1.5::FLOAT8, true, NULL::STRING`,
+		},
 	}
 
 	for i, test := range tests {
@@ -5748,7 +5755,7 @@ comment on extension: could not be parsed
 create extension if not exists with: could not be parsed
 alter aggregate: could not be parsed
 alter domain: could not be parsed
-create function: could not be parsed
+CREATE FUNCTION public.isnumeric(STRING) RETURNS BOOL LANGUAGE sql AS e'\nSELECT $1 ~ \'^[0-9]+$\'\n': unsupported by IMPORT
 `,
 			`alter function: could not be parsed
 alter table alter column add: could not be parsed
@@ -6366,6 +6373,48 @@ func TestImportAvro(t *testing.T) {
 	})
 }
 
+func TestImportNDJSON(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	baseDir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+	tc := testcluster.StartTestCluster(
+		t, 1, base.TestClusterArgs{ServerArgs: base.TestServerArgs{ExternalIODir: baseDir}})
+	defer tc.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(tc.Conns[0])
+
+	const contents = `{"A": 1, "C": {"D": 10}, "j": {"x": null}}
+{"a": 2, "c": {"d": 20}, "j": null}
+{"a": 3, "j": [1, 2]}
+`
+	f, err := ioutil.TempFile(baseDir, "data")
+	require.NoError(t, err)
+	_, err = f.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	data := fmt.Sprintf("nodelocal://0/%s", filepath.Base(f.Name()))
+
+	sqlDB.Exec(t, `CREATE TABLE t (a INT PRIMARY KEY, "c.d" INT, j JSONB)`)
+	sqlDB.Exec(t, `IMPORT INTO t NDJSON DATA ($1) WITH path_separator = '.'`, data)
+	sqlDB.CheckQueryResults(t, `SELECT a, "c.d", j, j IS NULL FROM t ORDER BY a`, [][]string{
+		{"1", "10", `{"x": null}`, "false"},
+		{"2", "20", "NULL", "true"},
+		{"3", "NULL", "[1, 2]", "false"},
+	})
+
+	t.Run("json_column", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE u (a INT PRIMARY KEY, rest JSONB)`)
+		sqlDB.Exec(t, `IMPORT INTO u NDJSON DATA ($1) WITH json_column = 'rest'`, data)
+		sqlDB.CheckQueryResults(t, `SELECT a, rest FROM u ORDER BY a`, [][]string{
+			{"1", `{"A": 1, "C": {"D": 10}, "j": {"x": null}}`},
+			{"2", `{"a": 2, "c": {"d": 20}, "j": null}`},
+			{"3", `{"a": 3, "j": [1, 2]}`},
+		})
+	})
+}
+
 func TestImportMultiRegion(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -6794,6 +6843,8 @@ func TestDisallowsInvalidFormatOptions(t *testing.T) {
 	addOpts(mysqlOutAllowedOptions)
 	addOpts(pgDumpAllowedOptions)
 	addOpts(pgCopyAllowedOptions)
+	addOpts(parquetAllowedOptions)
+	addOpts(ndjsonAllowedOptions)
 
 	// Helper to pick num options from the set of allowed and the set
 	// of all other options.  Returns generated options plus a flag indicating
@@ -6840,6 +6891,8 @@ func TestDisallowsInvalidFormatOptions(t *testing.T) {
 		{"mysqldump", mysqlDumpAllowedOptions},
 		{"pgdump", pgDumpAllowedOptions},
 		{"pgcopy", pgCopyAllowedOptions},
+		{"parquet", parquetAllowedOptions},
+		{"ndjson", ndjsonAllowedOptions},
 	}
 
 	for _, tc := range tests {
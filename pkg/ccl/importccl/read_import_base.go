@@ -196,6 +196,9 @@ func readInputFiles(
 			defer raw.Close()
 
 			src := &fileReader{total: fileSizes[dataFileIndex], counter: byteCounter{r: raw}}
+			if guessCompressionFromName(dataFile, format.Compression) == roachpb.IOFileFormat_None {
+				src.es = es
+			}
 			decompressed, err := decompressingReader(&src.counter, dataFile, format.Compression)
 			if err != nil {
 				return err
@@ -205,7 +208,8 @@ func readInputFiles(
 
 			var rejected chan string
 			if (format.Format == roachpb.IOFileFormat_CSV && format.SaveRejected) ||
-				(format.Format == roachpb.IOFileFormat_MysqlOutfile && format.SaveRejected) {
+				(format.Format == roachpb.IOFileFormat_MysqlOutfile && format.SaveRejected) ||
+				(format.Format == roachpb.IOFileFormat_NDJSON && format.SaveRejected) {
 				rejected = make(chan string)
 			}
 			if rejected != nil {
@@ -328,6 +332,10 @@ type fileReader struct {
 	io.Reader
 	total   int64
 	counter byteCounter
+	// es is the storage of the file, which formats that need random access to
+	// the file (such as parquet) use instead of Reader. It is nil if the file
+	// is compressed, in which case it can only be read sequentially.
+	es cloud.ExternalStorage
 }
 
 func (f fileReader) ReadFraction() float32 {
@@ -348,6 +356,8 @@ type inputConverter interface {
 func formatHasNamedColumns(format roachpb.IOFileFormat_FileFormat) bool {
 	switch format {
 	case roachpb.IOFileFormat_Avro,
+		roachpb.IOFileFormat_Parquet,
+		roachpb.IOFileFormat_NDJSON,
 		roachpb.IOFileFormat_Mysqldump,
 		roachpb.IOFileFormat_PgDump:
 		return true
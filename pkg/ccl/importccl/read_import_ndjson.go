@@ -0,0 +1,326 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package importccl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// ndjsonValueToDatum converts a JSON value to the datum of appropriate type.
+// JSON nulls are SQL NULLs, in every column. JSONB columns hold the other
+// values as is. JSON arrays are converted element by element into arrays.
+// Other values, including objects, are parsed from their text representation,
+// like strings of the other import formats.
+func ndjsonValueToDatum(v json.JSON, typ *types.T, evalCtx *tree.EvalContext) (tree.Datum, error) {
+	if v.Type() == json.NullJSONType {
+		return tree.DNull, nil
+	}
+	if typ.Family() == types.JsonFamily {
+		return tree.NewDJSON(v), nil
+	}
+	switch v.Type() {
+	case json.ArrayJSONType:
+		if typ.Family() != types.ArrayFamily {
+			break
+		}
+		arr := tree.NewDArray(typ.ArrayContents())
+		for i := 0; i < v.Len(); i++ {
+			elt, err := v.FetchValIdx(i)
+			if err != nil {
+				return nil, err
+			}
+			d, err := ndjsonValueToDatum(elt, typ.ArrayContents(), evalCtx)
+			if err != nil {
+				return nil, err
+			}
+			if err := arr.Append(d); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	}
+	s, err := v.AsText()
+	if err != nil {
+		return nil, err
+	}
+	return rowenc.ParseDatumStringAs(typ, *s, evalCtx)
+}
+
+// ndjsonColumn is a column of the table whose value is read from a key of the
+// objects.
+type ndjsonColumn struct {
+	idx  int
+	name string
+	// path is the path to the value below the top-level key, if the column
+	// name is a path into nested objects.
+	path []string
+}
+
+// ndjsonConsumer implements importRowConsumer interface.
+type ndjsonConsumer struct {
+	// columnsByKey maps top-level keys to the columns read from their values.
+	columnsByKey map[string][]ndjsonColumn
+	// jsonColIdx is the index of the column holding whole objects, or -1.
+	jsonColIdx int
+	strict     bool
+}
+
+var _ importRowConsumer = &ndjsonConsumer{}
+
+func newNDJSONConsumer(
+	tableDesc catalog.TableDescriptor, opts roachpb.NDJSONOptions,
+) (*ndjsonConsumer, error) {
+	c := &ndjsonConsumer{
+		columnsByKey: make(map[string][]ndjsonColumn),
+		jsonColIdx:   -1,
+		strict:       opts.StrictMode,
+	}
+	for idx, col := range tableDesc.VisibleColumns() {
+		if opts.JsonColumn != "" && col.GetName() == opts.JsonColumn {
+			if col.GetType().Family() != types.JsonFamily {
+				return nil, errors.Errorf("%s column %s must be of type JSONB, found %s",
+					ndjsonJSONColumn, col.GetName(), col.GetType().SQLString())
+			}
+			c.jsonColIdx = idx
+			continue
+		}
+		path := []string{col.GetName()}
+		if opts.PathSeparator != "" {
+			path = strings.Split(col.GetName(), opts.PathSeparator)
+		}
+		c.columnsByKey[path[0]] = append(c.columnsByKey[path[0]], ndjsonColumn{
+			idx: idx, name: col.GetName(), path: path[1:],
+		})
+	}
+	if opts.JsonColumn != "" && c.jsonColIdx < 0 {
+		return nil, errors.Errorf("%s column %s does not exist", ndjsonJSONColumn, opts.JsonColumn)
+	}
+	return c, nil
+}
+
+// FillDatums implements importRowConsumer interface.
+func (c *ndjsonConsumer) FillDatums(
+	native interface{}, rowNum int64, conv *row.DatumRowConverter,
+) error {
+	line, ok := native.(string)
+	if !ok {
+		return fmt.Errorf("unexpected native type; expected string found %T instead", native)
+	}
+	obj, err := json.ParseJSON(line)
+	if err != nil {
+		return newImportRowError(err, line, rowNum)
+	}
+	it, err := obj.ObjectIter()
+	if err != nil {
+		return newImportRowError(err, line, rowNum)
+	}
+	if it == nil {
+		return newImportRowError(errors.New("expected a JSON object"), line, rowNum)
+	}
+
+	for i := range conv.VisibleCols {
+		conv.Datums[i] = nil
+	}
+	for it.Next() {
+		key := lexbase.NormalizeName(it.Key())
+		cols, ok := c.columnsByKey[key]
+		if !ok {
+			// Unknown keys are kept in the JSONB column, if there is one.
+			if c.strict && c.jsonColIdx < 0 {
+				return newImportRowError(
+					errors.Errorf("could not find column for key %s", it.Key()), line, rowNum)
+			}
+			continue
+		}
+		for _, col := range cols {
+			v := it.Value()
+			for _, k := range col.path {
+				if v, err = ndjsonFetchKey(v, k); err != nil {
+					return newImportRowError(err, line, rowNum)
+				}
+				if v == nil {
+					break
+				}
+			}
+			if v == nil {
+				continue
+			}
+			datum, err := ndjsonValueToDatum(v, conv.VisibleColTypes[col.idx], conv.EvalCtx)
+			if err != nil {
+				return newImportRowError(errors.Wrapf(err, "column %s", col.name), line, rowNum)
+			}
+			conv.Datums[col.idx] = datum
+		}
+	}
+	if c.jsonColIdx >= 0 {
+		conv.Datums[c.jsonColIdx] = tree.NewDJSON(obj)
+	}
+
+	// Set any nil datums to DNull (in case the object didn't have the value
+	// set at all).
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) && conv.Datums[i] == nil {
+			if c.strict {
+				return newImportRowError(
+					errors.Errorf("field %s was not set in the ndjson import", conv.VisibleCols[i].GetName()),
+					line, rowNum)
+			}
+			conv.Datums[i] = tree.DNull
+		}
+	}
+	return nil
+}
+
+// ndjsonFetchKey returns the value of the key of a nested object which is
+// name once normalized, like the top-level keys, or nil if there is none or v
+// is not an object.
+func ndjsonFetchKey(v json.JSON, name string) (json.JSON, error) {
+	it, err := v.ObjectIter()
+	if err != nil || it == nil {
+		return nil, err
+	}
+	for it.Next() {
+		if lexbase.NormalizeName(it.Key()) == name {
+			return it.Value(), nil
+		}
+	}
+	return nil, nil
+}
+
+// ndjsonStream implements importRowProducer interface over the lines of a
+// newline delimited JSON file. Blank lines are skipped.
+type ndjsonStream struct {
+	input   *fileReader
+	scanner *bufio.Scanner
+	line    string
+	err     error
+}
+
+var _ importRowProducer = &ndjsonStream{}
+
+// Scan implements importRowProducer interface.
+func (s *ndjsonStream) Scan() bool {
+	for s.scanner.Scan() {
+		if len(bytes.TrimSpace(s.scanner.Bytes())) == 0 {
+			continue
+		}
+		s.line = s.scanner.Text()
+		return true
+	}
+	if err := s.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			err = wrapWithLineTooLongHint(errors.New("line too long"))
+		}
+		s.err = err
+	}
+	return false
+}
+
+// Err implements importRowProducer interface.
+func (s *ndjsonStream) Err() error {
+	return s.err
+}
+
+// Skip implements importRowProducer interface.
+func (s *ndjsonStream) Skip() error {
+	return nil
+}
+
+// Row implements importRowProducer interface.
+func (s *ndjsonStream) Row() (interface{}, error) {
+	return s.line, nil
+}
+
+// Progress implements importRowProducer interface.
+func (s *ndjsonStream) Progress() float32 {
+	return s.input.ReadFraction()
+}
+
+type ndjsonInputReader struct {
+	importContext *parallelImportContext
+	opts          roachpb.NDJSONOptions
+}
+
+var _ inputConverter = &ndjsonInputReader{}
+
+func newNDJSONInputReader(
+	semaCtx *tree.SemaContext,
+	kvCh chan row.KVBatch,
+	tableDesc catalog.TableDescriptor,
+	ndjsonOpts roachpb.NDJSONOptions,
+	walltime int64,
+	parallelism int,
+	evalCtx *tree.EvalContext,
+) (*ndjsonInputReader, error) {
+	return &ndjsonInputReader{
+		importContext: &parallelImportContext{
+			semaCtx:    semaCtx,
+			walltime:   walltime,
+			numWorkers: parallelism,
+			evalCtx:    evalCtx,
+			tableDesc:  tableDesc,
+			kvCh:       kvCh,
+		},
+		opts: ndjsonOpts,
+	}, nil
+}
+
+func (n *ndjsonInputReader) start(group ctxgroup.Group) {}
+
+func (n *ndjsonInputReader) readFiles(
+	ctx context.Context,
+	dataFiles map[int32]string,
+	resumePos map[int32]int64,
+	format roachpb.IOFileFormat,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	user security.SQLUsername,
+) error {
+	return readInputFiles(ctx, dataFiles, resumePos, format, n.readFile, makeExternalStorage, user)
+}
+
+func (n *ndjsonInputReader) readFile(
+	ctx context.Context, input *fileReader, inputIdx int32, resumePos int64, rejected chan string,
+) error {
+	consumer, err := newNDJSONConsumer(n.importContext.tableDesc, n.opts)
+	if err != nil {
+		return err
+	}
+	maxRowSize := int(n.opts.MaxRowSize)
+	if maxRowSize == 0 {
+		maxRowSize = defaultScanBuffer
+	}
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(nil, maxRowSize)
+	producer := &ndjsonStream{input: input, scanner: scanner}
+
+	fileCtx := &importFileContext{
+		source:   inputIdx,
+		skip:     resumePos,
+		rejected: rejected,
+		rowLimit: n.opts.RowLimit,
+	}
+	return runParallelImport(ctx, n.importContext, fileCtx, producer, consumer)
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package importccl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONConsumer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := tree.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	semaCtx := tree.MakeSemaContext()
+	tableDesc := descForTable(ctx, t,
+		`CREATE TABLE t (a INT PRIMARY KEY, b STRING, "c.d" INT, tags STRING[], extra JSONB)`,
+		100, 150, 200, NoFKs).ImmutableCopy().(catalog.TableDescriptor)
+
+	// readRows returns the rows of the input as strings, or the error of the
+	// first row that could not be converted.
+	readRows := func(t *testing.T, opts roachpb.NDJSONOptions, input string) ([]string, error) {
+		consumer, err := newNDJSONConsumer(tableDesc, opts)
+		require.NoError(t, err)
+		conv, err := row.NewDatumRowConverter(
+			ctx, &semaCtx, tableDesc, nil, evalCtx.Copy(), nil,
+			nil /* seqChunkProvider */, nil, /* metrics */
+		)
+		require.NoError(t, err)
+		r := &fileReader{Reader: bytes.NewBufferString(input)}
+		producer := &ndjsonStream{input: r, scanner: bufio.NewScanner(r)}
+		var rows []string
+		for rowNum := int64(1); producer.Scan(); rowNum++ {
+			rec, err := producer.Row()
+			require.NoError(t, err)
+			if err := consumer.FillDatums(rec, rowNum, conv); err != nil {
+				return rows, err
+			}
+			rows = append(rows, tree.AsString(tree.Datums(conv.Datums[:len(conv.VisibleCols)])))
+		}
+		require.NoError(t, producer.Err())
+		return rows, nil
+	}
+
+	const input = `{"a": 1, "b": "one", "c": {"d": 10}, "tags": ["x", null]}
+
+{"A": 2, "C": {"D": 20}, "extra": null, "other": true}
+{"a": 3, "c": {"e": 1}, "extra": {"f": null}}
+`
+
+	t.Run("default", func(t *testing.T) {
+		rows, err := readRows(t, roachpb.NDJSONOptions{}, input)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`(1, 'one', NULL, ARRAY['x',NULL], NULL)`,
+			`(2, NULL, NULL, NULL, NULL)`,
+			`(3, NULL, NULL, NULL, '{"f": null}')`,
+		}, rows)
+	})
+
+	t.Run("path_separator", func(t *testing.T) {
+		rows, err := readRows(t, roachpb.NDJSONOptions{PathSeparator: "."}, input)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`(1, 'one', 10, ARRAY['x',NULL], NULL)`,
+			`(2, NULL, 20, NULL, NULL)`,
+			`(3, NULL, NULL, NULL, '{"f": null}')`,
+		}, rows)
+	})
+
+	t.Run("json_column", func(t *testing.T) {
+		rows, err := readRows(t, roachpb.NDJSONOptions{JsonColumn: "extra", StrictMode: true},
+			`{"a": 1, "b": "one", "c.d": 2, "tags": [], "other": true}`)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`(1, 'one', 2, ARRAY[], '{"a": 1, "b": "one", "c.d": 2, "other": true, "tags": []}')`,
+		}, rows)
+
+		_, err = newNDJSONConsumer(tableDesc, roachpb.NDJSONOptions{JsonColumn: "b"})
+		require.EqualError(t, err, "json_column column b must be of type JSONB, found STRING")
+		_, err = newNDJSONConsumer(tableDesc, roachpb.NDJSONOptions{JsonColumn: "missing"})
+		require.EqualError(t, err, "json_column column missing does not exist")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		_, err := readRows(t, roachpb.NDJSONOptions{StrictMode: true},
+			`{"a": 1, "b": "one", "c.d": 2, "tags": [], "extra": {}, "other": true}`)
+		require.Regexp(t, "could not find column for key other", err)
+
+		_, err = readRows(t, roachpb.NDJSONOptions{StrictMode: true}, `{"a": 1}`)
+		require.Regexp(t, "field b was not set in the ndjson import", err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := readRows(t, roachpb.NDJSONOptions{}, `[1, 2]`)
+		require.Regexp(t, "expected a JSON object", err)
+
+		_, err = readRows(t, roachpb.NDJSONOptions{}, `{"a": 1`)
+		require.Regexp(t, `error parsing row 1: .* \(row: "{\\"a\\": 1"\)`, err)
+
+		_, err = readRows(t, roachpb.NDJSONOptions{}, `{"a": "one"}`)
+		require.Regexp(t, "column a", err)
+	})
+}
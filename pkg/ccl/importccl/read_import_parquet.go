@@ -0,0 +1,673 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package importccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+)
+
+// parquetDecodeFn converts a value, as returned by the parquet reader for a
+// column, to a datum.
+type parquetDecodeFn func(v interface{}, evalCtx *tree.EvalContext) (tree.Datum, error)
+
+// makeParquetDecodeFn returns the function converting the values of the given
+// parquet column into datums of type typ.
+//
+// The parquet physical types are boolean, int32, int64, int96, float, double,
+// byte arrays and fixed length byte arrays. A column may carry a logical type
+// (or, in older files, a converted type) describing how to interpret them:
+// strings, enums and JSON are byte arrays, dates are a number of days since the
+// unix epoch, times and timestamps are a number of milli, micro or nanoseconds,
+// and decimals are unscaled integers or big-endian byte arrays. Lists are
+// groups with a single repeated child. Int96 values are legacy timestamps
+// written by Impala and Spark. Other groups (maps and structs) are not
+// supported.
+//
+// Values whose type does not match typ are cast to it, except for byte arrays
+// which are parsed as typ, like in the other import formats.
+func makeParquetDecodeFn(col *parquetschema.ColumnDefinition, typ *types.T) (parquetDecodeFn, error) {
+	elem := col.SchemaElement
+	logical := elem.GetLogicalType()
+	if isParquetList(elem) {
+		return makeParquetListDecodeFn(col, typ)
+	}
+	if len(col.Children) > 0 {
+		return nil, errors.Errorf("parquet group column %s is not supported", elem.Name)
+	}
+
+	var fn parquetDecodeFn
+	switch {
+	case logical != nil && logical.IsSetDECIMAL():
+		fn = makeParquetDecimalDecodeFn(logical.DECIMAL.Scale)
+	case elem.ConvertedType != nil && *elem.ConvertedType == parquet.ConvertedType_DECIMAL:
+		fn = makeParquetDecimalDecodeFn(elem.GetScale())
+
+	case logical != nil && logical.IsSetDATE(),
+		elem.ConvertedType != nil && *elem.ConvertedType == parquet.ConvertedType_DATE:
+		fn = func(v interface{}, _ *tree.EvalContext) (tree.Datum, error) {
+			days, ok := v.(int32)
+			if !ok {
+				return nil, errors.Errorf("unexpected date value %T", v)
+			}
+			d, err := pgdate.MakeDateFromUnixEpoch(int64(days))
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDDate(d), nil
+		}
+
+	case logical != nil && logical.IsSetTIMESTAMP():
+		fn = makeParquetTimestampDecodeFn(
+			parquetTimeUnit(logical.TIMESTAMP.Unit), logical.TIMESTAMP.IsAdjustedToUTC)
+	case elem.ConvertedType != nil && *elem.ConvertedType == parquet.ConvertedType_TIMESTAMP_MILLIS:
+		fn = makeParquetTimestampDecodeFn(time.Millisecond, true /* adjustedToUTC */)
+	case elem.ConvertedType != nil && *elem.ConvertedType == parquet.ConvertedType_TIMESTAMP_MICROS:
+		fn = makeParquetTimestampDecodeFn(time.Microsecond, true /* adjustedToUTC */)
+
+	case logical != nil && logical.IsSetTIME():
+		fn = makeParquetTimeDecodeFn(parquetTimeUnit(logical.TIME.Unit))
+	case elem.ConvertedType != nil && *elem.ConvertedType == parquet.ConvertedType_TIME_MILLIS:
+		fn = makeParquetTimeDecodeFn(time.Millisecond)
+	case elem.ConvertedType != nil && *elem.ConvertedType == parquet.ConvertedType_TIME_MICROS:
+		fn = makeParquetTimeDecodeFn(time.Microsecond)
+
+	case logical != nil && logical.IsSetUUID():
+		fn = func(v interface{}, _ *tree.EvalContext) (tree.Datum, error) {
+			b, ok := v.([]byte)
+			if !ok {
+				return nil, errors.Errorf("unexpected uuid value %T", v)
+			}
+			u, err := uuid.FromBytes(b)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDUuid(tree.DUuid{UUID: u}), nil
+		}
+
+	case elem.GetType() == parquet.Type_INT96:
+		fn = func(v interface{}, _ *tree.EvalContext) (tree.Datum, error) {
+			b, ok := v.([12]byte)
+			if !ok {
+				return nil, errors.Errorf("unexpected int96 value %T", v)
+			}
+			// The first 8 bytes are the nanoseconds since midnight, and the last
+			// 4 bytes the julian day.
+			const julianDayOfUnixEpoch = 2440588
+			const secondsPerDay = 24 * 60 * 60
+			nanos := int64(binary.LittleEndian.Uint64(b[:8]))
+			days := int64(binary.LittleEndian.Uint32(b[8:])) - julianDayOfUnixEpoch
+			t := timeutil.Unix(days*secondsPerDay, nanos)
+			return tree.MakeDTimestampTZ(t, time.Microsecond)
+		}
+
+	default:
+		fn = func(v interface{}, evalCtx *tree.EvalContext) (tree.Datum, error) {
+			switch v := v.(type) {
+			case bool:
+				return tree.MakeDBool(tree.DBool(v)), nil
+			case int32:
+				return tree.NewDInt(tree.DInt(v)), nil
+			case int64:
+				return tree.NewDInt(tree.DInt(v)), nil
+			case float32:
+				return tree.NewDFloat(tree.DFloat(v)), nil
+			case float64:
+				return tree.NewDFloat(tree.DFloat(v)), nil
+			case []byte:
+				if typ.Family() == types.BytesFamily {
+					return tree.NewDBytes(tree.DBytes(v)), nil
+				}
+				// Like in the avro import, byte arrays (which include strings, enums
+				// and JSON values) are parsed as the target type.
+				return rowenc.ParseDatumStringAs(typ, string(v), evalCtx)
+			}
+			return nil, errors.Errorf("unexpected parquet value %T", v)
+		}
+	}
+
+	return func(v interface{}, evalCtx *tree.EvalContext) (tree.Datum, error) {
+		if v == nil {
+			return tree.DNull, nil
+		}
+		d, err := fn(v, evalCtx)
+		if err != nil {
+			return nil, err
+		}
+		if !typ.Equivalent(d.ResolvedType()) {
+			return tree.PerformCast(evalCtx, d, typ)
+		}
+		return d, nil
+	}, nil
+}
+
+// isParquetList returns whether the schema element is a group annotated as a
+// list.
+func isParquetList(elem *parquet.SchemaElement) bool {
+	if logical := elem.GetLogicalType(); logical != nil && logical.IsSetLIST() {
+		return true
+	}
+	return elem.ConvertedType != nil && *elem.ConvertedType == parquet.ConvertedType_LIST
+}
+
+// makeParquetListDecodeFn returns the function decoding a list column into an
+// array. A list column is a group containing a single repeated field, which
+// is either a group containing the element column or, in files using the
+// legacy two-level representation, the element column itself.
+func makeParquetListDecodeFn(
+	col *parquetschema.ColumnDefinition, typ *types.T,
+) (parquetDecodeFn, error) {
+	if typ.Family() != types.ArrayFamily {
+		return nil, errors.Errorf("cannot convert parquet list %s to %s", col.SchemaElement.Name, typ)
+	}
+	if len(col.Children) != 1 {
+		return nil, errors.Errorf("invalid parquet list %s", col.SchemaElement.Name)
+	}
+	repeated := col.Children[0]
+	elemCol := repeated
+	if len(repeated.Children) == 1 {
+		elemCol = repeated.Children[0]
+	}
+	elemFn, err := makeParquetDecodeFn(elemCol, typ.ArrayContents())
+	if err != nil {
+		return nil, err
+	}
+
+	decodeElem := func(e interface{}, evalCtx *tree.EvalContext) (tree.Datum, error) {
+		if elemCol != repeated {
+			m, ok := e.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("unexpected list element %T", e)
+			}
+			e = m[elemCol.SchemaElement.Name]
+		}
+		return elemFn(e, evalCtx)
+	}
+
+	return func(v interface{}, evalCtx *tree.EvalContext) (tree.Datum, error) {
+		if v == nil {
+			return tree.DNull, nil
+		}
+		group, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected list value %T", v)
+		}
+		arr := tree.NewDArray(typ.ArrayContents())
+		appendElem := func(e interface{}) error {
+			d, err := decodeElem(e, evalCtx)
+			if err != nil {
+				return err
+			}
+			return arr.Append(d)
+		}
+		switch elems := group[repeated.SchemaElement.Name].(type) {
+		case nil:
+		case []map[string]interface{}:
+			for _, e := range elems {
+				if err := appendElem(e); err != nil {
+					return nil, err
+				}
+			}
+		case []interface{}:
+			for _, e := range elems {
+				if err := appendElem(e); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, errors.Errorf("unexpected list value %T", elems)
+		}
+		return arr, nil
+	}, nil
+}
+
+// parquetTimeUnit returns the duration of the unit of a time or timestamp
+// logical type.
+func parquetTimeUnit(unit *parquet.TimeUnit) time.Duration {
+	switch {
+	case unit.IsSetMILLIS():
+		return time.Millisecond
+	case unit.IsSetNANOS():
+		return time.Nanosecond
+	default:
+		return time.Microsecond
+	}
+}
+
+func makeParquetTimestampDecodeFn(unit time.Duration, adjustedToUTC bool) parquetDecodeFn {
+	return func(v interface{}, _ *tree.EvalContext) (tree.Datum, error) {
+		n, ok := v.(int64)
+		if !ok {
+			return nil, errors.Errorf("unexpected timestamp value %T", v)
+		}
+		t := timeutil.Unix(0, n*int64(unit))
+		if adjustedToUTC {
+			return tree.MakeDTimestampTZ(t, time.Microsecond)
+		}
+		return tree.MakeDTimestamp(t, time.Microsecond)
+	}
+}
+
+func makeParquetTimeDecodeFn(unit time.Duration) parquetDecodeFn {
+	return func(v interface{}, _ *tree.EvalContext) (tree.Datum, error) {
+		var n int64
+		switch v := v.(type) {
+		case int32:
+			n = int64(v)
+		case int64:
+			n = v
+		default:
+			return nil, errors.Errorf("unexpected time value %T", v)
+		}
+		micros := n * int64(unit) / int64(time.Microsecond)
+		return tree.MakeDTime(timeofday.FromInt(micros)), nil
+	}
+}
+
+// makeParquetDecimalDecodeFn returns the function decoding decimals, which are
+// stored as unscaled int32, int64 or big-endian two's complement byte arrays.
+func makeParquetDecimalDecodeFn(scale int32) parquetDecodeFn {
+	return func(v interface{}, _ *tree.EvalContext) (tree.Datum, error) {
+		var unscaled big.Int
+		switch v := v.(type) {
+		case int32:
+			unscaled.SetInt64(int64(v))
+		case int64:
+			unscaled.SetInt64(v)
+		case []byte:
+			unscaled.SetBytes(v)
+			if len(v) > 0 && v[0]&0x80 != 0 {
+				// The value is negative: subtract 2^(8*len(v)).
+				var offset big.Int
+				offset.Lsh(big.NewInt(1), uint(8*len(v)))
+				unscaled.Sub(&unscaled, &offset)
+			}
+		default:
+			return nil, errors.Errorf("unexpected decimal value %T", v)
+		}
+		d := &tree.DDecimal{}
+		d.Negative = unscaled.Sign() < 0
+		d.Coeff.Abs(&unscaled)
+		d.Exponent = -scale
+		return d, nil
+	}
+}
+
+// parquetImportColumn describes a column of the parquet file which is imported
+// into a column of the table.
+type parquetImportColumn struct {
+	name   string
+	idx    int
+	decode parquetDecodeFn
+}
+
+// parquetConsumer implements importRowConsumer interface.
+type parquetConsumer struct {
+	columns []parquetImportColumn
+	strict  bool
+}
+
+var _ importRowConsumer = &parquetConsumer{}
+
+// FillDatums implements importRowConsumer interface.
+func (p *parquetConsumer) FillDatums(
+	native interface{}, rowIndex int64, conv *row.DatumRowConverter,
+) error {
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected native type; expected map[string]interface{} found %T instead", native)
+	}
+	for _, col := range p.columns {
+		datum, err := col.decode(record[col.name], conv.EvalCtx)
+		if err != nil {
+			return errors.Wrapf(err, "column %s", col.name)
+		}
+		conv.Datums[col.idx] = datum
+	}
+
+	// Set any nil datums to DNull (in case the column is not present in the
+	// parquet file).
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) && conv.Datums[i] == nil {
+			if p.strict {
+				return fmt.Errorf("field %s was not set in the parquet import", conv.VisibleCols[i].GetName())
+			}
+			conv.Datums[i] = tree.DNull
+		}
+	}
+	return nil
+}
+
+// parquetRowStream implements importRowProducer interface over the rows of a
+// parquet file. The reader goes through the row groups of the file in order.
+type parquetRowStream struct {
+	reader   *goparquet.FileReader
+	numRows  int64
+	rowsRead int64
+	row      map[string]interface{}
+	err      error
+}
+
+var _ importRowProducer = &parquetRowStream{}
+
+// Scan implements importRowProducer interface.
+func (p *parquetRowStream) Scan() bool {
+	if p.rowsRead >= p.numRows {
+		return false
+	}
+	p.row, p.err = p.reader.NextRow()
+	if p.err == io.EOF {
+		p.err = nil
+		return false
+	}
+	p.rowsRead++
+	return p.err == nil
+}
+
+// Err implements importRowProducer interface.
+func (p *parquetRowStream) Err() error {
+	return p.err
+}
+
+// Skip implements importRowProducer interface.
+func (p *parquetRowStream) Skip() error {
+	p.row = nil
+	return nil
+}
+
+// Row implements importRowProducer interface.
+func (p *parquetRowStream) Row() (interface{}, error) {
+	res := p.row
+	p.row = nil
+	return res, nil
+}
+
+// Progress implements importRowProducer interface.
+func (p *parquetRowStream) Progress() float32 {
+	if p.numRows == 0 {
+		return 0
+	}
+	return float32(p.rowsRead) / float32(p.numRows)
+}
+
+// externalStorageReadSeeker is an io.ReadSeeker over a file in external
+// storage. A parquet file is read by seeking to its footer, which holds the
+// schema and the location of each row group, and then to the column chunks of
+// the row groups, which are read sequentially. So reads go through a stream
+// opened by ReadFileAt at the current offset, which is only reopened when the
+// offset is moved by a seek.
+type externalStorageReadSeeker struct {
+	ctx  context.Context
+	es   cloud.ExternalStorage
+	size int64
+	pos  int64
+	// r reads the file from pos. It is nil if it needs to be opened.
+	r io.ReadCloser
+}
+
+var _ io.ReadSeeker = &externalStorageReadSeeker{}
+
+// Read implements the io.Reader interface.
+func (f *externalStorageReadSeeker) Read(p []byte) (int, error) {
+	if f.pos >= f.size {
+		return 0, io.EOF
+	}
+	if f.r == nil {
+		r, _, err := f.es.ReadFileAt(f.ctx, "", f.pos)
+		if err != nil {
+			return 0, err
+		}
+		f.r = r
+	}
+	n, err := f.r.Read(p)
+	f.pos += int64(n)
+	return n, err
+}
+
+// Seek implements the io.Seeker interface.
+func (f *externalStorageReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	pos := offset
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		pos += f.pos
+	case io.SeekEnd:
+		pos += f.size
+	default:
+		return 0, errors.Errorf("invalid whence %d", whence)
+	}
+	if pos < 0 {
+		return 0, errors.Errorf("negative position %d", pos)
+	}
+	if pos != f.pos {
+		if err := f.Close(); err != nil {
+			return 0, err
+		}
+		f.pos = pos
+	}
+	return pos, nil
+}
+
+// Close closes the stream opened by Read, if any.
+func (f *externalStorageReadSeeker) Close() error {
+	if f.r == nil {
+		return nil
+	}
+	err := f.r.Close()
+	f.r = nil
+	return err
+}
+
+// openParquetFile returns a reader of the given parquet file. Only the footer
+// and the column chunks which are decoded are read from external storage. A
+// compressed file can only be read sequentially, so it is read in memory.
+func openParquetFile(ctx context.Context, input *fileReader) (io.ReadSeeker, func(), error) {
+	if input.es == nil {
+		data, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return bytes.NewReader(data), func() {}, nil
+	}
+	size, err := input.es.Size(ctx, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	f := &externalStorageReadSeeker{ctx: ctx, es: input.es, size: size}
+	return f, func() { _ = f.Close() }, nil
+}
+
+// newImportParquetPipeline returns the producer and consumer of the rows of
+// the given parquet file. The returned cleanup function must be called once
+// the rows have been read.
+//
+// The row groups of a file are read in order by a single processor, like the
+// files of the other formats: the unit of work distributed to the processors
+// of an IMPORT, and of its progress and resume positions, is a file. Large
+// files should be split into several files to be imported in parallel.
+func newImportParquetPipeline(
+	ctx context.Context, p *parquetInputReader, input *fileReader,
+) (importRowProducer, importRowConsumer, func(), error) {
+	file, cleanup, err := openParquetFile(ctx, input)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	producer, consumer, err := makeParquetPipeline(p, file)
+	if err != nil {
+		cleanup()
+		return nil, nil, nil, err
+	}
+	return producer, consumer, cleanup, nil
+}
+
+func makeParquetPipeline(
+	p *parquetInputReader, file io.ReadSeeker,
+) (importRowProducer, importRowConsumer, error) {
+	// Files with the schema of the previous file are only opened once, with
+	// the projection of the previous file.
+	var selected []string
+	if p.projection != nil {
+		selected = p.projection.selected
+	}
+	reader, err := goparquet.NewFileReader(file, selected...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "reading parquet file")
+	}
+
+	schema := reader.GetSchemaDefinition()
+	if p.projection == nil || p.projection.schema != schema.String() {
+		projection, err := makeParquetProjection(p, schema)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(selected) > 0 || len(projection.selected) < len(schema.RootColumn.Children) {
+			// Only decode the columns of the file that are imported.
+			reader, err = goparquet.NewFileReader(file, projection.selected...)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "reading parquet file")
+			}
+		}
+		p.projection = projection
+	}
+
+	consumer := &parquetConsumer{columns: p.projection.columns, strict: p.opts.StrictMode}
+	producer := &parquetRowStream{reader: reader, numRows: reader.NumRows()}
+	return producer, consumer, nil
+}
+
+// parquetProjection maps the columns of the parquet files with a given schema
+// to the columns of the table.
+type parquetProjection struct {
+	// schema is the textual representation of the schema of the files.
+	schema string
+	// selected contains the names of the columns of the files that are
+	// imported.
+	selected []string
+	columns  []parquetImportColumn
+}
+
+func makeParquetProjection(
+	p *parquetInputReader, schema *parquetschema.SchemaDefinition,
+) (*parquetProjection, error) {
+	tableCols := p.importContext.tableDesc.VisibleColumns()
+	fieldIdxByName := make(map[string]int)
+	for idx, col := range tableCols {
+		fieldIdxByName[col.GetName()] = idx
+	}
+
+	projection := &parquetProjection{schema: schema.String()}
+	for _, fileCol := range schema.RootColumn.Children {
+		name := fileCol.SchemaElement.Name
+		idx, ok := fieldIdxByName[lexbase.NormalizeName(name)]
+		if !ok {
+			if p.opts.StrictMode {
+				return nil, fmt.Errorf("could not find column for parquet field %s", name)
+			}
+			continue
+		}
+		decode, err := makeParquetDecodeFn(fileCol, tableCols[idx].GetType())
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %s", tableCols[idx].GetName())
+		}
+		projection.columns = append(projection.columns, parquetImportColumn{
+			name: name, idx: idx, decode: decode,
+		})
+		projection.selected = append(projection.selected, name)
+	}
+	return projection, nil
+}
+
+type parquetInputReader struct {
+	importContext *parallelImportContext
+	opts          roachpb.ParquetOptions
+	// projection is the projection of the last file that was read. The files
+	// of an import usually share a schema, and the projection is reused
+	// across them.
+	projection *parquetProjection
+}
+
+var _ inputConverter = &parquetInputReader{}
+
+func newParquetInputReader(
+	semaCtx *tree.SemaContext,
+	kvCh chan row.KVBatch,
+	tableDesc catalog.TableDescriptor,
+	parquetOpts roachpb.ParquetOptions,
+	walltime int64,
+	parallelism int,
+	evalCtx *tree.EvalContext,
+) (*parquetInputReader, error) {
+	return &parquetInputReader{
+		importContext: &parallelImportContext{
+			semaCtx:    semaCtx,
+			walltime:   walltime,
+			numWorkers: parallelism,
+			evalCtx:    evalCtx,
+			tableDesc:  tableDesc,
+			kvCh:       kvCh,
+		},
+		opts: parquetOpts,
+	}, nil
+}
+
+func (p *parquetInputReader) start(group ctxgroup.Group) {}
+
+func (p *parquetInputReader) readFiles(
+	ctx context.Context,
+	dataFiles map[int32]string,
+	resumePos map[int32]int64,
+	format roachpb.IOFileFormat,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	user security.SQLUsername,
+) error {
+	return readInputFiles(ctx, dataFiles, resumePos, format, p.readFile, makeExternalStorage, user)
+}
+
+func (p *parquetInputReader) readFile(
+	ctx context.Context, input *fileReader, inputIdx int32, resumePos int64, rejected chan string,
+) error {
+	producer, consumer, cleanup, err := newImportParquetPipeline(ctx, p, input)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fileCtx := &importFileContext{
+		source:   inputIdx,
+		skip:     resumePos,
+		rejected: rejected,
+		rowLimit: p.opts.RowLimit,
+	}
+	return runParallelImport(ctx, p.importContext, fileCtx, producer, consumer)
+}
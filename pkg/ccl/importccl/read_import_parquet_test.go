@@ -0,0 +1,229 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package importccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/blobs"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/nodelocal"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestParquetColumn returns the definition of a parquet column with the
+// given physical type and annotations.
+func makeTestParquetColumn(
+	typ parquet.Type, converted *parquet.ConvertedType, logical *parquet.LogicalType,
+) *parquetschema.ColumnDefinition {
+	elem := parquet.NewSchemaElement()
+	elem.Name = "col"
+	elem.Type = parquet.TypePtr(typ)
+	elem.ConvertedType = converted
+	elem.LogicalType = logical
+	return &parquetschema.ColumnDefinition{SchemaElement: elem}
+}
+
+func TestParquetDecodeFn(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := tree.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(context.Background())
+
+	date := parquet.NewLogicalType()
+	date.DATE = parquet.NewDateType()
+	timestampMicrosUTC := parquet.NewLogicalType()
+	timestampMicrosUTC.TIMESTAMP = &parquet.TimestampType{
+		IsAdjustedToUTC: true,
+		Unit:            &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()},
+	}
+	timeMillis := parquet.NewLogicalType()
+	timeMillis.TIME = &parquet.TimeType{
+		Unit: &parquet.TimeUnit{MILLIS: parquet.NewMilliSeconds()},
+	}
+	decimal := parquet.NewLogicalType()
+	decimal.DECIMAL = &parquet.DecimalType{Scale: 2, Precision: 4}
+	str := parquet.NewLogicalType()
+	str.STRING = parquet.NewStringType()
+
+	// An int96 for 1970-01-02 00:00:01.
+	var int96 [12]byte
+	binary.LittleEndian.PutUint64(int96[:8], 1e9)
+	binary.LittleEndian.PutUint32(int96[8:], 2440589)
+
+	tests := []struct {
+		name     string
+		col      *parquetschema.ColumnDefinition
+		typ      *types.T
+		value    interface{}
+		expected string
+	}{
+		{"null", makeTestParquetColumn(parquet.Type_INT64, nil, nil), types.Int, nil, ""},
+		{"int", makeTestParquetColumn(parquet.Type_INT64, nil, nil), types.Int, int64(5), "5"},
+		{"int32", makeTestParquetColumn(parquet.Type_INT32, nil, nil), types.Int4, int32(5), "5"},
+		{"int-as-decimal", makeTestParquetColumn(parquet.Type_INT64, nil, nil), types.Decimal, int64(5), "5"},
+		{"bool", makeTestParquetColumn(parquet.Type_BOOLEAN, nil, nil), types.Bool, true, "true"},
+		{"double", makeTestParquetColumn(parquet.Type_DOUBLE, nil, nil), types.Float, 1.5, "1.5"},
+		{"bytes", makeTestParquetColumn(parquet.Type_BYTE_ARRAY, nil, nil), types.Bytes, []byte("abc"), "abc"},
+		{"string", makeTestParquetColumn(parquet.Type_BYTE_ARRAY, nil, str), types.String, []byte("abc"), "abc"},
+		{
+			"string-as-uuid", makeTestParquetColumn(parquet.Type_BYTE_ARRAY, nil, str), types.Uuid,
+			[]byte("63616665-6630-3064-6465-616462656566"), "63616665-6630-3064-6465-616462656566",
+		},
+		{"date", makeTestParquetColumn(parquet.Type_INT32, nil, date), types.Date, int32(1), "1970-01-02"},
+		{
+			"converted-date", makeTestParquetColumn(parquet.Type_INT32, parquet.ConvertedTypePtr(parquet.ConvertedType_DATE), nil),
+			types.Date, int32(1), "1970-01-02",
+		},
+		{
+			"timestamp", makeTestParquetColumn(parquet.Type_INT64, nil, timestampMicrosUTC),
+			types.TimestampTZ, int64(1e6), "1970-01-01 00:00:01+00",
+		},
+		{
+			"timestamp-millis", makeTestParquetColumn(parquet.Type_INT64, parquet.ConvertedTypePtr(parquet.ConvertedType_TIMESTAMP_MILLIS), nil),
+			types.Timestamp, int64(1e3), "1970-01-01 00:00:01",
+		},
+		{"time", makeTestParquetColumn(parquet.Type_INT32, nil, timeMillis), types.Time, int32(1e3), "00:00:01"},
+		{"int96", makeTestParquetColumn(parquet.Type_INT96, nil, nil), types.TimestampTZ, int96, "1970-01-02 00:00:01+00"},
+		{"decimal-int", makeTestParquetColumn(parquet.Type_INT64, nil, decimal), types.Decimal, int64(-150), "-1.50"},
+		{
+			"decimal-bytes", makeTestParquetColumn(parquet.Type_FIXED_LEN_BYTE_ARRAY, nil, decimal),
+			types.Decimal, []byte{0xff, 0x38}, "-2.00",
+		},
+		{
+			"converted-decimal", makeTestParquetColumn(parquet.Type_BYTE_ARRAY, parquet.ConvertedTypePtr(parquet.ConvertedType_DECIMAL), nil),
+			types.Decimal, []byte{0x01, 0x00}, "256",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fn, err := makeParquetDecodeFn(test.col, test.typ)
+			require.NoError(t, err)
+			d, err := fn(test.value, evalCtx)
+			require.NoError(t, err)
+			if test.value == nil {
+				require.Equal(t, tree.DNull, d)
+				return
+			}
+			expected, err := rowenc.ParseDatumStringAs(test.typ, test.expected, evalCtx)
+			require.NoError(t, err)
+			require.Equal(t, 0, d.Compare(evalCtx, expected), "expected %s, got %s", expected, d)
+		})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		list := parquet.NewLogicalType()
+		list.LIST = parquet.NewListType()
+		col := makeTestParquetColumn(parquet.Type_INT64, nil, list)
+		col.SchemaElement.Type = nil
+		repeated := makeTestParquetColumn(parquet.Type_INT64, nil, nil)
+		repeated.SchemaElement.Name = "list"
+		repeated.SchemaElement.Type = nil
+		element := makeTestParquetColumn(parquet.Type_INT64, nil, nil)
+		element.SchemaElement.Name = "element"
+		repeated.Children = []*parquetschema.ColumnDefinition{element}
+		col.Children = []*parquetschema.ColumnDefinition{repeated}
+
+		fn, err := makeParquetDecodeFn(col, types.IntArray)
+		require.NoError(t, err)
+		d, err := fn(map[string]interface{}{
+			"list": []map[string]interface{}{{"element": int64(1)}, {}, {"element": int64(3)}},
+		}, evalCtx)
+		require.NoError(t, err)
+		expected, err := rowenc.ParseDatumStringAs(types.IntArray, "{1,NULL,3}", evalCtx)
+		require.NoError(t, err)
+		require.Equal(t, 0, d.Compare(evalCtx, expected), "expected %s, got %s", expected, d)
+
+		_, err = makeParquetDecodeFn(col, types.Int)
+		require.Regexp(t, "cannot convert parquet list col to", err)
+	})
+
+	t.Run("group", func(t *testing.T) {
+		col := makeTestParquetColumn(parquet.Type_INT64, nil, nil)
+		col.Children = []*parquetschema.ColumnDefinition{makeTestParquetColumn(parquet.Type_INT64, nil, nil)}
+		_, err := makeParquetDecodeFn(col, types.Int)
+		require.EqualError(t, err, "parquet group column col is not supported")
+	})
+}
+
+func TestExternalStorageReadSeeker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+	settings := cluster.MakeTestingClusterSettings()
+	settings.ExternalIODir = dir
+	makeStorage := func(path string) cloud.ExternalStorage {
+		es, err := nodelocal.TestingMakeLocalStorage(
+			ctx, roachpb.ExternalStorage_LocalFilePath{Path: path}, settings,
+			blobs.TestBlobServiceClient(dir), base.ExternalIODirConfig{},
+		)
+		require.NoError(t, err)
+		return es
+	}
+
+	const contents = "0123456789"
+	dirStorage := makeStorage("/")
+	defer dirStorage.Close()
+	require.NoError(t, cloud.WriteFile(ctx, dirStorage, "f", bytes.NewReader([]byte(contents))))
+	// Like the storage of an IMPORT input, the storage is rooted at the file.
+	es := makeStorage("/f")
+	defer es.Close()
+	f := &externalStorageReadSeeker{ctx: ctx, es: es, size: int64(len(contents))}
+	defer func() { require.NoError(t, f.Close()) }()
+
+	read := func(n int) string {
+		buf := make([]byte, n)
+		_, err := io.ReadFull(f, buf)
+		require.NoError(t, err)
+		return string(buf)
+	}
+
+	// Seek to the end of the file, like a parquet reader reading the footer.
+	pos, err := f.Seek(-3, io.SeekEnd)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), pos)
+	require.Equal(t, "789", read(3))
+	n, err := f.Read(make([]byte, 1))
+	require.Equal(t, 0, n)
+	require.Equal(t, io.EOF, err)
+
+	// Sequential reads continue from the current position.
+	_, err = f.Seek(2, io.SeekStart)
+	require.NoError(t, err)
+	require.Equal(t, "23", read(2))
+	require.Equal(t, "456", read(3))
+	pos, err = f.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), pos)
+	_, err = f.Seek(-6, io.SeekCurrent)
+	require.NoError(t, err)
+	require.Equal(t, "1", read(1))
+
+	_, err = f.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
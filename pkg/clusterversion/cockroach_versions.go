@@ -328,6 +328,25 @@ const (
 	SeedTenantSpanConfigs
 	// Public schema is backed by a descriptor.
 	PublicSchemasWithDescriptors
+	// NotificationsTable adds the system.notifications table, which stores the
+	// notifications sent with NOTIFY.
+	NotificationsTable
+	// RowLevelSecurity adds the row-level security policies and settings to
+	// table descriptors.
+	RowLevelSecurity
+	// ReadCommittedIsolation adds the isolation level to transaction records
+	// and allows transactions to run under READ COMMITTED isolation.
+	ReadCommittedIsolation
+	// ExclusionConstraints adds exclusion constraints to table descriptors and
+	// validates the ones added to existing tables in the schema changer.
+	ExclusionConstraints
+	// UserDefinedFunctions adds function descriptors, the function mappings on
+	// schema and database descriptors, and the function back references on
+	// table descriptors.
+	UserDefinedFunctions
+	// Domains adds domain configurations to type descriptors and the domain
+	// metadata of user-defined types.
+	Domains
 
 	// *************************************************
 	// Step (1): Add new versions here.
@@ -577,6 +596,30 @@ var versionsSingleton = keyedVersions{
 		Key:     PublicSchemasWithDescriptors,
 		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 34},
 	},
+	{
+		Key:     NotificationsTable,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 36},
+	},
+	{
+		Key:     RowLevelSecurity,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 38},
+	},
+	{
+		Key:     ReadCommittedIsolation,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 40},
+	},
+	{
+		Key:     ExclusionConstraints,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 42},
+	},
+	{
+		Key:     UserDefinedFunctions,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 44},
+	},
+	{
+		Key:     Domains,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 46},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
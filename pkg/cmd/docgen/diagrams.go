@@ -563,6 +563,10 @@ var specs = []stmtSpec{
 		inline:  []string{"opt_with_copy_options", "copy_options_list", "opt_with", "opt_where_clause", "where_clause"},
 		exclude: []*regexp.Regexp{regexp.MustCompile("'WHERE'")},
 	},
+	{
+		name:   "copy_to_stmt",
+		inline: []string{"opt_with_copy_options", "copy_options_list", "opt_with"},
+	},
 	{
 		name:    "cancel_job",
 		stmt:    "cancel_jobs_stmt",
@@ -611,6 +615,23 @@ var specs = []stmtSpec{
 			regexp.MustCompile("'OPTIONS'")},
 		unlink: []string{"table_name", "sink", "option", "value"},
 	},
+	{
+		name:   "create_domain",
+		stmt:   "create_domain_stmt",
+		inline: []string{"domain_qual_list", "domain_qualification", "domain_qualification_elem"},
+	},
+	{
+		name:    "create_foreign_table",
+		stmt:    "create_foreign_table_stmt",
+		inline:  []string{"opt_foreign_options", "foreign_option_list", "foreign_option"},
+		replace: map[string]string{"unrestricted_name": "option", "'SCONST'": "value"},
+		unlink:  []string{"option", "value"},
+	},
+	{
+		name:   "create_function",
+		stmt:   "create_func_stmt",
+		inline: []string{"opt_func_arg_list", "func_arg_list"},
+	},
 	{
 		name:   "create_index_stmt",
 		inline: []string{"opt_unique", "opt_storing", "storing", "index_params", "index_elem", "opt_asc_desc", "opt_index_access_method", "opt_hash_sharded", "opt_concurrently", "opt_with_storage_parameter_list", "storage_parameter_list"},
@@ -635,6 +656,11 @@ var specs = []stmtSpec{
 		},
 		nosplit: true,
 	},
+	{
+		name:   "create_policy",
+		stmt:   "create_policy_stmt",
+		inline: []string{"opt_policy_restrictive", "opt_policy_command", "opt_policy_roles", "opt_policy_using", "opt_policy_with_check"},
+	},
 	{
 		name:   "create_schedule_for_backup_stmt",
 		inline: []string{"string_or_placeholder_opt_list", "string_or_placeholder_list", "opt_with_backup_options", "cron_expr", "opt_full_backup_clause", "opt_with_schedule_options", "opt_backup_targets"},
@@ -655,6 +681,13 @@ var specs = []stmtSpec{
 		unlink:  []string{"integer", "sequence_name", "column_path"},
 		nosplit: true,
 	},
+	{
+		name:    "create_server",
+		stmt:    "create_server_stmt",
+		inline:  []string{"opt_foreign_options", "foreign_option_list", "foreign_option"},
+		replace: map[string]string{"unrestricted_name": "option", "'SCONST'": "value"},
+		unlink:  []string{"option", "value"},
+	},
 	{
 		name:    "create_stats_stmt",
 		replace: map[string]string{"name_list": "column_name"},
@@ -675,6 +708,11 @@ var specs = []stmtSpec{
 		replace: map[string]string{" name": "column_name"},
 		unlink:  []string{"column_name"},
 	},
+	{
+		name:   "create_trigger",
+		stmt:   "create_trigger_stmt",
+		inline: []string{"trigger_action_time", "trigger_event_list", "opt_trigger_when"},
+	},
 	{
 		name: "create_type",
 		stmt: "create_type_stmt",
@@ -702,7 +740,7 @@ var specs = []stmtSpec{
 	},
 	{
 		name:   "delete_stmt",
-		inline: []string{"opt_with_clause", "with_clause", "cte_list", "table_expr_opt_alias_idx", "table_name_opt_idx", "opt_where_clause", "where_clause", "returning_clause", "opt_sort_clause", "opt_limit_clause", "opt_only", "opt_descendant"},
+		inline: []string{"opt_with_clause", "with_clause", "cte_list", "table_expr_opt_alias_idx", "table_name_opt_idx", "opt_using_clause", "from_list", "opt_where_clause", "where_clause", "returning_clause", "opt_sort_clause", "opt_limit_clause", "opt_only", "opt_descendant"},
 		replace: map[string]string{
 			"relation_expr": "table_name",
 		},
@@ -751,6 +789,11 @@ var specs = []stmtSpec{
 		inline: []string{"opt_drop_behavior"},
 		match:  []*regexp.Regexp{regexp.MustCompile("'DROP' 'DATABASE'")},
 	},
+	{
+		name:   "drop_function",
+		stmt:   "drop_func_stmt",
+		inline: []string{"function_with_argtypes_list", "opt_drop_behavior"},
+	},
 	{
 		name:   "drop_index",
 		stmt:   "drop_index_stmt",
@@ -761,6 +804,10 @@ var specs = []stmtSpec{
 		},
 		replace: map[string]string{"standalone_index_name": "index_name"},
 	},
+	{
+		name: "drop_policy",
+		stmt: "drop_policy_stmt",
+	},
 	{
 		name:    "drop_role_stmt",
 		inline:  []string{"role_or_group_or_user"},
@@ -787,6 +834,11 @@ var specs = []stmtSpec{
 		inline: []string{"opt_drop_behavior", "table_name_list"},
 		match:  []*regexp.Regexp{regexp.MustCompile("'DROP' 'TABLE'")},
 	},
+	{
+		name:   "drop_trigger",
+		stmt:   "drop_trigger_stmt",
+		inline: []string{"opt_drop_behavior"},
+	},
 	{
 		name:    "drop_type",
 		stmt:    "drop_type_stmt",
@@ -938,6 +990,41 @@ var specs = []stmtSpec{
 		name:   "like_table_option_list",
 		inline: []string{"like_table_option"},
 	},
+	{name: "listen_stmt"},
+	{
+		name: "merge_stmt",
+		inline: []string{
+			"opt_with_clause",
+			"with_clause",
+			"cte_list",
+			"table_expr_opt_alias_idx",
+			"table_name_opt_idx",
+			"merge_when_list",
+			"merge_when_clause",
+			"opt_merge_cond",
+			"set_clause_list",
+			"set_clause",
+			"single_set_clause",
+			"multiple_set_clause",
+			"in_expr",
+			"expr_list",
+			"expr_tuple1_ambiguous",
+			"tuple1_ambiguous_values",
+			"insert_column_list",
+			"insert_column_item",
+			"opt_only",
+			"opt_descendant",
+		},
+		replace: map[string]string{
+			"relation_expr":      "table_name",
+			"select_with_parens": "'(' select_stmt ')'",
+		},
+		relink: map[string]string{
+			"table_name":       "relation_expr",
+			"column_name_list": "insert_column_list",
+		},
+		nosplit: true,
+	},
 	{
 		name: "on_conflict",
 		inline: []string{"name_list", "set_clause_list", "insert_column_list",
@@ -950,6 +1037,7 @@ var specs = []stmtSpec{
 		nosplit: true,
 	},
 	{name: "iso_level"},
+	{name: "notify_stmt"},
 	{
 		name: "not_null_column_level",
 		stmt: "stmt_block",
@@ -1164,6 +1252,12 @@ var specs = []stmtSpec{
 			regexp.MustCompile("'SET' 'CLUSTER'"),
 		},
 	},
+	{
+		name:   "set_constraints",
+		stmt:   "nonpreparable_set_stmt",
+		inline: []string{"set_constraints_stmt", "constraints_set_mode"},
+		match:  []*regexp.Regexp{regexp.MustCompile("'SET' 'CONSTRAINTS'")},
+	},
 	{
 		name: "set_transaction",
 		stmt: "nonpreparable_set_stmt",
@@ -1392,6 +1486,7 @@ var specs = []stmtSpec{
 		replace: map[string]string{"	stmt": "	'CREATE' 'TABLE' table_name '(' ( column_def ( ',' column_def )* ) ( 'CONSTRAINT' name | ) 'UNIQUE' '(' ( column_name ( ',' column_name )* ) ')' ( table_constraints | ) ')'"},
 		unlink: []string{"table_name", "check_expr", "table_constraints"},
 	},
+	{name: "unlisten_stmt"},
 	{
 		name:    "unsplit_index_at",
 		stmt:    "alter_unsplit_index_stmt",
@@ -407,6 +407,7 @@ const (
 	TenantUsageTableID                  = 45
 	SQLInstancesTableID                 = 46
 	SpanConfigurationsTableID           = 47
+	NotificationsTableID                = 48
 
 	// CommentType is type for system.comments
 	DatabaseCommentType   = 0
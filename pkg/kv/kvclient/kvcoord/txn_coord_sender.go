@@ -54,6 +54,14 @@ const (
 	// batches except EndTxn(commit=false) will be rejected.
 	txnError
 
+	// txnRetryableError means that a batch encountered a retriable error which
+	// did not restart the transaction, because the transaction reads from a new
+	// snapshot in each statement and can instead retry the statement that
+	// encountered the error. Further batches except EndTxn(commit=false) will be
+	// rejected until the client rolls back to a savepoint created before the
+	// statement, or restarts the transaction through ManualRestart.
+	txnRetryableError
+
 	// txnFinalized means that an EndTxn(commit=true) has been executed
 	// successfully, or an EndTxn(commit=false) was sent - regardless of
 	// whether it executed successfully or not. Further batches except
@@ -105,8 +113,8 @@ type TxnCoordSender struct {
 		syncutil.Mutex
 
 		txnState txnState
-		// storedErr is set when txnState == txnError. This storedErr is returned to
-		// clients on Send().
+		// storedErr is set when txnState == txnError or txnRetryableError. This
+		// storedErr is returned to clients on Send().
 		storedErr *roachpb.Error
 
 		// active is set whenever the transaction has sent any requests. Rolling
@@ -686,7 +694,7 @@ func (tc *TxnCoordSender) maybeRejectClientLocked(
 	switch tc.mu.txnState {
 	case txnPending:
 		// All good.
-	case txnError:
+	case txnError, txnRetryableError:
 		return tc.mu.storedErr
 	case txnFinalized:
 		msg := fmt.Sprintf("client already committed or rolled back the transaction. "+
@@ -808,6 +816,10 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 		tc.metrics.RestartsUnknown.Inc()
 	}
 	errTxnID := pErr.GetTxn().ID
+	if _, aborted := pErr.GetDetail().(*roachpb.TransactionAbortedError); !aborted &&
+		tc.mu.txn.IsolationLevel.PerStatementReadSnapshot() {
+		return tc.handleStatementRetryableErrLocked(ctx, pErr)
+	}
 	newTxn := roachpb.PrepareTransactionForRetry(ctx, pErr, tc.mu.userPriority, tc.clock)
 
 	// We'll pass a TransactionRetryWithProtoRefreshError up to the next layer.
@@ -842,6 +854,31 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 	return retErr
 }
 
+// handleStatementRetryableErrLocked is like handleRetryableErrLocked, but for
+// transactions that read from a new snapshot in each statement. Instead of
+// restarting the transaction at a new epoch, the TxnCoordSender moves to the
+// txnRetryableError state and the transaction's read timestamp is moved
+// forward. The client can then roll back to a savepoint created before the
+// statement that encountered the error and retry it, or restart the whole
+// transaction through ManualRestart.
+func (tc *TxnCoordSender) handleStatementRetryableErrLocked(
+	ctx context.Context, pErr *roachpb.Error,
+) *roachpb.TransactionRetryWithProtoRefreshError {
+	newTxn := roachpb.PrepareTransactionForStatementRetry(ctx, pErr, tc.clock)
+	retErr := roachpb.NewStatementRetryWithProtoRefreshError(
+		pErr.String(), pErr.GetTxn().ID, newTxn)
+
+	tc.mu.txn.Update(&newTxn)
+	// The reads performed by the statement at the previous read timestamp
+	// don't need to be refreshed, since the statement will be retried.
+	tc.interceptorAlloc.txnSpanRefresher.readTimestampSteppedLocked(tc.mu.txn.ReadTimestamp)
+
+	log.VEventf(ctx, 2, "retrying statement at new read timestamp %s", tc.mu.txn.ReadTimestamp)
+	tc.mu.txnState = txnRetryableError
+	tc.mu.storedErr = roachpb.NewError(retErr)
+	return retErr
+}
+
 // updateStateLocked updates the transaction state in both the success and error
 // cases. It also updates retryable errors with the updated transaction for use
 // by client restarts.
@@ -1015,6 +1052,24 @@ func (tc *TxnCoordSender) SetUserPriority(pri roachpb.UserPriority) error {
 	return nil
 }
 
+// SetIsolationLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) SetIsolationLevel(level enginepb.IsolationLevel) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mu.active && level != tc.mu.txn.IsolationLevel {
+		return errors.New("cannot change the isolation level of a running transaction")
+	}
+	tc.mu.txn.IsolationLevel = level
+	return nil
+}
+
+// IsolationLevel is part of the client.TxnSender interface.
+func (tc *TxnCoordSender) IsolationLevel() enginepb.IsolationLevel {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.mu.txn.IsolationLevel
+}
+
 // SetDebugName is part of the client.TxnSender interface.
 func (tc *TxnCoordSender) SetDebugName(name string) {
 	tc.mu.Lock()
@@ -1134,7 +1189,10 @@ func (tc *TxnCoordSender) IsSerializablePushAndRefreshNotPossible() bool {
 		tc.mu.txn.CommitTimestampFixed
 	// We check CommitTimestampFixed here because, if that's set, refreshing
 	// of reads is not performed.
-	return isTxnPushed && refreshAttemptNotPossible
+	return isTxnPushed && refreshAttemptNotPossible &&
+		// Transactions that tolerate write skew don't need to refresh their reads
+		// when pushed.
+		!tc.mu.txn.IsolationLevel.ToleratesWriteSkew()
 }
 
 // Epoch is part of the client.TxnSender interface.
@@ -1303,6 +1361,22 @@ func (tc *TxnCoordSender) Step(ctx context.Context) error {
 	return tc.interceptorAlloc.txnSeqNumAllocator.stepLocked(ctx)
 }
 
+// StepReadTimestamp is part of the TxnSender interface.
+func (tc *TxnCoordSender) StepReadTimestamp(ctx context.Context) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if !tc.mu.txn.IsolationLevel.PerStatementReadSnapshot() || tc.mu.txn.CommitTimestampFixed {
+		return nil
+	}
+	if pErr := tc.maybeRejectClientLocked(ctx, nil /* ba */); pErr != nil {
+		return pErr.GoError()
+	}
+	tc.mu.txn.BumpReadTimestamp(tc.clock.NowAsClockTimestamp(), tc.clock.MaxOffset().Nanoseconds())
+	tc.interceptorAlloc.txnSpanRefresher.readTimestampSteppedLocked(tc.mu.txn.ReadTimestamp)
+	log.VEventf(ctx, 2, "stepped read timestamp to %s", tc.mu.txn.ReadTimestamp)
+	return nil
+}
+
 // ConfigureStepping is part of the TxnSender interface.
 func (tc *TxnCoordSender) ConfigureStepping(
 	ctx context.Context, mode kv.SteppingMode,
@@ -1321,6 +1395,20 @@ func (tc *TxnCoordSender) GetSteppingMode(ctx context.Context) (curMode kv.Stepp
 	return curMode
 }
 
+// GetReadSeqNum is part of the TxnSender interface.
+func (tc *TxnCoordSender) GetReadSeqNum() enginepb.TxnSeq {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.interceptorAlloc.txnSeqNumAllocator.readSeq
+}
+
+// SetReadSeqNum is part of the TxnSender interface.
+func (tc *TxnCoordSender) SetReadSeqNum(seq enginepb.TxnSeq) error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.interceptorAlloc.txnSeqNumAllocator.setReadSeqLocked(seq)
+}
+
 // ManualRefresh is part of the TxnSender interface.
 func (tc *TxnCoordSender) ManualRefresh(ctx context.Context) error {
 	tc.mu.Lock()
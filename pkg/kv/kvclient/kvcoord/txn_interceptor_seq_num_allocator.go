@@ -144,6 +144,20 @@ func (s *txnSeqNumAllocator) stepLocked(ctx context.Context) error {
 	return nil
 }
 
+// setReadSeqLocked sets the read seqnum to a value previously obtained from
+// readSeq. Used by the TxnCoordSender's SetReadSeqNum() method.
+func (s *txnSeqNumAllocator) setReadSeqLocked(seq enginepb.TxnSeq) error {
+	if !s.steppingModeEnabled {
+		return errors.AssertionFailedf("stepping mode is not enabled")
+	}
+	if seq > s.writeSeq {
+		return errors.AssertionFailedf(
+			"cannot set read seqnum %d beyond write seqnum %d", seq, s.writeSeq)
+	}
+	s.readSeq = seq
+	return nil
+}
+
 // configureSteppingLocked configures the stepping mode.
 //
 // When enabling stepping from the non-enabled state, the read seqnum
@@ -228,6 +228,52 @@ func TestSequenceNumberAllocationWithStep(t *testing.T) {
 	require.NotNil(t, br)
 }
 
+// TestSequenceNumberAllocationSetReadSeq tests that read-only requests are
+// assigned the read seqnum set by setReadSeqLocked, and that it cannot be set
+// beyond the write seqnum or outside of stepping mode.
+func TestSequenceNumberAllocationSetReadSeq(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	s, mockSender := makeMockTxnSeqNumAllocator()
+
+	txn := makeTxnProto()
+	keyA := roachpb.Key("a")
+
+	require.Error(t, s.setReadSeqLocked(0))
+
+	s.configureSteppingLocked(true /* enabled */)
+	var ba roachpb.BatchRequest
+	ba.Header = roachpb.Header{Txn: &txn}
+	ba.Add(&roachpb.PutRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}})
+	ba.Add(&roachpb.PutRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}})
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		br := ba.CreateReply()
+		br.Txn = ba.Txn
+		return br, nil
+	})
+	_, pErr := s.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NoError(t, s.stepLocked(ctx))
+	require.Equal(t, enginepb.TxnSeq(2), s.readSeq)
+
+	require.Error(t, s.setReadSeqLocked(3))
+	require.NoError(t, s.setReadSeqLocked(1))
+
+	ba.Requests = nil
+	ba.Add(&roachpb.GetRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}})
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Len(t, ba.Requests, 1)
+		require.Equal(t, enginepb.TxnSeq(1), ba.Requests[0].GetInner().Header().Sequence)
+
+		br := ba.CreateReply()
+		br.Txn = ba.Txn
+		return br, nil
+	})
+	_, pErr = s.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+}
+
 // TestSequenceNumberAllocationTxnRequests tests sequence number allocation's
 // interaction with transaction state requests (HeartbeatTxn and EndTxn). Only
 // EndTxn requests should be assigned unique sequence numbers.
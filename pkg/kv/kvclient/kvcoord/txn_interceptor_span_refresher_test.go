@@ -18,6 +18,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -1135,6 +1136,67 @@ func TestTxnSpanRefresherEpochIncrement(t *testing.T) {
 	require.Equal(t, hlc.Timestamp{}, tsr.refreshedTimestamp)
 }
 
+// TestTxnSpanRefresherReadCommitted tests that the txnSpanRefresher does not
+// refresh the reads of a transaction that tolerates write skew before it
+// commits at a pushed timestamp, and that stepping the read timestamp of such a
+// transaction discards its refresh spans.
+func TestTxnSpanRefresherReadCommitted(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	tsr, mockSender := makeMockTxnSpanRefresher()
+
+	txn := makeTxnProto()
+	txn.IsolationLevel = enginepb.ReadCommitted
+	keyA, keyB := roachpb.Key("a"), roachpb.Key("b")
+
+	// Send a Scan request so that the txnSpanRefresher records refresh spans.
+	var ba roachpb.BatchRequest
+	ba.Header = roachpb.Header{Txn: &txn}
+	scanArgs := roachpb.ScanRequest{RequestHeader: roachpb.RequestHeader{Key: keyA, EndKey: keyB}}
+	ba.Add(&scanArgs)
+
+	br, pErr := tsr.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NotNil(t, br)
+	require.Equal(t, []roachpb.Span{scanArgs.Span()}, tsr.refreshFootprint.asSlice())
+
+	// Push the txn and send an EndTxn request. The txn is allowed to commit at
+	// its pushed timestamp, so it should not be refreshed.
+	txn.WriteTimestamp = txn.WriteTimestamp.Add(1, 0)
+	origReadTs := txn.ReadTimestamp
+	pushedWriteTs := txn.WriteTimestamp
+
+	ba.Requests = nil
+	ba.Add(&roachpb.EndTxnRequest{Commit: true})
+
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Len(t, ba.Requests, 1)
+		require.IsType(t, &roachpb.EndTxnRequest{}, ba.Requests[0].GetInner())
+		require.Equal(t, origReadTs, ba.Txn.ReadTimestamp)
+		require.Equal(t, pushedWriteTs, ba.Txn.WriteTimestamp)
+
+		br := ba.CreateReply()
+		br.Txn = ba.Txn.Clone()
+		br.Txn.Status = roachpb.COMMITTED
+		return br, nil
+	})
+
+	br, pErr = tsr.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NotNil(t, br)
+	require.Equal(t, int64(0), tsr.refreshSuccess.Count())
+	require.Equal(t, int64(0), tsr.refreshFail.Count())
+
+	// Stepping the read timestamp discards the refresh spans.
+	steppedTs := pushedWriteTs.Add(1, 0)
+	tsr.readTimestampSteppedLocked(steppedTs)
+
+	require.Equal(t, []roachpb.Span(nil), tsr.refreshFootprint.asSlice())
+	require.False(t, tsr.refreshInvalid)
+	require.Equal(t, steppedTs, tsr.refreshedTimestamp)
+}
+
 // TestTxnSpanRefresherSavepoint checks that the span refresher can savepoint
 // its state and restore it.
 func TestTxnSpanRefresherSavepoint(t *testing.T) {
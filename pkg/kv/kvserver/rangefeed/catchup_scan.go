@@ -214,7 +214,9 @@ func (i *CatchUpIterator) CatchUpScan(
 			}
 
 			if !ignore {
-				// Add value to reorderBuf to be output.
+				// Add value to reorderBuf to be output. Committed values do not
+				// record the transaction which wrote them, so the event has no
+				// TxnID.
 				var event roachpb.RangeFeedEvent
 				event.MustSetValue(&roachpb.RangeFeedValue{
 					Key: key,
@@ -124,6 +124,16 @@ func rangeFeedValue(key roachpb.Key, val roachpb.Value) *roachpb.RangeFeedEvent
 	return rangeFeedValueWithPrev(key, val, roachpb.Value{})
 }
 
+func rangeFeedValueWithTxn(
+	key roachpb.Key, val roachpb.Value, txnID uuid.UUID,
+) *roachpb.RangeFeedEvent {
+	return makeRangeFeedEvent(&roachpb.RangeFeedValue{
+		Key:   key,
+		Value: val,
+		TxnID: txnID,
+	})
+}
+
 func rangeFeedCheckpoint(span roachpb.Span, ts hlc.Timestamp) *roachpb.RangeFeedEvent {
 	return makeRangeFeedEvent(&roachpb.RangeFeedCheckpoint{
 		Span:       span,
@@ -303,12 +313,13 @@ func TestProcessorBasic(t *testing.T) {
 	p.syncEventAndRegistrations()
 	require.Equal(t,
 		[]*roachpb.RangeFeedEvent{
-			rangeFeedValue(
+			rangeFeedValueWithTxn(
 				roachpb.Key("e"),
 				roachpb.Value{
 					RawBytes:  []byte("ival"),
 					Timestamp: hlc.Timestamp{WallTime: 13},
 				},
+				txn2,
 			),
 			rangeFeedCheckpoint(
 				roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("m")},
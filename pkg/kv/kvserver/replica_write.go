@@ -614,6 +614,16 @@ func (r *Replica) evaluate1PC(
 				pErr:    roachpb.NewError(err),
 			}
 		}
+		// The batch was evaluated without the transaction, so its writes were
+		// logged as non-transactional values. Attribute them to the transaction
+		// so that rangefeeds can report which transaction committed them.
+		if res.LogicalOpLog != nil {
+			for _, op := range res.LogicalOpLog.Ops {
+				if writeValue, ok := op.GetValue().(*enginepb.MVCCWriteValueOp); ok {
+					writeValue.TxnID = clonedTxn.ID
+				}
+			}
+		}
 	}
 
 	// Even though the transaction is 1PC and hasn't written any intents, it may
@@ -87,6 +87,17 @@ func (m *MockTransactionalSender) SetDebugName(name string) {
 	m.txn.Name = name
 }
 
+// SetIsolationLevel is part of the TxnSender interface.
+func (m *MockTransactionalSender) SetIsolationLevel(level enginepb.IsolationLevel) error {
+	m.txn.IsolationLevel = level
+	return nil
+}
+
+// IsolationLevel is part of the TxnSender interface.
+func (m *MockTransactionalSender) IsolationLevel() enginepb.IsolationLevel {
+	return m.txn.IsolationLevel
+}
+
 // String is part of the TxnSender interface.
 func (m *MockTransactionalSender) String() string {
 	return m.txn.String()
@@ -196,6 +207,11 @@ func (m *MockTransactionalSender) Step(_ context.Context) error {
 	return nil
 }
 
+// StepReadTimestamp is part of the TxnSender interface.
+func (m *MockTransactionalSender) StepReadTimestamp(_ context.Context) error {
+	return nil
+}
+
 // ConfigureStepping is part of the TxnSender interface.
 func (m *MockTransactionalSender) ConfigureStepping(context.Context, SteppingMode) SteppingMode {
 	// See Step() above.
@@ -207,6 +223,16 @@ func (m *MockTransactionalSender) GetSteppingMode(context.Context) SteppingMode
 	return SteppingDisabled
 }
 
+// GetReadSeqNum is part of the TxnSender interface.
+func (m *MockTransactionalSender) GetReadSeqNum() enginepb.TxnSeq {
+	return 0
+}
+
+// SetReadSeqNum is part of the TxnSender interface.
+func (m *MockTransactionalSender) SetReadSeqNum(enginepb.TxnSeq) error {
+	return nil
+}
+
 // ManualRefresh is part of the TxnSender interface.
 func (m *MockTransactionalSender) ManualRefresh(ctx context.Context) error {
 	panic("unimplemented")
@@ -129,6 +129,14 @@ type TxnSender interface {
 	// SetDebugName sets the txn's debug name.
 	SetDebugName(name string)
 
+	// SetIsolationLevel sets the txn's isolation level. It returns an error if
+	// the transaction is already active and the level differs from the
+	// current one.
+	SetIsolationLevel(enginepb.IsolationLevel) error
+
+	// IsolationLevel returns the txn's isolation level.
+	IsolationLevel() enginepb.IsolationLevel
+
 	// String returns a string representation of the txn.
 	String() string
 
@@ -285,6 +293,12 @@ type TxnSender interface {
 	// The method is idempotent.
 	Step(context.Context) error
 
+	// StepReadTimestamp establishes a new read snapshot for transactions
+	// whose isolation level calls for one per statement, moving the
+	// transaction's read timestamp up to the current time. It is a no-op
+	// for other transactions.
+	StepReadTimestamp(context.Context) error
+
 	// ConfigureStepping sets the sequencing point behavior.
 	//
 	// Note that a Sender is initially in the non-stepping mode,
@@ -300,6 +314,18 @@ type TxnSender interface {
 	// for use in tests and assertion checks.
 	GetSteppingMode(ctx context.Context) (curMode SteppingMode)
 
+	// GetReadSeqNum returns the sequence number at which read-only
+	// operations are performed in stepping mode, i.e. the sequence
+	// number established by the latest sequencing point.
+	GetReadSeqNum() enginepb.TxnSeq
+
+	// SetReadSeqNum sets the sequence number at which read-only
+	// operations are performed in stepping mode. It is used to go back
+	// to the snapshot of a previous sequencing point, which must have
+	// been obtained with GetReadSeqNum() in the same epoch. Stepping
+	// mode must be enabled.
+	SetReadSeqNum(seq enginepb.TxnSeq) error
+
 	// ManualRefresh attempts to refresh a transactions read timestamp up to its
 	// provisional commit timestamp. In the case that the two are already the
 	// same, it is a no-op. The reason one might want to do that is to ensure
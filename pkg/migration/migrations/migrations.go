@@ -153,6 +153,12 @@ var migrations = []migration.Migration{
 		NoPrecondition,
 		insertMissingPublicSchemaNamespaceEntry,
 	),
+	migration.NewTenantMigration(
+		"add the system.notifications table",
+		toCV(clusterversion.NotificationsTable),
+		NoPrecondition,
+		notificationsTableMigration,
+	),
 }
 
 func init() {
@@ -1138,6 +1138,23 @@ func (t *Transaction) Refresh(timestamp hlc.Timestamp) {
 	t.WriteTooOld = false
 }
 
+// BumpReadTimestamp moves the transaction's read timestamp, along with its
+// write timestamp, forward to the given clock reading, establishing a new read
+// snapshot. Unlike Refresh, it does not require the reads that the transaction
+// has performed so far to be valid at the new timestamp, so it must only be
+// used by transactions whose isolation level reads from a new snapshot in each
+// statement. The uncertainty interval of the transaction is moved along with
+// the read timestamp.
+func (t *Transaction) BumpReadTimestamp(now hlc.ClockTimestamp, maxOffsetNs int64) {
+	ts := now.ToTimestamp()
+	t.WriteTimestamp.Forward(ts)
+	t.ReadTimestamp.Forward(t.WriteTimestamp)
+	t.GlobalUncertaintyLimit.Forward(ts.Add(maxOffsetNs, 0))
+	// The timestamps observed from nodes were taken during the previous
+	// snapshot, so they can't be used to limit the new uncertainty interval.
+	t.ResetObservedTimestamps()
+}
+
 // Update ratchets priority, timestamp and original timestamp values (among
 // others) for the transaction. If t.ID is empty, then the transaction is
 // copied from o.
@@ -1436,15 +1453,13 @@ func PrepareTransactionForRetry(
 	}
 
 	txn := *pErr.GetTxn()
-	aborted := false
-	switch tErr := pErr.GetDetail().(type) {
-	case *TransactionAbortedError:
+	if _, ok := pErr.GetDetail().(*TransactionAbortedError); ok {
 		// The txn coming with a TransactionAbortedError is not supposed to be used
 		// for the restart. Instead, a brand new transaction is created.
-		aborted = true
 		// TODO(andrei): Should we preserve the ObservedTimestamps across the
 		// restart?
 		errTxnPri := txn.Priority
+		errTxnIsoLevel := txn.IsolationLevel
 		// Start the new transaction at the current time from the local clock.
 		// The local hlc should have been advanced to at least the error's
 		// timestamp already.
@@ -1461,6 +1476,62 @@ func PrepareTransactionForRetry(
 		)
 		// Use the priority communicated back by the server.
 		txn.Priority = errTxnPri
+		// The new transaction runs at the same isolation level.
+		txn.IsolationLevel = errTxnIsoLevel
+		return txn
+	}
+
+	forwardTransactionForRetry(ctx, pErr, &txn, clock)
+	if txn.Status.IsFinalized() {
+		log.Fatalf(ctx, "transaction unexpectedly finalized in (%T): %s", pErr.GetDetail(), pErr)
+	}
+	txn.Restart(pri, txn.Priority, txn.WriteTimestamp)
+	return txn
+}
+
+// PrepareTransactionForStatementRetry returns a new Transaction to be used for
+// retrying the statement of the original Transaction that encountered the
+// given retriable error. It is only used for transactions whose isolation
+// level reads from a new snapshot in each statement, which can retry the
+// statement instead of restarting.
+//
+// Unlike PrepareTransactionForRetry, the transaction is not restarted: it keeps
+// its epoch and all its epoch-scoped state, and only its timestamps are moved
+// forward. The error must not be a TransactionAbortedError.
+func PrepareTransactionForStatementRetry(
+	ctx context.Context, pErr *Error, clock *hlc.Clock,
+) Transaction {
+	if pErr.TransactionRestart() == TransactionRestart_NONE {
+		log.Fatalf(ctx, "invalid retryable err (%T): %s", pErr.GetDetail(), pErr)
+	}
+	if pErr.GetTxn() == nil {
+		log.Fatalf(ctx, "missing txn for retryable error: %s", pErr)
+	}
+	if !pErr.GetTxn().IsolationLevel.PerStatementReadSnapshot() {
+		log.Fatalf(ctx, "statement retry for txn with isolation level %s: %s",
+			pErr.GetTxn().IsolationLevel, pErr)
+	}
+
+	txn := *pErr.GetTxn()
+	forwardTransactionForRetry(ctx, pErr, &txn, clock)
+	if txn.Status.IsFinalized() {
+		log.Fatalf(ctx, "transaction unexpectedly finalized in (%T): %s", pErr.GetDetail(), pErr)
+	}
+	// The statement is retried at a new read snapshot, so its reads don't need
+	// to be refreshed.
+	txn.ReadTimestamp.Forward(txn.WriteTimestamp)
+	txn.WriteTooOld = false
+	return txn
+}
+
+// forwardTransactionForRetry forwards the timestamp, and potentially the
+// priority, of a transaction that encountered the given retriable error so
+// that it doesn't run into the same error when it retries. The error must not
+// be a TransactionAbortedError.
+func forwardTransactionForRetry(
+	ctx context.Context, pErr *Error, txn *Transaction, clock *hlc.Clock,
+) {
+	switch tErr := pErr.GetDetail().(type) {
 	case *ReadWithinUncertaintyIntervalError:
 		txn.WriteTimestamp.Forward(readWithinUncertaintyIntervalRetryTimestamp(tErr))
 	case *TransactionPushError:
@@ -1497,13 +1568,6 @@ func PrepareTransactionForRetry(
 	default:
 		log.Fatalf(ctx, "invalid retryable err (%T): %s", pErr.GetDetail(), pErr)
 	}
-	if !aborted {
-		if txn.Status.IsFinalized() {
-			log.Fatalf(ctx, "transaction unexpectedly finalized in (%T): %s", pErr.GetDetail(), pErr)
-		}
-		txn.Restart(pri, txn.Priority, txn.WriteTimestamp)
-	}
-	return txn
 }
 
 // TransactionRefreshTimestamp returns whether the supplied error is a retry
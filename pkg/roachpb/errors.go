@@ -737,6 +737,21 @@ func (e *TransactionRetryWithProtoRefreshError) PrevTxnAborted() bool {
 	return !e.TxnID.Equal(e.Transaction.ID)
 }
 
+// NewStatementRetryWithProtoRefreshError initializes a new
+// TransactionRetryWithProtoRefreshError for a transaction that can retry the
+// statement that encountered the error instead of restarting. See
+// TransactionRetryWithProtoRefreshError.StatementRetry.
+func NewStatementRetryWithProtoRefreshError(
+	msg string, txnID uuid.UUID, txn Transaction,
+) *TransactionRetryWithProtoRefreshError {
+	return &TransactionRetryWithProtoRefreshError{
+		Msg:            msg,
+		TxnID:          txnID,
+		Transaction:    txn,
+		StatementRetry: true,
+	}
+}
+
 // NewTransactionPushError initializes a new TransactionPushError.
 func NewTransactionPushError(pusheeTxn Transaction) *TransactionPushError {
 	// Note: this error will cause a txn restart. The error that the client
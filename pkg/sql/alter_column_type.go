@@ -86,6 +86,15 @@ func AlterColumnType(
 		return err
 	}
 
+	// The constraints of domains are not validated on the existing rows, nor
+	// are the back references of domains updated.
+	if typ.IsDomain() || col.GetType().IsDomain() {
+		return unimplemented.Newf(
+			"alter column type domain",
+			"ALTER COLUMN TYPE is not supported for columns of domain types",
+		)
+	}
+
 	// Special handling for STRING COLLATE xy to verify that we recognize the language.
 	if t.Collation != "" {
 		if types.IsStringType(typ) {
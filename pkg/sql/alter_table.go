@@ -82,6 +82,9 @@ func (p *planner) AlterTable(ctx context.Context, n *tree.AlterTable) (planNode,
 	if tableDesc == nil {
 		return newZeroNode(nil /* columns */), nil
 	}
+	if err := checkNotForeignTable(tableDesc); err != nil {
+		return nil, err
+	}
 
 	// This check for CREATE privilege is kept for backwards compatibility.
 	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
@@ -90,6 +93,24 @@ func (p *planner) AlterTable(ctx context.Context, n *tree.AlterTable) (planNode,
 			tree.Name(tableDesc.GetName()), tree.Name(tableDesc.GetName()))
 	}
 
+	// Changing the row-level security of the table requires ownership.
+	for _, cmd := range n.Cmds {
+		rls, ok := cmd.(*tree.AlterTableSetRowLevelSecurity)
+		if !ok {
+			continue
+		}
+		if rls.Mode == tree.RowLevelSecurityEnable || rls.Mode == tree.RowLevelSecurityForce {
+			if err := checkRowLevelSecurityVersion(
+				ctx, p.ExecCfg(), fmt.Sprintf("ALTER TABLE %s ROW LEVEL SECURITY", rls.Mode),
+			); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.checkRowLevelSecurityOwnership(ctx, tableDesc); err != nil {
+			return nil, err
+		}
+	}
+
 	n.HoistAddColumnConstraints()
 
 	// See if there's any "inject statistics" in the query and type check the
@@ -218,6 +239,10 @@ func (n *alterTableNode) startExec(params runParams) error {
 					continue
 				}
 
+				if d.Deferrable != tree.ConstraintNotDeferrable {
+					return errDeferrableUniqueIndex
+				}
+
 				if d.PrimaryKey {
 					// Translate this operation into an ALTER PRIMARY KEY command.
 					alterPK := &tree.AlterTableAlterPrimaryKey{
@@ -361,6 +386,17 @@ func (n *alterTableNode) startExec(params runParams) error {
 					return err
 				}
 
+			case *tree.ExclusionConstraintTableDef:
+				// The constraint is added in the Validating state and the existing
+				// rows are validated against it by the schema changer, as for UNIQUE
+				// WITHOUT INDEX constraints.
+				if err := addExclusionConstraintTableDef(
+					params.ctx, params.ExecCfg().Settings, d, n.tableDesc, *tn,
+					params.p.SemaCtx(), NonEmptyTable,
+				); err != nil {
+					return err
+				}
+
 			case *tree.ForeignKeyConstraintTableDef:
 				// We want to reject uses of FK ON UPDATE actions where there is already
 				// an ON UPDATE expression for the column.
@@ -569,6 +605,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 				return err
 			}
 
+			// Policies that use the column are only dropped with CASCADE.
+			if err := dropPoliciesUsingColumn(n.tableDesc, colToDrop, t.DropBehavior); err != nil {
+				return err
+			}
+
 			if n.tableDesc.GetPrimaryIndex().CollectKeyColumnIDs().Contains(colToDrop.GetID()) {
 				return pgerror.Newf(pgcode.InvalidColumnReference,
 					"column %q is referenced by the primary key", colToDrop.GetName())
@@ -676,6 +717,29 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			n.tableDesc.UniqueWithoutIndexConstraints = n.tableDesc.UniqueWithoutIndexConstraints[:sliceIdx]
 
+			// Drop exclusion constraints which reference the column, either as
+			// one of their columns or in their predicate.
+			exclusionConstraints := n.tableDesc.ExclusionConstraints[:0]
+			for i := range n.tableDesc.ExclusionConstraints {
+				c := &n.tableDesc.ExclusionConstraints[i]
+				used := descpb.ColumnIDs(c.ColumnIDs).Contains(colToDrop.GetID())
+				if !used && c.Predicate != "" {
+					expr, err := parser.ParseExpr(c.Predicate)
+					if err != nil {
+						return err
+					}
+					colIDs, err := schemaexpr.ExtractColumnIDs(n.tableDesc, expr)
+					if err != nil {
+						return err
+					}
+					used = colIDs.Contains(colToDrop.GetID())
+				}
+				if !used {
+					exclusionConstraints = append(exclusionConstraints, *c)
+				}
+			}
+			n.tableDesc.ExclusionConstraints = exclusionConstraints
+
 			// Drop check constraints which reference the column.
 			constraintsToDrop := make([]string, 0, len(n.tableDesc.Checks))
 			constraintInfo, err := n.tableDesc.GetConstraintInfo()
@@ -838,6 +902,12 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 				foundFk.Validity = descpb.ConstraintValidity_Validated
 
+			case descpb.ConstraintTypeExclusion:
+				// Exclusion constraints are only unvalidated while the schema changer
+				// is validating them.
+				return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+					"constraint %q in the middle of being added, try again later", t.Constraint)
+
 			case descpb.ConstraintTypeUnique:
 				if constraint.Index == nil {
 					var foundUnique *descpb.UniqueWithoutIndexConstraint
@@ -954,6 +1024,13 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableSetRowLevelSecurity:
+			changed, err := n.tableDesc.SetRowLevelSecurity(t.Mode)
+			if err != nil {
+				return err
+			}
+			descriptorChanged = descriptorChanged || changed
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -1583,6 +1660,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -87,6 +87,9 @@ func (p *planner) AlterTableSetSchema(
 			)
 		}
 	}
+	if err := p.checkNoDependentFunctions(ctx, tableDesc, tableDesc.Name, "set schema on"); err != nil {
+		return nil, err
+	}
 
 	return &alterTableSetSchemaNode{
 		newSchema: string(n.Schema),
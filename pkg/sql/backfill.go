@@ -260,6 +260,10 @@ func (sc *SchemaChanger) runBackfill(ctx context.Context) error {
 					isValidating = c.ForeignKey().Validity == descpb.ConstraintValidity_Validating
 				} else if c.IsUniqueWithoutIndex() {
 					isValidating = c.UniqueWithoutIndex().Validity == descpb.ConstraintValidity_Validating
+				} else if c.IsExclusion() {
+					// Exclusion constraints are always validated before they can be
+					// added.
+					isValidating = true
 				} else if c.IsNotNull() {
 					// NOT NULL constraints are always validated before they can be added
 					isValidating = true
@@ -461,6 +465,26 @@ func (sc *SchemaChanger) dropConstraints(
 						constraint.ConstraintToUpdateDesc(),
 					)
 				}
+			} else if constraint.IsExclusion() {
+				found := false
+				for j, c := range scTable.ExclusionConstraints {
+					if c.Name == constraint.GetName() {
+						scTable.ExclusionConstraints = append(
+							scTable.ExclusionConstraints[:j],
+							scTable.ExclusionConstraints[j+1:]...,
+						)
+						found = true
+						break
+					}
+				}
+				if !found {
+					log.VEventf(
+						ctx, 2,
+						"backfiller tried to drop constraint %+v but it was not found, "+
+							"presumably due to a retry or rollback",
+						constraint.ConstraintToUpdateDesc(),
+					)
+				}
 			}
 		}
 		if err := descsCol.WriteDescToBatch(
@@ -615,6 +639,26 @@ func (sc *SchemaChanger) addConstraints(
 					scTable.UniqueWithoutIndexConstraints = append(scTable.UniqueWithoutIndexConstraints,
 						constraint.UniqueWithoutIndex())
 				}
+			} else if constraint.IsExclusion() {
+				found := false
+				for i := range scTable.ExclusionConstraints {
+					c := &scTable.ExclusionConstraints[i]
+					if c.Name == constraint.GetName() {
+						log.VEventf(
+							ctx, 2,
+							"backfiller tried to add constraint %+v but found existing constraint %+v, "+
+								"presumably due to a retry or rollback",
+							constraint.ConstraintToUpdateDesc(), c,
+						)
+						c.Validity = descpb.ConstraintValidity_Validating
+						found = true
+						break
+					}
+				}
+				if !found {
+					scTable.ExclusionConstraints = append(scTable.ExclusionConstraints,
+						constraint.Exclusion())
+				}
 			}
 		}
 		if err := descsCol.WriteDescToBatch(
@@ -716,6 +760,10 @@ func (sc *SchemaChanger) validateConstraints(
 					if err := validateUniqueWithoutIndexConstraintInTxn(ctx, evalCtx.SchemaChangeInternalExecutor, desc, txn, c.GetName()); err != nil {
 						return err
 					}
+				} else if c.IsExclusion() {
+					if err := validateExclusionConstraintInTxn(ctx, evalCtx.SchemaChangeInternalExecutor, desc, txn, c.GetName()); err != nil {
+						return err
+					}
 				} else if c.IsNotNull() {
 					if err := validateCheckInTxn(
 						ctx, &semaCtx, evalCtx.SchemaChangeInternalExecutor, evalCtx.SessionData(), desc, txn, c.Check().Expr,
@@ -2085,6 +2133,16 @@ func runSchemaChangesInTxn(
 							break
 						}
 					}
+				} else if c.IsExclusion() {
+					for i := range tableDesc.ExclusionConstraints {
+						if tableDesc.ExclusionConstraints[i].Name == c.GetName() {
+							tableDesc.ExclusionConstraints = append(
+								tableDesc.ExclusionConstraints[:i],
+								tableDesc.ExclusionConstraints[i+1:]...,
+							)
+							break
+						}
+					}
 				} else {
 					return errors.AssertionFailedf("unsupported constraint type: %d", c.ConstraintToUpdateDesc().ConstraintType)
 				}
@@ -2147,6 +2205,14 @@ func runSchemaChangesInTxn(
 				}
 				uwi.Validity = descpb.ConstraintValidity_Validated
 			}
+		} else if c.IsExclusion() {
+			ec := &c.ConstraintToUpdateDesc().ExclusionConstraint
+			if err := validateExclusionConstraint(
+				ctx, tableDesc, ec, planner.ExecCfg().InternalExecutor, planner.txn,
+			); err != nil {
+				return err
+			}
+			ec.Validity = descpb.ConstraintValidity_Validated
 		} else {
 			return errors.AssertionFailedf("unsupported constraint type: %d", c.ConstraintToUpdateDesc().ConstraintType)
 		}
@@ -2191,6 +2257,10 @@ func runSchemaChangesInTxn(
 			tableDesc.UniqueWithoutIndexConstraints = append(
 				tableDesc.UniqueWithoutIndexConstraints, c.ConstraintToUpdateDesc().UniqueWithoutIndexConstraint,
 			)
+		} else if c.IsExclusion() {
+			tableDesc.ExclusionConstraints = append(
+				tableDesc.ExclusionConstraints, c.ConstraintToUpdateDesc().ExclusionConstraint,
+			)
 		} else {
 			return errors.AssertionFailedf("unsupported constraint type: %d", c.ConstraintToUpdateDesc().ConstraintType)
 		}
@@ -2313,6 +2383,42 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	})
 }
 
+// validateExclusionConstraintInTxn validates an exclusion constraint within
+// the provided transaction. If the provided table descriptor version is newer
+// than the cluster version, it will be used in the InternalExecutor that
+// performs the validation query.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateExclusionConstraintInTxn(
+	ctx context.Context,
+	ie *InternalExecutor,
+	tableDesc *tabledesc.Mutable,
+	txn *kv.Txn,
+	constraintName string,
+) error {
+	var syntheticDescs []catalog.Descriptor
+	if tableDesc.Version > tableDesc.ClusterVersion.Version {
+		syntheticDescs = append(syntheticDescs, tableDesc)
+	}
+
+	var ec *descpb.ExclusionConstraint
+	for i := range tableDesc.ExclusionConstraints {
+		def := &tableDesc.ExclusionConstraints[i]
+		if def.Name == constraintName {
+			ec = def
+			break
+		}
+	}
+	if ec == nil {
+		return errors.AssertionFailedf("exclusion constraint %s does not exist", constraintName)
+	}
+
+	return ie.WithSyntheticDescriptors(syntheticDescs, func() error {
+		return validateExclusionConstraint(ctx, tableDesc, ec, ie, txn)
+	})
+}
+
 // columnBackfillInTxn backfills columns for all mutation columns in
 // the mutation list.
 //
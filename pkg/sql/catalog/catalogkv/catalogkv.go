@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -99,7 +100,7 @@ func ResolveSchemaID(
 func NewBuilderWithMVCCTimestamp(
 	desc *descpb.Descriptor, mvccTimestamp hlc.Timestamp,
 ) catalog.DescriptorBuilder {
-	table, database, typ, schema := descpb.FromDescriptorWithMVCCTimestamp(desc, mvccTimestamp)
+	table, database, typ, schema, function := descpb.FromDescriptorWithMVCCTimestamp(desc, mvccTimestamp)
 	switch {
 	case table != nil:
 		return tabledesc.NewBuilder(table)
@@ -109,6 +110,8 @@ func NewBuilderWithMVCCTimestamp(
 		return typedesc.NewBuilder(typ)
 	case schema != nil:
 		return schemadesc.NewBuilder(schema)
+	case function != nil:
+		return funcdesc.NewBuilder(function)
 	default:
 		return nil
 	}
@@ -238,6 +241,9 @@ func requiredError(expectedObjectType catalog.DescriptorType, id descpb.ID) erro
 	case catalog.Type:
 		err = sqlerrors.NewUndefinedTypeError(tree.NewUnqualifiedTypeName(fmt.Sprintf("[%d]", id)))
 		wrapper = catalog.WrapTypeDescRefErr
+	case catalog.Function:
+		err = pgerror.Newf(pgcode.UndefinedFunction, "function [%d] does not exist", id)
+		wrapper = catalog.WrapFunctionDescRefErr
 	default:
 		err = errors.Errorf("failed to find descriptor [%d]", id)
 		wrapper = func(_ descpb.ID, err error) error { return err }
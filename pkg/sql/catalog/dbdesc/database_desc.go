@@ -240,6 +240,26 @@ func (desc *immutable) GetNonDroppedSchemaName(schemaID descpb.ID) string {
 	return ""
 }
 
+// GetForeignServer implements the DatabaseDescriptor interface.
+func (desc *immutable) GetForeignServer(
+	name string,
+) (descpb.DatabaseDescriptor_ForeignServer, bool) {
+	for _, server := range desc.ForeignServers {
+		if server.Name == name {
+			return server, true
+		}
+	}
+	return descpb.DatabaseDescriptor_ForeignServer{}, false
+}
+
+// GetPublicSchemaFunction implements the DatabaseDescriptor interface.
+func (desc *immutable) GetPublicSchemaFunction(
+	name string,
+) (descpb.SchemaDescriptor_Function, bool) {
+	fn, ok := desc.PublicSchemaFunctions[name]
+	return fn, ok
+}
+
 // ValidateSelf validates that the database descriptor is well formed.
 // Checks include validate the database name, and verifying that there
 // is at least one read and write user.
@@ -261,6 +281,12 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	if desc.IsMultiRegion() {
 		desc.validateMultiRegion(vea)
 	}
+
+	if len(desc.PublicSchemaFunctions) > 0 && desc.HasPublicSchemaWithDescriptor() {
+		vea.Report(errors.AssertionFailedf(
+			"database %d has public schema functions but its public schema has a descriptor",
+			desc.GetID()))
+	}
 }
 
 // validateMultiRegion performs checks specific to multi-region DBs.
@@ -497,6 +523,28 @@ func (desc *Mutable) AddSchemaToDatabase(
 	desc.Schemas[schemaName] = schemaInfo
 }
 
+// AddForeignServer adds a foreign server to the database. The caller must
+// ensure that the database has no server with the same name.
+func (desc *Mutable) AddForeignServer(server descpb.DatabaseDescriptor_ForeignServer) {
+	desc.ForeignServers = append(desc.ForeignServers, server)
+}
+
+// AddPublicSchemaFunction adds the overload of the given function descriptor
+// to the functions mapping of the public schema of the database, which must
+// not be backed by a descriptor.
+func (desc *Mutable) AddPublicSchemaFunction(
+	name string, overload descpb.SchemaDescriptor_FunctionOverload,
+) {
+	desc.PublicSchemaFunctions = descpb.AddFunctionOverload(desc.PublicSchemaFunctions, name, overload)
+}
+
+// RemovePublicSchemaFunction removes the overload with the given function
+// descriptor ID from the functions mapping of the public schema of the
+// database.
+func (desc *Mutable) RemovePublicSchemaFunction(name string, id descpb.ID) {
+	descpb.RemoveFunctionOverload(desc.PublicSchemaFunctions, name, id)
+}
+
 // maybeRemoveDroppedSelfEntryFromSchemas removes an entry in the Schemas map corresponding to the
 // database itself which was added due to a bug in prior versions when dropping any user-defined schema.
 // The bug inserted an entry for the database rather than the schema being dropped. This function fixes the
@@ -47,6 +47,23 @@ func (x ForeignKeyReference_Match) String() string {
 	}
 }
 
+// ConstraintDeferrabilityValue allows the conversion from a
+// tree.ConstraintDeferrability to a ConstraintDeferrability.
+var ConstraintDeferrabilityValue = [...]ConstraintDeferrability{
+	tree.ConstraintNotDeferrable:      ConstraintDeferrability_NotDeferrable,
+	tree.ConstraintInitiallyImmediate: ConstraintDeferrability_InitiallyImmediate,
+	tree.ConstraintInitiallyDeferred:  ConstraintDeferrability_InitiallyDeferred,
+}
+
+// ConstraintDeferrabilityType allows the conversion from a
+// ConstraintDeferrability to a tree.ConstraintDeferrability. This should match
+// ConstraintDeferrabilityValue.
+var ConstraintDeferrabilityType = [...]tree.ConstraintDeferrability{
+	ConstraintDeferrability_NotDeferrable:      tree.ConstraintNotDeferrable,
+	ConstraintDeferrability_InitiallyImmediate: tree.ConstraintInitiallyImmediate,
+	ConstraintDeferrability_InitiallyDeferred:  tree.ConstraintInitiallyDeferred,
+}
+
 // ForeignKeyReferenceActionType allows the conversion between a
 // tree.ReferenceAction and a ForeignKeyReference_Action.
 var ForeignKeyReferenceActionType = [...]tree.ReferenceAction{
@@ -83,6 +100,22 @@ func (x ForeignKeyReference_Action) String() string {
 	}
 }
 
+// ExclusionConstraintOperatorValue allows the conversion from a
+// tree.ComparisonOperatorSymbol to an ExclusionConstraint_Operator. It only
+// contains the operators supported by exclusion constraints.
+var ExclusionConstraintOperatorValue = map[tree.ComparisonOperatorSymbol]ExclusionConstraint_Operator{
+	tree.EQ:       ExclusionConstraint_EQ,
+	tree.Overlaps: ExclusionConstraint_OVERLAPS,
+}
+
+// ExclusionConstraintOperatorType allows the conversion from an
+// ExclusionConstraint_Operator to a tree.ComparisonOperatorSymbol. This should
+// match ExclusionConstraintOperatorValue.
+var ExclusionConstraintOperatorType = [...]tree.ComparisonOperatorSymbol{
+	ExclusionConstraint_EQ:       tree.EQ,
+	ExclusionConstraint_OVERLAPS: tree.Overlaps,
+}
+
 // ConstraintType is used to identify the type of a constraint.
 type ConstraintType string
 
@@ -95,6 +128,8 @@ const (
 	ConstraintTypeUnique ConstraintType = "UNIQUE"
 	// ConstraintTypeCheck identifies a CHECK constraint.
 	ConstraintTypeCheck ConstraintType = "CHECK"
+	// ConstraintTypeExclusion identifies an EXCLUDE constraint.
+	ConstraintTypeExclusion ConstraintType = "EXCLUDE"
 )
 
 // ConstraintDetail describes a constraint.
@@ -119,4 +154,7 @@ type ConstraintDetail struct {
 
 	// Only populated for Check Constraints.
 	CheckConstraint *TableDescriptor_CheckConstraint
+
+	// Only populated for Exclusion Constraints.
+	ExclusionConstraint *ExclusionConstraint
 }
@@ -266,7 +266,13 @@ func (desc *TableDescriptor) MaterializedView() bool {
 
 // IsPhysicalTable implements the TableDescriptor interface.
 func (desc *TableDescriptor) IsPhysicalTable() bool {
-	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable()) || desc.MaterializedView()
+	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable() && !desc.IsForeignTable()) ||
+		desc.MaterializedView()
+}
+
+// IsForeignTable implements the TableDescriptor interface.
+func (desc *TableDescriptor) IsForeignTable() bool {
+	return desc.ForeignTable != nil
 }
 
 // IsAs implements the TableDescriptor interface.
@@ -377,6 +383,22 @@ func (u *UniqueWithoutIndexConstraint) IsPartial() bool {
 	return u.Predicate != ""
 }
 
+// FiresOn returns true if the trigger fires on the given event.
+func (t *TriggerDescriptor) FiresOn(event TriggerDescriptor_Event) bool {
+	for _, e := range t.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// AppliesTo returns true if the policy applies to statements of the given
+// kind.
+func (p *PolicyDescriptor) AppliesTo(command PolicyDescriptor_Command) bool {
+	return p.Command == PolicyDescriptor_ALL || p.Command == command
+}
+
 // GetParentID implements the catalog.NameKeyHaver interface.
 func (ni NameInfo) GetParentID() ID {
 	return ni.ParentID
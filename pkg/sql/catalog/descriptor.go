@@ -43,6 +43,9 @@ const (
 
 	// Schema is for schema descriptors.
 	Schema = "schema"
+
+	// Function is for function descriptors.
+	Function = "function"
 )
 
 // MutationPublicationFilter is used by MakeFirstMutationPublic to filter the
@@ -239,6 +242,13 @@ type DatabaseDescriptor interface {
 	// database.
 	GetDefaultPrivilegeDescriptor() DefaultPrivilegeDescriptor
 	HasPublicSchemaWithDescriptor() bool
+	// GetForeignServer returns the foreign server with the given name, and
+	// false if the database has no such server.
+	GetForeignServer(name string) (descpb.DatabaseDescriptor_ForeignServer, bool)
+	// GetPublicSchemaFunction returns the overloads of the user defined function
+	// with the given name in the public schema of the database, if the public
+	// schema is not backed by a descriptor and contains the function.
+	GetPublicSchemaFunction(name string) (descpb.SchemaDescriptor_Function, bool)
 }
 
 // TableDescriptor is an interface around the table descriptor types.
@@ -267,10 +277,15 @@ type TableDescriptor interface {
 	// virtual Table (like the information_schema tables) and thus doesn't
 	// need to be physically stored.
 	IsVirtualTable() bool
+	// IsForeignTable returns true if the TableDescriptor describes a foreign
+	// table, whose rows are read from files in external storage. Like virtual
+	// tables, foreign tables don't need to be physically stored.
+	IsForeignTable() bool
 	// IsPhysicalTable returns true if the TableDescriptor actually describes a
 	// physical Table that needs to be stored in the kv layer, as opposed to a
-	// different resource like a view or a virtual table. Physical tables have
-	// primary keys, column families, and indexes (unlike virtual tables).
+	// different resource like a view, a virtual table or a foreign table.
+	// Physical tables have primary keys, column families, and indexes (unlike
+	// virtual tables).
 	// Sequences count as physical tables because their values are stored in
 	// the KV layer.
 	IsPhysicalTable() bool
@@ -296,6 +311,13 @@ type TableDescriptor interface {
 	// GetViewQuery returns this view's CREATE VIEW declaration. Only valid if
 	// IsView is true.
 	GetViewQuery() string
+	// GetIncrementalViewQuery returns the query used to maintain this view if
+	// it is an incrementally maintained materialized view, or the empty string
+	// otherwise.
+	GetIncrementalViewQuery() string
+	// GetForeignTable returns the server and options of this table. Only valid
+	// if IsForeignTable is true.
+	GetForeignTable() *descpb.ForeignTable
 
 	// GetLease returns this table's schema change lease.
 	GetLease() *descpb.TableDescriptor_SchemaChangeLease
@@ -451,8 +473,8 @@ type TableDescriptor interface {
 	// progress, as mutation columns may have NULL values.
 	ReadableColumns() []Column
 	// UserDefinedTypeColumns returns a slice of Column interfaces
-	// containing the table's columns with user defined types, in the
-	// canonical order.
+	// containing the table's columns with user defined types, including
+	// domains, in the canonical order.
 	UserDefinedTypeColumns() []Column
 	// SystemColumns returns a slice of Column interfaces
 	// containing the table's system columns, as defined in
@@ -539,6 +561,9 @@ type TableDescriptor interface {
 	// GetDependsOnTypes returns the IDs of all types that this view depends on.
 	// It's only non-nil if IsView is true.
 	GetDependsOnTypes() []descpb.ID
+	// GetDependedOnByFunctions returns the IDs of all user-defined functions
+	// whose bodies reference this relation.
+	GetDependedOnByFunctions() []descpb.ID
 
 	// GetConstraintInfoWithLookup returns a summary of all constraints on the
 	// table using the provided function to fetch a TableDescriptor from an ID.
@@ -555,6 +580,28 @@ type TableDescriptor interface {
 	// "inactive" ones queued in the mutations list.
 	AllActiveAndInactiveUniqueWithoutIndexConstraints() []*descpb.UniqueWithoutIndexConstraint
 
+	// GetTriggers returns all the row-level triggers defined on this table.
+	GetTriggers() []descpb.TriggerDescriptor
+
+	// GetRowLevelSecurity returns true if row-level security is enabled for
+	// this table.
+	GetRowLevelSecurity() bool
+	// GetForceRowLevelSecurity returns true if row-level security also applies
+	// to the owner of this table.
+	GetForceRowLevelSecurity() bool
+	// GetPolicies returns all the row-level security policies defined on this
+	// table.
+	GetPolicies() []descpb.PolicyDescriptor
+
+	// GetExclusionConstraints returns all the exclusion constraints defined on
+	// this table.
+	GetExclusionConstraints() []descpb.ExclusionConstraint
+	// AllActiveAndInactiveExclusionConstraints returns all exclusion
+	// constraints, including both "active" ones on the table descriptor which
+	// are being enforced for all writes, and "inactive" ones queued in the
+	// mutations list.
+	AllActiveAndInactiveExclusionConstraints() []*descpb.ExclusionConstraint
+
 	// ForeachOutboundFK calls f for every outbound foreign key in desc until an
 	// error is returned.
 	ForeachOutboundFK(f func(fk *descpb.ForeignKeyConstraint) error) error
@@ -692,6 +739,36 @@ type TypeDescriptorResolver interface {
 	GetTypeDescriptor(ctx context.Context, id descpb.ID) (tree.TypeName, TypeDescriptor, error)
 }
 
+// FunctionDescriptor is an interface around the function descriptor types.
+type FunctionDescriptor interface {
+	Descriptor
+
+	// FuncDesc returns the backing protobuf for this function.
+	FuncDesc() *descpb.FunctionDescriptor
+
+	// GetArgs returns the arguments of the function.
+	GetArgs() []descpb.FunctionDescriptor_Argument
+	// ArgTypes returns the types of the arguments of the function.
+	ArgTypes() []*types.T
+	// GetReturnType returns the type of the value returned by the function.
+	GetReturnType() *types.T
+	// GetLang returns the language of the body of the function.
+	GetLang() descpb.FunctionDescriptor_Language
+	// GetFunctionBody returns the body of the function.
+	GetFunctionBody() string
+	// GetVolatility returns the declared volatility of the function.
+	GetVolatility() descpb.FunctionDescriptor_Volatility
+	// GetLeakProof returns whether the function was declared LEAKPROOF.
+	GetLeakProof() bool
+	// GetNullInputBehavior returns the behavior of the function when called
+	// with NULL arguments.
+	GetNullInputBehavior() descpb.FunctionDescriptor_NullInputBehavior
+
+	// ToOverload returns the tree.Overload with which calls to the function
+	// are type-checked and planned.
+	ToOverload() *tree.Overload
+}
+
 // DefaultPrivilegeDescriptor is an interface for default privileges to ensure
 // DefaultPrivilegeDescriptor protos are not accessed and interacted
 // with directly.
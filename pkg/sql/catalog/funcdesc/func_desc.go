@@ -0,0 +1,478 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package funcdesc contains the concrete implementations of
+// catalog.FunctionDescriptor.
+package funcdesc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+	"github.com/lib/pq/oid"
+)
+
+var _ catalog.FunctionDescriptor = (*immutable)(nil)
+var _ catalog.FunctionDescriptor = (*Mutable)(nil)
+var _ catalog.MutableDescriptor = (*Mutable)(nil)
+
+// immutable wraps a Function descriptor and provides methods on it.
+type immutable struct {
+	descpb.FunctionDescriptor
+
+	// isUncommittedVersion is set to true if this descriptor was created from
+	// a copy of a Mutable with an uncommitted version.
+	isUncommittedVersion bool
+}
+
+// Mutable is a custom type for FunctionDescriptors undergoing any types of
+// modifications.
+type Mutable struct {
+	immutable
+
+	// ClusterVersion represents the version of the function descriptor read
+	// from the store.
+	ClusterVersion *immutable
+
+	// changed represents whether or not the descriptor was changed
+	// after RunPostDeserializationChanges.
+	changed bool
+}
+
+// NewMutableFunctionDescriptor returns a Mutable for a new function with the
+// given properties.
+func NewMutableFunctionDescriptor(
+	id descpb.ID,
+	parentID descpb.ID,
+	parentSchemaID descpb.ID,
+	name string,
+	args []descpb.FunctionDescriptor_Argument,
+	returnType *types.T,
+	privs *descpb.PrivilegeDescriptor,
+) *Mutable {
+	return &Mutable{
+		immutable: immutable{
+			FunctionDescriptor: descpb.FunctionDescriptor{
+				Name:           name,
+				ID:             id,
+				ParentID:       parentID,
+				ParentSchemaID: parentSchemaID,
+				Version:        1,
+				State:          descpb.DescriptorState_PUBLIC,
+				Privileges:     privs,
+				Args:           args,
+				ReturnType:     returnType,
+			},
+		},
+	}
+}
+
+// FuncIDToOID converts a function descriptor ID into the OID of the function.
+// User-defined functions share the OID space of user-defined types, so that
+// their OIDs never collide with the ones of builtin functions.
+func FuncIDToOID(id descpb.ID) oid.Oid {
+	return oid.Oid(id) + oidext.CockroachPredefinedOIDMax
+}
+
+// UserDefinedFunctionOIDToID converts the OID of a user-defined function into
+// the ID of its descriptor. The function returns an error if the OID is not
+// the one of a user-defined function.
+func UserDefinedFunctionOIDToID(oid oid.Oid) (descpb.ID, error) {
+	if descpb.ID(oid) <= oidext.CockroachPredefinedOIDMax {
+		return 0, errors.Newf("user-defined OID %d should be greater "+
+			"than predefined Max: %d.", oid, oidext.CockroachPredefinedOIDMax)
+	}
+	return descpb.ID(oid) - oidext.CockroachPredefinedOIDMax, nil
+}
+
+// IsOIDUserDefinedFunction returns whether the given OID may be the one of a
+// user-defined function.
+func IsOIDUserDefinedFunction(oid oid.Oid) bool {
+	return oid > oidext.CockroachPredefinedOIDMax
+}
+
+// SafeMessage makes immutable a SafeMessager.
+func (desc *immutable) SafeMessage() string {
+	return formatSafeMessage("funcdesc.immutable", desc)
+}
+
+// SafeMessage makes Mutable a SafeMessager.
+func (desc *Mutable) SafeMessage() string {
+	return formatSafeMessage("funcdesc.Mutable", desc)
+}
+
+func formatSafeMessage(typeName string, desc catalog.FunctionDescriptor) string {
+	var buf redact.StringBuilder
+	buf.Printf(typeName + ": {")
+	catalog.FormatSafeDescriptorProperties(&buf, desc)
+	buf.Printf("}")
+	return buf.String()
+}
+
+var _ redact.SafeMessager = (*immutable)(nil)
+
+// IsUncommittedVersion implements the Descriptor interface.
+func (desc *immutable) IsUncommittedVersion() bool {
+	return desc.isUncommittedVersion
+}
+
+// GetDrainingNames implements the Descriptor interface. Functions don't have
+// namespace entries, hence they never have draining names.
+func (desc *immutable) GetDrainingNames() []descpb.NameInfo {
+	return nil
+}
+
+// GetAuditMode implements the DescriptorProto interface.
+func (desc *immutable) GetAuditMode() descpb.TableDescriptor_AuditMode {
+	return descpb.TableDescriptor_DISABLED
+}
+
+// DescriptorType implements the DescriptorProto interface.
+func (desc *immutable) DescriptorType() catalog.DescriptorType {
+	return catalog.Function
+}
+
+// FuncDesc implements the FunctionDescriptor interface.
+func (desc *immutable) FuncDesc() *descpb.FunctionDescriptor {
+	return &desc.FunctionDescriptor
+}
+
+// Public implements the Descriptor interface.
+func (desc *immutable) Public() bool {
+	return desc.State == descpb.DescriptorState_PUBLIC
+}
+
+// Adding implements the Descriptor interface.
+func (desc *immutable) Adding() bool {
+	return false
+}
+
+// Offline implements the Descriptor interface.
+func (desc *immutable) Offline() bool {
+	return desc.State == descpb.DescriptorState_OFFLINE
+}
+
+// Dropped implements the Descriptor interface.
+func (desc *immutable) Dropped() bool {
+	return desc.State == descpb.DescriptorState_DROP
+}
+
+// DescriptorProto wraps a FunctionDescriptor in a Descriptor.
+func (desc *immutable) DescriptorProto() *descpb.Descriptor {
+	return &descpb.Descriptor{
+		Union: &descpb.Descriptor_Function{
+			Function: &desc.FunctionDescriptor,
+		},
+	}
+}
+
+// ByteSize implements the Descriptor interface.
+func (desc *immutable) ByteSize() int64 {
+	return int64(desc.Size())
+}
+
+// NewBuilder implements the catalog.Descriptor interface.
+func (desc *immutable) NewBuilder() catalog.DescriptorBuilder {
+	return NewBuilder(desc.FuncDesc())
+}
+
+// ArgTypes implements the FunctionDescriptor interface.
+func (desc *immutable) ArgTypes() []*types.T {
+	ret := make([]*types.T, len(desc.Args))
+	for i := range desc.Args {
+		ret[i] = desc.Args[i].Type
+	}
+	return ret
+}
+
+// ToOverload implements the FunctionDescriptor interface.
+func (desc *immutable) ToOverload() *tree.Overload {
+	argTypes := make(tree.ArgTypes, len(desc.Args))
+	for i, arg := range desc.Args {
+		argTypes[i].Name = arg.Name
+		argTypes[i].Typ = arg.Type
+	}
+	name := desc.Name
+	return &tree.Overload{
+		Types:      argTypes,
+		ReturnType: tree.FixedReturnType(desc.ReturnType),
+		Volatility: desc.treeVolatility(),
+		Fn: func(*tree.EvalContext, tree.Datums) (tree.Datum, error) {
+			return nil, errors.AssertionFailedf(
+				"cannot evaluate user-defined function %s outside the optimizer", name)
+		},
+		Info:              "User-defined function.",
+		Oid:               FuncIDToOID(desc.ID),
+		IsUDF:             true,
+		Body:              desc.FunctionBody,
+		CalledOnNullInput: desc.NullInputBehavior == descpb.FunctionDescriptor_CALLED_ON_NULL_INPUT,
+		Version:           uint64(desc.Version),
+	}
+}
+
+// treeVolatility returns the tree.Volatility matching the declared volatility
+// of the function.
+func (desc *immutable) treeVolatility() tree.Volatility {
+	switch desc.Volatility {
+	case descpb.FunctionDescriptor_IMMUTABLE:
+		if desc.LeakProof {
+			return tree.VolatilityLeakProof
+		}
+		return tree.VolatilityImmutable
+	case descpb.FunctionDescriptor_STABLE:
+		return tree.VolatilityStable
+	default:
+		return tree.VolatilityVolatile
+	}
+}
+
+// ValidateSelf implements the catalog.Descriptor interface.
+func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
+	// Validate local properties of the descriptor.
+	vea.Report(catalog.ValidateName(desc.Name, "function"))
+	if desc.GetID() == descpb.InvalidID {
+		vea.Report(errors.AssertionFailedf("invalid ID %d", desc.GetID()))
+	}
+	if desc.GetParentID() == descpb.InvalidID {
+		vea.Report(errors.AssertionFailedf("invalid parentID %d", desc.GetParentID()))
+	}
+	if desc.GetParentSchemaID() == descpb.InvalidID {
+		vea.Report(errors.AssertionFailedf("invalid parent schema ID %d", desc.GetParentSchemaID()))
+	}
+	if desc.Privileges == nil {
+		vea.Report(errors.AssertionFailedf("privileges not set"))
+	} else {
+		vea.Report(catprivilege.Validate(*desc.Privileges, desc, privilege.Function))
+	}
+	for i, arg := range desc.Args {
+		if arg.Type == nil {
+			vea.Report(errors.AssertionFailedf("type of argument %d not set", i+1))
+		}
+	}
+	if desc.ReturnType == nil {
+		vea.Report(errors.AssertionFailedf("return type not set"))
+	}
+	if desc.LeakProof && desc.Volatility != descpb.FunctionDescriptor_IMMUTABLE {
+		vea.Report(errors.AssertionFailedf(
+			"leak proof function must be immutable, but got volatility: %s", desc.Volatility))
+	}
+}
+
+// GetReferencedDescIDs returns the IDs of all descriptors referenced by
+// this descriptor, including itself.
+func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
+	ids := catalog.MakeDescriptorIDSet(desc.GetID(), desc.GetParentID())
+	// Functions in the synthetic public schema are recorded on the parent
+	// database, which has no schema descriptor to reference.
+	if desc.GetParentSchemaID() != keys.PublicSchemaID {
+		ids.Add(desc.GetParentSchemaID())
+	}
+	for _, id := range desc.DependsOn {
+		ids.Add(id)
+	}
+	return ids, nil
+}
+
+// ValidateCrossReferences implements the catalog.Descriptor interface.
+func (desc *immutable) ValidateCrossReferences(
+	vea catalog.ValidationErrorAccumulator, vdg catalog.ValidationDescGetter,
+) {
+	// Validate the parentID.
+	dbDesc, err := vdg.GetDatabaseDescriptor(desc.GetParentID())
+	if err != nil {
+		vea.Report(err)
+	}
+	// Validate the parentSchemaID, and check that the parent schema has an
+	// entry for the function in its functions mapping. Functions in the
+	// synthetic public schema are mapped on the parent database instead.
+	var fn descpb.SchemaDescriptor_Function
+	var found, resolved bool
+	if desc.GetParentSchemaID() == keys.PublicSchemaID {
+		if dbDesc != nil {
+			fn, found = dbDesc.GetPublicSchemaFunction(desc.GetName())
+			resolved = true
+		}
+	} else if schemaDesc, err := vdg.GetSchemaDescriptor(desc.GetParentSchemaID()); err != nil {
+		vea.Report(err)
+	} else {
+		fn, found = schemaDesc.GetFunction(desc.GetName())
+		resolved = true
+	}
+	if resolved && (!found || !hasOverload(fn, desc.GetID())) {
+		vea.Report(errors.AssertionFailedf("not present in parent schema [%d] functions mapping",
+			desc.GetParentSchemaID()))
+	}
+	// Check that every relation the body depends on exists and has a back
+	// reference to this function.
+	for _, id := range desc.DependsOn {
+		tableDesc, err := vdg.GetTableDescriptor(id)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		found := false
+		for _, backRef := range tableDesc.GetDependedOnByFunctions() {
+			if backRef == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			vea.Report(errors.AssertionFailedf("depends-on relation %q (%d) has no corresponding back reference",
+				tableDesc.GetName(), tableDesc.GetID()))
+		}
+	}
+}
+
+// hasOverload returns whether fn has an overload with the given ID.
+func hasOverload(fn descpb.SchemaDescriptor_Function, id descpb.ID) bool {
+	for _, o := range fn.Overloads {
+		if o.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTxnCommit implements the catalog.Descriptor interface.
+func (desc *immutable) ValidateTxnCommit(
+	_ catalog.ValidationErrorAccumulator, _ catalog.ValidationDescGetter,
+) {
+	// No-op.
+}
+
+// SetDrainingNames implements the MutableDescriptor interface. Functions don't
+// have namespace entries, hence this is a no-op.
+//
+// Deprecated: Do not use.
+func (desc *Mutable) SetDrainingNames(names []descpb.NameInfo) {}
+
+// AddDrainingName implements the MutableDescriptor interface. Functions don't
+// have namespace entries, hence this is a no-op.
+//
+// Deprecated: Do not use.
+func (desc *Mutable) AddDrainingName(name descpb.NameInfo) {}
+
+// MaybeIncrementVersion implements the MutableDescriptor interface.
+func (desc *Mutable) MaybeIncrementVersion() {
+	// Already incremented, no-op.
+	if desc.ClusterVersion == nil || desc.Version == desc.ClusterVersion.Version+1 {
+		return
+	}
+	desc.Version++
+	desc.ModificationTime = hlc.Timestamp{}
+}
+
+// OriginalName implements the MutableDescriptor interface.
+func (desc *Mutable) OriginalName() string {
+	if desc.ClusterVersion == nil {
+		return ""
+	}
+	return desc.ClusterVersion.Name
+}
+
+// OriginalID implements the MutableDescriptor interface.
+func (desc *Mutable) OriginalID() descpb.ID {
+	if desc.ClusterVersion == nil {
+		return descpb.InvalidID
+	}
+	return desc.ClusterVersion.ID
+}
+
+// OriginalVersion implements the MutableDescriptor interface.
+func (desc *Mutable) OriginalVersion() descpb.DescriptorVersion {
+	if desc.ClusterVersion == nil {
+		return 0
+	}
+	return desc.ClusterVersion.Version
+}
+
+// ImmutableCopy implements the MutableDescriptor interface.
+func (desc *Mutable) ImmutableCopy() catalog.Descriptor {
+	imm := NewBuilder(desc.FuncDesc()).BuildImmutable()
+	imm.(*immutable).isUncommittedVersion = desc.IsUncommittedVersion()
+	return imm
+}
+
+// IsNew implements the MutableDescriptor interface.
+func (desc *Mutable) IsNew() bool {
+	return desc.ClusterVersion == nil
+}
+
+// SetPublic implements the MutableDescriptor interface.
+func (desc *Mutable) SetPublic() {
+	desc.State = descpb.DescriptorState_PUBLIC
+	desc.OfflineReason = ""
+}
+
+// SetDropped implements the MutableDescriptor interface.
+func (desc *Mutable) SetDropped() {
+	desc.State = descpb.DescriptorState_DROP
+	desc.OfflineReason = ""
+}
+
+// SetOffline implements the MutableDescriptor interface.
+func (desc *Mutable) SetOffline(reason string) {
+	desc.State = descpb.DescriptorState_OFFLINE
+	desc.OfflineReason = reason
+}
+
+// IsUncommittedVersion implements the Descriptor interface.
+func (desc *Mutable) IsUncommittedVersion() bool {
+	return desc.IsNew() || desc.GetVersion() != desc.ClusterVersion.GetVersion()
+}
+
+// HasPostDeserializationChanges returns if the MutableDescriptor was changed after running
+// RunPostDeserializationChanges.
+func (desc *Mutable) HasPostDeserializationChanges() bool {
+	return desc.changed
+}
+
+// SetVolatility sets the volatility of the function.
+func (desc *Mutable) SetVolatility(v descpb.FunctionDescriptor_Volatility) {
+	desc.Volatility = v
+}
+
+// SetLeakProof sets whether the function is leak proof.
+func (desc *Mutable) SetLeakProof(v bool) {
+	desc.LeakProof = v
+}
+
+// SetNullInputBehavior sets the behavior of the function on NULL arguments.
+func (desc *Mutable) SetNullInputBehavior(v descpb.FunctionDescriptor_NullInputBehavior) {
+	desc.NullInputBehavior = v
+}
+
+// SetFunctionBody sets the body of the function.
+func (desc *Mutable) SetFunctionBody(lang descpb.FunctionDescriptor_Language, body string) {
+	desc.Lang = lang
+	desc.FunctionBody = body
+}
+
+// CheckLeakProofVolatility returns an error if the function is leak proof but
+// isn't immutable.
+func CheckLeakProofVolatility(desc catalog.FunctionDescriptor) error {
+	if desc.GetLeakProof() && desc.GetVolatility() != descpb.FunctionDescriptor_IMMUTABLE {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"cannot set leakproof on function with non-immutable volatility: %s",
+			desc.GetVolatility().String())
+	}
+	return nil
+}
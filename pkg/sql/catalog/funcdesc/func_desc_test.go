@@ -0,0 +1,217 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package funcdesc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFuncDesc(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	const (
+		dbID     = 51
+		schemaID = 52
+		funcID   = 53
+		tableID  = 54
+		table2ID = 55
+	)
+	privs := descpb.NewBasePrivilegeDescriptor(security.AdminRoleName())
+	descs := catalog.MakeMapDescGetter()
+	descs.Descriptors[dbID] = dbdesc.NewBuilder(&descpb.DatabaseDescriptor{
+		Name:       "db",
+		ID:         dbID,
+		Privileges: privs,
+		Schemas: map[string]descpb.DatabaseDescriptor_SchemaInfo{
+			"sc": {ID: schemaID},
+		},
+		PublicSchemaFunctions: map[string]descpb.SchemaDescriptor_Function{
+			"pf": {
+				Name:      "pf",
+				Overloads: []descpb.SchemaDescriptor_FunctionOverload{{ID: funcID}},
+			},
+		},
+	}).BuildImmutable()
+	descs.Descriptors[schemaID] = schemadesc.NewBuilder(&descpb.SchemaDescriptor{
+		Name:       "sc",
+		ID:         schemaID,
+		ParentID:   dbID,
+		Privileges: privs,
+		Functions: map[string]descpb.SchemaDescriptor_Function{
+			"f": {
+				Name:      "f",
+				Overloads: []descpb.SchemaDescriptor_FunctionOverload{{ID: funcID}},
+			},
+		},
+	}).BuildImmutable()
+	descs.Descriptors[tableID] = tabledesc.NewBuilder(&descpb.TableDescriptor{
+		Name:                  "t",
+		ID:                    tableID,
+		ParentID:              dbID,
+		DependedOnByFunctions: []descpb.ID{funcID},
+	}).BuildImmutable()
+	descs.Descriptors[table2ID] = tabledesc.NewBuilder(&descpb.TableDescriptor{
+		Name:     "t2",
+		ID:       table2ID,
+		ParentID: dbID,
+	}).BuildImmutable()
+
+	makeDesc := func(mutate func(desc *descpb.FunctionDescriptor)) descpb.FunctionDescriptor {
+		desc := descpb.FunctionDescriptor{
+			Name:           "f",
+			ID:             funcID,
+			ParentID:       dbID,
+			ParentSchemaID: schemaID,
+			Privileges:     privs,
+			Args:           []descpb.FunctionDescriptor_Argument{{Name: "a", Type: types.Int}},
+			ReturnType:     types.Int,
+			FunctionBody:   "SELECT a + 1",
+			Volatility:     descpb.FunctionDescriptor_IMMUTABLE,
+		}
+		mutate(&desc)
+		return desc
+	}
+
+	testCases := []struct {
+		err  string
+		desc descpb.FunctionDescriptor
+	}{
+		{
+			err:  ``,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {}),
+		},
+		{
+			err: `invalid parentID 0`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.ParentID = descpb.InvalidID
+			}),
+		},
+		{
+			err: `return type not set`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.ReturnType = nil
+			}),
+		},
+		{
+			err: `type of argument 1 not set`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.Args[0].Type = nil
+			}),
+		},
+		{
+			err: `leak proof function must be immutable, but got volatility: STABLE`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.Volatility = descpb.FunctionDescriptor_STABLE
+				desc.LeakProof = true
+			}),
+		},
+		{
+			err: `referenced schema ID 500: descriptor not found`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.ParentSchemaID = 500
+			}),
+		},
+		{
+			err: `not present in parent schema [52] functions mapping`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.Name = "g"
+			}),
+		},
+		{
+			err: ``,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.Name = "pf"
+				desc.ParentSchemaID = keys.PublicSchemaID
+			}),
+		},
+		{
+			err: `not present in parent schema [29] functions mapping`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.ParentSchemaID = keys.PublicSchemaID
+			}),
+		},
+		{
+			err: ``,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.DependsOn = []descpb.ID{tableID}
+			}),
+		},
+		{
+			err: `depends-on relation "t2" (55) has no corresponding back reference`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.DependsOn = []descpb.ID{table2ID}
+			}),
+		},
+		{
+			err: `referenced table ID 500: descriptor not found`,
+			desc: makeDesc(func(desc *descpb.FunctionDescriptor) {
+				desc.DependsOn = []descpb.ID{500}
+			}),
+		},
+	}
+
+	for i, test := range testCases {
+		desc := funcdesc.NewBuilder(&test.desc).BuildImmutable()
+		results := catalog.Validate(ctx, descs, catalog.NoValidationTelemetry, catalog.ValidationLevelCrossReferences, desc)
+		err := results.CombinedError()
+		if test.err == "" {
+			require.NoError(t, err, "%d", i)
+			continue
+		}
+		expectedErr := fmt.Sprintf("%s %q (%d): %s", desc.DescriptorType(), desc.GetName(), desc.GetID(), test.err)
+		require.EqualError(t, err, expectedErr, "%d", i)
+	}
+}
+
+func TestToOverload(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	desc := funcdesc.NewBuilder(&descpb.FunctionDescriptor{
+		Name:              "f",
+		ID:                53,
+		Version:           3,
+		Args:              []descpb.FunctionDescriptor_Argument{{Name: "a", Type: types.Int}},
+		ReturnType:        types.String,
+		FunctionBody:      "SELECT a::STRING",
+		Volatility:        descpb.FunctionDescriptor_STABLE,
+		NullInputBehavior: descpb.FunctionDescriptor_RETURNS_NULL_ON_NULL_INPUT,
+	}).BuildImmutableFunction()
+
+	o := desc.ToOverload()
+	require.True(t, o.IsUDF)
+	require.Equal(t, "SELECT a::STRING", o.Body)
+	require.Equal(t, tree.VolatilityStable, o.Volatility)
+	require.False(t, o.CalledOnNullInput)
+	require.Equal(t, uint64(3), o.Version)
+	require.Equal(t, funcdesc.FuncIDToOID(53), o.Oid)
+	require.Equal(t, types.String, o.FixedReturnType())
+	require.Equal(t, "a: int", o.Types.String())
+
+	id, err := funcdesc.UserDefinedFunctionOIDToID(o.Oid)
+	require.NoError(t, err)
+	require.Equal(t, descpb.ID(53), id)
+}
@@ -26,6 +26,10 @@ type SchemaDescriptor interface {
 	// GetDefaultPrivilegeDescriptor returns the default privileges for this
 	// database.
 	GetDefaultPrivilegeDescriptor() DefaultPrivilegeDescriptor
+
+	// GetFunction returns the overloads of the user defined function with the
+	// given name in the schema, if it exists.
+	GetFunction(name string) (descpb.SchemaDescriptor_Function, bool)
 }
 
 // ResolvedSchemaKind is an enum that represents what kind of schema
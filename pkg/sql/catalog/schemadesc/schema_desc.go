@@ -241,6 +241,12 @@ func (desc *immutable) GetDefaultPrivilegeDescriptor() catalog.DefaultPrivilegeD
 	return catprivilege.MakeDefaultPrivileges(defaultPrivilegeDescriptor)
 }
 
+// GetFunction implements the SchemaDescriptor interface.
+func (desc *immutable) GetFunction(name string) (descpb.SchemaDescriptor_Function, bool) {
+	fn, ok := desc.Functions[name]
+	return fn, ok
+}
+
 // MaybeIncrementVersion implements the MutableDescriptor interface.
 func (desc *Mutable) MaybeIncrementVersion() {
 	// Already incremented, no-op.
@@ -338,6 +344,18 @@ func (desc *Mutable) SetDefaultPrivilegeDescriptor(
 	desc.DefaultPrivileges = defaultPrivilegeDescriptor
 }
 
+// AddFunction adds the overload of the given function descriptor to the
+// functions mapping of the schema, replacing any overload with the same ID.
+func (desc *Mutable) AddFunction(name string, overload descpb.SchemaDescriptor_FunctionOverload) {
+	desc.Functions = descpb.AddFunctionOverload(desc.Functions, name, overload)
+}
+
+// RemoveFunction removes the overload with the given function descriptor ID
+// from the functions mapping of the schema.
+func (desc *Mutable) RemoveFunction(name string, id descpb.ID) {
+	descpb.RemoveFunctionOverload(desc.Functions, name, id)
+}
+
 // IsSchemaNameValid returns whether the input name is valid for a user defined
 // schema.
 func IsSchemaNameValid(name string) error {
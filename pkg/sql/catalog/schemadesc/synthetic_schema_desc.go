@@ -108,6 +108,12 @@ func (p synthetic) SchemaDesc() *descpb.SchemaDescriptor {
 	return nil // unreachable
 }
 
+// GetFunction implements the SchemaDescriptor interface. Synthetic schemas
+// never contain user defined functions.
+func (p synthetic) GetFunction(name string) (descpb.SchemaDescriptor_Function, bool) {
+	return descpb.SchemaDescriptor_Function{}, false
+}
+
 // GetDefaultPrivilegeDescriptor returns a DefaultPrivilegeDescriptor.
 func (p synthetic) GetDefaultPrivilegeDescriptor() catalog.DefaultPrivilegeDescriptor {
 	return catprivilege.MakeDefaultPrivileges(catprivilege.MakeDefaultPrivilegeDescriptor(descpb.DefaultPrivilegeDescriptor_SCHEMA))
@@ -0,0 +1,118 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// domainValueName is the name by which the CHECK constraints of a domain refer
+// to the value being checked.
+const domainValueName = "value"
+
+// ValidateDomainCheck verifies that an expression is a valid CHECK constraint
+// of a domain with the given base type. If the expression is valid, it returns
+// the serialized expression.
+//
+// A domain check expression is valid if all of the following are true:
+//
+//   - It results in a boolean.
+//   - It does not refer to any columns, other than VALUE.
+//   - It does not include subqueries.
+//   - It does not include aggregate, window, or set returning functions.
+//   - It does not include non-immutable functions.
+//
+func ValidateDomainCheck(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	// Replace VALUE with a dummyColumn of the base type so that the expression
+	// can be type-checked.
+	replacedExpr, err := replaceDomainValue(expr, &dummyColumn{typ: baseType, name: domainValueName})
+	if err != nil {
+		return "", err
+	}
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, replacedExpr, types.Bool, "DOMAIN CHECK", semaCtx, tree.VolatilityImmutable,
+	)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// DomainCheck is a boolean expression which enforces a constraint of a domain
+// on a value.
+type DomainCheck struct {
+	// Name is the name of the CHECK constraint of the domain. It is empty for
+	// the NOT NULL constraint.
+	Name string
+	// NotNull is true for the NOT NULL constraint of the domain.
+	NotNull bool
+	// Expr is true or NULL if the value satisfies the constraint.
+	Expr tree.Expr
+}
+
+// MakeDomainChecks returns the expressions which enforce the constraints of
+// the given hydrated domain type on value: the CHECK constraints of the domain,
+// with value substituted for VALUE, followed by a (value IS NOT NULL)
+// expression if the domain is NOT NULL.
+func MakeDomainChecks(typ *types.T, value tree.Expr) ([]DomainCheck, error) {
+	domainData := typ.TypeMeta.DomainData
+	if domainData == nil {
+		return nil, errors.AssertionFailedf("domain type %s is not hydrated", typ.SQLString())
+	}
+	checks := make([]DomainCheck, 0, len(domainData.CheckExprs)+1)
+	for i, checkExpr := range domainData.CheckExprs {
+		expr, err := parser.ParseExpr(checkExpr)
+		if err != nil {
+			return nil, err
+		}
+		if expr, err = replaceDomainValue(expr, value); err != nil {
+			return nil, err
+		}
+		checks = append(checks, DomainCheck{Name: domainData.CheckNames[i], Expr: expr})
+	}
+	if domainData.NotNull {
+		checks = append(checks, DomainCheck{
+			NotNull: true,
+			Expr:    &tree.IsNotNullExpr{Expr: value},
+		})
+	}
+	return checks, nil
+}
+
+// replaceDomainValue replaces the references to VALUE in the given domain
+// check expression with value. It returns an error if the expression refers to
+// other columns.
+func replaceDomainValue(rootExpr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(rootExpr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		if c, ok := v.(*tree.ColumnItem); ok && c.TableName == nil && c.ColumnName == domainValueName {
+			return false, value, nil
+		}
+		return false, nil, pgerror.New(pgcode.InvalidColumnReference,
+			"cannot use column references in domain check constraint")
+	})
+}
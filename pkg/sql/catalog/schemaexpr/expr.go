@@ -373,8 +373,10 @@ func SanitizeVarFreeExpr(
 	// which uses the properties field.
 	defer semaCtx.Properties.Restore(semaCtx.Properties)
 
-	// Ensure that the expression doesn't contain special functions.
-	flags := tree.RejectSpecial
+	// Ensure that the expression doesn't contain special functions, nor
+	// user-defined functions, which can only be evaluated by inlining them in
+	// queries.
+	flags := tree.RejectSpecial | tree.RejectUDFs
 
 	switch maxVolatility {
 	case tree.VolatilityImmutable:
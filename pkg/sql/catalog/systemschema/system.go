@@ -657,6 +657,21 @@ CREATE TABLE system.span_configurations (
     CONSTRAINT check_bounds CHECK (start_key < end_key),
     FAMILY "primary" (start_key, end_key, config)
 )`
+
+	// notifications stores the notifications sent with NOTIFY or pg_notify()
+	// until they are garbage collected. Every SQL instance watches the table
+	// with a rangefeed to deliver them to the sessions listening on their
+	// channels. pid identifies the SQL instance which sent the notification.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+    id       INT8 NOT NULL,
+    channel  STRING NOT NULL,
+    payload  STRING NOT NULL,
+    pid      INT8 NOT NULL,
+    created  TIMESTAMP NOT NULL,
+    CONSTRAINT "primary" PRIMARY KEY (id),
+    FAMILY "primary" (id, channel, payload, pid, created)
+)`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -2292,6 +2307,30 @@ var (
 		},
 	)
 
+	// NotificationsTable is the descriptor for the notifications table.
+	NotificationsTable = registerSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			keys.NotificationsTableID,
+			[]descpb.ColumnDescriptor{
+				{Name: "id", ID: 1, Type: types.Int},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "pid", ID: 4, Type: types.Int},
+				{Name: "created", ID: 5, Type: types.Timestamp},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"id", "channel", "payload", "pid", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			pk("id"),
+		))
+
 	// UnleasableSystemDescriptors contains the system descriptors which cannot
 	// be leased. This includes the lease table itself, among others.
 	UnleasableSystemDescriptors = func(s []catalog.Descriptor) map[descpb.ID]catalog.Descriptor {
@@ -332,6 +332,10 @@ type ConstraintToUpdate interface {
 	// without index constraint.
 	IsUniqueWithoutIndex() bool
 
+	// IsExclusion returns true iff this is an update for an exclusion
+	// constraint.
+	IsExclusion() bool
+
 	// Check returns the underlying check constraint, if there is one.
 	Check() descpb.TableDescriptor_CheckConstraint
 
@@ -344,6 +348,9 @@ type ConstraintToUpdate interface {
 	// UniqueWithoutIndex returns the underlying unique without index constraint, if
 	// there is one.
 	UniqueWithoutIndex() descpb.UniqueWithoutIndexConstraint
+
+	// Exclusion returns the underlying exclusion constraint, if there is one.
+	Exclusion() descpb.ExclusionConstraint
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -329,7 +329,7 @@ func newColumnCache(desc *descpb.TableDescriptor, mutations *mutationCache) *col
 		if col.Public() && !col.IsInaccessible() {
 			lazyAllocAppendColumn(&c.accessible, col, numPublic)
 		}
-		if col.HasType() && col.GetType().UserDefined() {
+		if col.HasType() && (col.GetType().UserDefined() || col.GetType().IsDomain()) {
 			lazyAllocAppendColumn(&c.withUDTs, col, numDeletable)
 		}
 	}
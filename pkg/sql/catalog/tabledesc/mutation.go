@@ -131,6 +131,16 @@ func (c constraintToUpdate) UniqueWithoutIndex() descpb.UniqueWithoutIndexConstr
 	return c.desc.UniqueWithoutIndexConstraint
 }
 
+// IsExclusion returns true iff this is an update for an exclusion constraint.
+func (c constraintToUpdate) IsExclusion() bool {
+	return c.desc.ConstraintType == descpb.ConstraintToUpdate_EXCLUSION
+}
+
+// Exclusion returns the underlying exclusion constraint, if there is one.
+func (c constraintToUpdate) Exclusion() descpb.ExclusionConstraint {
+	return c.desc.ExclusionConstraint
+}
+
 // primaryKeySwap implements the catalog.PrimaryKeySwap interface.
 type primaryKeySwap struct {
 	maybeMutation
@@ -296,6 +296,28 @@ func (desc *wrapper) AllActiveAndInactiveUniqueWithoutIndexConstraints() []*desc
 	return ucs
 }
 
+// AllActiveAndInactiveExclusionConstraints implements the TableDescriptor
+// interface.
+func (desc *wrapper) AllActiveAndInactiveExclusionConstraints() []*descpb.ExclusionConstraint {
+	ecs := make([]*descpb.ExclusionConstraint, 0, len(desc.ExclusionConstraints))
+	for i := range desc.ExclusionConstraints {
+		ec := &desc.ExclusionConstraints[i]
+		// Constraints being validated are present both on the table descriptor
+		// and in the mutations list, so they are excluded here to avoid
+		// double-counting.
+		if ec.Validity != descpb.ConstraintValidity_Validating {
+			ecs = append(ecs, ec)
+		}
+	}
+	for i := range desc.Mutations {
+		if c := desc.Mutations[i].GetConstraint(); c != nil &&
+			c.ConstraintType == descpb.ConstraintToUpdate_EXCLUSION {
+			ecs = append(ecs, &c.ExclusionConstraint)
+		}
+	}
+	return ecs
+}
+
 // AllActiveAndInactiveForeignKeys implements the TableDescriptor interface.
 func (desc *wrapper) AllActiveAndInactiveForeignKeys() []*descpb.ForeignKeyConstraint {
 	fks := make([]*descpb.ForeignKeyConstraint, 0, len(desc.OutboundFKs))
@@ -664,8 +686,9 @@ func (desc *Mutable) AllocateIDsWithoutValidation(ctx context.Context) error {
 		}
 	}
 
-	// Only tables and materialized views can have / need indexes and column families.
-	if desc.IsTable() || desc.MaterializedView() {
+	// Only tables and materialized views can have / need indexes and column
+	// families. Foreign tables have neither, like views.
+	if (desc.IsTable() && !desc.IsForeignTable()) || desc.MaterializedView() {
 		if err := desc.allocateIndexIDs(columnNames); err != nil {
 			return err
 		}
@@ -1394,6 +1417,23 @@ func (desc *Mutable) DropConstraint(
 			}
 		}
 
+	case descpb.ConstraintTypeExclusion:
+		if detail.ExclusionConstraint.Validity == descpb.ConstraintValidity_Validating {
+			return unimplemented.NewWithIssueDetailf(42844,
+				"drop-constraint-exclusion-validating",
+				"constraint %q in the middle of being added, try again later", name)
+		}
+		// Exclusion constraints only restrict the rows written to the table, so
+		// they can be dropped immediately.
+		for i := range desc.ExclusionConstraints {
+			if desc.ExclusionConstraints[i].Name == name {
+				desc.ExclusionConstraints = append(
+					desc.ExclusionConstraints[:i], desc.ExclusionConstraints[i+1:]...,
+				)
+				return nil
+			}
+		}
+
 	case descpb.ConstraintTypeFK:
 		if detail.FK.Validity == descpb.ConstraintValidity_Validating {
 			return unimplemented.NewWithIssueDetailf(42844,
@@ -1529,6 +1569,16 @@ func (desc *Mutable) RenameConstraint(
 		detail.CheckConstraint.Name = newName
 		return nil
 
+	case descpb.ConstraintTypeExclusion:
+		if detail.ExclusionConstraint.Validity == descpb.ConstraintValidity_Validating {
+			return unimplemented.NewWithIssueDetailf(42844,
+				"rename-constraint-exclusion-mutation",
+				"constraint %q in the middle of being added, try again later",
+				tree.ErrNameStringP(&detail.ExclusionConstraint.Name))
+		}
+		detail.ExclusionConstraint.Name = newName
+		return nil
+
 	default:
 		return unimplemented.Newf(fmt.Sprintf("rename-constraint-%s", detail.Kind),
 			"constraint %q has unsupported type", tree.ErrNameString(oldName))
@@ -1667,6 +1717,17 @@ func (desc *Mutable) MakeMutationComplete(m descpb.DescriptorMutation) error {
 						t.Constraint.UniqueWithoutIndexConstraint.Validity,
 					)
 				}
+			case descpb.ConstraintToUpdate_EXCLUSION:
+				// Exclusion constraints are always validated before they are added,
+				// so the constraint is already on the table descriptor and only
+				// needs to be marked as Validated.
+				for i := range desc.ExclusionConstraints {
+					ec := &desc.ExclusionConstraints[i]
+					if ec.Name == t.Constraint.Name {
+						ec.Validity = descpb.ConstraintValidity_Validated
+						break
+					}
+				}
 			case descpb.ConstraintToUpdate_NOT_NULL:
 				// Remove the dummy check constraint that was in place during
 				// validation.
@@ -1931,6 +1992,24 @@ func (desc *Mutable) AddUniqueWithoutIndexMutation(
 	desc.addMutation(m)
 }
 
+// AddExclusionConstraintMutation adds an exclusion constraint mutation to
+// desc.Mutations.
+func (desc *Mutable) AddExclusionConstraintMutation(
+	ec *descpb.ExclusionConstraint, direction descpb.DescriptorMutation_Direction,
+) {
+	m := descpb.DescriptorMutation{
+		Descriptor_: &descpb.DescriptorMutation_Constraint{
+			Constraint: &descpb.ConstraintToUpdate{
+				ConstraintType:      descpb.ConstraintToUpdate_EXCLUSION,
+				Name:                ec.Name,
+				ExclusionConstraint: *ec,
+			},
+		},
+		Direction: direction,
+	}
+	desc.addMutation(m)
+}
+
 // MakeNotNullCheckConstraint creates a dummy check constraint equivalent to a
 // NOT NULL constraint on a column, so that NOT NULL constraints can be added
 // and dropped correctly in the schema changer. This function mutates inuseNames
@@ -2284,6 +2363,26 @@ func (desc *Mutable) SetAuditMode(mode tree.AuditMode) (bool, error) {
 	return prev != desc.AuditMode, nil
 }
 
+// SetRowLevelSecurity applies the given row-level security mode to the table
+// descriptor. It returns whether the descriptor changed.
+func (desc *Mutable) SetRowLevelSecurity(mode tree.RowLevelSecurityMode) (bool, error) {
+	prevEnabled, prevForced := desc.RowLevelSecurity, desc.ForceRowLevelSecurity
+	switch mode {
+	case tree.RowLevelSecurityEnable:
+		desc.RowLevelSecurity = true
+	case tree.RowLevelSecurityDisable:
+		desc.RowLevelSecurity = false
+	case tree.RowLevelSecurityForce:
+		desc.ForceRowLevelSecurity = true
+	case tree.RowLevelSecurityNoForce:
+		desc.ForceRowLevelSecurity = false
+	default:
+		return false, pgerror.Newf(pgcode.InvalidParameterValue,
+			"unknown row-level security mode: %s (%d)", mode, mode)
+	}
+	return prevEnabled != desc.RowLevelSecurity || prevForced != desc.ForceRowLevelSecurity, nil
+}
+
 // FindAllReferences returns all the references from a table.
 func (desc *wrapper) FindAllReferences() (map[descpb.ID]struct{}, error) {
 	refs := map[descpb.ID]struct{}{}
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -138,6 +139,16 @@ func MakeColumnDefDescs(
 	}
 	col.Type = resType
 
+	// Columns of a domain type without a default of their own use the default
+	// of the domain.
+	if !d.HasDefaultExpr() && !d.GeneratedIdentity.IsGeneratedAsIdentity && resType.IsDomain() {
+		if domainData := resType.TypeMeta.DomainData; domainData != nil && domainData.Default != "" {
+			if d.DefaultExpr.Expr, err = parser.ParseExpr(domainData.Default); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if d.HasDefaultExpr() {
 		// Verify the default expression type is compatible with the column type
 		// and does not contain invalid functions.
@@ -413,6 +424,24 @@ func (desc *wrapper) collectConstraintInfo(
 		}
 		info[c.Name] = detail
 	}
+
+	for _, c := range desc.AllActiveAndInactiveExclusionConstraints() {
+		if _, ok := info[c.Name]; ok {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"duplicate constraint name: %q", c.Name)
+		}
+		detail := descpb.ConstraintDetail{Kind: descpb.ConstraintTypeExclusion}
+		// Constraints in the Validating state are considered Unvalidated for this
+		// purpose.
+		detail.Unvalidated = c.Validity != descpb.ConstraintValidity_Validated
+		var err error
+		detail.Columns, err = desc.NamesForColumnIDs(c.ColumnIDs)
+		if err != nil {
+			return nil, err
+		}
+		detail.ExclusionConstraint = c
+		info[c.Name] = detail
+	}
 	return info, nil
 }
 
@@ -633,6 +662,30 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policies.
+	for i := range tableDesc.Policies {
+		policy := &tableDesc.Policies[i]
+		if policy.UsingExpr != "" {
+			if err := renameInExpr(&policy.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if policy.WithCheckExpr != "" {
+			if err := renameInExpr(&policy.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Rename the column in exclusion constraint predicates.
+	for i := range tableDesc.ExclusionConstraints {
+		if c := &tableDesc.ExclusionConstraints[i]; c.Predicate != "" {
+			if err := renameInExpr(&c.Predicate); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Do all of the above renames inside check constraints, computed expressions,
 	// and idx predicates that are in mutations.
 	for i := range tableDesc.Mutations {
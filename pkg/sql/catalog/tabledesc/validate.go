@@ -111,6 +111,9 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	for _, id := range desc.GetDependedOnByFunctions() {
+		ids.Add(id)
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -371,6 +374,9 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			desc.validateColumnFamilies(columnIDs),
 			desc.validateCheckConstraints(columnIDs),
 			desc.validateUniqueWithoutIndexConstraints(columnIDs),
+			desc.validateExclusionConstraints(columnIDs),
+			desc.validateTriggers(),
+			desc.validatePolicies(),
 			desc.validateTableIndexes(columnNames),
 			desc.validatePartitioning(),
 		}
@@ -386,6 +392,13 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		}
 	}
 
+	if desc.IsForeignTable() {
+		if err := desc.validateForeignTable(); err != nil {
+			vea.Report(err)
+			return
+		}
+	}
+
 	// Validate the privilege descriptor.
 	vea.Report(catprivilege.Validate(*desc.Privileges, desc, privilege.Table))
 
@@ -755,6 +768,129 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 	return nil
 }
 
+// validateExclusionConstraints validates that exclusion constraints are well
+// formed. Checks include validating the column IDs and operators, and
+// verifying that the predicate only refers to columns of the table.
+func (desc *wrapper) validateExclusionConstraints(
+	columnIDs map[descpb.ColumnID]*descpb.ColumnDescriptor,
+) error {
+	for _, c := range desc.AllActiveAndInactiveExclusionConstraints() {
+		if err := catalog.ValidateName(c.Name, "exclusion constraint"); err != nil {
+			return err
+		}
+		if len(c.ColumnIDs) == 0 {
+			return errors.Newf("exclusion constraint %q has no columns", c.Name)
+		}
+		if len(c.Operators) != len(c.ColumnIDs) {
+			return errors.Newf(
+				"exclusion constraint %q has %d operators for %d columns",
+				c.Name, len(c.Operators), len(c.ColumnIDs),
+			)
+		}
+		for _, colID := range c.ColumnIDs {
+			if _, ok := columnIDs[colID]; !ok {
+				return errors.Newf(
+					"exclusion constraint %q contains unknown column \"%d\"", c.Name, colID,
+				)
+			}
+		}
+		if c.Predicate != "" {
+			expr, err := parser.ParseExpr(c.Predicate)
+			if err != nil {
+				return err
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errors.Newf(
+					"exclusion constraint %q refers to unknown columns in predicate: %s",
+					c.Name, c.Predicate,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTriggers validates that row-level triggers are well formed. Checks
+// include validating the trigger names and verifying that they are unique.
+func (desc *wrapper) validateTriggers() error {
+	names := make(map[string]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		if err := catalog.ValidateName(t.Name, "trigger"); err != nil {
+			return err
+		}
+		if _, ok := names[t.Name]; ok {
+			return errors.Newf("duplicate trigger name: %q", t.Name)
+		}
+		names[t.Name] = struct{}{}
+		if len(t.Events) == 0 {
+			return errors.Newf("trigger %q has no events", t.Name)
+		}
+		if t.Body == "" {
+			return errors.Newf("trigger %q has no body", t.Name)
+		}
+	}
+	return nil
+}
+
+// validateForeignTable validates that a foreign table is well formed. Checks
+// include verifying that it has a server and, like views, no indexes.
+func (desc *wrapper) validateForeignTable() error {
+	if desc.IsView() || desc.IsSequence() {
+		return errors.Newf("foreign table %q cannot be a view or a sequence", desc.Name)
+	}
+	if desc.ForeignTable.Server == "" {
+		return errors.Newf("foreign table %q has no server", desc.Name)
+	}
+	if len(desc.Indexes) > 0 || desc.PrimaryIndex.ID != 0 {
+		return errors.Newf("foreign table %q cannot have indexes", desc.Name)
+	}
+	return nil
+}
+
+// validatePolicies validates that row-level security policies are well formed.
+// Checks include validating the policy names and verifying that they are
+// unique, and verifying that their expressions only refer to columns of the
+// table.
+func (desc *wrapper) validatePolicies() error {
+	names := make(map[string]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if err := catalog.ValidateName(p.Name, "policy"); err != nil {
+			return err
+		}
+		if _, ok := names[p.Name]; ok {
+			return errors.Newf("duplicate policy name: %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+		if len(p.Roles) == 0 {
+			return errors.Newf("policy %q has no roles", p.Name)
+		}
+		for _, e := range []string{p.UsingExpr, p.WithCheckExpr} {
+			if e == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(e)
+			if err != nil {
+				return err
+			}
+			valid, err := schemaexpr.HasValidColumnReferences(desc, expr)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return errors.Newf("policy %q refers to unknown columns in expression: %s",
+					p.Name, e)
+			}
+		}
+	}
+	return nil
+}
+
 // validateTableIndexes validates that indexes are well formed. Checks include
 // validating the columns involved in the index, verifying the index names and
 // IDs are unique, and the family of the primary key is 0. This does not check
@@ -122,6 +122,10 @@ var validationMap = []struct {
 			"OutboundFKs":                   {status: iSolemnlySwearThisFieldIsValidated},
 			"InboundFKs":                    {status: iSolemnlySwearThisFieldIsValidated},
 			"UniqueWithoutIndexConstraints": {status: iSolemnlySwearThisFieldIsValidated},
+			"Triggers":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"RowLevelSecurity":              {status: thisFieldReferencesNoObjects},
+			"ForceRowLevelSecurity":         {status: thisFieldReferencesNoObjects},
+			"Policies":                      {status: iSolemnlySwearThisFieldIsValidated},
 			"Temporary":                     {status: thisFieldReferencesNoObjects},
 			"LocalityConfig":                {status: iSolemnlySwearThisFieldIsValidated},
 			"PartitionAllBy":                {status: iSolemnlySwearThisFieldIsValidated},
@@ -1045,6 +1049,91 @@ func TestValidateTableDesc(t *testing.T) {
 					},
 				},
 			}},
+		{`duplicate trigger name: "audit"`,
+			descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1},
+						ColumnNames: []string{"bar"},
+					},
+				},
+				NextColumnID: 2,
+				NextFamilyID: 1,
+				Triggers: []descpb.TriggerDescriptor{
+					{
+						Name:   "audit",
+						Events: []descpb.TriggerDescriptor_Event{descpb.TriggerDescriptor_INSERT},
+						Body:   "SELECT 1",
+					},
+					{
+						Name:   "audit",
+						Events: []descpb.TriggerDescriptor_Event{descpb.TriggerDescriptor_DELETE},
+						Body:   "SELECT 1",
+					},
+				},
+			}},
+		{`duplicate policy name: "tenant"`,
+			descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1},
+						ColumnNames: []string{"bar"},
+					},
+				},
+				NextColumnID: 2,
+				NextFamilyID: 1,
+				Policies: []descpb.PolicyDescriptor{
+					{
+						Name:      "tenant",
+						Roles:     []string{"public"},
+						UsingExpr: "bar = 1:::INT8",
+					},
+					{
+						Name:    "tenant",
+						Command: descpb.PolicyDescriptor_INSERT,
+						Roles:   []string{"public"},
+					},
+				},
+			}},
+		{`policy "tenant" refers to unknown columns in expression: baz = 1:::INT8`,
+			descpb.TableDescriptor{
+				ID:            2,
+				ParentID:      1,
+				Name:          "foo",
+				FormatVersion: descpb.InterleavedFormatVersion,
+				Columns: []descpb.ColumnDescriptor{
+					{ID: 1, Name: "bar"},
+				},
+				Families: []descpb.ColumnFamilyDescriptor{
+					{ID: 0, Name: "primary",
+						ColumnIDs:   []descpb.ColumnID{1},
+						ColumnNames: []string{"bar"},
+					},
+				},
+				NextColumnID: 2,
+				NextFamilyID: 1,
+				Policies: []descpb.PolicyDescriptor{
+					{
+						Name:      "tenant",
+						Roles:     []string{"public"},
+						UsingExpr: "baz = 1:::INT8",
+					},
+				},
+			}},
 		{`index "sec" cannot store virtual column "c3"`,
 			descpb.TableDescriptor{
 				ID:            2,
@@ -43,6 +43,9 @@ func formatSafeTypeProperties(w *redact.StringBuilder, desc catalog.TypeDescript
 	if td.Alias != nil {
 		w.Printf(", Alias: %d", td.Alias.Oid())
 	}
+	if td.DomainConfig != nil && td.DomainConfig.BaseType != nil {
+		w.Printf(", DomainBaseType: %d", td.DomainConfig.BaseType.Oid())
+	}
 	if td.ArrayTypeID != 0 {
 		w.Printf(", ArrayTypeID: %d", td.ArrayTypeID)
 	}
@@ -129,6 +129,9 @@ func UserDefinedTypeOIDToID(oid oid.Oid) (descpb.ID, error) {
 
 // GetUserDefinedTypeDescID gets the type descriptor ID from a user defined type.
 func GetUserDefinedTypeDescID(t *types.T) (descpb.ID, error) {
+	if t.IsDomain() {
+		return UserDefinedTypeOIDToID(t.DomainOID())
+	}
 	return UserDefinedTypeOIDToID(t.Oid())
 }
 
@@ -521,6 +524,20 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.GetArrayTypeID() != descpb.InvalidID {
 			vea.Report(errors.AssertionFailedf("ALIAS type desc has array type ID %d", desc.GetArrayTypeID()))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		vea.Report(catprivilege.Validate(*desc.Privileges, desc, privilege.Type))
+		if desc.RegionConfig != nil {
+			vea.Report(errors.AssertionFailedf("found region config on %s type desc", desc.Kind.String()))
+		}
+		if desc.DomainConfig == nil || desc.DomainConfig.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has no base type"))
+		} else if desc.DomainConfig.BaseType.UserDefined() {
+			vea.Report(errors.AssertionFailedf(
+				"DOMAIN type desc has user defined base type %s", desc.DomainConfig.BaseType.SQLString()))
+		}
+		if desc.GetArrayTypeID() != descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has array type ID %d", desc.GetArrayTypeID()))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -737,6 +754,12 @@ func (desc *immutable) MakeTypesT(
 			return nil, err
 		}
 		return desc.Alias, nil
+	case descpb.TypeDescriptor_DOMAIN:
+		typ := types.MakeDomain(desc.DomainConfig.BaseType, TypeIDToOID(desc.GetID()))
+		if err := desc.HydrateTypeInfoWithName(ctx, typ, name, res); err != nil {
+			return nil, err
+		}
+		return typ, nil
 	default:
 		return nil, errors.AssertionFailedf("unknown type kind %s", t.String())
 	}
@@ -749,7 +772,7 @@ func EnsureTypeIsHydrated(
 	// maybeHydrateType checks if t is a user-defined type that hasn't been
 	// hydrated yet, and installs the metadata if so.
 	maybeHydrateType := func(ctx context.Context, t *types.T, res catalog.TypeDescriptorResolver) error {
-		if !t.UserDefined() || t.IsHydrated() {
+		if !(t.UserDefined() || t.IsDomain()) || t.IsHydrated() {
 			return nil
 		}
 		id, err := GetUserDefinedTypeDescID(t)
@@ -834,6 +857,20 @@ func (desc *immutable) HydrateTypeInfoWithName(
 			}
 		}
 		return nil
+	case descpb.TypeDescriptor_DOMAIN:
+		if !typ.IsDomain() {
+			return errors.New("cannot hydrate a non-domain type with a domain type descriptor")
+		}
+		domainData := &types.DomainMetadata{
+			Default: desc.DomainConfig.GetDefaultExpr(),
+			NotNull: desc.DomainConfig.NotNull,
+		}
+		for _, c := range desc.DomainConfig.Checks {
+			domainData.CheckNames = append(domainData.CheckNames, c.Name)
+			domainData.CheckExprs = append(domainData.CheckExprs, c.Expr)
+		}
+		typ.TypeMeta.DomainData = domainData
+		return nil
 	default:
 		return errors.AssertionFailedf("unknown type descriptor kind %s", desc.Kind)
 	}
@@ -939,8 +976,8 @@ func (desc *immutable) GetIDClosure() (map[descpb.ID]struct{}, error) {
 		for id := range children {
 			ret[id] = struct{}{}
 		}
-	} else {
-		// Otherwise, take the array type ID.
+	} else if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		// Otherwise, take the array type ID. Domains don't have array types.
 		ret[desc.ArrayTypeID] = struct{}{}
 	}
 	return ret, nil
@@ -949,6 +986,15 @@ func (desc *immutable) GetIDClosure() (map[descpb.ID]struct{}, error) {
 // GetTypeDescriptorClosure returns all type descriptor IDs that are
 // referenced by this input types.T.
 func GetTypeDescriptorClosure(typ *types.T) (map[descpb.ID]struct{}, error) {
+	if typ.IsDomain() {
+		// Domains don't have array types, and their base types are never user
+		// defined, so only the domain itself is referenced.
+		id, err := GetUserDefinedTypeDescID(typ)
+		if err != nil {
+			return nil, err
+		}
+		return map[descpb.ID]struct{}{id: {}}, nil
+	}
 	if !typ.UserDefined() {
 		return map[descpb.ID]struct{}{}, nil
 	}
@@ -304,6 +304,8 @@ func (vea *validationErrorAccumulator) decorate(err error) error {
 			err = errors.Wrapf(err, Schema+" %q (%d)", name, id)
 		case Type:
 			err = errors.Wrapf(err, Type+" %q (%d)", name, id)
+		case Function:
+			err = errors.Wrapf(err, Function+" %q (%d)", name, id)
 		default:
 			return err
 		}
@@ -535,6 +537,11 @@ func validateNamespace(
 	if desc.GetID() == keys.NamespaceTableID || desc.GetID() == keys.DeprecatedNamespaceTableID {
 		return
 	}
+	// Functions don't have namespace entries, their overloads are found through
+	// the functions mapping of their parent schema instead.
+	if desc.DescriptorType() == Function {
+		return
+	}
 
 	id := namespace[descpb.NameInfo{
 		ParentID:       desc.GetParentID(),
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
@@ -419,6 +420,132 @@ func validateUniqueConstraint(
 	return nil
 }
 
+// conflictingRowQuery generates and returns a query for a pair of distinct
+// rows that violate the specified exclusion constraint. Rows in the table with
+// null values in the constrained columns never conflict with other rows.
+//
+// For example, an exclusion constraint (a WITH =, b WITH &&) on the table
+// "tbl" with primary key k would require the following query:
+//
+// SELECT t1.a, t1.b, t2.a, t2.b
+// FROM (SELECT a, b, k FROM tbl) AS t1
+// JOIN (SELECT a, b, k FROM tbl) AS t2
+// ON t1.a = t2.a AND t1.b && t2.b AND (t1.k) != (t2.k)
+// LIMIT 1
+//
+// If the exclusion constraint has a predicate, only the rows which satisfy it
+// are considered.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor, c *descpb.ExclusionConstraint,
+) (sql string, colNames []string, _ error) {
+	colNames, err := srcTbl.NamesForColumnIDs(c.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// The subqueries project the constrained columns and the primary key
+	// columns which are not constrained.
+	projCols := make([]string, 0, len(colNames)+srcTbl.GetPrimaryIndex().NumKeyColumns())
+	seen := make(map[string]struct{}, cap(projCols))
+	for _, n := range colNames {
+		projCols = append(projCols, tree.NameString(n))
+		seen[n] = struct{}{}
+	}
+	pkIndex := srcTbl.GetPrimaryIndex()
+	t1PK := make([]string, pkIndex.NumKeyColumns())
+	t2PK := make([]string, pkIndex.NumKeyColumns())
+	for i := range t1PK {
+		n := pkIndex.GetKeyColumnName(i)
+		if _, ok := seen[n]; !ok {
+			projCols = append(projCols, tree.NameString(n))
+			seen[n] = struct{}{}
+		}
+		t1PK[i] = fmt.Sprintf("t1.%s", tree.NameString(n))
+		t2PK[i] = fmt.Sprintf("t2.%s", tree.NameString(n))
+	}
+
+	t1Cols := make([]string, len(colNames))
+	t2Cols := make([]string, len(colNames))
+	on := make([]string, 0, len(colNames)+1)
+	for i, n := range colNames {
+		t1Cols[i] = fmt.Sprintf("t1.%s", tree.NameString(n))
+		t2Cols[i] = fmt.Sprintf("t2.%s", tree.NameString(n))
+		op := descpb.ExclusionConstraintOperatorType[c.Operators[i]]
+		on = append(on, fmt.Sprintf("%s %s %s", t1Cols[i], op, t2Cols[i]))
+	}
+	on = append(on, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(t1PK, ", "), strings.Join(t2PK, ", "),
+	))
+
+	where := ""
+	if c.Predicate != "" {
+		where = fmt.Sprintf(" WHERE %s", c.Predicate)
+	}
+	src := fmt.Sprintf("SELECT %s FROM [%d AS tbl]%s", strings.Join(projCols, ", "), srcTbl.GetID(), where)
+	return fmt.Sprintf(
+		`SELECT %[1]s, %[2]s FROM (%[3]s) AS t1 JOIN (%[3]s) AS t2 ON %[4]s LIMIT 1`,
+		strings.Join(t1Cols, ", "), // 1
+		strings.Join(t2Cols, ", "), // 2
+		src,                        // 3
+		strings.Join(on, " AND "),  // 4
+	), colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict with each other according to the given exclusion constraint.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	c *descpb.ExclusionConstraint,
+	ie sqlutil.InternalExecutor,
+	txn *kv.Txn,
+) error {
+	query, colNames, err := conflictingRowQuery(srcTable, c)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		c.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := ie.QueryRowEx(ctx, "validate exclusion constraint", txn,
+		sessiondata.NodeUserSessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		n := len(colNames)
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting rows.
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "could not create exclusion constraint %q", c.Name,
+				),
+				c.Name,
+			),
+			fmt.Sprintf(
+				"Key (%[1]s)=(%[2]s) conflicts with key (%[1]s)=(%[3]s).",
+				strings.Join(colNames, ", "),
+				strings.Join(valuesStr[:n], ", "),
+				strings.Join(valuesStr[n:], ", "),
+			),
+		)
+	}
+	return nil
+}
+
 func formatValues(colNames []string, values tree.Datums) string {
 	var pairs bytes.Buffer
 	for i := range values {
@@ -488,5 +615,79 @@ func checkMutationInput(
 		}
 		colIdx++
 	}
+
+	// The row-level security check, if any, follows the active checks. Unlike
+	// CHECK constraints, a NULL result is a violation.
+	ord := len(checks)
+	if tabDesc.GetRowLevelSecurity() {
+		if checkOrds.Contains(ord) {
+			if res, err := tree.GetBool(checkVals[colIdx]); err != nil {
+				return err
+			} else if !res {
+				return pgerror.Newf(pgcode.InsufficientPrivilege,
+					"new row violates row-level security policy for table %q", tabDesc.GetName())
+			}
+			colIdx++
+		}
+		ord++
+	}
+
+	// The checks of the domains of the columns follow. They are only built to
+	// report a violation.
+	for i, ok := checkOrds.Next(ord); ok; i, ok = checkOrds.Next(i + 1) {
+		if res, err := tree.GetBool(checkVals[colIdx]); err != nil {
+			return err
+		} else if !res && checkVals[colIdx] != tree.DNull {
+			domainChecks, err := makeDomainChecks(tabDesc)
+			if err != nil {
+				return err
+			}
+			if i-ord >= len(domainChecks) {
+				return errors.AssertionFailedf("unknown check constraint %d", i)
+			}
+			return domainChecks[i-ord].violationError()
+		}
+		colIdx++
+	}
 	return nil
 }
+
+// domainCheck is a check which enforces a constraint of the domain of a column
+// on the values written to the column. These checks are synthesized by the
+// optimizer table, after the CHECK constraints of the table.
+type domainCheck struct {
+	schemaexpr.DomainCheck
+	domain *types.T
+}
+
+// makeDomainChecks returns the checks which enforce the constraints of the
+// domains of the public columns of the table, in the order of the columns.
+func makeDomainChecks(desc catalog.TableDescriptor) ([]domainCheck, error) {
+	var checks []domainCheck
+	for _, col := range desc.PublicColumns() {
+		typ := col.GetType()
+		if !typ.IsDomain() {
+			continue
+		}
+		colChecks, err := schemaexpr.MakeDomainChecks(typ, &tree.ColumnItem{ColumnName: col.ColName()})
+		if err != nil {
+			return nil, err
+		}
+		for i := range colChecks {
+			checks = append(checks, domainCheck{DomainCheck: colChecks[i], domain: typ})
+		}
+	}
+	return checks, nil
+}
+
+// violationError returns the error for a value which violates the check.
+func (c *domainCheck) violationError() error {
+	name := c.domain.TypeMeta.Name.Basename()
+	if c.NotNull {
+		return pgerror.Newf(pgcode.NotNullViolation,
+			"domain %s does not allow null values", name)
+	}
+	return pgerror.WithConstraintName(pgerror.Newf(pgcode.CheckViolation,
+		"value for domain %s violates check constraint %q", name, c.Name,
+	), c.Name)
+}
@@ -173,6 +173,9 @@ func supportedNatively(spec *execinfrapb.ProcessorSpec) error {
 		if spec.Core.TableReader.IsCheck {
 			return errors.Newf("scrub table reader is unsupported in vectorized")
 		}
+		if !spec.Core.TableReader.IncrementalFrom.IsEmpty() {
+			return errors.Newf("incremental table reader is unsupported in vectorized")
+		}
 		return nil
 
 	case spec.Core.JoinReader != nil:
@@ -249,6 +252,7 @@ var (
 	errBackfillerWrap                 = errors.New("core.Backfiller is not supported (not an execinfra.RowSource)")
 	errCSVWriterWrap                  = errors.New("core.CSVWriter is not supported (not an execinfra.RowSource)")
 	errParquetWriterWrap              = errors.New("core.ParquetWriter is not supported (not an execinfra.RowSource)")
+	errExportManifestWriterWrap       = errors.New("core.ExportManifestWriter is not supported (not an execinfra.RowSource)")
 	errSamplerWrap                    = errors.New("core.Sampler is not supported (not an execinfra.RowSource)")
 	errSampleAggregatorWrap           = errors.New("core.SampleAggregator is not supported (not an execinfra.RowSource)")
 	errExperimentalWrappingProhibited = errors.New("wrapping for non-JoinReader and non-LocalPlanNode cores is prohibited in vectorize=experimental_always")
@@ -278,6 +282,8 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.ProcessorSp
 		return errCSVWriterWrap
 	case spec.Core.ParquetWriter != nil:
 		return errParquetWriterWrap
+	case spec.Core.ExportManifestWriter != nil:
+		return errExportManifestWriterWrap
 	case spec.Core.Sampler != nil:
 		return errSamplerWrap
 	case spec.Core.SampleAggregator != nil:
@@ -315,6 +321,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, spec *execinfrapb.ProcessorSp
 	case spec.Core.Filterer != nil:
 	case spec.Core.StreamIngestionData != nil:
 	case spec.Core.StreamIngestionFrontier != nil:
+	case spec.Core.ForeignScan != nil:
 	default:
 		return errors.AssertionFailedf("unexpected processor core %q", spec.Core)
 	}
@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -49,6 +50,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/persistedsqlstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/sslocal"
 	"github.com/cockroachdb/cockroach/pkg/sql/stmtdiagnostics"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -921,6 +923,7 @@ func (s *Server) newConnExecutorWithTxn(
 		txn.ReadTimestamp().GoTime(),
 		nil, /* historicalTimestamp */
 		roachpb.UnspecifiedUserPriority,
+		txn.IsolationLevel(),
 		tree.ReadWrite,
 		txn,
 		ex.transitionCtx)
@@ -986,6 +989,10 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		txnEv = txnRollback
 	}
 
+	// The goroutines of suspended portals need to finish before the
+	// connExecutor goes away.
+	ex.closePausedPortals(ctx)
+
 	if closeType == normalClose {
 		// We'll cleanup the SQL txn by creating a non-retriable (commit:true) event.
 		// This event is guaranteed to be accepted in every state.
@@ -1040,6 +1047,8 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		}
 	}
 
+	ex.listens.close()
+
 	if closeType != panicClose {
 		// Close all statements and prepared portals.
 		ex.extraTxnState.prepStmtsNamespace.resetToEmpty(
@@ -1272,6 +1281,11 @@ type connExecutor struct {
 
 		schemaChangerState SchemaChangerState
 
+		// deferredConstraints contains the modes of deferrable constraints set
+		// with SET CONSTRAINTS, and the pending violations of deferred
+		// constraints, which are checked when the transaction commits.
+		deferredConstraints deferredConstraintState
+
 		// shouldCollectTxnExecutionStats specifies whether the statements in
 		// this transaction should collect execution stats.
 		shouldCollectTxnExecutionStats bool
@@ -1383,6 +1397,10 @@ type connExecutor struct {
 	// going to find a suitable time to close the connection.
 	draining bool
 
+	// listens is the state of the LISTEN and UNLISTEN statements of the
+	// session.
+	listens listenState
+
 	// executorType is set to whether this executor is an ordinary executor which
 	// responds to user queries or an internal one.
 	executorType executorType
@@ -1519,6 +1537,8 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) err
 	ex.extraTxnState.schemaChangerState = SchemaChangerState{
 		mode: ex.sessionData().NewSchemaChangerMode,
 	}
+	ex.extraTxnState.deferredConstraints.reset()
+	ex.listens.reset()
 
 	for k := range ex.extraTxnState.schemaChangeJobRecords {
 		delete(ex.extraTxnState.schemaChangeJobRecords, k)
@@ -1742,6 +1762,7 @@ func (ex *connExecutor) execCmd(ctx context.Context) error {
 				0,  /* limit */
 				"", /* portalName */
 				ex.implicitTxn(),
+				false, /* pausable */
 			)
 			res = stmtRes
 
@@ -1792,6 +1813,17 @@ func (ex *connExecutor) execCmd(ctx context.Context) error {
 			}
 			ex.curStmtAST = portal.Stmt.AST
 
+			// If the portal is executed with a row count limit, set it up so
+			// that its execution is suspended when the limit is reached, if
+			// possible, and resumed by the next Execute message for the portal.
+			if tcmd.Limit > 0 && !portal.exhausted &&
+				(portal.pausablePortal == nil || portal.pausablePortal.finished()) &&
+				ex.canPausePortal(portal) {
+				portal.pausablePortal = ex.newPausablePortal(ctx)
+				ex.extraTxnState.prepStmtsNamespace.portals[portalName] = portal
+			}
+			pausable := portal.pausablePortal != nil && !portal.pausablePortal.finished()
+
 			pinfo := &tree.PlaceholderInfo{
 				PlaceholderTypesInfo: tree.PlaceholderTypesInfo{
 					TypeHints: portal.Stmt.TypeHints,
@@ -1811,6 +1843,7 @@ func (ex *connExecutor) execCmd(ctx context.Context) error {
 				tcmd.Limit,
 				portalName,
 				ex.implicitTxn(),
+				pausable,
 			)
 			res = stmtRes
 			ev, payload, err = ex.execPortal(ctx, portal, portalName, stmtRes, pinfo)
@@ -1854,7 +1887,9 @@ func (ex *connExecutor) execCmd(ctx context.Context) error {
 		payload = eventNonRetriableErrPayload{err: tcmd.Err}
 	case Sync:
 		// Note that the Sync result will flush results to the network connection.
-		res = ex.clientComm.CreateSyncResult(pos)
+		syncRes := ex.clientComm.CreateSyncResult(pos)
+		ex.bufferNotifications(syncRes)
+		res = syncRes
 		if ex.draining {
 			// If we're draining, check whether this is a good time to finish the
 			// connection. If we're not inside a transaction, we stop processing
@@ -1874,6 +1909,13 @@ func (ex *connExecutor) execCmd(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+	case CopyOut:
+		res = ex.clientComm.CreateCopyOutResult(pos)
+		var err error
+		ev, payload, err = ex.execCopyOut(ctx, tcmd)
+		if err != nil {
+			return err
+		}
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -1887,6 +1929,11 @@ func (ex *connExecutor) execCmd(ctx context.Context) error {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case SendNotifications:
+		// Closing the res will flush the notifications to the client.
+		notificationRes := ex.clientComm.CreateNotificationResult(pos)
+		ex.bufferNotifications(notificationRes)
+		res = notificationRes
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2067,12 +2114,14 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				canAdvance = true
 			case Sync:
 				canAdvance = true
-			case CopyIn:
+			case CopyIn, CopyOut:
 				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case SendNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -2233,6 +2282,41 @@ func (ex *connExecutor) execCopyIn(
 	return nil, nil, nil
 }
 
+// execCopyOut handles the CopyTo statement like execCopyIn handles CopyFrom: the
+// connection is handed over until the CommandComplete message has been sent.
+// The query of the statement runs in the session's transaction, if there is
+// one open, or else in a transaction of its own.
+func (ex *connExecutor) execCopyOut(
+	ctx context.Context, cmd CopyOut,
+) (fsm.Event, fsm.EventPayload, error) {
+	// When we're done, unblock the network connection.
+	defer cmd.CopyDone.Done()
+
+	state := ex.machine.CurState()
+	_, isNoTxn := state.(stateNoTxn)
+	_, isOpen := state.(stateOpen)
+	if !isNoTxn && !isOpen {
+		ev := eventNonRetriableErr{IsCommit: fsm.False}
+		payload := eventNonRetriableErrPayload{
+			err: sqlerrors.NewTransactionAbortedError("" /* customMsg */)}
+		return ev, payload, nil
+	}
+
+	var txn *kv.Txn
+	if isOpen {
+		txn = ex.state.mu.txn
+	}
+	ie := ex.server.cfg.InternalExecutorFactory(ctx, ex.sessionData())
+	if err := runCopyOut(ctx, ie, txn, cmd, ex.sessionData()); err != nil {
+		// As in execCopyIn, all errors are treated as query errors and abort the
+		// txn (if any).
+		ev := eventNonRetriableErr{IsCommit: fsm.False}
+		payload := eventNonRetriableErrPayload{err: err}
+		return ev, payload, nil
+	}
+	return nil, nil, nil
+}
+
 // stmtHasNoData returns true if describing a result of the input statement
 // type should return NoData.
 func stmtHasNoData(stmt tree.Statement) bool {
@@ -2373,9 +2457,10 @@ func (ex *connExecutor) setTransactionModes(
 			return err
 		}
 	}
-	if modes.Isolation != tree.UnspecifiedIsolation && modes.Isolation != tree.SerializableIsolation {
-		return errors.AssertionFailedf(
-			"unknown isolation level: %s", errors.Safe(modes.Isolation))
+	if modes.Isolation != tree.UnspecifiedIsolation {
+		if err := ex.state.setIsolationLevel(ex.txnIsolationLevelToKV(ex.Ctx(), modes.Isolation)); err != nil {
+			return err
+		}
 	}
 	rwMode := modes.ReadWriteMode
 	if modes.AsOf.Expr != nil && asOfTs.IsEmpty() {
@@ -2417,6 +2502,41 @@ func (ex *connExecutor) txnPriorityWithSessionDefault(mode tree.UserPriority) ro
 	return txnPriorityToProto(mode)
 }
 
+// txnIsolationLevelToKV maps a SQL isolation level to the isolation level that
+// the KV transaction will run with. READ COMMITTED is upgraded to SERIALIZABLE
+// unless it is enabled through the sql.txn.read_committed_isolation.enabled
+// cluster setting and all nodes understand the isolation level of
+// transactions.
+func (ex *connExecutor) txnIsolationLevelToKV(
+	ctx context.Context, level tree.IsolationLevel,
+) enginepb.IsolationLevel {
+	switch level {
+	case tree.SerializableIsolation, tree.UnspecifiedIsolation:
+		return enginepb.Serializable
+	case tree.ReadCommittedIsolation:
+		// Nodes running an older version ignore the isolation level of the
+		// transaction record and would evaluate the transaction as
+		// SERIALIZABLE, so the upgrade is only done once all nodes support it.
+		if readCommittedIsolationEnabled.Get(&ex.server.cfg.Settings.SV) &&
+			ex.server.cfg.Settings.Version.IsActive(ctx, clusterversion.ReadCommittedIsolation) {
+			return enginepb.ReadCommitted
+		}
+		return enginepb.Serializable
+	default:
+		log.Fatalf(ctx, "unknown isolation level: %s", level)
+		return enginepb.Serializable
+	}
+}
+
+func (ex *connExecutor) txnIsolationLevelWithSessionDefault(
+	ctx context.Context, level tree.IsolationLevel,
+) enginepb.IsolationLevel {
+	if level == tree.UnspecifiedIsolation {
+		level = tree.IsolationLevel(ex.sessionData().DefaultTxnIsolationLevel)
+	}
+	return ex.txnIsolationLevelToKV(ctx, level)
+}
+
 func (ex *connExecutor) readWriteModeWithSessionDefault(
 	mode tree.ReadWriteMode,
 ) tree.ReadWriteMode {
@@ -2502,6 +2622,17 @@ func (ex *connExecutor) resetEvalCtx(evalCtx *extendedEvalContext, txn *kv.Txn,
 	evalCtx.PrepareOnly = false
 	evalCtx.SkipNormalize = false
 	evalCtx.SchemaChangerState = &ex.extraTxnState.schemaChangerState
+	// Statements run by the internal executor don't commit the transaction in
+	// which they run, so their constraints are always checked immediately.
+	evalCtx.DeferredConstraints = nil
+	if ex.executorType != executorTypeInternal {
+		evalCtx.DeferredConstraints = &ex.extraTxnState.deferredConstraints
+	}
+	// Internal executor sessions can't receive notifications.
+	evalCtx.Listens = nil
+	if ex.executorType != executorTypeInternal {
+		evalCtx.Listens = &ex.listens
+	}
 
 	// If we are retrying due to an unsatisfiable timestamp bound which is
 	// retriable, it means we were unable to serve the previous minimum timestamp
@@ -2567,6 +2698,7 @@ func (ex *connExecutor) resetPlanner(
 	p.semaCtx.DateStyleEnabled = ex.sessionData().DateStyleEnabled
 	p.semaCtx.Annotations = nil
 	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 	p.semaCtx.TableNameResolver = p
 	p.semaCtx.DateStyle = ex.sessionData().GetDateStyle()
 	p.semaCtx.IntervalStyle = ex.sessionData().GetIntervalStyle()
@@ -2595,6 +2727,10 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 		txnIsOpen = true
 	}
 
+	// The flows of suspended portals use the transaction, so they are closed
+	// before the transaction changes state.
+	ex.closePausedPortals(ex.Ctx())
+
 	ex.mu.Lock()
 	err := ex.machine.ApplyWithPayload(withStatement(ex.Ctx(), ex.curStmtAST), ev, payload)
 	ex.mu.Unlock()
@@ -2715,6 +2851,8 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 		}
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionEndPostCommitJob, timeutil.Now())
 
+		ex.commitListens(ex.ctxHolder.connCtx)
+
 		fallthrough
 	case txnRestart, txnRollback:
 		if err := ex.resetExtraTxnState(ex.Ctx(), advInfo.txnEvent); err != nil {
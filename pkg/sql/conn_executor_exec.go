@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
@@ -111,6 +112,10 @@ func (ex *connExecutor) execStmt(
 		ev, payload = ex.execStmtInNoTxnState(ctx, ast)
 
 	case stateOpen:
+		execStmtInOpenState := ex.execStmtInOpenState
+		if pp, ok := res.(*pausablePortal); ok {
+			execStmtInOpenState = pp.execStmtInOpenState
+		}
 		if ex.server.cfg.Settings.CPUProfileType() == cluster.CPUProfileWithLabels {
 			remoteAddr := "internal"
 			if rAddr := ex.sessionData().RemoteAddr; rAddr != nil {
@@ -123,10 +128,10 @@ func (ex *connExecutor) execStmt(
 				"stmt.no.constants", formatStatementHideConstants(ast),
 			)
 			pprof.Do(ctx, labels, func(ctx context.Context) {
-				ev, payload, err = ex.execStmtInOpenState(ctx, parserStmt, prepared, pinfo, res)
+				ev, payload, err = execStmtInOpenState(ctx, parserStmt, prepared, pinfo, res)
 			})
 		} else {
-			ev, payload, err = ex.execStmtInOpenState(ctx, parserStmt, prepared, pinfo, res)
+			ev, payload, err = execStmtInOpenState(ctx, parserStmt, prepared, pinfo, res)
 		}
 		switch ev.(type) {
 		case eventNonRetriableErr:
@@ -213,9 +218,21 @@ func (ex *connExecutor) execPortal(
 		if portal.exhausted {
 			return nil, nil, nil
 		}
-		ev, payload, err = ex.execStmt(ctx, portal.Stmt.Statement, portal.Stmt, pinfo, stmtRes)
-		// Portal suspension is supported via a "side" state machine
-		// (see pgwire.limitedCommandResult for details), so when
+		var res RestrictedCommandResult = stmtRes
+		pp := portal.pausablePortal
+		if pp != nil && !pp.finished() {
+			// The execution of the portal can be suspended when it reaches
+			// the row count limit; it resumes producing rows into the result
+			// of this Execute command.
+			pp.res = stmtRes
+			res = pp
+		}
+		ev, payload, err = ex.execStmt(ctx, portal.Stmt.Statement, portal.Stmt, pinfo, res)
+		if pp != nil && pp.state == pausablePortalSuspended {
+			return ev, payload, err
+		}
+		// Portal suspension is otherwise supported via a "side" state
+		// machine (see pgwire.limitedCommandResult for details), so when
 		// execStmt returns, we know for sure that the portal has been
 		// executed to completion, thus, it is exhausted.
 		// Note that the portal is considered exhausted regardless of
@@ -349,13 +366,21 @@ func (ex *connExecutor) execStmtInOpenState(
 	}(ctx, res)
 
 	makeErrEvent := func(err error) (fsm.Event, fsm.EventPayload, error) {
+		ex.maybeRestartTxnAfterStmtRetryErr(ctx, err)
 		ev, payload := ex.makeErrEvent(err, ast)
 		return ev, payload, nil
 	}
 
 	p := &ex.planner
+	pp, _ := res.(*pausablePortal)
+	if pp != nil {
+		p = &pp.planner
+	} else {
+		// A pausable portal has a stats collector of its own, which was
+		// created when its execution started (see pausablePortal).
+		ex.statsCollector.Reset(ex.applicationStats, ex.phaseTimes)
+	}
 	stmtTS := ex.server.cfg.Clock.PhysicalTime()
-	ex.statsCollector.Reset(ex.applicationStats, ex.phaseTimes)
 	ex.resetPlanner(ctx, p, ex.state.mu.txn, stmtTS)
 	p.sessionDataMutatorIterator.paramStatusUpdater = res
 	p.noticeSender = res
@@ -504,11 +529,15 @@ func (ex *connExecutor) execStmtInOpenState(
 
 	case *tree.CommitTransaction:
 		// CommitTransaction is executed fully here; there's no plan for it.
+		// The suspended portals are closed first, since their flows use the
+		// transaction.
+		ex.closePausedPortals(ctx)
 		ev, payload := ex.commitSQLTransaction(ctx, ast, ex.commitSQLTransactionInternal)
 		return ev, payload, nil
 
 	case *tree.RollbackTransaction:
 		// RollbackTransaction is executed fully here; there's no plan for it.
+		ex.closePausedPortals(ctx)
 		ev, payload := ex.rollbackSQLTransaction(ctx, s)
 		return ev, payload, nil
 
@@ -680,6 +709,11 @@ func (ex *connExecutor) execStmtInOpenState(
 	if err := ex.state.mu.txn.Step(ctx); err != nil {
 		return makeErrEvent(err)
 	}
+	if pp != nil {
+		// The portal keeps reading at this snapshot when it is resumed after
+		// other statements have been executed.
+		pp.pinReadSeqNum()
+	}
 
 	if err := p.semaCtx.Placeholders.Assign(pinfo, stmt.NumPlaceholders); err != nil {
 		return makeErrEvent(err)
@@ -697,7 +731,12 @@ func (ex *connExecutor) execStmtInOpenState(
 		ctx, stmtThresholdSpan = createRootOrChildSpan(ctx, "trace-stmt-threshold", ex.transitionCtx.tracer, tracing.WithRecording(tracing.RecordingVerbose))
 	}
 
-	if err := ex.dispatchToExecutionEngine(ctx, p, res); err != nil {
+	dispatch := ex.dispatchToExecutionEngine
+	if ex.executorType != executorTypeInternal &&
+		ex.state.mu.txn.IsolationLevel().PerStatementReadSnapshot() {
+		dispatch = ex.dispatchReadCommittedStmtToExecutionEngine
+	}
+	if err := dispatch(ctx, p, res); err != nil {
 		stmtThresholdSpan.Finish()
 		return nil, nil, err
 	}
@@ -809,6 +848,7 @@ func (ex *connExecutor) commitSQLTransaction(
 ) (fsm.Event, fsm.EventPayload) {
 	ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartTransactionCommit, timeutil.Now())
 	if err := commitFn(ctx, ast); err != nil {
+		ex.maybeRestartTxnAfterStmtRetryErr(ctx, err)
 		return ex.makeErrEvent(err, ast)
 	}
 	ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndTransactionCommit, timeutil.Now())
@@ -860,6 +900,10 @@ func (ex *connExecutor) reportSessionDataChanges(fn func() error) error {
 func (ex *connExecutor) commitSQLTransactionInternal(
 	ctx context.Context, ast tree.Statement,
 ) error {
+	if err := ex.checkDeferredConstraints(ctx); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -909,6 +953,18 @@ func (ex *connExecutor) createJobs(ctx context.Context) error {
 	return nil
 }
 
+// maybeRestartTxnAfterStmtRetryErr restarts the transaction if err is a
+// retryable error which only prepared the transaction for retrying the
+// statement that hit it. Such errors are returned when the statement could
+// not be retried on its own, in which case the whole transaction needs to be
+// restarted instead.
+func (ex *connExecutor) maybeRestartTxnAfterStmtRetryErr(ctx context.Context, err error) {
+	if retryErr := (*roachpb.TransactionRetryWithProtoRefreshError)(nil); errors.As(err, &retryErr) &&
+		retryErr.StatementRetry {
+		ex.state.mu.txn.ManualRestart(ctx, retryErr.Transaction.WriteTimestamp)
+	}
+}
+
 // rollbackSQLTransaction executes a ROLLBACK statement: the KV transaction is
 // rolled-back and an event is produced.
 func (ex *connExecutor) rollbackSQLTransaction(
@@ -927,6 +983,62 @@ func (ex *connExecutor) rollbackSQLTransaction(
 	return eventTxnFinishAborted{}, nil
 }
 
+// maxReadCommittedStmtRetries is the number of times a statement in a READ
+// COMMITTED transaction is retried before its retryable error is returned.
+const maxReadCommittedStmtRetries = 10
+
+// dispatchReadCommittedStmtToExecutionEngine is like dispatchToExecutionEngine,
+// but for transactions that take a new read snapshot for every statement. The
+// statement is executed against a fresh snapshot and, if it hits a retryable
+// error that only requires the statement to be retried, its effects are rolled
+// back and it is executed again against a newer snapshot. Retrying is only
+// possible as long as none of the statement's results have been delivered to
+// the client.
+func (ex *connExecutor) dispatchReadCommittedStmtToExecutionEngine(
+	ctx context.Context, planner *planner, res RestrictedCommandResult,
+) error {
+	txn := ex.state.mu.txn
+	for attempt := 0; ; attempt++ {
+		if err := txn.StepReadTimestamp(ctx); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		sp, err := txn.CreateSavepoint(ctx)
+		if err != nil {
+			res.SetError(err)
+			return nil
+		}
+		if err := ex.dispatchToExecutionEngine(ctx, planner, res); err != nil {
+			return err
+		}
+		retryErr := (*roachpb.TransactionRetryWithProtoRefreshError)(nil)
+		if !errors.As(res.Err(), &retryErr) || !retryErr.StatementRetry {
+			if res.Err() == nil {
+				if err := txn.ReleaseSavepoint(ctx, sp); err != nil {
+					res.SetError(err)
+				}
+			}
+			return nil
+		}
+		if attempt >= maxReadCommittedStmtRetries || !res.TruncateForRetry(ctx) {
+			// The error is returned to the client and the whole transaction
+			// is retried, if it can be.
+			return nil
+		}
+		log.VEventf(ctx, 2, "retrying statement after error: %v", retryErr)
+		if err := txn.RollbackToSavepoint(ctx, sp); err != nil {
+			res.SetError(err)
+			return nil
+		}
+		// Re-establish the sequencing point so that the retried statement does
+		// not observe the writes that were rolled back.
+		if err := txn.Step(ctx); err != nil {
+			res.SetError(err)
+			return nil
+		}
+	}
+}
+
 // dispatchToExecutionEngine executes the statement, writes the result to res
 // and returns an event for the connection's state machine.
 //
@@ -1489,6 +1601,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		return eventStartExplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
+				ex.txnIsolationLevelWithSessionDefault(ctx, s.Modes.Isolation),
 				mode,
 				sqlTs,
 				historicalTs,
@@ -1509,6 +1622,7 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		return eventStartImplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+				ex.txnIsolationLevelWithSessionDefault(ctx, tree.UnspecifiedIsolation),
 				mode,
 				sqlTs,
 				historicalTs,
@@ -519,6 +519,9 @@ func (ex *connExecutor) deletePortal(ctx context.Context, name string) {
 	if !ok {
 		return
 	}
+	if portal.pausablePortal != nil {
+		portal.pausablePortal.close(ctx)
+	}
 	portal.close(ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc, name)
 	delete(ex.extraTxnState.prepStmtsNamespace.portals, name)
 }
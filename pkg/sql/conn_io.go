@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -332,6 +333,26 @@ func (CopyIn) String() string {
 
 var _ Command = CopyIn{}
 
+// CopyOut is the command for execution of the Copy-out pgwire subprotocol.
+type CopyOut struct {
+	Stmt *tree.CopyTo
+	// Conn is the network connection. Execution of the CopyTo statement takes
+	// control of the connection.
+	Conn pgwirebase.Conn
+	// CopyDone is decremented once execution finishes, signaling that control of
+	// the connection is being handed back to the network routine.
+	CopyDone *sync.WaitGroup
+}
+
+// command implements the Command interface.
+func (CopyOut) command() string { return "copy out" }
+
+func (CopyOut) String() string {
+	return "CopyOut"
+}
+
+var _ Command = CopyOut{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -347,6 +368,24 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// SendNotifications is a command that, upon execution, sends the pending
+// notifications of the channels the session listens on to the client. It is
+// pushed by the session's subscription when notifications arrive, so that they
+// are delivered to idle clients.
+//
+// Notifications are only sent outside of transactions; otherwise they are sent
+// by the next Sync executed outside of a transaction.
+type SendNotifications struct{}
+
+// command implements the Command interface.
+func (SendNotifications) command() string { return "send notifications" }
+
+func (SendNotifications) String() string {
+	return "SendNotifications"
+}
+
+var _ Command = SendNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -587,6 +626,10 @@ type ClientComm interface {
 	// It should be nil if statement type != Rows. Otherwise, it can be nil, in
 	// which case every column will be encoded using the text encoding, otherwise
 	// it needs to contain a value for every column.
+	//
+	// pausable specifies if the result is used to execute a portal whose
+	// execution can be suspended when it reaches the row count limit, in which
+	// case AddRow returns ErrPortalSuspended when the limit is reached.
 	CreateStatementResult(
 		stmt tree.Statement,
 		descOpt RowDescOpt,
@@ -597,6 +640,7 @@ type ClientComm interface {
 		limit int,
 		portalName string,
 		implicitTxn bool,
+		pausable bool,
 	) CommandResult
 	// CreatePrepareResult creates a result for a PrepareStmt command.
 	CreatePrepareResult(pos CmdPos) ParseResult
@@ -617,8 +661,13 @@ type ClientComm interface {
 	CreateEmptyQueryResult(pos CmdPos) EmptyQueryResult
 	// CreateCopyInResult creates a result for a Copy-in command.
 	CreateCopyInResult(pos CmdPos) CopyInResult
+	// CreateCopyOutResult creates a result for a Copy-out command.
+	CreateCopyOutResult(pos CmdPos) CopyOutResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// CreateNotificationResult creates a result for a SendNotifications
+	// command.
+	CreateNotificationResult(pos CmdPos) NotificationResult
 
 	// lockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -741,6 +790,12 @@ type RestrictedCommandResult interface {
 	// to this CommandResult, will be flushed immediately to the client.
 	// This is currently used for sinkless changefeeds.
 	DisableBuffering()
+
+	// TruncateForRetry discards everything accumulated by the result so far,
+	// including its error, so that the statement can be executed again. It
+	// returns false, leaving the result untouched, if some of the result has
+	// already been delivered to the client.
+	TruncateForRetry(ctx context.Context) bool
 }
 
 // DescribeResult represents the result of a Describe command (for either
@@ -786,6 +841,14 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+
+	// BufferNotification buffers a notification to be sent to the client
+	// before the readyForQuery message.
+	BufferNotification(notification pgnotify.Notification)
+
+	// BufferNotice buffers a notice to be sent to the client before the
+	// readyForQuery message.
+	BufferNotice(notice pgnotice.Notice)
 }
 
 // FlushResult represents the result of a Flush command. When this result is
@@ -800,6 +863,20 @@ type DrainResult interface {
 	ResultBase
 }
 
+// NotificationResult represents the result of a SendNotifications command.
+// When closed, the buffered notifications are flushed to the client.
+type NotificationResult interface {
+	ResultBase
+
+	// BufferNotification buffers a notification to be sent to the client when
+	// the result is closed.
+	BufferNotification(notification pgnotify.Notification)
+
+	// BufferNotice buffers a notice to be sent to the client when the result
+	// is closed.
+	BufferNotice(notice pgnotice.Notice)
+}
+
 // EmptyQueryResult represents the result of an empty query (a query
 // representing a blank string).
 type EmptyQueryResult interface {
@@ -812,6 +889,12 @@ type CopyInResult interface {
 	ResultBase
 }
 
+// CopyOutResult represents the result of a CopyOut command. Closing this result
+// produces no output for the client.
+type CopyOutResult interface {
+	ResultBase
+}
+
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
 // represents a lock on the delivery of results to a SQL client. While such a
 // lock is used, no more results are delivered. The lock itself can be used to
@@ -909,6 +992,9 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	panic("unimplemented")
 }
 
+// BufferNotification is part of the SyncResult interface.
+func (r *streamingCommandResult) BufferNotification(pgnotify.Notification) {}
+
 // ResetStmtType is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) ResetStmtType(stmt tree.Statement) {
 	panic("unimplemented")
@@ -940,6 +1026,12 @@ func (r *streamingCommandResult) DisableBuffering() {
 	panic("cannot disable buffering here")
 }
 
+// TruncateForRetry is part of the RestrictedCommandResult interface.
+func (r *streamingCommandResult) TruncateForRetry(context.Context) bool {
+	// The rows have already been handed off to the consumer.
+	return false
+}
+
 // SetError is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) SetError(err error) {
 	r.err = err
@@ -0,0 +1,180 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// runCopyOut implements the Copy-out pgwire subprotocol (COPY ... TO STDOUT).
+// The query of the statement is run through ie, within txn if it is set, and
+// its rows are sent to the client as they are produced. As for COPY FROM, all
+// the protocol messages, including CommandComplete, are sent on the connection
+// by runCopyOut. Errors however are not sent; the higher layer is responsible
+// for sending them.
+//
+// See: https://www.postgresql.org/docs/current/static/sql-copy.html
+// and: https://www.postgresql.org/docs/current/static/protocol-flow.html#PROTOCOL-COPY
+func runCopyOut(
+	ctx context.Context,
+	ie sqlutil.InternalExecutor,
+	txn *kv.Txn,
+	cmd CopyOut,
+	sd *sessiondata.SessionData,
+) (retErr error) {
+	opts, err := makeCopyOutOptions(&cmd.Stmt.Options, sd)
+	if err != nil {
+		return err
+	}
+	query, err := copyOutQuery(cmd.Stmt)
+	if err != nil {
+		return err
+	}
+
+	it, err := ie.QueryIteratorEx(
+		ctx, "copy-out", txn, sessiondata.NoSessionDataOverride, query,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		retErr = errors.CombineErrors(retErr, it.Close())
+	}()
+
+	// The columns of the result are only known once the iterator has been
+	// advanced.
+	ok, err := it.Next(ctx)
+	if err != nil {
+		return err
+	}
+	cols := it.Types()
+	typs := make([]*types.T, len(cols))
+	for i := range cols {
+		typs[i] = cols[i].Typ
+	}
+	if err := cmd.Conn.BeginCopyOut(ctx, cols, opts); err != nil {
+		return err
+	}
+	var numRows int64
+	for ; ok; ok, err = it.Next(ctx) {
+		if err := cmd.Conn.SendCopyOutRow(ctx, it.Cur(), typs, opts); err != nil {
+			return err
+		}
+		numRows++
+	}
+	if err != nil {
+		return err
+	}
+	if err := cmd.Conn.SendCopyDone(ctx, opts); err != nil {
+		return err
+	}
+
+	// Finalize execution by sending the statement tag and number of rows
+	// copied.
+	tag := []byte(cmd.Stmt.StatementTag())
+	tag = append(tag, ' ')
+	tag = strconv.AppendInt(tag, numRows, 10 /* base */)
+	return cmd.Conn.SendCommandComplete(tag)
+}
+
+// makeCopyOutOptions returns the options describing how the rows of a COPY TO
+// statement are encoded.
+func makeCopyOutOptions(
+	o *tree.CopyOptions, sd *sessiondata.SessionData,
+) (*pgwirebase.CopyOutOptions, error) {
+	opts := &pgwirebase.CopyOutOptions{
+		Format:   o.CopyFormat,
+		Conv:     sd.DataConversionConfig,
+		Location: sd.GetLocation(),
+	}
+	switch o.CopyFormat {
+	case tree.CopyFormatText:
+		opts.Null = `\N`
+		opts.Delimiter = '\t'
+	case tree.CopyFormatCSV:
+		opts.Null = ""
+		opts.Delimiter = ','
+	}
+
+	if o.Destination != nil {
+		return nil, errors.Newf("DESTINATION unsupported in COPY TO")
+	}
+	if o.Delimiter != nil {
+		if o.CopyFormat == tree.CopyFormatBinary {
+			return nil, errors.Newf("DELIMITER unsupported in BINARY format")
+		}
+		delim, err := copyOutStringOption(o.Delimiter, "DELIMITER")
+		if err != nil {
+			return nil, err
+		}
+		if len(delim) != 1 || !utf8.ValidString(delim) {
+			return nil, errors.Newf("delimiter must be a single-byte character")
+		}
+		opts.Delimiter = delim[0]
+	}
+	if o.Null != nil {
+		if o.CopyFormat == tree.CopyFormatBinary {
+			return nil, errors.Newf("NULL unsupported in BINARY format")
+		}
+		null, err := copyOutStringOption(o.Null, "NULL")
+		if err != nil {
+			return nil, err
+		}
+		opts.Null = null
+	}
+	return opts, nil
+}
+
+// copyOutStringOption returns the value of a string option of a COPY TO
+// statement. COPY is not supported in the extended protocol, so the option
+// can't be a placeholder.
+func copyOutStringOption(e tree.Expr, name string) (string, error) {
+	s, ok := e.(*tree.StrVal)
+	if !ok {
+		return "", errors.Newf("%s must be a string literal", name)
+	}
+	return s.RawString(), nil
+}
+
+// copyOutQuery returns the query producing the rows copied by a COPY TO
+// statement.
+func copyOutQuery(n *tree.CopyTo) (string, error) {
+	if n.Statement != nil {
+		if n.Statement.StatementReturnType() != tree.Rows {
+			return "", pgerror.Newf(pgcode.FeatureNotSupported,
+				"COPY query must return rows, found %s", n.Statement.StatementTag())
+		}
+		return tree.AsStringWithFlags(n.Statement, tree.FmtParsable), nil
+	}
+	sel := &tree.SelectClause{
+		Exprs: tree.SelectExprs{tree.StarSelectExpr()},
+		From:  tree.From{Tables: tree.TableExprs{&n.Table}},
+	}
+	if len(n.Columns) > 0 {
+		sel.Exprs = make(tree.SelectExprs, len(n.Columns))
+		for i := range n.Columns {
+			sel.Exprs[i].Expr = &tree.ColumnItem{ColumnName: n.Columns[i]}
+		}
+	}
+	return tree.AsStringWithFlags(&tree.Select{Select: sel}, tree.FmtParsable), nil
+}
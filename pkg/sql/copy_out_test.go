@@ -0,0 +1,111 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/tests"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyOut(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	params, _ := tests.CreateTestServerParams()
+	s, db, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (id INT PRIMARY KEY, s STRING, f FLOAT, n INT)`)
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, e'a\tb\\c', 1.5, NULL), (2, 'x,"y"', -2, 3)`)
+
+	pgURL, cleanupGoDB := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "StartServer" /* prefix */, url.User(security.RootUser))
+	defer cleanupGoDB()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	copyOut := func(t *testing.T, stmt string) string {
+		var buf bytes.Buffer
+		tag, err := conn.PgConn().CopyTo(ctx, &buf, stmt)
+		require.NoError(t, err)
+		require.Equal(t, "COPY", string(bytes.Fields(tag)[0]))
+		return buf.String()
+	}
+
+	t.Run("text", func(t *testing.T) {
+		require.Equal(t,
+			"1\ta\\tb\\\\c\t1.5\t\\N\n2\tx,\"y\"\t-2\t3\n",
+			copyOut(t, `COPY t TO STDOUT`),
+		)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		require.Equal(t,
+			"1,a\tb\\c,1.5,\n2,\"x,\"\"y\"\"\",-2,3\n",
+			copyOut(t, `COPY t TO STDOUT CSV`),
+		)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		require.Equal(t,
+			"1;NUL\n2;3\n",
+			copyOut(t, `COPY t (id, n) TO STDOUT WITH CSV DELIMITER ';' NULL 'NUL'`),
+		)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		out := copyOut(t, `COPY t (id) TO STDOUT BINARY`)
+		const header = "PGCOPY\n\377\r\n\000" + "\x00\x00\x00\x00" + "\x00\x00\x00\x00"
+		const tuple = "\x00\x01" + "\x00\x00\x00\x08"
+		require.Equal(t,
+			header+
+				tuple+"\x00\x00\x00\x00\x00\x00\x00\x01"+
+				tuple+"\x00\x00\x00\x00\x00\x00\x00\x02"+
+				"\xff\xff",
+			out,
+		)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		require.Equal(t,
+			"10\n20\n",
+			copyOut(t, `COPY (SELECT id * 10 FROM t ORDER BY id) TO STDOUT`),
+		)
+
+		var buf bytes.Buffer
+		_, err := conn.PgConn().CopyTo(ctx, &buf, `COPY (INSERT INTO t VALUES (3)) TO STDOUT`)
+		require.Regexp(t, "COPY query must return rows", err)
+	})
+
+	t.Run("txn", func(t *testing.T) {
+		_, err := conn.Exec(ctx, `BEGIN`)
+		require.NoError(t, err)
+		_, err = conn.Exec(ctx, `INSERT INTO t VALUES (3, 'z', 0, 0)`)
+		require.NoError(t, err)
+		require.Equal(t, "1\n2\n3\n", copyOut(t, `COPY t (id) TO STDOUT`))
+		_, err = conn.Exec(ctx, `ROLLBACK`)
+		require.NoError(t, err)
+		require.Equal(t, "1\n2\n", copyOut(t, `COPY t (id) TO STDOUT`))
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type createDomainNode struct {
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+	config   descpb.TypeDescriptor_DomainConfig
+}
+
+// CreateDomain creates a domain, a type descriptor which refers to a base type
+// and adds constraints to it.
+// Privileges: CREATE on database.
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.Domains) {
+		return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"CREATE DOMAIN requires all nodes to be upgraded to %s",
+			clusterversion.ByKey(clusterversion.Domains))
+	}
+
+	typeName, db, err := resolveNewTypeName(p.RunParams(ctx), n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	n.TypeName.SetAnnotation(&p.semaCtx.Annotations, typeName)
+
+	baseType, err := tree.ResolveType(ctx, n.BaseType, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return nil, err
+	}
+	// Domains over user defined types would need to track changes to these
+	// types, like the implicit array types of enums.
+	if baseType.UserDefined() || baseType.IsDomain() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"domains over user defined type %s are not supported", baseType.SQLString())
+	}
+	if err := colinfo.ValidateColumnDefType(baseType); err != nil {
+		return nil, err
+	}
+
+	config := descpb.TypeDescriptor_DomainConfig{
+		BaseType: baseType,
+		NotNull:  n.NotNull,
+	}
+	if n.Default != nil {
+		typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+			ctx, n.Default, baseType, "DEFAULT", p.SemaCtx(), tree.VolatilityVolatile,
+		)
+		if err != nil {
+			return nil, err
+		}
+		defaultExpr := tree.Serialize(typedExpr)
+		config.DefaultExpr = &defaultExpr
+	}
+
+	// Unnamed CHECK constraints are named after the domain, like in Postgres.
+	usedNames := make(map[string]struct{}, len(n.Checks))
+	for i := range n.Checks {
+		if name := string(n.Checks[i].Name); name != "" {
+			if _, ok := usedNames[name]; ok {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"constraint %q for domain %q already exists", name, typeName.Type())
+			}
+			usedNames[name] = struct{}{}
+		}
+	}
+	for i := range n.Checks {
+		name := string(n.Checks[i].Name)
+		if name == "" {
+			name = typeName.Type() + "_check"
+			for j := 1; ; j++ {
+				if _, ok := usedNames[name]; !ok {
+					break
+				}
+				name = typeName.Type() + "_check" + strconv.Itoa(j)
+			}
+			usedNames[name] = struct{}{}
+		}
+		expr, err := schemaexpr.ValidateDomainCheck(ctx, n.Checks[i].Expr, baseType, p.SemaCtx())
+		if err != nil {
+			return nil, err
+		}
+		config.Checks = append(config.Checks, descpb.TypeDescriptor_DomainConfig_Check{
+			Name: name,
+			Expr: expr,
+		})
+	}
+
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+		config:   config,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	schema, err := getCreateTypeParams(params, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+
+	id, err := catalogkv.GenerateUniqueDescID(
+		params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec,
+	)
+	if err != nil {
+		return err
+	}
+
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		tree.Types,
+		n.dbDesc.GetPrivileges(),
+	)
+
+	// Domains don't have an implicit array type, so the type descriptor is
+	// created by itself.
+	typeDesc := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           n.typeName.Type(),
+		ID:             id,
+		ParentID:       n.dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		DomainConfig:   &n.config,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType()
+
+	if err := params.p.createDescriptorWithID(
+		params.ctx,
+		catalogkeys.MakeObjectNameKey(params.ExecCfg().Codec, n.dbDesc.GetID(), schema.GetID(), n.typeName.Type()),
+		id,
+		typeDesc,
+		params.EvalContext().Settings,
+		n.typeName.String(),
+	); err != nil {
+		return err
+	}
+
+	// Log the event.
+	return params.p.logEvent(params.ctx,
+		typeDesc.GetID(),
+		&eventpb.CreateType{
+			TypeName: n.typeName.FQString(),
+		})
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
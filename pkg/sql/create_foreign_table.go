@@ -0,0 +1,351 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+// The options of a foreign table. The names of the format options are the
+// same as the ones of IMPORT.
+const (
+	foreignTableLocation   = "location"
+	foreignTableFormat     = "format"
+	foreignTableDelimiter  = "delimiter"
+	foreignTableNullIf     = "nullif"
+	foreignTableSkip       = "skip"
+	foreignTableDecompress = "decompress"
+	foreignTableStrict     = "strict_validation"
+)
+
+type createForeignTableNode struct {
+	n            *tree.CreateForeignTable
+	dbDesc       catalog.DatabaseDescriptor
+	columnDefs   []*tree.ColumnTableDef
+	foreignTable descpb.ForeignTable
+}
+
+// CreateForeignTable creates a foreign table, whose rows are read from files
+// in the external storage of a foreign server instead of being stored in the
+// cluster.
+// Privileges: admin and CREATE on database.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	un := n.Table.ToUnresolvedObjectName()
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	// The rows of a foreign table are read from the external storage of its
+	// server on behalf of the cluster, and foreign servers have no privileges
+	// of their own, so only admins may choose which of its files are exposed.
+	if err := p.RequireAdminRole(ctx, "CREATE FOREIGN TABLE"); err != nil {
+		return nil, err
+	}
+
+	server, ok := dbDesc.GetForeignServer(string(n.Server))
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", n.Server)
+	}
+	foreignTable := descpb.ForeignTable{Server: server.Name}
+	for _, opt := range n.Options {
+		foreignTable.Options = append(foreignTable.Options, descpb.ForeignTable_Option{
+			Key:   string(opt.Key),
+			Value: opt.Value,
+		})
+	}
+	if _, _, err := makeForeignTableFormat(&foreignTable); err != nil {
+		return nil, err
+	}
+
+	// The rows of a foreign table are only read, so the table only has
+	// columns. The NOT NULL constraints are checked when the rows are read.
+	columnDefs := make([]*tree.ColumnTableDef, 0, len(n.Defs))
+	for _, def := range n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"foreign tables can only have columns, found %s", tree.AsString(def))
+		}
+		if d.IsSerial || d.GeneratedIdentity.IsGeneratedAsIdentity || d.Hidden ||
+			d.PrimaryKey.IsPrimaryKey || d.Unique.IsUnique || d.HasDefaultExpr() ||
+			d.HasOnUpdateExpr() || len(d.CheckExprs) > 0 || d.HasFKConstraint() ||
+			d.IsComputed() || d.HasColumnFamily() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q of a foreign table can only be NULL or NOT NULL", d.Name)
+		}
+		columnDefs = append(columnDefs, d)
+	}
+
+	return &createForeignTableNode{
+		n:            n,
+		dbDesc:       dbDesc,
+		columnDefs:   columnDefs,
+		foreignTable: foreignTable,
+	}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createForeignTableNode) ReadingOwnWrites() {}
+
+func (n *createForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("foreign_table"))
+
+	schema, err := getSchemaForCreateTable(params, n.dbDesc, tree.PersistencePermanent, &n.n.Table,
+		tree.ResolveRequireTableDesc, n.n.IfNotExists)
+	if err != nil {
+		if sqlerrors.IsRelationAlreadyExistsError(err) && n.n.IfNotExists {
+			return nil
+		}
+		return err
+	}
+
+	id, err := catalogkv.GenerateUniqueDescID(params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec)
+	if err != nil {
+		return err
+	}
+
+	privs := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		tree.Tables,
+		n.dbDesc.GetPrivileges(),
+	)
+
+	// creationTime is initialized to a zero value and populated at read time.
+	// See the comment in desc.MaybeIncrementVersion.
+	var creationTime hlc.Timestamp
+	desc := tabledesc.InitTableDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		schema.GetID(),
+		n.n.Table.Table(),
+		creationTime,
+		privs,
+		tree.PersistencePermanent,
+	)
+	desc.ForeignTable = &n.foreignTable
+	if n.dbDesc.IsMultiRegion() {
+		desc.SetTableLocalityRegionalByTable(tree.PrimaryRegionNotSpecifiedName)
+	}
+	for _, d := range n.columnDefs {
+		cdd, err := tabledesc.MakeColumnDefDescs(params.ctx, d, &params.p.semaCtx, params.EvalContext())
+		if err != nil {
+			return err
+		}
+		// The rows are read by the processors of the import readers, which don't
+		// have the user defined types.
+		if cdd.ColumnDescriptor.Type.UserDefined() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"columns of user defined type %s are not supported in foreign tables",
+				cdd.ColumnDescriptor.Type.SQLString())
+		}
+		desc.AddColumn(cdd.ColumnDescriptor)
+	}
+	if err := desc.AllocateIDs(params.ctx); err != nil {
+		return err
+	}
+
+	if err := params.p.createDescriptorWithID(
+		params.ctx,
+		catalogkeys.MakeObjectNameKey(params.ExecCfg().Codec, n.dbDesc.GetID(), schema.GetID(), n.n.Table.Table()),
+		id,
+		&desc,
+		params.EvalContext().Settings,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+
+	if err := validateDescriptor(params.ctx, params.p, &desc); err != nil {
+		return err
+	}
+
+	// Log Create Table event. This is an auditable log event and is
+	// recorded in the same transaction as the table descriptor update.
+	return params.p.logEvent(params.ctx,
+		desc.ID,
+		&eventpb.CreateTable{
+			TableName: n.n.Table.FQString(),
+		})
+}
+
+func (*createForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*createForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createForeignTableNode) Close(context.Context)        {}
+
+// makeForeignTableFormat returns the format of the files of a foreign table
+// and their location, relative to the URI of the server of the table. It
+// returns an error if the options of the table are invalid.
+func makeForeignTableFormat(t *descpb.ForeignTable) (roachpb.IOFileFormat, string, error) {
+	var format roachpb.IOFileFormat
+	opts := make(map[string]string, len(t.Options))
+	for _, opt := range t.Options {
+		if _, ok := opts[opt.Key]; ok {
+			return format, "", pgerror.Newf(pgcode.DuplicateObject,
+				"option %q provided more than once", opt.Key)
+		}
+		opts[opt.Key] = opt.Value
+	}
+
+	location, ok := opts[foreignTableLocation]
+	if !ok {
+		return format, "", pgerror.Newf(pgcode.FdwOptionNameNotFound,
+			"option %q is required", foreignTableLocation)
+	}
+	// The location is joined to the path of the server URI, so it must not be
+	// able to refer to files outside of it.
+	if cleaned := path.Clean(location); path.IsAbs(location) ||
+		cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return format, "", pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+			"option %q must be a path relative to the uri of the server, found %q",
+			foreignTableLocation, location)
+	}
+	formatName, ok := opts[foreignTableFormat]
+	if !ok {
+		return format, "", pgerror.Newf(pgcode.FdwOptionNameNotFound,
+			"option %q is required", foreignTableFormat)
+	}
+	switch strings.ToLower(formatName) {
+	case "csv":
+		format.Format = roachpb.IOFileFormat_CSV
+	case "avro":
+		format.Format = roachpb.IOFileFormat_Avro
+		format.Avro.Format = roachpb.AvroOptions_OCF
+	case "parquet":
+		format.Format = roachpb.IOFileFormat_Parquet
+	default:
+		return format, "", pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+			"unsupported format %q", formatName)
+	}
+
+	for _, opt := range t.Options {
+		key, value := opt.Key, opt.Value
+		isCSVOption := false
+		switch key {
+		case foreignTableLocation, foreignTableFormat:
+			continue
+
+		case foreignTableDelimiter:
+			isCSVOption = true
+			comma, err := util.GetSingleRune(value)
+			if err != nil {
+				return format, "", pgerror.Wrap(err, pgcode.FdwInvalidAttributeValue, "invalid comma value")
+			}
+			format.Csv.Comma = comma
+
+		case foreignTableNullIf:
+			isCSVOption = true
+			nullIf := value
+			format.Csv.NullEncoding = &nullIf
+
+		case foreignTableSkip:
+			isCSVOption = true
+			skip, err := strconv.Atoi(value)
+			if err != nil {
+				return format, "", pgerror.Wrapf(err, pgcode.FdwInvalidAttributeValue,
+					"invalid %s value", foreignTableSkip)
+			}
+			if skip < 0 {
+				return format, "", pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+					"%s must be >= 0", foreignTableSkip)
+			}
+			format.Csv.Skip = uint32(skip)
+
+		case foreignTableStrict:
+			strict, err := strconv.ParseBool(value)
+			if err != nil {
+				return format, "", pgerror.Wrapf(err, pgcode.FdwInvalidAttributeValue,
+					"invalid %s value", foreignTableStrict)
+			}
+			switch format.Format {
+			case roachpb.IOFileFormat_Avro:
+				format.Avro.StrictMode = strict
+			case roachpb.IOFileFormat_Parquet:
+				format.Parquet.StrictMode = strict
+			default:
+				return format, "", pgerror.Newf(pgcode.FdwInvalidOptionName,
+					"option %q is not supported by format %s", key, formatName)
+			}
+
+		case foreignTableDecompress:
+			found := false
+			for name, value := range roachpb.IOFileFormat_Compression_value {
+				if strings.EqualFold(name, opt.Value) {
+					format.Compression = roachpb.IOFileFormat_Compression(value)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return format, "", pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+					"unsupported compression value: %q", opt.Value)
+			}
+
+		default:
+			return format, "", pgerror.Newf(pgcode.FdwInvalidOptionName, "invalid option %q", key)
+		}
+		if isCSVOption && format.Format != roachpb.IOFileFormat_CSV {
+			return format, "", pgerror.Newf(pgcode.FdwInvalidOptionName,
+				"option %q is not supported by format %s", key, formatName)
+		}
+	}
+	return format, location, nil
+}
+
+// checkNotForeignTable returns an error if the given table is a foreign table.
+// It is used by the schema changes which don't apply to foreign tables, since
+// their rows are not stored in the cluster.
+func checkNotForeignTable(desc catalog.TableDescriptor) error {
+	if desc.IsForeignTable() {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is a foreign table", desc.GetName())
+	}
+	return nil
+}
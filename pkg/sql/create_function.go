@@ -0,0 +1,334 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type createFunctionNode struct {
+	// cf is the CREATE FUNCTION statement. The types of its arguments and its
+	// return type are resolved, and the data sources in its body are fully
+	// qualified.
+	cf     *tree.CreateFunction
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+	// deps contains the IDs of the relations referenced by the function body.
+	deps catalog.DescriptorIDSet
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FUNCTION performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createFunctionNode) ReadingOwnWrites() {}
+
+func (n *createFunctionNode) startExec(params runParams) error {
+	switch n.scDesc.SchemaKind() {
+	case catalog.SchemaUserDefined, catalog.SchemaPublic:
+	case catalog.SchemaTemporary:
+		return unimplemented.NewWithIssue(17511,
+			"user-defined functions cannot be created in temporary schemas")
+	default:
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"schema cannot be modified: %q", n.scDesc.GetName())
+	}
+	parent, err := params.p.getMutableFunctionParent(params.ctx, n.dbDesc.GetID(), n.scDesc.GetID())
+	if err != nil {
+		return err
+	}
+
+	name := n.cf.Name.Object()
+	args := make([]descpb.FunctionDescriptor_Argument, len(n.cf.Args))
+	argTypes := make([]*types.T, len(n.cf.Args))
+	for i := range n.cf.Args {
+		argTypes[i] = n.cf.Args[i].Type.(*types.T)
+		args[i] = descpb.FunctionDescriptor_Argument{
+			Name: string(n.cf.Args[i].Name),
+			Type: argTypes[i],
+		}
+	}
+	retType := n.cf.ReturnType.(*types.T)
+
+	// Look for an existing overload with the same argument types.
+	if fn, ok := parent.getFunction(name); ok {
+		for _, o := range fn.Overloads {
+			fnDesc, err := params.p.Descriptors().GetMutableFunctionByID(
+				params.ctx, params.p.txn, o.ID, tree.ObjectLookupFlags{},
+			)
+			if err != nil {
+				return err
+			}
+			if !typesIdentical(fnDesc.ArgTypes(), argTypes) {
+				continue
+			}
+			if !n.cf.Replace {
+				return pgerror.Newf(pgcode.DuplicateFunction,
+					"function %q already exists with same argument types", name)
+			}
+			if !fnDesc.ReturnType.Identical(retType) {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"cannot change return type of existing function")
+			}
+			hasOwnership, err := params.p.HasOwnership(params.ctx, fnDesc)
+			if err != nil {
+				return err
+			}
+			if !hasOwnership {
+				return pgerror.Newf(pgcode.InsufficientPrivilege, "must be owner of function %q", name)
+			}
+			fnDesc.Args = args
+			if err := setFunctionOptions(fnDesc, n.cf.Options); err != nil {
+				return err
+			}
+			if err := params.p.updateFunctionBackReferences(params.ctx, fnDesc, n.deps); err != nil {
+				return err
+			}
+			return params.p.Descriptors().WriteDesc(
+				params.ctx, params.extendedEvalCtx.Tracing.KVTracingEnabled(), fnDesc, params.p.txn,
+			)
+		}
+	}
+
+	id, err := catalogkv.GenerateUniqueDescID(params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec)
+	if err != nil {
+		return err
+	}
+	fnDesc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		n.scDesc.GetID(),
+		name,
+		args,
+		retType,
+		descpb.NewBasePrivilegeDescriptor(params.p.User()),
+	)
+	if err := setFunctionOptions(fnDesc, n.cf.Options); err != nil {
+		return err
+	}
+	if err := params.p.updateFunctionBackReferences(params.ctx, fnDesc, n.deps); err != nil {
+		return err
+	}
+	if err := params.p.Descriptors().WriteDesc(
+		params.ctx, params.extendedEvalCtx.Tracing.KVTracingEnabled(), fnDesc, params.p.txn,
+	); err != nil {
+		return err
+	}
+
+	parent.addFunction(name, descpb.SchemaDescriptor_FunctionOverload{ID: id})
+	return params.p.writeFunctionParentChange(
+		params.ctx, parent, fmt.Sprintf("updating %s for function %q", parent, name),
+	)
+}
+
+func (*createFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (*createFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createFunctionNode) Close(context.Context)        {}
+
+// setFunctionOptions applies the options of a CREATE FUNCTION statement to a
+// function descriptor. Options that are not specified are reset to their
+// default value.
+func setFunctionOptions(fnDesc *funcdesc.Mutable, options tree.FunctionOptions) error {
+	fnDesc.SetVolatility(descpb.FunctionDescriptor_VOLATILE)
+	fnDesc.SetLeakProof(false)
+	fnDesc.SetNullInputBehavior(descpb.FunctionDescriptor_CALLED_ON_NULL_INPUT)
+	for _, option := range options {
+		switch t := option.(type) {
+		case tree.FunctionVolatility:
+			switch t {
+			case tree.FunctionVolatile:
+				fnDesc.SetVolatility(descpb.FunctionDescriptor_VOLATILE)
+			case tree.FunctionStable:
+				fnDesc.SetVolatility(descpb.FunctionDescriptor_STABLE)
+			case tree.FunctionImmutable:
+				fnDesc.SetVolatility(descpb.FunctionDescriptor_IMMUTABLE)
+			}
+		case tree.FunctionLeakProof:
+			fnDesc.SetLeakProof(bool(t))
+		case tree.FunctionNullInputBehavior:
+			switch t {
+			case tree.FunctionCalledOnNullInput:
+				fnDesc.SetNullInputBehavior(descpb.FunctionDescriptor_CALLED_ON_NULL_INPUT)
+			case tree.FunctionReturnsNullOnNullInput, tree.FunctionStrict:
+				fnDesc.SetNullInputBehavior(descpb.FunctionDescriptor_RETURNS_NULL_ON_NULL_INPUT)
+			}
+		case tree.FunctionBody:
+			// The language was validated by the optimizer, and SQL is the only
+			// supported language.
+			fnDesc.SetFunctionBody(descpb.FunctionDescriptor_SQL, string(t))
+		}
+	}
+	return funcdesc.CheckLeakProofVolatility(fnDesc)
+}
+
+// functionParent is the descriptor holding the functions mapping of a schema.
+// This is the schema descriptor for user-defined schemas and for public schemas
+// backed by a descriptor, and the parent database descriptor for the synthetic
+// public schema, which has no descriptor of its own.
+type functionParent struct {
+	schema *schemadesc.Mutable
+	db     *dbdesc.Mutable
+}
+
+// getMutableFunctionParent returns the descriptor holding the functions
+// mapping of the schema with the given ID in the given database.
+func (p *planner) getMutableFunctionParent(
+	ctx context.Context, dbID, schemaID descpb.ID,
+) (functionParent, error) {
+	// TODO(richardjcai): Remove logic for keys.PublicSchemaID in 22.2.
+	if schemaID == keys.PublicSchemaID {
+		desc, err := p.Descriptors().GetMutableDescriptorByID(ctx, dbID, p.txn)
+		if err != nil {
+			return functionParent{}, err
+		}
+		db, ok := desc.(*dbdesc.Mutable)
+		if !ok {
+			return functionParent{}, errors.AssertionFailedf(
+				"expected mutable database descriptor, found %T", desc)
+		}
+		return functionParent{db: db}, nil
+	}
+	desc, err := p.Descriptors().GetMutableDescriptorByID(ctx, schemaID, p.txn)
+	if err != nil {
+		return functionParent{}, err
+	}
+	schema, ok := desc.(*schemadesc.Mutable)
+	if !ok {
+		return functionParent{}, errors.AssertionFailedf(
+			"expected mutable schema descriptor, found %T", desc)
+	}
+	return functionParent{schema: schema}, nil
+}
+
+func (fp functionParent) getFunction(name string) (descpb.SchemaDescriptor_Function, bool) {
+	if fp.db != nil {
+		return fp.db.GetPublicSchemaFunction(name)
+	}
+	return fp.schema.GetFunction(name)
+}
+
+func (fp functionParent) addFunction(name string, overload descpb.SchemaDescriptor_FunctionOverload) {
+	if fp.db != nil {
+		fp.db.AddPublicSchemaFunction(name, overload)
+		return
+	}
+	fp.schema.AddFunction(name, overload)
+}
+
+func (fp functionParent) removeFunction(name string, id descpb.ID) {
+	if fp.db != nil {
+		fp.db.RemovePublicSchemaFunction(name, id)
+		return
+	}
+	fp.schema.RemoveFunction(name, id)
+}
+
+// String implements the fmt.Stringer interface.
+func (fp functionParent) String() string {
+	if fp.db != nil {
+		return fmt.Sprintf("database %q", fp.db.GetName())
+	}
+	return fmt.Sprintf("schema %q", fp.schema.GetName())
+}
+
+// writeFunctionParentChange writes the descriptor holding the updated
+// functions mapping.
+func (p *planner) writeFunctionParentChange(
+	ctx context.Context, fp functionParent, jobDesc string,
+) error {
+	if fp.db != nil {
+		return p.writeNonDropDatabaseChange(ctx, fp.db, jobDesc)
+	}
+	return p.writeSchemaDescChange(ctx, fp.schema, jobDesc)
+}
+
+// updateFunctionBackReferences sets the relations the function depends on to
+// deps, and adds or removes the function from the back references of the
+// relations accordingly. The function descriptor itself is not written.
+func (p *planner) updateFunctionBackReferences(
+	ctx context.Context, fnDesc *funcdesc.Mutable, deps catalog.DescriptorIDSet,
+) error {
+	var oldDeps catalog.DescriptorIDSet
+	for _, id := range fnDesc.DependsOn {
+		oldDeps.Add(id)
+		if deps.Contains(id) {
+			continue
+		}
+		if err := p.removeFunctionBackReference(ctx, fnDesc, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range deps.Ordered() {
+		if oldDeps.Contains(id) {
+			continue
+		}
+		tableDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, id, p.txn)
+		if err != nil {
+			return err
+		}
+		if tableDesc.Temporary {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot reference temporary relation %q in a function", tableDesc.GetName())
+		}
+		tableDesc.DependedOnByFunctions = append(tableDesc.DependedOnByFunctions, fnDesc.GetID())
+		if err := p.writeSchemaChange(
+			ctx, tableDesc, descpb.InvalidMutationID,
+			fmt.Sprintf("updating function reference %q in table %s(%d)",
+				fnDesc.GetName(), tableDesc.GetName(), tableDesc.GetID()),
+		); err != nil {
+			return err
+		}
+	}
+	fnDesc.DependsOn = deps.Ordered()
+	return nil
+}
+
+// removeFunctionBackReference removes the back reference to the function from
+// the relation with the given ID, unless the relation is being dropped.
+func (p *planner) removeFunctionBackReference(
+	ctx context.Context, fnDesc *funcdesc.Mutable, tableID descpb.ID,
+) error {
+	tableDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, tableID, p.txn)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving dependency relation ID %d", tableID)
+	}
+	// The dependency is also being deleted, so we don't have to remove the
+	// references.
+	if tableDesc.Dropped() {
+		return nil
+	}
+	refs := tableDesc.DependedOnByFunctions[:0]
+	for _, id := range tableDesc.DependedOnByFunctions {
+		if id != fnDesc.GetID() {
+			refs = append(refs, id)
+		}
+	}
+	tableDesc.DependedOnByFunctions = refs
+	return p.writeSchemaChange(
+		ctx, tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("removing function reference %q from table %s(%d)",
+			fnDesc.GetName(), tableDesc.GetName(), tableDesc.GetID()),
+	)
+}
@@ -63,6 +63,9 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 	if tableDesc.IsView() && !tableDesc.MaterializedView() {
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a table or materialized view", tableDesc.Name)
 	}
+	if err := checkNotForeignTable(tableDesc); err != nil {
+		return nil, err
+	}
 
 	if tableDesc.MaterializedView() {
 		if n.Sharded != nil {
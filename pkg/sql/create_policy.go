@@ -0,0 +1,196 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type createPolicyNode struct {
+	n         *tree.CreatePolicy
+	tableDesc *tabledesc.Mutable
+	policy    descpb.PolicyDescriptor
+}
+
+// CreatePolicy creates a row-level security policy.
+// Privileges: ownership of the table or admin.
+//   notes: postgres requires ownership of the table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE POLICY",
+	); err != nil {
+		return nil, err
+	}
+	if err := checkRowLevelSecurityVersion(ctx, p.ExecCfg(), "CREATE POLICY"); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkRowLevelSecurityOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+
+	name := string(n.Name)
+	for i := range tableDesc.Policies {
+		if tableDesc.Policies[i].Name == name {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", name, tableDesc.GetName())
+		}
+	}
+
+	policy := descpb.PolicyDescriptor{
+		Name:        name,
+		Restrictive: n.Restrictive,
+	}
+	switch n.Command {
+	case tree.PolicyCommandAll:
+		policy.Command = descpb.PolicyDescriptor_ALL
+	case tree.PolicyCommandSelect:
+		policy.Command = descpb.PolicyDescriptor_SELECT
+	case tree.PolicyCommandInsert:
+		policy.Command = descpb.PolicyDescriptor_INSERT
+	case tree.PolicyCommandUpdate:
+		policy.Command = descpb.PolicyDescriptor_UPDATE
+	case tree.PolicyCommandDelete:
+		policy.Command = descpb.PolicyDescriptor_DELETE
+	}
+	if n.Using != nil && n.Command == tree.PolicyCommandInsert {
+		return nil, pgerror.New(pgcode.Syntax, "only WITH CHECK expression allowed for INSERT")
+	}
+	if n.WithCheck != nil &&
+		(n.Command == tree.PolicyCommandSelect || n.Command == tree.PolicyCommandDelete) {
+		return nil, pgerror.New(pgcode.Syntax, "WITH CHECK cannot be applied to SELECT or DELETE")
+	}
+
+	// A policy without roles applies to all roles.
+	roles := []security.SQLUsername{security.PublicRoleName()}
+	if len(n.Roles) > 0 {
+		roles, err = n.Roles.ToSQLUsernames(p.SessionData(), security.UsernameValidation)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.validateRoles(ctx, roles, true /* isPublicValid */); err != nil {
+			return nil, err
+		}
+	}
+	for _, role := range roles {
+		policy.Roles = append(policy.Roles, role.Normalized())
+	}
+
+	if n.Using != nil {
+		if policy.UsingExpr, err = p.validatePolicyExpr(ctx, tableDesc, n.Using, &n.Table); err != nil {
+			return nil, err
+		}
+	}
+	if n.WithCheck != nil {
+		if policy.WithCheckExpr, err = p.validatePolicyExpr(ctx, tableDesc, n.WithCheck, &n.Table); err != nil {
+			return nil, err
+		}
+	}
+
+	return &createPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+}
+
+// checkRowLevelSecurityVersion returns an error if the cluster version does not
+// allow the row-level security of a table to be configured. Nodes running an
+// older version ignore the policies and row-level security settings of the
+// table descriptors, so they would return or accept rows that the policies
+// hide or reject.
+func checkRowLevelSecurityVersion(ctx context.Context, execCfg *ExecutorConfig, stmt string) error {
+	if !execCfg.Settings.Version.IsActive(ctx, clusterversion.RowLevelSecurity) {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"%s requires all nodes to be upgraded to %s",
+			stmt, clusterversion.ByKey(clusterversion.RowLevelSecurity),
+		)
+	}
+	return nil
+}
+
+// checkRowLevelSecurityOwnership returns an error unless the current user owns
+// the table or is an admin. Like in Postgres, the row-level security of a table
+// can only be configured by its owner.
+func (p *planner) checkRowLevelSecurityOwnership(
+	ctx context.Context, desc catalog.TableDescriptor,
+) error {
+	hasAdminRole, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	hasOwnership, err := p.HasOwnership(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if !(hasOwnership || hasAdminRole) {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", tree.Name(desc.GetName()))
+	}
+	return nil
+}
+
+// validatePolicyExpr validates the USING or WITH CHECK expression of a policy
+// and returns its serialized form. The expression must be a boolean
+// expression which only refers to the columns of the table, and which
+// contains no subqueries, aggregates or volatile functions.
+func (p *planner) validatePolicyExpr(
+	ctx context.Context, desc catalog.TableDescriptor, expr tree.Expr, tn *tree.TableName,
+) (string, error) {
+	serialized, _, _, err := schemaexpr.DequalifyAndValidateExpr(
+		ctx,
+		desc,
+		expr,
+		types.Bool,
+		"POLICY EXPRESSION",
+		p.SemaCtx(),
+		tree.VolatilityStable,
+		tn,
+	)
+	return serialized, err
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE POLICY performs multiple KV operations on descriptors
+// and expects to see its own writes.
+func (n *createPolicyNode) ReadingOwnWrites() {}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	tableDesc := n.tableDesc
+	tableDesc.Policies = append(tableDesc.Policies, n.policy)
+
+	if err := validateDescriptor(params.ctx, params.p, tableDesc); err != nil {
+		return err
+	}
+
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()))
+}
+
+func (n *createPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPolicyNode) Close(context.Context)        {}
@@ -0,0 +1,114 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// externalStorageForeignDataWrapper is the name of the only foreign data
+// wrapper, which reads the rows of foreign tables from external storage.
+const externalStorageForeignDataWrapper = "external_storage"
+
+type createServerNode struct {
+	n      *tree.CreateServer
+	dbDesc *dbdesc.Mutable
+	server descpb.DatabaseDescriptor_ForeignServer
+}
+
+// CreateServer adds a foreign server to the current database. A server refers
+// to an external storage location, from which the rows of the foreign tables
+// of the server are read.
+// Privileges: admin.
+func (p *planner) CreateServer(ctx context.Context, n *tree.CreateServer) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE SERVER",
+	); err != nil {
+		return nil, err
+	}
+
+	// The URI of a server gives access to external storage on behalf of the
+	// cluster, like the URIs of IMPORT.
+	if err := p.RequireAdminRole(ctx, "CREATE SERVER"); err != nil {
+		return nil, err
+	}
+
+	if p.CurrentDatabase() == "" {
+		return nil, pgerror.New(pgcode.UndefinedDatabase,
+			"cannot create server without being connected to a database")
+	}
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, p.CurrentDatabase(),
+		tree.DatabaseLookupFlags{Required: true})
+	if err != nil {
+		return nil, err
+	}
+	if dbDesc.ID == keys.SystemDatabaseID {
+		return nil, pgerror.New(pgcode.InvalidObjectDefinition,
+			"cannot create servers in the system database")
+	}
+
+	if n.Wrapper != externalStorageForeignDataWrapper {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"foreign-data wrapper %q does not exist", n.Wrapper)
+	}
+	server := descpb.DatabaseDescriptor_ForeignServer{
+		Name:    string(n.Name),
+		Wrapper: string(n.Wrapper),
+	}
+	for _, opt := range n.Options {
+		switch opt.Key {
+		case "uri":
+			server.URI = opt.Value
+		default:
+			return nil, pgerror.Newf(pgcode.FdwInvalidOptionName, "invalid option %q", opt.Key)
+		}
+	}
+	if server.URI == "" {
+		return nil, pgerror.New(pgcode.FdwOptionNameNotFound, "option \"uri\" is required")
+	}
+	if _, err := cloud.ExternalStorageConfFromURI(server.URI, p.User()); err != nil {
+		return nil, pgerror.Wrap(err, pgcode.FdwInvalidAttributeValue, "invalid option \"uri\"")
+	}
+
+	return &createServerNode{n: n, dbDesc: dbDesc, server: server}, nil
+}
+
+func (n *createServerNode) startExec(params runParams) error {
+	if _, ok := n.dbDesc.GetForeignServer(n.server.Name); ok {
+		if n.n.IfNotExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.DuplicateObject, "server %q already exists", n.server.Name)
+	}
+	n.dbDesc.AddForeignServer(n.server)
+	// The URI of the server may contain credentials, so it is left out of the
+	// job description.
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.dbDesc,
+		tree.AsStringWithFlags(n.n, tree.FmtHideConstants),
+	)
+}
+
+func (n *createServerNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createServerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createServerNode) Close(ctx context.Context)           {}
+func (n *createServerNode) ReadingOwnWrites()                   {}
@@ -638,6 +638,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintNotDeferrable,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -694,7 +695,7 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrable, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
@@ -714,6 +715,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrable tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -767,11 +769,12 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:      constraintName,
-		TableID:   tbl.ID,
-		ColumnIDs: columnIDs,
-		Predicate: predicate,
-		Validity:  validity,
+		Name:          constraintName,
+		TableID:       tbl.ID,
+		ColumnIDs:     columnIDs,
+		Predicate:     predicate,
+		Validity:      validity,
+		Deferrability: descpb.ConstraintDeferrabilityValue[deferrable],
 	}
 
 	if ts == NewTable {
@@ -783,6 +786,112 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// addExclusionConstraintTableDef adds an exclusion constraint to the given
+// table descriptor. If the table is not new, the constraint is added in the
+// Validating state as a mutation, and the schema changer validates the
+// existing rows of the table against it.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	st *cluster.Settings,
+	d *tree.ExclusionConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	semaCtx *tree.SemaContext,
+	ts TableState,
+) error {
+	if !st.Version.IsActive(ctx, clusterversion.ExclusionConstraints) {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"exclusion constraints require all nodes to be upgraded to %s",
+			clusterversion.ByKey(clusterversion.ExclusionConstraints))
+	}
+	var colSet catalog.TableColSet
+	columnIDs := make(descpb.ColumnIDs, len(d.Elems))
+	operators := make([]descpb.ExclusionConstraint_Operator, len(d.Elems))
+	colNames := make([]string, len(d.Elems))
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		if elem.Expr != nil {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"exclusion constraints on expressions are not supported",
+			)
+		}
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return err
+		}
+		if colSet.Contains(col.GetID()) {
+			return pgerror.Newf(pgcode.DuplicateColumn,
+				"column %q appears twice in exclusion constraint", col.GetName())
+		}
+		colSet.Add(col.GetID())
+
+		sym := elem.Operator.Symbol
+		op, ok := descpb.ExclusionConstraintOperatorValue[sym]
+		if !ok {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"operator %s is not supported by exclusion constraints", sym)
+		}
+		typ := col.GetType()
+		if _, ok := tree.CmpOps[sym].LookupImpl(typ, typ); !ok {
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"unsupported comparison operator: <%s> %s <%s>", typ, sym, typ)
+		}
+		columnIDs[i] = col.GetID()
+		operators[i] = op
+		colNames[i] = col.GetName()
+	}
+
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, _, _, err = schemaexpr.DequalifyAndValidateExpr(
+			ctx,
+			desc,
+			d.Predicate,
+			types.Bool,
+			"exclusion constraint predicate",
+			semaCtx,
+			tree.VolatilityImmutable,
+			&tn,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	constraintInfo, err := desc.GetConstraintInfo()
+	if err != nil {
+		return err
+	}
+	name := string(d.Name)
+	if name == "" {
+		name = tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_excl", desc.Name, strings.Join(colNames, "_")),
+			func(p string) bool {
+				_, ok := constraintInfo[p]
+				return ok
+			},
+		)
+	} else if _, ok := constraintInfo[name]; ok {
+		return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", name)
+	}
+
+	ec := descpb.ExclusionConstraint{
+		Name:      name,
+		ColumnIDs: columnIDs,
+		Operators: operators,
+		Predicate: predicate,
+		Validity:  descpb.ConstraintValidity_Validated,
+	}
+	if ts == NewTable {
+		desc.ExclusionConstraints = append(desc.ExclusionConstraints, ec)
+	} else {
+		ec.Validity = descpb.ConstraintValidity_Validating
+		desc.AddExclusionConstraintMutation(&ec, descpb.DescriptorMutation_ADD)
+	}
+	return nil
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -1026,6 +1135,7 @@ func ResolveFK(
 		OnDelete:            descpb.ForeignKeyReferenceActionValue[d.Actions.Delete],
 		OnUpdate:            descpb.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               descpb.CompositeKeyMatchMethodValue[d.Match],
+		Deferrability:       descpb.ConstraintDeferrabilityValue[d.Deferrable],
 	}
 
 	if ts == NewTable {
@@ -1781,6 +1891,9 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.Deferrable != tree.ConstraintNotDeferrable {
+				return nil, errDeferrableUniqueIndex
+			}
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
@@ -1887,7 +2000,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExclusionConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2079,6 +2193,13 @@ func NewTableDesc(
 				return nil, err
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, st, d, &desc, n.Table, semaCtx, NewTable,
+			); err != nil {
+				return nil, err
+			}
+
 		default:
 			return nil, errors.Errorf("unsupported table def: %T", def)
 		}
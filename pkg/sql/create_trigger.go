@@ -0,0 +1,87 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type createTriggerNode struct {
+	// ct is the CREATE TRIGGER statement. The data sources in its body are
+	// fully qualified.
+	ct      *tree.CreateTrigger
+	tableID descpb.ID
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE TRIGGER performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createTriggerNode) ReadingOwnWrites() {}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+	tableDesc, err := p.Descriptors().GetMutableTableVersionByID(ctx, n.tableID, p.txn)
+	if err != nil {
+		return err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return err
+	}
+
+	name := string(n.ct.Name)
+	for i := range tableDesc.Triggers {
+		if tableDesc.Triggers[i].Name == name {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", name, tableDesc.GetName())
+		}
+	}
+
+	trigger := descpb.TriggerDescriptor{
+		Name:       name,
+		ActionTime: descpb.TriggerDescriptor_AFTER,
+		Body:       n.ct.Body,
+	}
+	if n.ct.ActionTime == tree.TriggerBefore {
+		trigger.ActionTime = descpb.TriggerDescriptor_BEFORE
+	}
+	for _, e := range n.ct.Events {
+		switch e {
+		case tree.TriggerEventInsert:
+			trigger.Events = append(trigger.Events, descpb.TriggerDescriptor_INSERT)
+		case tree.TriggerEventUpdate:
+			trigger.Events = append(trigger.Events, descpb.TriggerDescriptor_UPDATE)
+		case tree.TriggerEventDelete:
+			trigger.Events = append(trigger.Events, descpb.TriggerDescriptor_DELETE)
+		}
+	}
+	if n.ct.When != nil {
+		trigger.When = tree.Serialize(n.ct.When)
+	}
+	tableDesc.Triggers = append(tableDesc.Triggers, trigger)
+
+	if err := validateDescriptor(ctx, p, tableDesc); err != nil {
+		return err
+	}
+
+	return p.writeSchemaChange(
+		ctx, tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.ct, params.Ann()))
+}
+
+func (*createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (*createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createTriggerNode) Close(context.Context)        {}
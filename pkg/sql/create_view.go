@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/docs"
@@ -49,7 +50,12 @@ type createViewNode struct {
 	viewName *tree.TableName
 	// viewQuery contains the view definition, with all table names fully
 	// qualified.
-	viewQuery    string
+	viewQuery string
+	// incrementalViewQuery is set if the view is an incrementally maintained
+	// materialized view. It contains the view query extended with the hidden
+	// columns of the view which are used to maintain it.
+	incrementalViewQuery string
+
 	ifNotExists  bool
 	replace      bool
 	persistence  tree.Persistence
@@ -233,6 +239,17 @@ func (n *createViewNode) startExec(params runParams) error {
 			desc.IsMaterializedView = true
 			desc.State = descpb.DescriptorState_ADD
 			desc.CreateAsOfTime = params.p.Txn().ReadTimestamp()
+			if n.incrementalViewQuery != "" {
+				desc.IncrementalViewQuery, err = serializeUserDefinedTypes(
+					params.ctx, &params.p.semaCtx, n.incrementalViewQuery,
+				)
+				if err != nil {
+					return err
+				}
+				if err := addIncrementalViewIndexes(&desc); err != nil {
+					return err
+				}
+			}
 			if err := desc.AllocateIDs(params.ctx); err != nil {
 				return err
 			}
@@ -290,6 +307,7 @@ func (n *createViewNode) startExec(params runParams) error {
 			// We need to do it here.
 			dep.ID = newDesc.ID
 			dep.ByID = updated.desc.IsSequence()
+			dep.Incremental = newDesc.IncrementalViewQuery != ""
 			backRefMutable.DependedOnBy = append(backRefMutable.DependedOnBy, dep)
 		}
 		if err := params.p.writeSchemaChange(
@@ -603,7 +621,9 @@ func addResultColumns(
 	resultColumns colinfo.ResultColumns,
 ) error {
 	for _, colRes := range resultColumns {
-		columnTableDef := tree.ColumnTableDef{Name: tree.Name(colRes.Name), Type: colRes.Typ}
+		columnTableDef := tree.ColumnTableDef{
+			Name: tree.Name(colRes.Name), Type: colRes.Typ, Hidden: colRes.Hidden,
+		}
 		// Nullability constraints do not need to exist on the view, since they are
 		// already enforced on the source data.
 		columnTableDef.Nullable.Nullability = tree.SilentNull
@@ -621,6 +641,77 @@ func addResultColumns(
 	return nil
 }
 
+// addIncrementalViewIndexes adds secondary indexes to the descriptor of an
+// incrementally maintained materialized view, which are used to find the rows
+// of the view that are affected by the mutation of a table it depends on. If
+// the view has a GROUP BY clause, the index is on the grouping columns.
+// Otherwise, there is an index for each table in the FROM clause, on the
+// hidden columns that contain the primary key of the table.
+func addIncrementalViewIndexes(desc *tabledesc.Mutable) error {
+	stmt, err := parser.ParseOne(desc.IncrementalViewQuery)
+	if err != nil {
+		return err
+	}
+	sel, ok := stmt.AST.(*tree.Select).Select.(*tree.SelectClause)
+	if !ok {
+		return errors.AssertionFailedf("unexpected incremental view query %s", desc.IncrementalViewQuery)
+	}
+	var indexCols [][]string
+	if len(sel.GroupBy) > 0 {
+		var groupCols []string
+		for i := range sel.Exprs {
+			expr := tree.AsStringWithFlags(sel.Exprs[i].Expr, tree.FmtParsable)
+			for _, g := range sel.GroupBy {
+				if expr == tree.AsStringWithFlags(g, tree.FmtParsable) {
+					groupCols = append(groupCols, desc.Columns[i].Name)
+					break
+				}
+			}
+		}
+		indexCols = append(indexCols, groupCols)
+	} else {
+		// The hidden key columns have the form <table>.<column>, and the columns
+		// of each table are consecutive.
+		prevTable := ""
+		for i := range sel.Exprs {
+			if !strings.HasPrefix(string(sel.Exprs[i].As), "crdb_internal_ivm_key_") {
+				continue
+			}
+			table := sel.Exprs[i].Expr.(*tree.UnresolvedName).Parts[1]
+			if table != prevTable {
+				indexCols = append(indexCols, nil)
+				prevTable = table
+			}
+			indexCols[len(indexCols)-1] = append(indexCols[len(indexCols)-1], desc.Columns[i].Name)
+		}
+	}
+
+	for i, cols := range indexCols {
+		indexable := true
+		for _, name := range cols {
+			col, err := desc.FindColumnWithName(tree.Name(name))
+			if err != nil {
+				return err
+			}
+			indexable = indexable && colinfo.ColumnTypeIsIndexable(col.GetType())
+		}
+		if !indexable {
+			// The view is maintained without an index, at the cost of scanning it.
+			continue
+		}
+		idx := descpb.IndexDescriptor{
+			Name: fmt.Sprintf("crdb_internal_ivm_%d_idx", i+1),
+			Type: descpb.IndexDescriptor_FORWARD,
+		}
+		idx.KeyColumnNames = cols
+		idx.KeyColumnDirections = make([]descpb.IndexDescriptor_Direction, len(cols))
+		if err := desc.AddSecondaryIndex(idx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // verifyReplacingViewColumns ensures that the new set of view columns must
 // have at least the same prefix of columns as the old view. We attempt to
 // match the postgres error message in each of the error cases below.
@@ -0,0 +1,220 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// errDeferrableUniqueIndex is returned when a UNIQUE constraint that is backed
+// by an index is declared DEFERRABLE. Unique indexes are enforced by the KV
+// layer as rows are written, so they cannot be deferred.
+var errDeferrableUniqueIndex = unimplemented.NewWithIssueDetail(31632,
+	"deferrable unique index",
+	"deferrable UNIQUE constraints are only supported for UNIQUE WITHOUT INDEX constraints")
+
+// constraintMode is the checking mode of a deferrable constraint, as set by
+// SET CONSTRAINTS.
+type constraintMode int
+
+const (
+	// constraintModeDefault means that the constraint is checked according to
+	// the mode it was declared with.
+	constraintModeDefault constraintMode = iota
+	// constraintModeImmediate means that the constraint is checked at the end
+	// of each statement.
+	constraintModeImmediate
+	// constraintModeDeferred means that the constraint is checked when the
+	// transaction commits.
+	constraintModeDeferred
+)
+
+// deferredConstraintState is the per-transaction state of deferrable
+// constraints. It records the constraint modes set with SET CONSTRAINTS, and
+// the deferred constraints that were violated by the FK and unique checks of
+// mutation statements. Since later statements of the transaction may resolve
+// the violations, each violated constraint is checked again, with a single
+// query over the whole table, before a violation is reported.
+type deferredConstraintState struct {
+	// all is the mode set with SET CONSTRAINTS ALL.
+	all constraintMode
+	// modes contains the modes set for individual constraints, by name. They
+	// take precedence over all.
+	modes map[string]constraintMode
+	// violated contains the deferred constraints with pending violations. A
+	// constraint is recorded once, no matter how many rows violate it, so the
+	// state doesn't grow with the number of modified rows.
+	violated []*exec.DeferrableCheck
+}
+
+// reset clears the state at the end of a transaction.
+func (s *deferredConstraintState) reset() {
+	*s = deferredConstraintState{}
+}
+
+// mode returns the mode of the constraint with the given name.
+func (s *deferredConstraintState) mode(name string) constraintMode {
+	if m, ok := s.modes[name]; ok {
+		return m
+	}
+	return s.all
+}
+
+// isDeferred returns true if the given check should be deferred until the
+// transaction commits.
+func (s *deferredConstraintState) isDeferred(check *exec.DeferrableCheck) bool {
+	switch s.mode(check.ConstraintName) {
+	case constraintModeImmediate:
+		return false
+	case constraintModeDeferred:
+		return true
+	default:
+		return check.InitiallyDeferred
+	}
+}
+
+// addViolation records that the given deferred constraint was violated.
+func (s *deferredConstraintState) addViolation(check *exec.DeferrableCheck) {
+	for _, c := range s.violated {
+		// Checks of the same constraint (e.g. of an insert into the origin
+		// table and of a delete from the referenced table of a FK) have the
+		// same query.
+		if c.Check == check.Check {
+			return
+		}
+	}
+	s.violated = append(s.violated, check)
+}
+
+// setMode applies a SET CONSTRAINTS statement.
+func (s *deferredConstraintState) setMode(n *tree.SetConstraints) {
+	m := constraintModeImmediate
+	if n.Deferred {
+		m = constraintModeDeferred
+	}
+	if n.All {
+		s.all = m
+		s.modes = nil
+		return
+	}
+	if s.modes == nil {
+		s.modes = make(map[string]constraintMode, len(n.Names))
+	}
+	for _, name := range n.Names {
+		s.modes[string(name)] = m
+	}
+}
+
+// checkViolations checks the constraints with pending violations again, using
+// the given function to run the check queries. If all is false, only the
+// constraints that are no longer deferred are checked, and the others remain
+// pending. An error is returned for the first constraint that is still
+// violated.
+func (s *deferredConstraintState) checkViolations(
+	ctx context.Context, all bool, run func(ctx context.Context, stmt string) (tree.Datums, error),
+) error {
+	var pending []*exec.DeferrableCheck
+	for _, c := range s.violated {
+		if !all && s.isDeferred(c) {
+			pending = append(pending, c)
+			continue
+		}
+		row, err := run(ctx, c.Check)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return c.MkErr(row)
+		}
+	}
+	s.violated = pending
+	return nil
+}
+
+// checkDeferredConstraints reports the violations of deferred constraints
+// that still exist when the transaction commits.
+func (ex *connExecutor) checkDeferredConstraints(ctx context.Context) error {
+	s := &ex.extraTxnState.deferredConstraints
+	if len(s.violated) == 0 {
+		return nil
+	}
+	ie := ex.server.cfg.InternalExecutorFactory(ctx, ex.sessionData())
+	run := func(ctx context.Context, stmt string) (tree.Datums, error) {
+		return ie.QueryRowEx(
+			ctx, "check-deferred-constraint", ex.state.mu.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			stmt,
+		)
+	}
+	return s.checkViolations(ctx, true /* all */, run)
+}
+
+// SetConstraints sets the checking mode of deferrable constraints for the
+// current transaction. When constraints are set to IMMEDIATE, their pending
+// violations are checked right away.
+// Privileges: None.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	if p.EvalContext().TxnImplicit {
+		p.BufferClientNotice(
+			ctx,
+			pgnotice.NewWithSeverityf("WARNING", "SET CONSTRAINTS can only be used in transaction blocks"),
+		)
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	for _, name := range n.Names {
+		row, err := p.QueryRowEx(
+			ctx, "set-constraints", p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			`SELECT bool_or(condeferrable) FROM pg_catalog.pg_constraint WHERE conname = $1`,
+			string(name),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if row[0] == tree.DNull {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q does not exist", string(name))
+		}
+		if !tree.MustBeDBool(row[0]) {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"constraint %q is not deferrable", string(name))
+		}
+	}
+
+	s := p.extendedEvalCtx.DeferredConstraints
+	if s == nil {
+		return newZeroNode(nil /* columns */), nil
+	}
+	s.setMode(n)
+	if !n.Deferred {
+		run := func(ctx context.Context, stmt string) (tree.Datums, error) {
+			return p.QueryRowEx(
+				ctx, "check-deferred-constraint", p.txn,
+				sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+				stmt,
+			)
+		}
+		if err := s.checkViolations(ctx, false /* all */, run); err != nil {
+			return nil, err
+		}
+	}
+	return newZeroNode(nil /* columns */), nil
+}
@@ -343,6 +343,7 @@ func (dsp *DistSQLPlanner) mustWrapNode(planCtx *PlanningCtx, node planNode) boo
 	case *distinctNode:
 	case *exportNode:
 	case *filterNode:
+	case *foreignScanNode:
 	case *groupNode:
 	case *indexJoinNode:
 	case *invertedFilterNode:
@@ -409,6 +410,10 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		}
 		return checkSupportForPlanNode(n.source.plan)
 
+	case *foreignScanNode:
+		// The files of a foreign table are read in parallel by all nodes.
+		return shouldDistribute, nil
+
 	case *groupNode:
 		rec, err := checkSupportForPlanNode(n.plan)
 		if err != nil {
@@ -1112,6 +1117,7 @@ func initTableReaderSpec(
 		LockingWaitPolicy: n.lockingWaitPolicy,
 		HasSystemColumns:  n.containsSystemColumns,
 		NeededColumns:     n.colCfg.wantedColumnsOrdinals,
+		IncrementalFrom:   n.incrementalFrom,
 	}
 	if vc := getInvertedColumn(n.colCfg.invertedColumn, n.cols); vc != nil {
 		s.InvertedColumn = vc.ColumnDesc()
@@ -2967,6 +2973,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 			return nil, err
 		}
 
+	case *foreignScanNode:
+		plan, err = dsp.createPlanForForeignScan(planCtx, n)
+
 	case *groupNode:
 		plan, err = dsp.createPhysPlanForPlanNode(planCtx, n.plan)
 		if err != nil {
@@ -3973,6 +3982,45 @@ func (dsp *DistSQLPlanner) createPlanForExport(
 		core, execinfrapb.PostProcessSpec{}, resTypes, execinfrapb.Ordering{},
 	)
 
+	// Incremental exports write a manifest once all the files are written.
+	if !n.incrementalFrom.IsEmpty() {
+		// The manifest writer reads the columns of the scan, which the filter
+		// refers to, and the primary key columns, which identify the rows to
+		// delete.
+		scan := n.incrementalScan
+		var colIDs []descpb.ColumnID
+		var cols catalog.TableColSet
+		for _, col := range scan.cols {
+			colIDs = append(colIDs, col.GetID())
+			cols.Add(col.GetID())
+		}
+		for i := 0; i < scan.index.NumKeyColumns(); i++ {
+			if id := scan.index.GetKeyColumnID(i); !cols.Contains(id) {
+				colIDs = append(colIDs, id)
+				cols.Add(id)
+			}
+		}
+		filter, err := physicalplan.MakeExpression(n.incrementalFilter, planCtx, nil /* indexVarMap */)
+		if err != nil {
+			return nil, err
+		}
+		plan.AddSingleGroupStage(
+			dsp.gatewayNodeID,
+			execinfrapb.ProcessorCoreUnion{ExportManifestWriter: &execinfrapb.ExportManifestWriterSpec{
+				Destination: n.destination,
+				Name:        n.manifestName,
+				StartTime:   n.incrementalFrom,
+				UserProto:   planCtx.planner.User().EncodeProto(),
+				Table:       *scan.desc.TableDesc(),
+				Spans:       scan.spans,
+				ColumnIDs:   colIDs,
+				Filter:      filter,
+			}},
+			execinfrapb.PostProcessSpec{},
+			resTypes,
+		)
+	}
+
 	// The CSVWriter produces the same columns as the EXPORT statement.
 	plan.PlanToStreamColMap = identityMap(plan.PlanToStreamColMap, len(colinfo.ExportColumns))
 	return plan, nil
@@ -4087,13 +4135,16 @@ func (dsp *DistSQLPlanner) NewPlanningCtx(
 		planner:         planner,
 	}
 	if !distribute {
-		if planner == nil || dsp.spanResolver == nil || planner.curPlan.flags.IsSet(planFlagContainsMutation) {
+		if planner == nil || dsp.spanResolver == nil || planner.curPlan.flags.IsSet(planFlagContainsMutation) ||
+			planner.pausablePortal {
 			// Don't parallelize the scans if we have a local plan if
 			// - we don't have a planner which is the case when we are not on
 			// the main query path;
 			// - we don't have a span resolver (this can happen only in tests);
 			// - the plan contains a mutation operation - we currently don't
-			// support any parallelism when mutations are present.
+			// support any parallelism when mutations are present;
+			// - the plan is executed by a pausable portal, which cannot use leaf
+			// txns.
 			return planCtx
 		}
 		prohibitParallelization, hasScanNodeToParallelize := checkScanParallelizationIfLocal(ctx, &planner.curPlan.planComponents)
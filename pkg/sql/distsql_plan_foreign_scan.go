@@ -0,0 +1,155 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+)
+
+// createPlanForForeignScan creates a physical plan which reads the files of a
+// foreign table. The files are distributed among the nodes of the cluster, and
+// each node runs a ForeignScan processor which reads its files.
+func (dsp *DistSQLPlanner) createPlanForForeignScan(
+	planCtx *PlanningCtx, n *foreignScanNode,
+) (*PhysicalPlan, error) {
+	files, err := dsp.expandForeignTableFiles(planCtx.ctx, planCtx.ExtendedEvalCtx.ExecCfg, n)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []roachpb.NodeID{dsp.gatewayNodeID}
+	if !planCtx.isLocal {
+		_, allNodes, err := dsp.SetupAllNodesPlanning(
+			planCtx.ctx, planCtx.ExtendedEvalCtx, planCtx.ExtendedEvalCtx.ExecCfg,
+		)
+		if err != nil {
+			return nil, err
+		}
+		nodes = nodes[:0]
+		for _, nodeID := range allNodes {
+			if dsp.CheckNodeHealthAndVersion(planCtx, nodeID) == NodeOK {
+				nodes = append(nodes, nodeID)
+			}
+		}
+		if len(nodes) == 0 {
+			nodes = append(nodes, dsp.gatewayNodeID)
+		}
+	}
+
+	// Assign the files to the nodes in a round-robin fashion, and only plan
+	// processors on the nodes which have files to read. At least one processor
+	// is planned so that the plan has a result stream.
+	filesByNode := make(map[roachpb.NodeID]map[int32]string)
+	for i, file := range files {
+		nodeID := nodes[i%len(nodes)]
+		if filesByNode[nodeID] == nil {
+			filesByNode[nodeID] = make(map[int32]string)
+		}
+		filesByNode[nodeID][int32(i)] = file
+	}
+	if len(filesByNode) == 0 {
+		filesByNode[dsp.gatewayNodeID] = nil
+	}
+
+	corePlacements := make([]physicalplan.ProcessorCorePlacement, 0, len(filesByNode))
+	for _, nodeID := range nodes {
+		nodeFiles, ok := filesByNode[nodeID]
+		if !ok {
+			continue
+		}
+		corePlacements = append(corePlacements, physicalplan.ProcessorCorePlacement{
+			NodeID: nodeID,
+			Core: execinfrapb.ProcessorCoreUnion{ForeignScan: &execinfrapb.ForeignScanSpec{
+				Table:     *n.desc.TableDesc(),
+				Uri:       nodeFiles,
+				Format:    n.format,
+				UserProto: n.user.EncodeProto(),
+			}},
+		})
+	}
+
+	p := planCtx.NewPhysicalPlan()
+	types := getTypesFromResultColumns(n.resultColumns)
+	p.AddNoInputStage(corePlacements, execinfrapb.PostProcessSpec{}, types, execinfrapb.Ordering{})
+	p.PlanToStreamColMap = identityMap(make([]int, len(n.resultColumns)), len(n.resultColumns))
+	return p, nil
+}
+
+// expandForeignTableFiles returns the URIs of the files of the given foreign
+// table. The location of the table may contain wildcards, like the files of
+// IMPORT.
+func (dsp *DistSQLPlanner) expandForeignTableFiles(
+	ctx context.Context, execCfg *ExecutorConfig, n *foreignScanNode,
+) ([]string, error) {
+	uri, err := url.Parse(n.uri)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.FdwInvalidAttributeValue, "invalid server uri")
+	}
+	serverPath := path.Clean(uri.Path)
+	uri.Path = path.Join(uri.Path, n.location)
+	// The location was validated when the table was created, but make sure
+	// that the files are still within the path of the server URI.
+	if !foreignTablePathWithin(serverPath, uri.Path) {
+		return nil, pgerror.Newf(pgcode.FdwInvalidAttributeValue,
+			"location %q of foreign table %q is outside of the uri of its server",
+			n.location, n.desc.GetName())
+	}
+	prefix := cloud.GetPrefixBeforeWildcard(uri.Path)
+	if len(prefix) == len(uri.Path) {
+		return []string{uri.String()}, nil
+	}
+	pattern := uri.Path[len(prefix):]
+	uri.Path = prefix
+	s, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, uri.String(), n.user)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	var files []string
+	if err := s.List(ctx, "", "", func(s string) error {
+		ok, err := path.Match(pattern, s)
+		if ok {
+			uri.Path = prefix + s
+			files = append(files, uri.String())
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if len(files) < 1 {
+		return nil, pgerror.Newf(pgcode.FdwTableNotFound,
+			"no files matched %q in prefix %q of foreign table %q", pattern, prefix, n.desc.GetName())
+	}
+	return files, nil
+}
+
+// foreignTablePathWithin returns whether the given path, obtained by joining a
+// location to the cleaned path of a server URI, is within the path of the
+// server URI.
+func foreignTablePathWithin(serverPath, p string) bool {
+	switch serverPath {
+	case ".":
+		return p != ".." && !strings.HasPrefix(p, "../") && !path.IsAbs(p)
+	case "/":
+		return path.IsAbs(p)
+	}
+	return p == serverPath || strings.HasPrefix(p, serverPath+"/")
+}
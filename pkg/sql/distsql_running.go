@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/flowinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
@@ -1133,6 +1134,10 @@ var (
 	// ErrLimitedResultClosed is a sentinel error produced by pgwire
 	// indicating the portal should be closed without error.
 	ErrLimitedResultClosed = errors.New("row count limit closed")
+	// ErrPortalSuspended is a sentinel error produced by pgwire indicating
+	// that the row count limit of a pausable portal was reached, and that its
+	// execution should be suspended until the portal is executed again.
+	ErrPortalSuspended = errors.New("portal suspended")
 )
 
 // ProducerDone is part of the execinfra.RowReceiver interface.
@@ -1411,71 +1416,25 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			}
 		}
 
-		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKName)
-
-		// We place a sequence point before every cascade, so
-		// that each subsequent cascade can observe the writes
-		// by the previous step.
-		// TODO(radu): the cascades themselves can have more cascades; if any of
-		// those fall back to legacy cascades code, it will disable stepping. So we
-		// have to reenable stepping each time.
-		_ = planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
-		if err := planner.Txn().Step(ctx); err != nil {
-			recv.SetError(err)
-			return false
+		switch {
+		case plan.cascades[i].Trigger:
+			log.VEventf(ctx, 2, "executing trigger %s", plan.cascades[i].FKName)
+		case plan.cascades[i].IncrementalView:
+			log.VEventf(ctx, 2, "executing maintenance of view %s", plan.cascades[i].FKName)
+		default:
+			log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKName)
 		}
 
-		evalCtx := evalCtxFactory()
-		execFactory := newExecFactory(planner)
 		// The cascading query is allowed to autocommit only if it is the last
 		// cascade and there are no check queries to run.
 		allowAutoCommit := planner.autoCommit
 		if len(plan.checkPlans) > 0 || i < len(plan.cascades)-1 {
 			allowAutoCommit = false
 		}
-		cascadePlan, err := plan.cascades[i].PlanFn(
-			ctx, &planner.semaCtx, &evalCtx.EvalContext, execFactory,
-			buf, numBufferedRows, allowAutoCommit,
-		)
-		if err != nil {
-			recv.SetError(err)
-			return false
-		}
-		cp := cascadePlan.(*planComponents)
-		plan.cascades[i].plan = cp.main
-		if len(cp.subqueryPlans) > 0 {
-			recv.SetError(errors.AssertionFailedf("cascades should not have subqueries"))
-			return false
-		}
-
-		// Queue any new cascades.
-		if len(cp.cascades) > 0 {
-			plan.cascades = append(plan.cascades, cp.cascades...)
-		}
-
-		// Collect any new checks.
-		if len(cp.checkPlans) > 0 {
-			plan.checkPlans = append(plan.checkPlans, cp.checkPlans...)
-		}
-
-		// In cyclical reference situations, the number of cascading operations can
-		// be arbitrarily large. To avoid OOM, we enforce a limit. This is also a
-		// safeguard in case we have a bug that results in an infinite cascade loop.
-		if limit := int(evalCtx.SessionData().OptimizerFKCascadesLimit); len(plan.cascades) > limit {
-			telemetry.Inc(sqltelemetry.CascadesLimitReached)
-			err := pgerror.Newf(pgcode.TriggeredActionException, "cascades limit (%d) reached", limit)
-			recv.SetError(err)
-			return false
-		}
-
-		if err := dsp.planAndRunPostquery(
-			ctx,
-			cp.main,
-			planner,
-			evalCtx,
-			recv,
-		); err != nil {
-			recv.SetError(err)
+		if !dsp.planAndRunCascade(
+			ctx, planner, evalCtxFactory, plan, &plan.cascades[i], buf, numBufferedRows,
+			allowAutoCommit, recv,
+		) {
 			return false
 		}
 	}
@@ -1512,6 +1471,104 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 	return true
 }
 
+// planAndRunCascade plans the given cascade with the given input buffer and
+// runs it, along with its subqueries. Any cascades and checks that the cascade
+// generates are appended to plan.cascades and plan.checkPlans.
+//
+// Returns false if an error was encountered and sets that error in the provided
+// receiver.
+func (dsp *DistSQLPlanner) planAndRunCascade(
+	ctx context.Context,
+	planner *planner,
+	evalCtxFactory func() *extendedEvalContext,
+	plan *planComponents,
+	c *cascadeMetadata,
+	buf exec.Node,
+	numBufferedRows int,
+	allowAutoCommit bool,
+	recv *DistSQLReceiver,
+) bool {
+	// We place a sequence point before every cascade, so
+	// that each subsequent cascade can observe the writes
+	// by the previous step.
+	// TODO(radu): the cascades themselves can have more cascades; if any of
+	// those fall back to legacy cascades code, it will disable stepping. So we
+	// have to reenable stepping each time.
+	_ = planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
+	if err := planner.Txn().Step(ctx); err != nil {
+		recv.SetError(err)
+		return false
+	}
+
+	evalCtx := evalCtxFactory()
+	execFactory := newExecFactory(planner)
+	cascadePlan, err := c.PlanFn(
+		ctx, &planner.semaCtx, &evalCtx.EvalContext, execFactory,
+		buf, numBufferedRows, allowAutoCommit,
+	)
+	if err != nil {
+		recv.SetError(err)
+		return false
+	}
+	cp := cascadePlan.(*planComponents)
+	c.plan = cp.main
+	c.subqueryPlans = cp.subqueryPlans
+
+	// Queue any new cascades. This can reallocate plan.cascades, so c must not
+	// be used after this point.
+	if len(cp.cascades) > 0 {
+		plan.cascades = append(plan.cascades, cp.cascades...)
+	}
+
+	// Collect any new checks.
+	if len(cp.checkPlans) > 0 {
+		plan.checkPlans = append(plan.checkPlans, cp.checkPlans...)
+	}
+
+	if err := checkCascadesLimit(planner, len(plan.cascades)); err != nil {
+		recv.SetError(err)
+		return false
+	}
+
+	if len(cp.subqueryPlans) > 0 {
+		// The subqueries of the cascade are referenced by index from its plan,
+		// so we temporarily replace the subqueries on the planner's curPlan
+		// (see also runPlanInsidePlan).
+		oldSubqueries := planner.curPlan.subqueryPlans
+		planner.curPlan.subqueryPlans = cp.subqueryPlans
+		defer func() {
+			planner.curPlan.subqueryPlans = oldSubqueries
+		}()
+		// Create a separate memory account for the results of the subqueries.
+		subqueryResultMemAcc := planner.EvalContext().Mon.MakeBoundAccount()
+		defer subqueryResultMemAcc.Close(ctx)
+		if !dsp.PlanAndRunSubqueries(
+			ctx, planner, evalCtxFactory, cp.subqueryPlans, recv, &subqueryResultMemAcc,
+		) {
+			return false
+		}
+	}
+	if err := dsp.planAndRunPostquery(ctx, cp.main, planner, evalCtx, recv); err != nil {
+		recv.SetError(err)
+		return false
+	}
+	return true
+}
+
+// checkCascadesLimit returns an error if the given number of cascading
+// operations exceeds the cascades limit.
+//
+// In cyclical reference situations, the number of cascading operations can be
+// arbitrarily large. To avoid OOM, we enforce a limit. This is also a
+// safeguard in case we have a bug that results in an infinite cascade loop.
+func checkCascadesLimit(planner *planner, numCascades int) error {
+	if limit := int(planner.SessionData().OptimizerFKCascadesLimit); numCascades > limit {
+		telemetry.Inc(sqltelemetry.CascadesLimitReached)
+		return pgerror.Newf(pgcode.TriggeredActionException, "cascades limit (%d) reached", limit)
+	}
+	return nil
+}
+
 // planAndRunPostquery runs a cascade or check query.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
 	ctx context.Context,
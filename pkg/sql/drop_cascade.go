@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
@@ -36,6 +37,7 @@ type dropCascadeState struct {
 	toDeleteByID            map[descpb.ID]*toDelete
 	allTableObjectsToDelete []*tabledesc.Mutable
 	typesToDelete           []*typedesc.Mutable
+	functionsToDelete       []*funcdesc.Mutable
 
 	droppedNames []string
 }
@@ -66,10 +68,33 @@ func (d *dropCascadeState) collectObjectsInSchema(
 	for i := range names {
 		d.objectNamesToDelete = append(d.objectNamesToDelete, &names[i])
 	}
+	// Functions have no namespace entries, so they are collected from the
+	// functions mapping of the schema instead.
+	var functions map[string]descpb.SchemaDescriptor_Function
+	switch schema.SchemaKind() {
+	case catalog.SchemaPublic:
+		functions = db.PublicSchemaFunctions
+	case catalog.SchemaUserDefined:
+		functions = schema.SchemaDesc().Functions
+	}
+	for _, fn := range functions {
+		for _, o := range fn.Overloads {
+			fnDesc, err := p.Descriptors().GetMutableFunctionByID(ctx, p.txn, o.ID, tree.ObjectLookupFlags{})
+			if err != nil {
+				return err
+			}
+			d.functionsToDelete = append(d.functionsToDelete, fnDesc)
+		}
+	}
 	d.schemasToDelete = append(d.schemasToDelete, schemaWithDbDesc{schema: schema, dbDesc: db})
 	return nil
 }
 
+// numObjectsToDelete returns the number of objects collected so far.
+func (d *dropCascadeState) numObjectsToDelete() int {
+	return len(d.objectNamesToDelete) + len(d.functionsToDelete)
+}
+
 // This resolves objects for DROP SCHEMA and DROP DATABASE ops.
 // db is used to generate a useful error message in the case
 // of DROP DATABASE; otherwise, db is nil.
@@ -136,6 +161,9 @@ func (d *dropCascadeState) resolveCollectedObjects(
 					return err
 				}
 			}
+			if err := p.canRemoveDependentFunctions(ctx, tbDesc, tree.DropCascade); err != nil {
+				return err
+			}
 			d.td = append(d.td, toDelete{objName, tbDesc})
 		} else {
 			// If we couldn't resolve objName as a table, try a type.
@@ -188,6 +216,15 @@ func (d *dropCascadeState) resolveCollectedObjects(
 }
 
 func (d *dropCascadeState) dropAllCollectedObjects(ctx context.Context, p *planner) error {
+	// Delete all of the collected functions first, so that the tables they
+	// depend on are not left with references to them. The functions mappings
+	// are not updated since the parent schemas are dropped as well.
+	for _, fnDesc := range d.functionsToDelete {
+		if err := p.dropFunctionImpl(ctx, fnDesc, true /* droppingParent */); err != nil {
+			return err
+		}
+	}
+
 	// Delete all of the collected tables.
 	for _, toDel := range d.td {
 		desc := toDel.desc
@@ -0,0 +1,258 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+type dropFunctionNode struct {
+	n      *tree.DropFunction
+	toDrop []*funcdesc.Mutable
+}
+
+// DropFunction drops one or more user-defined functions.
+// Privileges: ownership of the function.
+//   Notes: postgres requires ownership of the function.
+func (p *planner) DropFunction(ctx context.Context, n *tree.DropFunction) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP FUNCTION",
+	); err != nil {
+		return nil, err
+	}
+	if n.DropBehavior == tree.DropCascade {
+		return nil, unimplemented.NewWithIssue(17511, "DROP FUNCTION CASCADE is not yet supported")
+	}
+
+	node := &dropFunctionNode{n: n}
+	seen := make(map[descpb.ID]struct{})
+	for i := range n.Functions {
+		fnDesc, err := p.resolveFunctionForDrop(ctx, &n.Functions[i], !n.IfExists)
+		if err != nil {
+			return nil, err
+		}
+		if fnDesc == nil {
+			continue
+		}
+		if _, ok := seen[fnDesc.GetID()]; ok {
+			continue
+		}
+		seen[fnDesc.GetID()] = struct{}{}
+		hasOwnership, err := p.HasOwnership(ctx, fnDesc)
+		if err != nil {
+			return nil, err
+		}
+		if !hasOwnership {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of function %s", tree.ErrString(&n.Functions[i]))
+		}
+		node.toDrop = append(node.toDrop, fnDesc)
+	}
+	return node, nil
+}
+
+// resolveFunctionForDrop returns the descriptor of the function overload
+// identified by fn. If fn does not specify argument types, the function must
+// have a single overload. If required is false and the function does not
+// exist, a nil descriptor is returned.
+func (p *planner) resolveFunctionForDrop(
+	ctx context.Context, fn *tree.FuncObj, required bool,
+) (*funcdesc.Mutable, error) {
+	var argTypes []*types.T
+	if fn.Args != nil {
+		argTypes = make([]*types.T, len(fn.Args))
+		for i := range fn.Args {
+			typ, err := tree.ResolveType(ctx, fn.Args[i], p.semaCtx.GetTypeResolver())
+			if err != nil {
+				return nil, err
+			}
+			argTypes[i] = typ
+		}
+	}
+
+	notFound := func() (*funcdesc.Mutable, error) {
+		if !required {
+			return nil, nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedFunction,
+			"function %s does not exist", tree.ErrString(fn))
+	}
+
+	def, err := p.ResolveFunction(ctx, fn.FuncName.ToUnresolvedName(), p.CurrentSearchPath())
+	if err != nil {
+		if pgerror.GetPGCode(err) == pgcode.UndefinedFunction {
+			return notFound()
+		}
+		return nil, err
+	}
+	var res *funcdesc.Mutable
+	for _, impl := range def.Definition {
+		o := impl.(*tree.Overload)
+		if argTypes != nil && !typesIdentical(o.Types.Types(), argTypes) {
+			continue
+		}
+		if res != nil {
+			return nil, pgerror.Newf(pgcode.AmbiguousFunction,
+				"function name %q is not unique", fn.FuncName.Object())
+		}
+		id, err := funcdesc.UserDefinedFunctionOIDToID(o.Oid)
+		if err != nil {
+			return nil, err
+		}
+		res, err = p.Descriptors().GetMutableFunctionByID(ctx, p.txn, id, tree.ObjectLookupFlags{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if res == nil {
+		return notFound()
+	}
+	return res, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP FUNCTION performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *dropFunctionNode) ReadingOwnWrites() {}
+
+func (n *dropFunctionNode) startExec(params runParams) error {
+	for _, fnDesc := range n.toDrop {
+		if err := params.p.dropFunctionImpl(params.ctx, fnDesc, false /* droppingParent */); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropFunctionImpl removes the function from the functions mapping of its
+// parent schema, removes its back references from the relations it depends
+// on, and marks its descriptor as dropped. If droppingParent is set, the
+// parent schema or database is being dropped as well and its functions mapping
+// is left untouched.
+func (p *planner) dropFunctionImpl(
+	ctx context.Context, fnDesc *funcdesc.Mutable, droppingParent bool,
+) error {
+	if fnDesc.Dropped() {
+		return errors.Errorf("function %q is already being dropped", fnDesc.GetName())
+	}
+	for _, id := range fnDesc.DependsOn {
+		if err := p.removeFunctionBackReference(ctx, fnDesc, id); err != nil {
+			return err
+		}
+	}
+	fnDesc.DependsOn = nil
+	fnDesc.SetDropped()
+	if err := p.Descriptors().WriteDesc(
+		ctx, p.extendedEvalCtx.Tracing.KVTracingEnabled(), fnDesc, p.txn,
+	); err != nil {
+		return err
+	}
+	if droppingParent {
+		return nil
+	}
+	parent, err := p.getMutableFunctionParent(ctx, fnDesc.GetParentID(), fnDesc.GetParentSchemaID())
+	if err != nil {
+		return err
+	}
+	parent.removeFunction(fnDesc.GetName(), fnDesc.GetID())
+	return p.writeFunctionParentChange(
+		ctx, parent, fmt.Sprintf("updating %s for dropping function %q", parent, fnDesc.GetName()),
+	)
+}
+
+// checkNoDependentFunctions returns an error if a function depends on the
+// relation. It is used for the operations that would invalidate the bodies of
+// the dependent functions, since the bodies refer to relations by name.
+func (p *planner) checkNoDependentFunctions(
+	ctx context.Context, tableDesc catalog.TableDescriptor, objName string, op string,
+) error {
+	fnIDs := tableDesc.GetDependedOnByFunctions()
+	if len(fnIDs) == 0 {
+		return nil
+	}
+	fnDesc, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, fnIDs[0], tree.ObjectLookupFlags{})
+	if err != nil {
+		return errors.Wrapf(err, "error resolving dependent function ID %d", fnIDs[0])
+	}
+	return errors.WithHintf(
+		sqlerrors.NewDependentObjectErrorf("cannot %s %s %q because function %q depends on it",
+			op, tableDesc.DescriptorType(), objName, fnDesc.GetName()),
+		"you can drop %s instead.", fnDesc.GetName())
+}
+
+// canRemoveDependentFunctions returns an error if the relation cannot be
+// dropped with the given behavior because of the functions that depend on it.
+func (p *planner) canRemoveDependentFunctions(
+	ctx context.Context, from *tabledesc.Mutable, behavior tree.DropBehavior,
+) error {
+	if behavior != tree.DropCascade {
+		return p.checkNoDependentFunctions(ctx, from, from.GetName(), "drop")
+	}
+	for _, id := range from.DependedOnByFunctions {
+		fnDesc, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, id, tree.ObjectLookupFlags{})
+		if err != nil {
+			return errors.Wrapf(err, "error resolving dependent function ID %d", id)
+		}
+		hasOwnership, err := p.HasOwnership(ctx, fnDesc)
+		if err != nil {
+			return err
+		}
+		if !hasOwnership {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of function %q", fnDesc.GetName())
+		}
+	}
+	return nil
+}
+
+// dropDependentFunctions drops the functions that depend on the relation,
+// assuming that we wouldn't have made it to this point if `cascade` wasn't
+// enabled.
+func (p *planner) dropDependentFunctions(ctx context.Context, tableDesc *tabledesc.Mutable) error {
+	// Copy out the set of dependents as it may be overwritten in the loop.
+	fnIDs := append([]descpb.ID(nil), tableDesc.DependedOnByFunctions...)
+	for _, id := range fnIDs {
+		fnDesc, err := p.Descriptors().GetMutableFunctionByID(ctx, p.txn, id, tree.ObjectLookupFlags{
+			CommonLookupFlags: tree.CommonLookupFlags{IncludeDropped: true},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error resolving dependent function ID %d", id)
+		}
+		// This function is already getting dropped. Don't do it twice.
+		if fnDesc.Dropped() {
+			continue
+		}
+		if err := p.dropFunctionImpl(ctx, fnDesc, false /* droppingParent */); err != nil {
+			return err
+		}
+	}
+	tableDesc.DependedOnByFunctions = nil
+	return nil
+}
+
+func (*dropFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropFunctionNode) Close(context.Context)        {}
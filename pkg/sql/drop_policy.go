@@ -0,0 +1,133 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type dropPolicyNode struct {
+	n         *tree.DropPolicy
+	tableDesc *tabledesc.Mutable
+	idx       int
+}
+
+// DropPolicy drops a row-level security policy.
+// Privileges: ownership of the table or admin.
+//   notes: postgres requires ownership of the table.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP POLICY",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		// IfExists specified and table did not exist -- noop.
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	if err := p.checkRowLevelSecurityOwnership(ctx, tableDesc); err != nil {
+		return nil, err
+	}
+
+	for i := range tableDesc.Policies {
+		if tableDesc.Policies[i].Name == string(n.Name) {
+			return &dropPolicyNode{n: n, tableDesc: tableDesc, idx: i}, nil
+		}
+	}
+	if n.IfExists {
+		// Noop.
+		return newZeroNode(nil /* columns */), nil
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject,
+		"policy %q for table %q does not exist", string(n.Name), tableDesc.GetName())
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP POLICY performs multiple KV operations on descriptors
+// and expects to see its own writes.
+func (n *dropPolicyNode) ReadingOwnWrites() {}
+
+func (n *dropPolicyNode) startExec(params runParams) error {
+	tableDesc := n.tableDesc
+	tableDesc.Policies = append(tableDesc.Policies[:n.idx], tableDesc.Policies[n.idx+1:]...)
+
+	if err := validateDescriptor(params.ctx, params.p, tableDesc); err != nil {
+		return err
+	}
+
+	return params.p.writeSchemaChange(
+		params.ctx, tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()))
+}
+
+func (n *dropPolicyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPolicyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPolicyNode) Close(context.Context)        {}
+
+// dropPoliciesUsingColumn drops the row-level security policies of the table
+// whose expressions refer to the given column, which is being dropped. An
+// error is returned if there are such policies and the drop behavior is not
+// CASCADE.
+func dropPoliciesUsingColumn(
+	tableDesc *tabledesc.Mutable, col catalog.Column, behavior tree.DropBehavior,
+) error {
+	if len(tableDesc.Policies) == 0 {
+		return nil
+	}
+	policies := make([]descpb.PolicyDescriptor, 0, len(tableDesc.Policies))
+	for i := range tableDesc.Policies {
+		policy := &tableDesc.Policies[i]
+		used := false
+		for _, e := range []string{policy.UsingExpr, policy.WithCheckExpr} {
+			if e == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(e)
+			if err != nil {
+				return err
+			}
+			colIDs, err := schemaexpr.ExtractColumnIDs(tableDesc, expr)
+			if err != nil {
+				return err
+			}
+			used = used || colIDs.Contains(col.GetID())
+		}
+		if !used {
+			policies = append(policies, *policy)
+			continue
+		}
+		if behavior != tree.DropCascade {
+			return pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"cannot drop column %q because policy %q on table %q depends on it",
+				col.GetName(), policy.Name, tableDesc.GetName())
+		}
+	}
+	tableDesc.Policies = policies
+	return nil
+}
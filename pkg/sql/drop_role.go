@@ -77,6 +77,7 @@ const (
 	schema           objectType = "schema"
 	typeObject       objectType = "type"
 	defaultPrivilege objectType = "default_privilege"
+	policyObject     objectType = "policy"
 )
 
 type objectAndType struct {
@@ -193,6 +194,22 @@ func (n *DropRoleNode) startExec(params runParams) error {
 				break
 			}
 		}
+		for _, p := range tableDescriptor.GetPolicies() {
+			for _, r := range p.Roles {
+				role := security.MakeSQLUsernameFromPreNormalizedString(r)
+				if _, ok := userNames[role]; !ok {
+					continue
+				}
+				tn, err := getTableNameFromTableDescriptor(lCtx, tableDescriptor, "")
+				if err != nil {
+					return err
+				}
+				userNames[role] = append(userNames[role], objectAndType{
+					ObjectType: policyObject,
+					ObjectName: fmt.Sprintf("%s on table %s", tree.NameString(p.Name), tn.String()),
+				})
+			}
+		}
 	}
 	for _, schemaDesc := range lCtx.schemaDescs {
 		if !descriptorIsVisible(schemaDesc, true /* allowAdding */) {
@@ -259,6 +276,8 @@ func (n *DropRoleNode) startExec(params runParams) error {
 				switch obj.ObjectType {
 				case database, table, schema, typeObject:
 					objectsMsg.WriteString(fmt.Sprintf("\nowner of %s %s", obj.ObjectType, obj.ObjectName))
+				case policyObject:
+					objectsMsg.WriteString(fmt.Sprintf("\ntarget of %s %s", obj.ObjectType, obj.ObjectName))
 				case defaultPrivilege:
 					hasDependentDefaultPrivilege = true
 					objectsMsg.WriteString(fmt.Sprintf("\n%s", obj.ErrorMessage))
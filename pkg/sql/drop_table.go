@@ -97,6 +97,9 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		if err := p.canRemoveAllTableOwnedSequences(ctx, droppedDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
+		if err := p.canRemoveDependentFunctions(ctx, droppedDesc, n.DropBehavior); err != nil {
+			return nil, err
+		}
 
 	}
 
@@ -410,6 +413,10 @@ func (p *planner) initiateDropTable(
 	// Actually mark table descriptor as dropped.
 	tableDesc.SetDropped()
 
+	if err := p.dropDependentFunctions(ctx, tableDesc); err != nil {
+		return err
+	}
+
 	// Delete namespace entry for table.
 	b := p.txn.NewBatch()
 	p.dropNamespaceEntry(ctx, b, tableDesc)
@@ -97,6 +97,13 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 			return nil, err
 		}
 
+		// Record the descriptor for deletion.
+		node.toDrop[typeDesc.ID] = typeDesc
+		// Domains don't have an implicit array type.
+		if typeDesc.Kind == descpb.TypeDescriptor_DOMAIN {
+			continue
+		}
+
 		// Get the array type that needs to be dropped as well.
 		mutArrayDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, typeDesc.ArrayTypeID)
 		if err != nil {
@@ -106,8 +113,6 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if err := p.canDropTypeDesc(ctx, mutArrayDesc, n.DropBehavior); err != nil {
 			return nil, err
 		}
-		// Record these descriptors for deletion.
-		node.toDrop[typeDesc.ID] = typeDesc
 		node.toDrop[mutArrayDesc.ID] = mutArrayDesc
 	}
 	return node, nil
@@ -76,6 +76,9 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 				return nil, err
 			}
 		}
+		if err := p.canRemoveDependentFunctions(ctx, droppedDesc, n.DropBehavior); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(td) == 0 {
@@ -162,7 +165,7 @@ func (p *planner) canRemoveDependentViewGeneric(
 			return err
 		}
 	}
-	return nil
+	return p.canRemoveDependentFunctions(ctx, viewDesc, behavior)
 }
 
 // Drops the view and any additional views that depend on it.
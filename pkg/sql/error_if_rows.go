@@ -26,6 +26,11 @@ type errorIfRowsNode struct {
 	// produced.
 	mkErr exec.MkErrFn
 
+	// deferrable is set if the node checks a deferrable constraint. If the
+	// constraint is deferred, a violation is recorded in the transaction state
+	// instead of returning an error.
+	deferrable *exec.DeferrableCheck
+
 	nexted bool
 }
 
@@ -44,6 +49,13 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 		return false, err
 	}
 	if ok {
+		if state := params.extendedEvalCtx.DeferredConstraints; n.deferrable != nil &&
+			state != nil && state.isDeferred(n.deferrable) {
+			// The whole table is checked again at commit, so there is no need
+			// to look at the other violating rows.
+			state.addViolation(n.deferrable)
+			return false, nil
+		}
 		return false, n.mkErr(n.plan.Values())
 	}
 	return false, nil
@@ -256,7 +256,38 @@ func constructVirtualScan(
 	if err != nil {
 		return nil, err
 	}
+	return finishVirtualScan(ef, n, table, len(columns), params, reqOrdering)
+}
 
+// constructForeignScan constructs the scan of a foreign table. Foreign tables
+// are planned like virtual tables, but their rows are read by a foreignScanNode
+// instead of a virtual table generator.
+func constructForeignScan(
+	ef exec.Factory,
+	p *planner,
+	table cat.Table,
+	params exec.ScanParams,
+	reqOrdering exec.OutputOrdering,
+) (exec.Node, error) {
+	n, err := p.newForeignScanNode(p.EvalContext().Context, table.(*optVirtualTable).desc)
+	if err != nil {
+		return nil, err
+	}
+	return finishVirtualScan(ef, n, table, len(n.resultColumns), params, reqOrdering)
+}
+
+// finishVirtualScan adds the projection, limit and sort required by the given
+// scan parameters on top of n, the node producing all numColumns columns of a
+// virtual table.
+func finishVirtualScan(
+	ef exec.Factory,
+	n exec.Node,
+	table cat.Table,
+	numColumns int,
+	params exec.ScanParams,
+	reqOrdering exec.OutputOrdering,
+) (exec.Node, error) {
+	var err error
 	// Check for explicit use of the dummy column.
 	if params.NeededCols.Contains(0) {
 		return nil, errors.Errorf("use of %s column not allowed.", table.Column(0).ColName())
@@ -265,7 +296,7 @@ func constructVirtualScan(
 		// We shouldn't have allowed SELECT FOR UPDATE for a virtual table.
 		return nil, errors.AssertionFailedf("locking cannot be used with virtual table")
 	}
-	if needed := params.NeededCols; needed.Len() != len(columns) {
+	if needed := params.NeededCols; needed.Len() != numColumns {
 		// We are selecting a subset of columns; we need a projection.
 		cols := make([]exec.NodeColumnOrdinal, 0, needed.Len())
 		for ord, ok := needed.Next(0); ok; ord, ok = needed.Next(ord + 1) {
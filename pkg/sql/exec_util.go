@@ -63,6 +63,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/gcjob/gcjobnotifier"
+	"github.com/cockroachdb/cockroach/pkg/sql/notifywatcher"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
@@ -239,6 +240,17 @@ var traceSessionEventLogEnabled = settings.RegisterBoolSetting(
 	false,
 ).WithPublic()
 
+// readCommittedIsolationEnabled controls whether transactions that request
+// the READ COMMITTED isolation level actually run with it. When disabled,
+// such transactions are upgraded to SERIALIZABLE.
+var readCommittedIsolationEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.txn.read_committed_isolation.enabled",
+	"set to true to allow transactions to use the READ COMMITTED isolation level; "+
+		"if false, READ COMMITTED transactions are upgraded to SERIALIZABLE",
+	false,
+).WithPublic()
+
 // ReorderJoinsLimitClusterSettingName is the name of the cluster setting for
 // the maximum number of joins to reorder.
 const ReorderJoinsLimitClusterSettingName = "sql.defaults.reorder_joins_limit"
@@ -1163,6 +1175,10 @@ type ExecutorConfig struct {
 	// StmtDiagnosticsRecorder deals with recording statement diagnostics.
 	StmtDiagnosticsRecorder *stmtdiagnostics.Registry
 
+	// NotificationWatcher delivers the notifications sent with NOTIFY to the
+	// sessions listening on their channel.
+	NotificationWatcher *notifywatcher.Watcher
+
 	ExternalIODirConfig base.ExternalIODirConfig
 
 	GCJobNotifier *gcjobnotifier.Notifier
@@ -1496,6 +1512,12 @@ func getPlanDistribution(
 		return physicalplan.LocalPlan
 	}
 
+	// A pausable portal cannot use leaf transactions, since the transaction is
+	// used by other statements while the portal is suspended.
+	if p.pausablePortal {
+		return physicalplan.LocalPlan
+	}
+
 	if _, singleTenant := nodeID.OptionalNodeID(); !singleTenant {
 		return physicalplan.LocalPlan
 	}
@@ -1633,6 +1655,8 @@ func checkResultType(typ *types.T) error {
 	case types.TupleFamily:
 	case types.EnumFamily:
 	case types.VoidFamily:
+	case types.TSQueryFamily:
+	case types.TSVectorFamily:
 	case types.ArrayFamily:
 		if typ.ArrayContents().Family() == types.ArrayFamily {
 			// Technically we could probably return arrays of arrays to a
@@ -2739,6 +2763,10 @@ func (m *sessionDataMutator) SetDefaultTransactionPriority(val tree.UserPriority
 	m.data.DefaultTxnPriority = int64(val)
 }
 
+func (m *sessionDataMutator) SetDefaultTransactionIsolationLevel(val tree.IsolationLevel) {
+	m.data.DefaultTxnIsolationLevel = int64(val)
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionReadOnly(val bool) {
 	m.data.DefaultTxnReadOnly = val
 }
@@ -77,6 +77,16 @@ func (m *ParquetWriterSpec) User() security.SQLUsername {
 	return m.UserProto.Decode()
 }
 
+// User accesses the user field.
+func (m *ExportManifestWriterSpec) User() security.SQLUsername {
+	return m.UserProto.Decode()
+}
+
+// User accesses the user field.
+func (m *ForeignScanSpec) User() security.SQLUsername {
+	return m.UserProto.Decode()
+}
+
 // User accesses the user field.
 func (m *ReadImportDataSpec) User() security.SQLUsername {
 	return m.UserProto.Decode()
@@ -501,6 +501,21 @@ func (s *CSVWriterSpec) summary() (string, []string) {
 	return "CSVWriter", []string{s.Destination}
 }
 
+// summary implements the diagramCellType interface.
+func (s *ExportManifestWriterSpec) summary() (string, []string) {
+	return "ExportManifestWriter", []string{s.Destination, s.Name}
+}
+
+// summary implements the diagramCellType interface.
+func (s *ForeignScanSpec) summary() (string, []string) {
+	ss := make([]string, 0, len(s.Uri)+1)
+	ss = append(ss, s.Table.Name)
+	for _, u := range s.Uri {
+		ss = append(ss, u)
+	}
+	return "ForeignScan", ss
+}
+
 // summary implements the diagramCellType interface.
 func (s *BulkRowWriterSpec) summary() (string, []string) {
 	return "BulkRowWriterSpec", []string{}
@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -48,6 +49,15 @@ type exportNode struct {
 	fileCompression execinfrapb.FileCompression
 	colNames        []string
 	colNullability  []bool
+	// incrementalFrom is set for incremental exports, which only export the
+	// rows that changed after it, and write a manifest named manifestName.
+	incrementalFrom hlc.Timestamp
+	manifestName    string
+	// incrementalScan is the scan of the rows of an incremental export, and
+	// incrementalFilter the filter applied to them, if any. They are used to
+	// find the rows to report as deleted in the manifest.
+	incrementalScan   *scanNode
+	incrementalFilter tree.TypedExpr
 }
 
 func (e *exportNode) startExec(params runParams) error {
@@ -73,6 +83,9 @@ const (
 	exportOptionChunkSize   = "chunk_size"
 	exportOptionFileName    = "filename"
 	exportOptionCompression = "compression"
+	// exportOptionIncrementalFrom exports only the rows that changed after the
+	// given timestamp.
+	exportOptionIncrementalFrom = "incremental_from"
 )
 
 var exportOptionExpectValues = map[string]KVStringOptValidate{
@@ -82,6 +95,8 @@ var exportOptionExpectValues = map[string]KVStringOptValidate{
 	exportOptionNullAs:      KVStringOptRequireValue,
 	exportOptionCompression: KVStringOptRequireValue,
 	exportOptionChunkSize:   KVStringOptRequireValue,
+
+	exportOptionIncrementalFrom: KVStringOptRequireValue,
 }
 
 const exportChunkSizeDefault = int64(32 << 20) // 32 MB
@@ -90,6 +105,7 @@ const exportFilePatternPart = "%part%"
 const exportCompressionCodec = "gzip"
 const csvSuffix = "csv"
 const parquetSuffix = "parquet"
+const exportManifestSuffix = "manifest.json"
 
 // featureExportEnabled is used to enable and disable the EXPORT feature.
 var featureExportEnabled = settings.RegisterBoolSetting(
@@ -229,18 +245,102 @@ func (ef *execFactory) ConstructExport(
 		}
 	}
 
+	var incrementalFrom hlc.Timestamp
+	var incrementalScan *scanNode
+	var incrementalFilter tree.TypedExpr
+	if from, ok := optVals[exportOptionIncrementalFrom]; ok {
+		asOf, err := ef.planner.EvalAsOfTimestamp(
+			ef.planner.EvalContext().Context, tree.AsOfClause{Expr: tree.NewStrVal(from)},
+		)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid %s", exportOptionIncrementalFrom)
+		}
+		incrementalFrom = asOf.Timestamp
+		if readTS := ef.planner.Txn().ReadTimestamp(); !incrementalFrom.Less(readTS) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s %s must be before the statement time %s",
+				exportOptionIncrementalFrom, incrementalFrom, readTS)
+		}
+		incrementalScan, incrementalFilter, err = setIncrementalExportScan(
+			input.(planNode), incrementalFrom,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	exportID := ef.planner.stmt.QueryID.String()
 	namePattern := fmt.Sprintf("export%s-%s", exportID, exportFilePattern)
+	manifestName := fmt.Sprintf("export%s-%s", exportID, exportManifestSuffix)
 	return &exportNode{
-		source:          input.(planNode),
-		destination:     string(*destination),
-		fileNamePattern: namePattern,
-		csvOpts:         csvOpts,
-		parquetOpts:     parquetOpts,
-		chunkRows:       chunkRows,
-		chunkSize:       chunkSize,
-		fileCompression: codec,
-		colNames:        colNames,
-		colNullability:  colNullability,
+		source:            input.(planNode),
+		destination:       string(*destination),
+		fileNamePattern:   namePattern,
+		csvOpts:           csvOpts,
+		parquetOpts:       parquetOpts,
+		chunkRows:         chunkRows,
+		chunkSize:         chunkSize,
+		fileCompression:   codec,
+		colNames:          colNames,
+		colNullability:    colNullability,
+		incrementalFrom:   incrementalFrom,
+		manifestName:      manifestName,
+		incrementalScan:   incrementalScan,
+		incrementalFilter: incrementalFilter,
 	}, nil
 }
+
+// setIncrementalExportScan restricts the scan that produces the rows of an
+// incremental EXPORT to the rows that changed after ts. Only exports of the
+// (possibly filtered and projected) rows of a table can be incremental, since
+// the results of other queries cannot be computed from the changed rows alone.
+// Moreover, the scan must be over the primary index of a table with a single
+// column family, since the rows are read from the changed KVs.
+//
+// It returns the scan and the filter applied to the scanned rows, if any. The
+// rows that changed but no longer pass the filter are reported as deleted, so
+// the filter must refer to the columns of the scan.
+func setIncrementalExportScan(
+	plan planNode, ts hlc.Timestamp,
+) (*scanNode, tree.TypedExpr, error) {
+	switch n := plan.(type) {
+	case *renderNode:
+		return setIncrementalExportScan(n.source.plan, ts)
+	case *filterNode:
+		scan, filter, err := setIncrementalExportScan(n.source.plan, ts)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch n.source.plan.(type) {
+		case *scanNode, *filterNode:
+		default:
+			return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"incremental EXPORT only supports filters on the columns of %s", scan.desc.GetName())
+		}
+		if filter == nil {
+			return scan, n.filter, nil
+		}
+		return scan, tree.NewTypedAndExpr(filter, n.filter), nil
+	case *scanNode:
+		if n.index.GetID() != n.desc.GetPrimaryIndexID() {
+			return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"incremental EXPORT must scan the primary index of %s, found index %s",
+				n.desc.GetName(), n.index.GetName())
+		}
+		if len(n.desc.GetFamilies()) > 1 {
+			return nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"incremental EXPORT of %s: tables with multiple column families are not supported",
+				n.desc.GetName())
+		}
+		if n.reverse || n.hardLimit != 0 {
+			return nil, nil, pgerror.New(pgcode.FeatureNotSupported,
+				"incremental EXPORT does not support ordered or limited queries")
+		}
+		n.incrementalFrom = ts
+		return n, nil, nil
+	default:
+		return nil, nil, pgerror.New(pgcode.FeatureNotSupported,
+			"incremental EXPORT is only supported for queries over the rows of a single table")
+	}
+}
@@ -238,6 +238,16 @@ func (*DummyEvalPlanner) DecodeGist(gist string) ([]string, error) {
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// SendNotification is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) SendNotification(ctx context.Context, channel, payload string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// ListeningChannels is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) ListeningChannels() []string {
+	return nil
+}
+
 // ExecutorConfig is part of the EvalPlanner interface.
 func (*DummyEvalPlanner) ExecutorConfig() interface{} {
 	return nil
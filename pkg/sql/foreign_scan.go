@@ -0,0 +1,87 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// foreignScanNode reads all the rows of a foreign table from the files of the
+// table in external storage. Like scanNode, it is only a placeholder for the
+// ForeignScan processors which are planned by the DistSQLPlanner.
+type foreignScanNode struct {
+	desc catalog.TableDescriptor
+	// resultColumns are the public columns of the table.
+	resultColumns colinfo.ResultColumns
+	// uri is the URI of the server of the table.
+	uri string
+	// location is the path of the files of the table, relative to uri. It may
+	// contain wildcards.
+	location string
+	format   roachpb.IOFileFormat
+	// user is the user on behalf of whom the external storage is accessed.
+	user security.SQLUsername
+}
+
+// newForeignScanNode creates a foreignScanNode which reads the rows of the
+// given foreign table.
+func (p *planner) newForeignScanNode(
+	ctx context.Context, desc catalog.TableDescriptor,
+) (*foreignScanNode, error) {
+	foreignTable := desc.GetForeignTable()
+	if foreignTable == nil {
+		return nil, errors.AssertionFailedf("%q is not a foreign table", desc.GetName())
+	}
+	_, dbDesc, err := p.Descriptors().GetImmutableDatabaseByID(
+		ctx, p.txn, desc.GetParentID(), tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	server, ok := dbDesc.GetForeignServer(foreignTable.Server)
+	if !ok {
+		return nil, errors.AssertionFailedf(
+			"server %q of foreign table %q does not exist", foreignTable.Server, desc.GetName())
+	}
+	format, location, err := makeForeignTableFormat(foreignTable)
+	if err != nil {
+		return nil, err
+	}
+	return &foreignScanNode{
+		desc:          desc,
+		resultColumns: colinfo.ResultColumnsFromColumns(desc.GetID(), desc.PublicColumns()),
+		uri:           server.URI,
+		location:      location,
+		format:        format,
+		user:          p.User(),
+	}, nil
+}
+
+func (n *foreignScanNode) startExec(params runParams) error {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Next(params runParams) (bool, error) {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Values() tree.Datums {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Close(context.Context) {}
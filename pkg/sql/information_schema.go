@@ -1268,6 +1268,19 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 				tbNameStr := tree.NewDString(table.GetName())
 
 				for conName, c := range conInfo {
+					// Like in Postgres, exclusion constraints are not included.
+					if c.Kind == descpb.ConstraintTypeExclusion {
+						continue
+					}
+					deferrability := descpb.ConstraintDeferrability_NotDeferrable
+					switch {
+					case c.FK != nil:
+						deferrability = c.FK.Deferrability
+					case c.UniqueWithoutIndexConstraint != nil:
+						deferrability = c.UniqueWithoutIndexConstraint.Deferrability
+					}
+					isDeferrable := deferrability != descpb.ConstraintDeferrability_NotDeferrable
+					initiallyDeferred := deferrability == descpb.ConstraintDeferrability_InitiallyDeferred
 					if err := addRow(
 						dbNameStr,                       // constraint_catalog
 						scNameStr,                       // constraint_schema
@@ -1276,8 +1289,8 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 						scNameStr,                       // table_schema
 						tbNameStr,                       // table_name
 						tree.NewDString(string(c.Kind)), // constraint_type
-						yesOrNoDatum(false),             // is_deferrable
-						yesOrNoDatum(false),             // initially_deferred
+						yesOrNoDatum(isDeferrable),      // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -1390,6 +1403,7 @@ var (
 	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
 	tableTypeView       = tree.NewDString("VIEW")
 	tableTypeTemporary  = tree.NewDString("LOCAL TEMPORARY")
+	tableTypeForeign    = tree.NewDString("FOREIGN")
 )
 
 var informationSchemaTablesTable = virtualSchemaTable{
@@ -1421,6 +1435,9 @@ func addTablesTableRow(
 		} else if table.IsView() {
 			tableType = tableTypeView
 			insertable = noString
+		} else if table.IsForeignTable() {
+			tableType = tableTypeForeign
+			insertable = noString
 		} else if table.IsTemporary() {
 			tableType = tableTypeTemporary
 		}
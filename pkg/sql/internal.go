@@ -850,6 +850,7 @@ func (icc *internalClientComm) CreateStatementResult(
 	_ int,
 	_ string,
 	_ bool,
+	_ bool,
 ) CommandResult {
 	return icc.createRes(pos, nil /* onClose */)
 }
@@ -935,11 +936,21 @@ func (icc *internalClientComm) CreateCopyInResult(pos CmdPos) CopyInResult {
 	panic("unimplemented")
 }
 
+// CreateCopyOutResult is part of the ClientComm interface.
+func (icc *internalClientComm) CreateCopyOutResult(pos CmdPos) CopyOutResult {
+	panic("unimplemented")
+}
+
 // CreateDrainResult is part of the ClientComm interface.
 func (icc *internalClientComm) CreateDrainResult(pos CmdPos) DrainResult {
 	panic("unimplemented")
 }
 
+// CreateNotificationResult is part of the ClientComm interface.
+func (icc *internalClientComm) CreateNotificationResult(pos CmdPos) NotificationResult {
+	panic("unimplemented")
+}
+
 // noopClientLock is an implementation of ClientLock that says that no results
 // have been communicated to the client.
 type noopClientLock internalClientComm
@@ -85,6 +85,64 @@ REFRESH MATERIALIZED VIEW t.v;
 }
 
 // TestMaterializedViewRefreshVisibility ensures that intermediate results written
+// TestIncrementalViewBackfillAllowsWrites ensures that the tables an
+// incrementally maintained view depends on can be written while the view is
+// backfilled, and that these writes are reflected in the view exactly once.
+func TestIncrementalViewBackfillAllowsWrites(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	params, _ := tests.CreateTestServerParams()
+
+	waitForBackfill, waitToProceed := make(chan struct{}), make(chan struct{})
+	params.Knobs = base.TestingKnobs{
+		SQLSchemaChanger: &sql.SchemaChangerTestingKnobs{
+			RunBeforeQueryBackfill: func() error {
+				close(waitForBackfill)
+				<-waitToProceed
+				return nil
+			},
+		},
+	}
+
+	s, sqlDB, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(ctx)
+
+	runner := sqlutils.MakeSQLRunner(sqlDB)
+	runner.Exec(t, `
+CREATE DATABASE t;
+CREATE TABLE t.t (k INT PRIMARY KEY, g INT, v INT);
+INSERT INTO t.t VALUES (1, 1, 10), (2, 1, 20), (3, 2, 30);
+`)
+
+	createDone := make(chan struct{})
+	go func() {
+		defer close(createDone)
+		if _, err := sqlDB.Exec(`
+CREATE MATERIALIZED VIEW t.v WITH (incremental = true) AS
+  SELECT g, count(*) AS c, sum(v) AS s FROM t.t GROUP BY g
+`); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// Write to the table while the view is being backfilled.
+	<-waitForBackfill
+	runner.Exec(t, `
+INSERT INTO t.t VALUES (4, 2, 40), (5, 3, 50);
+UPDATE t.t SET v = v + 1 WHERE k = 1;
+DELETE FROM t.t WHERE k = 2;
+`)
+
+	close(waitToProceed)
+	<-createDone
+	runner.Exec(t, `INSERT INTO t.t VALUES (6, 1, 60)`)
+	runner.CheckQueryResults(t, "SELECT g, c, s FROM t.v ORDER BY g", [][]string{
+		{"1", "2", "71"}, {"2", "2", "70"}, {"3", "1", "50"},
+	})
+}
+
 // as part of the refresh backfill process aren't visibile until the refresh is done.
 func TestMaterializedViewRefreshVisibility(t *testing.T) {
 	defer leaktest.AfterTest(t)()
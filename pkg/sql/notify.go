@@ -0,0 +1,225 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/notifywatcher"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// maxChannelNameLength is the maximum length of a channel name, which is an
+// identifier in Postgres and is therefore limited to NAMEDATALEN-1 bytes.
+const maxChannelNameLength = 63
+
+// maxNotificationPayloadLength is the maximum length of the payload of a
+// notification, which matches the limit of Postgres.
+const maxNotificationPayloadLength = 8000
+
+// listenState is the state of the LISTEN and UNLISTEN statements of a session.
+type listenState struct {
+	// sub is the subscription of the session to the notifications sent on the
+	// channels it listens on. It is created when the first LISTEN statement of
+	// the session commits, and closed with the session.
+	sub *notifywatcher.Subscription
+	// pending are the LISTEN and UNLISTEN statements of the current
+	// transaction. Like in Postgres, they only take effect when the
+	// transaction commits.
+	pending []listenAction
+}
+
+// listenAction is a LISTEN or UNLISTEN statement which has not been committed
+// yet.
+type listenAction struct {
+	listen bool
+	// channel is empty for UNLISTEN *.
+	channel string
+}
+
+// channels returns the sorted names of the channels the session listens on.
+func (s *listenState) channels() []string {
+	if s.sub == nil {
+		return nil
+	}
+	return s.sub.Channels()
+}
+
+// commit applies the pending actions of the transaction which just committed.
+// subscribe is used to create the subscription of the session on its first
+// LISTEN statement.
+func (s *listenState) commit(subscribe func() *notifywatcher.Subscription) {
+	for _, a := range s.pending {
+		switch {
+		case a.listen:
+			if s.sub == nil {
+				s.sub = subscribe()
+			}
+			s.sub.Listen(a.channel)
+		case s.sub == nil:
+			// The session doesn't listen on any channel.
+		case a.channel == "":
+			s.sub.UnlistenAll()
+		default:
+			s.sub.Unlisten(a.channel)
+		}
+	}
+	s.reset()
+}
+
+// reset discards the pending actions of the current transaction.
+func (s *listenState) reset() {
+	s.pending = s.pending[:0]
+}
+
+// close closes the subscription of the session, if any.
+func (s *listenState) close() {
+	if s.sub != nil {
+		s.sub.Close()
+		s.sub = nil
+	}
+}
+
+// commitListens applies the LISTEN and UNLISTEN statements of the transaction
+// which just committed.
+func (ex *connExecutor) commitListens(ctx context.Context) {
+	ex.listens.commit(func() *notifywatcher.Subscription {
+		return ex.server.cfg.NotificationWatcher.Subscribe(func() {
+			// Wake up the session so that the notifications are sent to the
+			// client even if it is idle. The error is only returned when the
+			// statement buffer is closed, in which case the session is going
+			// away.
+			_ = ex.stmtBuf.Push(ctx, SendNotifications{})
+		})
+	})
+}
+
+// notificationBuffer is implemented by the results which can carry
+// notifications to the client.
+type notificationBuffer interface {
+	BufferNotification(notification pgnotify.Notification)
+	BufferNotice(notice pgnotice.Notice)
+}
+
+// bufferNotifications buffers the pending notifications of the session into
+// res. Like in Postgres, notifications are only sent to the client between
+// transactions, so nothing is buffered while a transaction is open. If
+// notifications were dropped because the queue of the session was full, a
+// warning is buffered as well so that the client knows it missed some.
+func (ex *connExecutor) bufferNotifications(res notificationBuffer) {
+	if ex.listens.sub == nil {
+		return
+	}
+	if _, noTxn := ex.machine.CurState().(stateNoTxn); !noTxn {
+		return
+	}
+	pending, dropped := ex.listens.sub.Drain()
+	if dropped > 0 {
+		res.BufferNotice(pgnotice.NewWithSeverityf("WARNING",
+			"%d notifications were dropped because the notification queue is full", dropped,
+		))
+	}
+	for _, n := range pending {
+		res.BufferNotification(n)
+	}
+}
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if err := p.addListenAction(ctx, "LISTEN", listenAction{
+		listen: true, channel: string(n.ChannelName),
+	}); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html for details.
+func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
+	if err := p.addListenAction(ctx, "UNLISTEN", listenAction{
+		channel: string(n.ChannelName),
+	}); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+func (p *planner) addListenAction(ctx context.Context, stmt string, a listenAction) error {
+	s := p.extendedEvalCtx.Listens
+	if s == nil {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported in this context", stmt)
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.NotificationsTable) {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"%s requires all nodes to be upgraded to %s",
+			stmt, clusterversion.ByKey(clusterversion.NotificationsTable),
+		)
+	}
+	s.pending = append(s.pending, a)
+	return nil
+}
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	var payload string
+	if n.Payload != nil {
+		payload = n.Payload.RawString()
+	}
+	if err := p.SendNotification(ctx, string(n.ChannelName), payload); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// SendNotification is part of the tree.EvalPlanner interface.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxChannelNameLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	if len(payload) >= maxNotificationPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	if p.EvalContext().TxnReadOnly {
+		return pgerror.New(pgcode.ReadOnlySQLTransaction,
+			"cannot execute NOTIFY in a read-only transaction")
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.NotificationsTable) {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"NOTIFY requires all nodes to be upgraded to %s",
+			clusterversion.ByKey(clusterversion.NotificationsTable),
+		)
+	}
+	return p.ExecCfg().NotificationWatcher.Notify(ctx, p.txn, pgnotify.Notification{
+		Channel: channel,
+		Payload: payload,
+		PID:     int32(p.ExecCfg().NodeID.SQLInstanceID()),
+	})
+}
+
+// ListeningChannels is part of the tree.EvalPlanner interface.
+func (p *planner) ListeningChannels() []string {
+	if s := p.extendedEvalCtx.Listens; s != nil {
+		return s.channels()
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package notifywatcher delivers the notifications sent with NOTIFY to the
+// sessions listening on their channel. Notifications are written to the
+// system.notifications table in the transaction of the sender, and every SQL
+// instance watches the table with a range feed, so notifications are only
+// delivered once the sending transaction commits.
+package notifywatcher
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// retention is the duration for which rows are kept in the notifications
+// table. Notifications are delivered when the sending transaction commits, so
+// rows only need to be retained for long enough for the range feeds of all
+// SQL instances to observe them.
+var retention = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"sql.notifications.retention",
+	"the duration for which sent notifications are kept in system.notifications",
+	10*time.Minute,
+	settings.PositiveDuration,
+)
+
+// MaxPendingNotifications is the maximum number of notifications which can be
+// queued for a single subscription. Notifications received while the queue is
+// full are dropped, and their number is reported by the next Drain so that the
+// session can warn the client.
+const MaxPendingNotifications = 10000
+
+// cleanupBatchSize is the maximum number of rows deleted from the
+// notifications table by a single statement.
+const cleanupBatchSize = 1000
+
+// Watcher watches the notifications table with a range feed and delivers
+// notifications to the subscriptions of the local sessions.
+type Watcher struct {
+	clock    *hlc.Clock
+	codec    keys.SQLCodec
+	settings *cluster.Settings
+	f        *rangefeed.Factory
+	stopper  *stop.Stopper
+	ie       sqlutil.InternalExecutor
+	dec      rowDecoder
+
+	mu struct {
+		syncutil.RWMutex
+
+		subs map[*Subscription]struct{}
+	}
+
+	delivered struct {
+		syncutil.Mutex
+
+		// ids maps the IDs of the notifications which were delivered to the
+		// subscriptions to the MVCC timestamp of their row. The range feed
+		// delivers rows again when it restarts from its frontier, so an ID is
+		// only forgotten once the frontier passes its timestamp.
+		ids map[int64]hlc.Timestamp
+	}
+}
+
+// New constructs a new Watcher.
+func New(
+	clock *hlc.Clock,
+	codec keys.SQLCodec,
+	settings *cluster.Settings,
+	f *rangefeed.Factory,
+	stopper *stop.Stopper,
+	ie sqlutil.InternalExecutor,
+) *Watcher {
+	w := &Watcher{
+		clock:    clock,
+		codec:    codec,
+		settings: settings,
+		f:        f,
+		stopper:  stopper,
+		ie:       ie,
+		dec:      makeRowDecoder(codec),
+	}
+	w.mu.subs = make(map[*Subscription]struct{})
+	w.delivered.ids = make(map[int64]hlc.Timestamp)
+	return w
+}
+
+// Start starts the range feed over the notifications table and the task which
+// deletes old notifications from it.
+func (w *Watcher) Start(ctx context.Context) error {
+	tablePrefix := w.codec.TablePrefix(keys.NotificationsTableID)
+	tableSpan := roachpb.Span{
+		Key:    tablePrefix,
+		EndKey: tablePrefix.PrefixEnd(),
+	}
+	rf, err := w.f.RangeFeed(ctx, "notifications", []roachpb.Span{tableSpan}, w.clock.Now(), func(
+		ctx context.Context, kv *roachpb.RangeFeedValue,
+	) {
+		id, n, tombstone, err := w.dec.decodeRow(roachpb.KeyValue{
+			Key:   kv.Key,
+			Value: kv.Value,
+		})
+		if err != nil {
+			log.Warningf(ctx, "failed to decode notifications row %v: %v", kv.Key, err)
+			return
+		}
+		if tombstone {
+			// This event corresponds to the deletion of an old notification.
+			return
+		}
+		w.deliver(id, kv.Value.Timestamp, n)
+	}, rangefeed.WithOnFrontierAdvance(func(ctx context.Context, frontier hlc.Timestamp) {
+		w.forgetDelivered(frontier)
+	}))
+	if err != nil {
+		// We are shutting down.
+		return err
+	}
+	w.stopper.AddCloser(rf)
+
+	ctx, _ = w.stopper.WithCancelOnQuiesce(ctx)
+	return w.stopper.RunAsyncTask(ctx, "notifications-cleanup", w.cleanupLoop)
+}
+
+// deliver delivers the notification with the given ID, whose row was written at
+// the given timestamp, to the subscriptions, unless it was already delivered.
+func (w *Watcher) deliver(id int64, ts hlc.Timestamp, n pgnotify.Notification) {
+	w.delivered.Lock()
+	_, ok := w.delivered.ids[id]
+	if !ok {
+		w.delivered.ids[id] = ts
+	}
+	w.delivered.Unlock()
+	if ok {
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for sub := range w.mu.subs {
+		sub.deliver(n)
+	}
+}
+
+// forgetDelivered forgets the IDs of the delivered notifications whose rows
+// were written before the frontier of the range feed. The range feed never
+// delivers these rows again.
+func (w *Watcher) forgetDelivered(frontier hlc.Timestamp) {
+	w.delivered.Lock()
+	defer w.delivered.Unlock()
+	for id, ts := range w.delivered.ids {
+		if ts.Less(frontier) {
+			delete(w.delivered.ids, id)
+		}
+	}
+}
+
+// Notify writes a notification to the notifications table in the supplied
+// transaction. The notification is delivered to the listening sessions once
+// the transaction commits.
+func (w *Watcher) Notify(ctx context.Context, txn *kv.Txn, n pgnotify.Notification) error {
+	_, err := w.ie.ExecEx(
+		ctx, "notify", txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`INSERT INTO system.notifications (id, channel, payload, pid, created)
+VALUES (unique_rowid(), $1, $2, $3, now())`,
+		n.Channel, n.Payload, n.PID,
+	)
+	return err
+}
+
+func (w *Watcher) cleanupLoop(ctx context.Context) {
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(retention.Get(&w.settings.SV))
+		select {
+		case <-timer.C:
+			timer.Read = true
+			if err := w.cleanup(ctx); err != nil && ctx.Err() == nil {
+				log.Warningf(ctx, "error deleting old notifications: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// cleanup deletes the notifications older than the retention.
+func (w *Watcher) cleanup(ctx context.Context) error {
+	if !w.settings.Version.IsActive(ctx, clusterversion.NotificationsTable) {
+		return nil
+	}
+	before := timeutil.Now().Add(-retention.Get(&w.settings.SV))
+	for {
+		rows, err := w.ie.ExecEx(
+			ctx, "delete-old-notifications", nil, /* txn */
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			`DELETE FROM system.notifications WHERE created < $1 LIMIT $2`,
+			before, cleanupBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+		if rows < cleanupBatchSize {
+			return nil
+		}
+	}
+}
+
+// Subscribe creates a new Subscription, which initially does not listen on
+// any channel. onNotify is called when a notification is queued for the
+// subscription while no other notification is pending, and it must not block.
+// The Subscription must be closed once it is no longer needed.
+func (w *Watcher) Subscribe(onNotify func()) *Subscription {
+	sub := &Subscription{
+		w:        w,
+		onNotify: onNotify,
+	}
+	sub.mu.channels = make(map[string]struct{})
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.mu.subs[sub] = struct{}{}
+	return sub
+}
+
+// Subscription is the set of channels a session listens on, along with the
+// notifications received on them which have not been sent to the client yet.
+type Subscription struct {
+	w        *Watcher
+	onNotify func()
+
+	mu struct {
+		syncutil.Mutex
+
+		channels map[string]struct{}
+		pending  []pgnotify.Notification
+		// dropped is the number of notifications which were dropped since the
+		// last Drain because too many notifications were pending.
+		dropped int
+	}
+}
+
+// Listen registers the subscription as a listener on the given channel.
+func (s *Subscription) Listen(channel string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.channels[channel] = struct{}{}
+}
+
+// Unlisten removes the subscription as a listener on the given channel.
+func (s *Subscription) Unlisten(channel string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.mu.channels, channel)
+}
+
+// UnlistenAll removes the subscription as a listener on all channels.
+func (s *Subscription) UnlistenAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.channels = make(map[string]struct{})
+}
+
+// Channels returns the sorted names of the channels the subscription listens
+// on.
+func (s *Subscription) Channels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channels := make([]string, 0, len(s.mu.channels))
+	for channel := range s.mu.channels {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Drain returns the pending notifications of the subscription, in the order in
+// which they were received, and clears them. It also returns the number of
+// notifications which were dropped since the last call because the queue of
+// the subscription was full.
+func (s *Subscription) Drain() (pending []pgnotify.Notification, dropped int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending, dropped = s.mu.pending, s.mu.dropped
+	s.mu.pending = nil
+	s.mu.dropped = 0
+	return pending, dropped
+}
+
+// Close removes the subscription from the watcher. No notifications are
+// delivered to the subscription after Close returns.
+func (s *Subscription) Close() {
+	s.w.mu.Lock()
+	defer s.w.mu.Unlock()
+	delete(s.w.mu.subs, s)
+}
+
+// deliver queues the notification if the subscription listens on its channel.
+func (s *Subscription) deliver(n pgnotify.Notification) {
+	if s.queue(n) {
+		s.onNotify()
+	}
+}
+
+// queue queues the notification if the subscription listens on its channel,
+// and returns whether it is the only pending notification. If the queue is
+// full, the notification is counted as dropped instead. The session was
+// already woken up by the first pending notification in that case, and it
+// reports the dropped notifications to the client when it drains the queue.
+func (s *Subscription) queue(n pgnotify.Notification) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.mu.channels[n.Channel]; !ok {
+		return false
+	}
+	if len(s.mu.pending) >= MaxPendingNotifications {
+		s.mu.dropped++
+		return false
+	}
+	s.mu.pending = append(s.mu.pending, n)
+	return len(s.mu.pending) == 1
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package notifywatcher_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWatcherDeliversCommittedNotifications ensures that notifications sent
+// on one node are delivered to the subscriptions of every node, and only if
+// the sending transaction commits.
+func TestWatcherDeliversCommittedNotifications(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 2, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	tdb := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	sender := int32(tc.Server(0).SQLInstanceID())
+
+	var callbacks int32
+	execCfg := tc.Server(1).ExecutorConfig().(sql.ExecutorConfig)
+	sub := execCfg.NotificationWatcher.Subscribe(func() {
+		atomic.AddInt32(&callbacks, 1)
+	})
+	defer sub.Close()
+	sub.Listen("foo")
+	require.Equal(t, []string{"foo"}, sub.Channels())
+
+	waitForNotifications := func(expected ...pgnotify.Notification) {
+		var received []pgnotify.Notification
+		testutils.SucceedsSoon(t, func() error {
+			pending, dropped := sub.Drain()
+			require.Zero(t, dropped)
+			received = append(received, pending...)
+			if len(received) < len(expected) {
+				return errors.Errorf("received %d notifications, expected %d", len(received), len(expected))
+			}
+			return nil
+		})
+		require.Equal(t, expected, received)
+		require.NotZero(t, atomic.LoadInt32(&callbacks))
+	}
+
+	// Notifications on channels which are not listened on and notifications
+	// sent by aborted transactions are never delivered.
+	tdb.Exec(t, "NOTIFY bar, 'ignored'")
+	tdb.Exec(t, "BEGIN; NOTIFY foo, 'aborted'; ROLLBACK")
+	tdb.Exec(t, "NOTIFY foo, 'first'")
+	waitForNotifications(pgnotify.Notification{Channel: "foo", Payload: "first", PID: sender})
+
+	tdb.Exec(t, "BEGIN; NOTIFY foo; SELECT pg_notify('foo', 'third'); COMMIT")
+	waitForNotifications(
+		pgnotify.Notification{Channel: "foo", Payload: "", PID: sender},
+		pgnotify.Notification{Channel: "foo", Payload: "third", PID: sender},
+	)
+
+	sub.UnlistenAll()
+	require.Empty(t, sub.Channels())
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package notifywatcher
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// rowDecoder decodes rows from the notifications table.
+type rowDecoder struct {
+	codec     keys.SQLCodec
+	alloc     rowenc.DatumAlloc
+	colIdxMap catalog.TableColMap
+}
+
+func makeRowDecoder(codec keys.SQLCodec) rowDecoder {
+	return rowDecoder{
+		codec: codec,
+		colIdxMap: row.ColIDtoRowIndexFromCols(
+			systemschema.NotificationsTable.PublicColumns(),
+		),
+	}
+}
+
+// decodeRow decodes a row of the system.notifications table, and returns the
+// ID of the notification along with it. If the value is not present, the
+// tombstone bool will be set.
+func (d *rowDecoder) decodeRow(
+	kv roachpb.KeyValue,
+) (id int64, n pgnotify.Notification, tombstone bool, _ error) {
+	tbl := systemschema.NotificationsTable
+	// The id column is the only column stored in the index key. It is not part
+	// of the notification, but it identifies the row when the range feed
+	// delivers it more than once.
+	{
+		types := []*types.T{tbl.PublicColumns()[0].GetType()}
+		idRow := make([]rowenc.EncDatum, 1)
+		_, matches, _, err := rowenc.DecodeIndexKey(d.codec, types, idRow, nil, kv.Key)
+		if err != nil {
+			return 0, pgnotify.Notification{}, false, errors.Wrap(err, "failed to decode key")
+		}
+		if !matches {
+			return 0, pgnotify.Notification{}, false, errors.Errorf(
+				"unexpected non-notifications KV with notifications prefix: %v", kv.Key,
+			)
+		}
+		if err := idRow[0].EnsureDecoded(types[0], &d.alloc); err != nil {
+			return 0, pgnotify.Notification{}, false, err
+		}
+		id = int64(tree.MustBeDInt(idRow[0].Datum))
+	}
+	if !kv.Value.IsPresent() {
+		return id, pgnotify.Notification{}, true, nil
+	}
+
+	// The rest of the columns are stored as a family, packed with diff-encoded
+	// column IDs followed by their values.
+	bytes, err := kv.Value.GetTuple()
+	if err != nil {
+		return 0, pgnotify.Notification{}, false, err
+	}
+	var colIDDiff uint32
+	var lastColID descpb.ColumnID
+	var res tree.Datum
+	for len(bytes) > 0 {
+		_, _, colIDDiff, _, err = encoding.DecodeValueTag(bytes)
+		if err != nil {
+			return 0, pgnotify.Notification{}, false, err
+		}
+		colID := lastColID + descpb.ColumnID(colIDDiff)
+		lastColID = colID
+		idx, ok := d.colIdxMap.Get(colID)
+		if !ok {
+			return 0, pgnotify.Notification{}, false, errors.Errorf("unknown column: %v", colID)
+		}
+		res, bytes, err = rowenc.DecodeTableValue(&d.alloc, tbl.PublicColumns()[idx].GetType(), bytes)
+		if err != nil {
+			return 0, pgnotify.Notification{}, false, err
+		}
+		switch colID {
+		case tbl.PublicColumns()[1].GetID(): // channel
+			n.Channel = string(tree.MustBeDString(res))
+		case tbl.PublicColumns()[2].GetID(): // payload
+			n.Payload = string(tree.MustBeDString(res))
+		case tbl.PublicColumns()[3].GetID(): // pid
+			n.PID = int32(tree.MustBeDInt(res))
+		case tbl.PublicColumns()[4].GetID(): // created
+		default:
+			return 0, pgnotify.Notification{}, false, errors.Errorf("unknown column: %v", colID)
+		}
+	}
+	return id, n, false, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package notifywatcher
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWatcherDedupesRedeliveredNotifications ensures that a notification whose
+// row is delivered again by the range feed is only queued once, until the
+// frontier of the range feed passes its timestamp.
+func TestWatcherDedupesRedeliveredNotifications(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	w := New(nil /* clock */, keys.SystemSQLCodec, nil /* settings */, nil /* f */, nil /* stopper */, nil /* ie */)
+	sub := w.Subscribe(func() {})
+	defer sub.Close()
+	sub.Listen("foo")
+
+	n := pgnotify.Notification{Channel: "foo", Payload: "p"}
+	ts := hlc.Timestamp{WallTime: 10}
+	w.deliver(1, ts, n)
+	w.deliver(1, ts, n)
+	w.deliver(2, ts, n)
+	pending, dropped := sub.Drain()
+	require.Equal(t, []pgnotify.Notification{n, n}, pending)
+	require.Zero(t, dropped)
+
+	// The IDs are kept while the frontier has not passed the timestamp of the
+	// rows, since the range feed may still deliver them again.
+	w.forgetDelivered(ts)
+	w.deliver(1, ts, n)
+	pending, _ = sub.Drain()
+	require.Empty(t, pending)
+
+	w.forgetDelivered(ts.Next())
+	require.Empty(t, w.delivered.ids)
+}
+
+// TestSubscriptionReportsDroppedNotifications ensures that the notifications
+// received while the queue of a subscription is full are counted, and that
+// the count is reset once the queue is drained.
+func TestSubscriptionReportsDroppedNotifications(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var wakeups int
+	w := New(nil /* clock */, keys.SystemSQLCodec, nil /* settings */, nil /* f */, nil /* stopper */, nil /* ie */)
+	sub := w.Subscribe(func() { wakeups++ })
+	defer sub.Close()
+	sub.Listen("foo")
+
+	const extra = 5
+	for i := 0; i < MaxPendingNotifications+extra; i++ {
+		w.deliver(int64(i), hlc.Timestamp{WallTime: 1}, pgnotify.Notification{Channel: "foo"})
+	}
+	// Notifications on other channels are neither queued nor dropped.
+	w.deliver(-1, hlc.Timestamp{WallTime: 1}, pgnotify.Notification{Channel: "bar"})
+	require.Equal(t, 1, wakeups)
+
+	pending, dropped := sub.Drain()
+	require.Len(t, pending, MaxPendingNotifications)
+	require.Equal(t, extra, dropped)
+
+	pending, dropped = sub.Drain()
+	require.Empty(t, pending)
+	require.Zero(t, dropped)
+}
@@ -126,6 +126,11 @@ type Catalog interface {
 		ctx context.Context, name *tree.UnresolvedObjectName,
 	) (*types.T, error)
 
+	// ResolveFunctionByOID is used to look up the current version of a
+	// user-defined function by OID. It returns an error with the
+	// UndefinedFunction code if the function no longer exists.
+	ResolveFunctionByOID(ctx context.Context, oid oid.Oid) (*tree.Overload, error)
+
 	// CheckPrivilege verifies that the current user has the given privilege on
 	// the given catalog object. If not, then CheckPrivilege returns an error.
 	CheckPrivilege(ctx context.Context, o Object, priv privilege.Kind) error
@@ -161,4 +166,9 @@ type Catalog interface {
 
 	// RoleExists returns true if the role exists.
 	RoleExists(ctx context.Context, role security.SQLUsername) (bool, error)
+
+	// UserRoles returns the roles whose privileges the current user has: the
+	// user itself, the roles it is a member of, directly or indirectly, and the
+	// public role. The roles are sorted by name.
+	UserRoles(ctx context.Context) ([]security.SQLUsername, error)
 }
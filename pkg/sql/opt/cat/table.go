@@ -13,6 +13,7 @@ package cat
 import (
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -41,11 +42,21 @@ type Table interface {
 	// information_schema tables.
 	IsVirtualTable() bool
 
+	// IsForeignTable returns true if this table is a foreign table, whose rows
+	// are read from files in external storage when it's queried. Foreign tables
+	// are also virtual tables.
+	IsForeignTable() bool
+
 	// IsMaterializedView returns true if this table is actually a materialized
 	// view. Materialized views are the same as tables in all aspects, other than
-	// that they cannot be mutated.
+	// that they cannot be mutated directly.
 	IsMaterializedView() bool
 
+	// IncrementalViewQuery returns the query which is used to maintain this
+	// table, if it is an incrementally maintained materialized view. It returns
+	// the empty string otherwise.
+	IncrementalViewQuery() string
+
 	// ColumnCount returns the number of columns in the table. This includes
 	// public columns, write-only columns, etc.
 	ColumnCount() int
@@ -134,6 +145,46 @@ type Table interface {
 	// i < UniqueCount.
 	Unique(i UniqueOrdinal) UniqueConstraint
 
+	// TriggerCount returns the number of row-level triggers defined on this
+	// table.
+	TriggerCount() int
+
+	// Trigger returns the ith row-level trigger defined on this table, where
+	// i < TriggerCount.
+	Trigger(i int) Trigger
+
+	// RowLevelSecurity returns whether row-level security is enabled for this
+	// table, and whether it also applies to the owner of the table.
+	RowLevelSecurity() (enabled, forced bool)
+
+	// Owner returns the owner of this table.
+	Owner() security.SQLUsername
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// this table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy defined on this table,
+	// where i < PolicyCount.
+	Policy(i int) Policy
+
+	// ExclusionConstraintCount returns the number of exclusion constraints
+	// defined on this table.
+	ExclusionConstraintCount() int
+
+	// ExclusionConstraint returns the ith exclusion constraint defined on this
+	// table, where i < ExclusionConstraintCount.
+	ExclusionConstraint(i int) ExclusionConstraint
+
+	// IncrementalViewCount returns the number of incrementally maintained
+	// materialized views which depend on this table.
+	IncrementalViewCount() int
+
+	// IncrementalView returns the ID of the ith incrementally maintained
+	// materialized view which depends on this table, where
+	// i < IncrementalViewCount.
+	IncrementalView(i int) StableID
+
 	// Zone returns a table's zone.
 	Zone() Zone
 }
@@ -148,6 +199,12 @@ type Table interface {
 type CheckConstraint struct {
 	Constraint string
 	Validated  bool
+
+	// RowLevelSecurity is set for the check that enforces the row-level
+	// security policies of a table on the rows written to it. Its Constraint
+	// is not used; the optimizer builds the check from the WITH CHECK
+	// expressions of the policies that apply to the current user.
+	RowLevelSecurity bool
 }
 
 // TableStatistic is an interface to a table statistic. Each statistic is
@@ -249,6 +306,10 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability returns whether the checks of the constraint can be
+	// deferred until the transaction commits.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -287,6 +348,112 @@ type UniqueConstraint interface {
 	// cannot make any assumptions about the data. An unvalidated constraint still
 	// needs to be enforced on new mutations.
 	Validated() bool
+
+	// Deferrability returns whether the uniqueness checks of the constraint can
+	// be deferred until the transaction commits. Only constraints that are not
+	// enforced by an index can be deferrable.
+	Deferrability() tree.ConstraintDeferrability
+}
+
+// Trigger represents a row-level trigger. A trigger executes a SQL statement
+// for every row of its table that is modified by a statement of a given kind.
+// For example, this trigger inserts a row into an audit table for every row
+// inserted into t:
+//   CREATE TRIGGER tr AFTER INSERT ON t FOR EACH ROW
+//     AS 'INSERT INTO audit VALUES (NEW.k, now())'
+// The optimizer plans the body of an AFTER trigger as a postquery of the
+// mutation, in the same way as FK cascades. The body of a BEFORE trigger is a
+// query that computes the new row, and is planned as part of the input of the
+// mutation.
+type Trigger interface {
+	// Name of the trigger.
+	Name() string
+
+	// ActionTime returns whether the trigger fires before or after the rows are
+	// modified.
+	ActionTime() tree.TriggerActionTime
+
+	// FiresOn returns true if the trigger fires for the given kind of
+	// statement.
+	FiresOn(event tree.TriggerEvent) bool
+
+	// When returns the condition of the trigger and true if the trigger has a
+	// WHEN clause. If it does not, the empty string and false are returned.
+	When() (string, bool)
+
+	// Body returns the SQL statement that is executed for every row that fires
+	// the trigger.
+	Body() string
+}
+
+// Policy represents a row-level security policy. When row-level security is
+// enabled for a table, its rows are only accessible to a statement if they
+// satisfy the policies that apply to the statement and to the current user.
+// For example, this policy restricts the rows of t to those of the tenant of
+// the session:
+//   CREATE POLICY tenant ON t
+//     USING (tenant_id = current_setting('app.tenant_id')::INT)
+// The optimizer adds the USING expressions of the policies as filters on the
+// rows read from the table, and the WITH CHECK expressions as checks on the
+// rows written to the table.
+type Policy interface {
+	// Name of the policy.
+	Name() string
+
+	// AppliesTo returns true if the policy applies to the given kind of
+	// statement.
+	AppliesTo(command tree.PolicyCommand) bool
+
+	// Restrictive returns true if the policy must pass for a row to be
+	// accessible, in addition to at least one of the permissive policies.
+	Restrictive() bool
+
+	// RoleCount returns the number of roles the policy applies to.
+	RoleCount() int
+
+	// Role returns the ith role the policy applies to, where i < RoleCount.
+	// The public role stands for all roles.
+	Role(i int) security.SQLUsername
+
+	// Using returns the condition on the existing rows of the table and true
+	// if the policy has a USING clause. If it does not, the empty string and
+	// false are returned.
+	Using() (string, bool)
+
+	// WithCheck returns the condition on the new rows written to the table and
+	// true if the policy has a WITH CHECK clause. If it does not, the empty
+	// string and false are returned.
+	WithCheck() (string, bool)
+}
+
+// ExclusionConstraint represents an exclusion constraint. It guarantees that
+// no two rows of the table have values which satisfy all the operators of the
+// constraint when compared with each other. For example, this constraint
+// prevents overlapping reservations of the same room:
+//   CREATE TABLE reservations (
+//     room INT, slots INT[], EXCLUDE (room WITH =, slots WITH &&)
+//   )
+// Like unique constraints without an index, exclusion constraints are enforced
+// by checks built by the optimizer, which look for conflicting rows.
+type ExclusionConstraint interface {
+	// Name of the exclusion constraint.
+	Name() string
+
+	// ColumnCount returns the number of columns in this constraint.
+	ColumnCount() int
+
+	// ColumnOrdinal returns the table column ordinal of the ith column in this
+	// constraint.
+	ColumnOrdinal(tab Table, i int) int
+
+	// Operator returns the comparison operator used to compare the values of
+	// the ith column in this constraint.
+	Operator(i int) tree.ComparisonOperatorSymbol
+
+	// Predicate returns the partial predicate expression and true if the
+	// constraint only applies to the rows which satisfy it. If it does not, the
+	// empty string and false are returned.
+	Predicate() (string, bool)
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
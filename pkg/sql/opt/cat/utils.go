@@ -151,6 +151,9 @@ func FormatTable(cat Catalog, tab Table, tp treeprinter.Node) {
 	}
 
 	for i := 0; i < tab.CheckCount(); i++ {
+		if tab.Check(i).RowLevelSecurity {
+			continue
+		}
 		child.Childf("CHECK (%s)", tab.Check(i).Constraint)
 	}
 
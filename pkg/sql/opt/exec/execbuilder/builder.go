@@ -81,6 +81,10 @@ type Builder struct {
 	// by scans. See forUpdateLocking.
 	forceForUpdateLocking bool
 
+	// isCascade is true if the builder builds the plan of a FK cascade. See
+	// shouldApplyImplicitLockingToMutationInput.
+	isCascade bool
+
 	// -- output --
 
 	// IsDDL is set to true if the statement contains DDL.
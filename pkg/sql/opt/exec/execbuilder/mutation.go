@@ -123,6 +123,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -150,12 +154,23 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 		return execPlan{}, false, nil
 	}
 
+	//  - the FK checks do not need to lock the referenced rows, which the fast
+	//    path does not support;
+	if len(ins.FKChecks) > 0 && b.txnToleratesWriteSkew() {
+		return execPlan{}, false, nil
+	}
+
 	// We cannot use the fast path if any uniqueness checks are needed.
 	// TODO(rytaft): try to relax this restriction (see #58047).
 	if len(ins.UniqueChecks) > 0 {
 		return execPlan{}, false, nil
 	}
 
+	//  - there are no triggers on the table.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, false, nil
+	}
+
 	md := b.mem.Metadata()
 	tab := md.Table(ins.Table)
 
@@ -169,6 +184,10 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 			return execPlan{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.Deferrability() != tree.ConstraintNotDeferrable {
+			// The fast path cannot defer the check.
+			return execPlan{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -410,7 +429,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
 	cnt := len(ups.InsertCols) + len(ups.FetchCols) + len(ups.UpdateCols) + len(ups.CheckCols) +
-		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 1
+		len(ups.PartialIndexPutCols) + len(ups.PartialIndexDelCols) + 2
 	colList := make(opt.ColList, 0, cnt)
 	colList = appendColsWhenPresent(colList, ups.InsertCols)
 	colList = appendColsWhenPresent(colList, ups.FetchCols)
@@ -418,6 +437,9 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	if ups.CanaryCol != 0 {
 		colList = append(colList, ups.CanaryCol)
 	}
+	if ups.MergeDeleteCol != 0 {
+		colList = append(colList, ups.MergeDeleteCol)
+	}
 	colList = appendColsWhenPresent(colList, ups.CheckCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexPutCols)
 	colList = appendColsWhenPresent(colList, ups.PartialIndexDelCols)
@@ -434,6 +456,10 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	if ups.CanaryCol != 0 {
 		canaryCol = input.getNodeColumnOrdinal(ups.CanaryCol)
 	}
+	deleteCol := exec.NodeColumnOrdinal(-1)
+	if ups.MergeDeleteCol != 0 {
+		deleteCol = input.getNodeColumnOrdinal(ups.MergeDeleteCol)
+	}
 	insertColOrds := ordinalSetFromColList(ups.InsertCols)
 	fetchColOrds := ordinalSetFromColList(ups.FetchCols)
 	updateColOrds := ordinalSetFromColList(ups.UpdateCols)
@@ -445,6 +471,7 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 		ups.ArbiterIndexes,
 		ups.ArbiterConstraints,
 		canaryCol,
+		deleteCol,
 		insertColOrds,
 		fetchColOrds,
 		updateColOrds,
@@ -490,8 +517,15 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (execPlan, error) {
 	//
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
-	colList := make(opt.ColList, 0, len(del.FetchCols)+len(del.PartialIndexDelCols))
+	cnt := len(del.FetchCols) + len(del.PassthroughCols) + len(del.PartialIndexDelCols)
+	colList := make(opt.ColList, 0, cnt)
 	colList = appendColsWhenPresent(colList, del.FetchCols)
+	// The RETURNING clause of the Delete can refer to the columns in any of the
+	// USING tables. As a result, the Delete may need to passthrough those
+	// columns so the projection above can use them.
+	if del.NeedResults() {
+		colList = append(colList, del.PassthroughCols...)
+	}
 	colList = appendColsWhenPresent(colList, del.PartialIndexDelCols)
 
 	input, err := b.buildMutationInput(del, del.Input, colList, &del.MutationPrivate)
@@ -504,11 +538,22 @@ func (b *Builder) buildDelete(del *memo.DeleteExpr) (execPlan, error) {
 	tab := md.Table(del.Table)
 	fetchColOrds := ordinalSetFromColList(del.FetchCols)
 	returnColOrds := ordinalSetFromColList(del.ReturnCols)
+
+	// Construct the result columns for the passthrough set.
+	var passthroughCols colinfo.ResultColumns
+	if del.NeedResults() {
+		for _, passthroughCol := range del.PassthroughCols {
+			colMeta := b.mem.Metadata().ColumnMeta(passthroughCol)
+			passthroughCols = append(passthroughCols, colinfo.ResultColumn{Name: colMeta.Alias, Typ: colMeta.Type})
+		}
+	}
+
 	node, err := b.factory.ConstructDelete(
 		input.root,
 		tab,
 		fetchColOrds,
 		returnColOrds,
+		passthroughCols,
 		b.allowAutoCommit && len(del.FKChecks) == 0 && len(del.FKCascades) == 0,
 	)
 	if err != nil {
@@ -686,6 +731,12 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 	md := b.mem.Metadata()
 	for i := range checks {
 		c := &checks[i]
+		if b.txnToleratesWriteSkew() {
+			// The check cannot see the rows written by concurrent transactions,
+			// and locking the rows it reads does not prevent them from inserting
+			// conflicting rows, so the constraint cannot be enforced.
+			return mkUniqueCheckIsolationErr(md, c)
+		}
 		// Construct the query that returns uniqueness violations.
 		query, err := b.buildRelational(c.Check)
 		if err != nil {
@@ -697,9 +748,13 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 			for i, col := range c.KeyCols {
 				keyVals[i] = row[query.getNodeColumnOrdinal(col)]
 			}
+			if c.Exclusion {
+				return mkExclusionCheckErr(md, c, keyVals)
+			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		deferrable := mkDeferrableUniqueCheck(md, c)
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -709,6 +764,16 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 }
 
 func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
+	if len(checks) > 0 && b.txnToleratesWriteSkew() {
+		// The rows read by the checks must not be removed or modified by
+		// concurrent transactions until this transaction commits, since the
+		// reads are not validated at commit time. A shared lock would suffice,
+		// but shared locks are promoted to exclusive locks under these
+		// isolation levels anyway (see scanParams).
+		prev := b.forceForUpdateLocking
+		b.forceForUpdateLocking = true
+		defer func() { b.forceForUpdateLocking = prev }()
+	}
 	md := b.mem.Metadata()
 	for i := range checks {
 		c := &checks[i]
@@ -725,7 +790,8 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		deferrable := mkDeferrableFKCheck(md, c)
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -734,9 +800,202 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// mkDeferrableUniqueCheck returns the information needed to defer the given
+// uniqueness check until the transaction commits, or nil if the constraint is
+// not deferrable. At commit, a single query checks the entire table for
+// duplicate keys:
+//
+//   SELECT a, b FROM [<table id> AS t]
+//   WHERE a IS NOT NULL AND b IS NOT NULL
+//   GROUP BY a, b HAVING count(*) > 1 LIMIT 1
+//
+func mkDeferrableUniqueCheck(md *opt.Metadata, c *memo.UniqueChecksItem) *exec.DeferrableCheck {
+	if c.Exclusion {
+		// Exclusion constraints are not deferrable.
+		return nil
+	}
+	tab := md.Table(c.Table)
+	uc := tab.Unique(c.CheckOrdinal)
+	if uc.Deferrability() == tree.ConstraintNotDeferrable {
+		return nil
+	}
+	// The key values returned by the query correspond to the constraint
+	// columns, as expected by mkUniqueCheckErr.
+	var cols bytes.Buffer
+	for i := 0; i < uc.ColumnCount(); i++ {
+		if i > 0 {
+			cols.WriteString(", ")
+		}
+		col := tab.Column(uc.ColumnOrdinal(tab, i))
+		lexbase.EncodeRestrictedSQLIdent(&cols, string(col.ColName()), lexbase.EncNoFlags)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "SELECT %s FROM [%d AS t] WHERE ", cols.String(), tab.ID())
+	for i := 0; i < uc.ColumnCount(); i++ {
+		if i > 0 {
+			buf.WriteString(" AND ")
+		}
+		col := tab.Column(uc.ColumnOrdinal(tab, i))
+		lexbase.EncodeRestrictedSQLIdent(&buf, string(col.ColName()), lexbase.EncNoFlags)
+		buf.WriteString(" IS NOT NULL")
+	}
+	if pred, isPartial := uc.Predicate(); isPartial {
+		fmt.Fprintf(&buf, " AND (%s)", pred)
+	}
+	fmt.Fprintf(&buf, " GROUP BY %s HAVING count(*) > 1 LIMIT 1", cols.String())
+	return &exec.DeferrableCheck{
+		ConstraintName:    uc.Name(),
+		InitiallyDeferred: uc.Deferrability() == tree.ConstraintInitiallyDeferred,
+		Check:             buf.String(),
+		MkErr: func(keyVals tree.Datums) error {
+			return mkUniqueCheckErr(md, c, keyVals)
+		},
+	}
+}
+
+// mkDeferrableFKCheck returns the information needed to defer the given foreign
+// key check until the transaction commits, or nil if the constraint is not
+// deferrable. At commit, a single query checks the entire origin table for
+// rows without a match in the referenced table:
+//
+//   SELECT o.a, o.b FROM [<origin id> AS o]
+//   WHERE (o.a IS NOT NULL AND o.b IS NOT NULL) AND NOT EXISTS (
+//     SELECT 1 FROM [<referenced id> AS r] WHERE r.x = o.a AND r.y = o.b
+//   ) LIMIT 1
+//
+// For MATCH FULL constraints, the rows with any non-NULL key column are
+// checked instead. The same query checks both new rows in the origin table and
+// rows removed from the referenced table.
+func mkDeferrableFKCheck(md *opt.Metadata, c *memo.FKChecksItem) *exec.DeferrableCheck {
+	origin := md.Table(c.OriginTable)
+	referenced := md.Table(c.ReferencedTable)
+	var fk cat.ForeignKeyConstraint
+	if c.FKOutbound {
+		fk = origin.OutboundForeignKey(c.FKOrdinal)
+	} else {
+		fk = referenced.InboundForeignKey(c.FKOrdinal)
+	}
+	if fk.Deferrability() == tree.ConstraintNotDeferrable {
+		return nil
+	}
+	originCol := func(buf *bytes.Buffer, i int) {
+		col := origin.Column(fk.OriginColumnOrdinal(origin, i))
+		buf.WriteString("o.")
+		lexbase.EncodeRestrictedSQLIdent(buf, string(col.ColName()), lexbase.EncNoFlags)
+	}
+	// The key values returned by the query correspond to the FK columns, as
+	// expected by mkFKCheckErr.
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	for i := 0; i < fk.ColumnCount(); i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		originCol(&buf, i)
+	}
+	fmt.Fprintf(&buf, " FROM [%d AS o] WHERE (", origin.ID())
+	sep := " AND "
+	if fk.MatchMethod() == tree.MatchFull {
+		sep = " OR "
+	}
+	for i := 0; i < fk.ColumnCount(); i++ {
+		if i > 0 {
+			buf.WriteString(sep)
+		}
+		originCol(&buf, i)
+		buf.WriteString(" IS NOT NULL")
+	}
+	fmt.Fprintf(&buf, ") AND NOT EXISTS (SELECT 1 FROM [%d AS r] WHERE ", referenced.ID())
+	for i := 0; i < fk.ColumnCount(); i++ {
+		if i > 0 {
+			buf.WriteString(" AND ")
+		}
+		col := referenced.Column(fk.ReferencedColumnOrdinal(referenced, i))
+		buf.WriteString("r.")
+		lexbase.EncodeRestrictedSQLIdent(&buf, string(col.ColName()), lexbase.EncNoFlags)
+		buf.WriteString(" = ")
+		originCol(&buf, i)
+	}
+	buf.WriteString(") LIMIT 1")
+	return &exec.DeferrableCheck{
+		ConstraintName:    fk.Name(),
+		InitiallyDeferred: fk.Deferrability() == tree.ConstraintInitiallyDeferred,
+		Check:             buf.String(),
+		MkErr: func(keyVals tree.Datums) error {
+			return mkFKCheckErr(md, c, keyVals)
+		},
+	}
+}
+
+// mkExclusionCheckErr generates a user-friendly error describing a violation
+// of an exclusion constraint. The keyVals are the values that correspond to the
+// cat.ExclusionConstraint columns.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	ec := tabMeta.Table.ExclusionConstraint(c.CheckOrdinal)
+	constraintName := ec.Name()
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (a, b)=(1, {2}) conflicts with existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	details.WriteString("Key (")
+	for i := 0; i < ec.ColumnCount(); i++ {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		col := tabMeta.Table.Column(ec.ColumnOrdinal(tabMeta.Table, i))
+		details.WriteString(string(col.ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+	details.WriteString(") conflicts with existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
+// mkUniqueCheckIsolationErr returns the error for a mutation which needs the
+// given uniqueness check in a transaction whose isolation level tolerates write
+// skew.
+func mkUniqueCheckIsolationErr(md *opt.Metadata, c *memo.UniqueChecksItem) error {
+	tabMeta := md.TableMeta(c.Table)
+	kind, name := "UNIQUE WITHOUT INDEX constraint", tabMeta.Table.Unique(c.CheckOrdinal).Name()
+	if c.Exclusion {
+		kind, name = "exclusion constraint", tabMeta.Table.ExclusionConstraint(c.CheckOrdinal).Name()
+	}
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"%s %q on table %q cannot be enforced under %s isolation",
+		kind, name, tabMeta.Table.Name(), tree.ReadCommittedIsolation,
+	)
+}
+
+// mkIncrementalViewIsolationErr returns the error for a mutation which needs to
+// maintain the incrementally maintained view of the given cascade in a
+// transaction whose isolation level tolerates write skew.
+func mkIncrementalViewIsolationErr(c *memo.FKCascade) error {
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"incrementally maintained view %q cannot be maintained under %s isolation",
+		c.FKName, tree.ReadCommittedIsolation,
+	)
+}
+
 func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
 	tabMeta := md.TableMeta(c.Table)
 	uc := tabMeta.Table.Unique(c.CheckOrdinal)
@@ -873,6 +1132,16 @@ func (b *Builder) buildFKCascades(withID opt.WithID, cascades memo.FKCascades) e
 	if len(cascades) == 0 {
 		return nil
 	}
+	if b.txnToleratesWriteSkew() {
+		for i := range cascades {
+			if cascades[i].IncrementalView {
+				// The maintenance query cannot see the rows written by concurrent
+				// transactions, so the changes it makes to the view could be based
+				// on stale rows of the view or of the other tables it reads.
+				return mkIncrementalViewIsolationErr(&cascades[i])
+			}
+		}
+	}
 	cb, err := makeCascadeBuilder(b, withID)
 	if err != nil {
 		return err
@@ -936,10 +1205,25 @@ func (b *Builder) canAutoCommit(rel memo.RelExpr) bool {
 // equivalent that used by a SELECT ... FOR UPDATE statement.
 var forUpdateLocking = &tree.LockingItem{Strength: tree.ForUpdate}
 
+// txnToleratesWriteSkew returns true if the statement runs in a transaction
+// whose isolation level does not validate its reads at commit time, such as
+// READ COMMITTED. Row-level locks are the only protection such transactions
+// have against concurrent writers, so they are acquired more eagerly.
+func (b *Builder) txnToleratesWriteSkew() bool {
+	return b.evalCtx.Txn != nil && b.evalCtx.Txn.IsolationLevel().ToleratesWriteSkew()
+}
+
 // shouldApplyImplicitLockingToMutationInput determines whether or not the
 // builder should apply a FOR UPDATE row-level locking mode to the initial row
 // scan of a mutation expression.
 func (b *Builder) shouldApplyImplicitLockingToMutationInput(mutExpr memo.RelExpr) bool {
+	if b.isCascade && b.txnToleratesWriteSkew() {
+		// The input of a cascade reads the rows which reference the rows
+		// modified by the statement. These rows must not be modified by
+		// concurrent transactions before the cascade modifies them, since the
+		// reads are not validated at commit time.
+		return true
+	}
 	switch t := mutExpr.(type) {
 	case *memo.InsertExpr:
 		// Unlike with the other three mutation expressions, it never makes
@@ -985,7 +1269,7 @@ func (b *Builder) shouldApplyImplicitLockingToMutationInput(mutExpr memo.RelExpr
 // not worth risking the transformation being a pessimization, so it is only
 // applied when doing so does not risk creating artificial contention.
 func (b *Builder) shouldApplyImplicitLockingToUpdateInput(upd *memo.UpdateExpr) bool {
-	if !b.evalCtx.SessionData().ImplicitSelectForUpdate {
+	if !b.evalCtx.SessionData().ImplicitSelectForUpdate && !b.txnToleratesWriteSkew() {
 		return false
 	}
 
@@ -1006,7 +1290,7 @@ func (b *Builder) shouldApplyImplicitLockingToUpdateInput(upd *memo.UpdateExpr)
 // should apply a FOR UPDATE row-level locking mode to the initial row scan of
 // an UPSERT statement.
 func (b *Builder) shouldApplyImplicitLockingToUpsertInput(ups *memo.UpsertExpr) bool {
-	if !b.evalCtx.SessionData().ImplicitSelectForUpdate {
+	if !b.evalCtx.SessionData().ImplicitSelectForUpdate && !b.txnToleratesWriteSkew() {
 		return false
 	}
 
@@ -253,6 +253,11 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.Max1RowExpr:
 		ep, err = b.buildMax1Row(t)
 
+	case *memo.BarrierExpr:
+		// The Barrier only affects the normalization of the expression, so
+		// its input is executed directly.
+		ep, err = b.buildRelational(t.Input)
+
 	case *memo.ProjectSetExpr:
 		ep, err = b.buildProjectSet(t)
 
@@ -283,6 +288,12 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.CreateViewExpr:
 		ep, err = b.buildCreateView(t)
 
+	case *memo.CreateFunctionExpr:
+		ep, err = b.buildCreateFunction(t)
+
+	case *memo.CreateTriggerExpr:
+		ep, err = b.buildCreateTrigger(t)
+
 	case *memo.WithExpr:
 		ep, err = b.buildWith(t)
 
@@ -558,6 +569,17 @@ func (b *Builder) scanParams(
 	if b.forceForUpdateLocking {
 		locking = forUpdateLocking
 	}
+	if locking != nil && locking.Strength > tree.ForNone && locking.Strength < tree.ForNoKeyUpdate &&
+		b.txnToleratesWriteSkew() {
+		// Shared locks are not acquired by the KV layer, which is fine under
+		// serializable isolation where reads are validated at commit time. Other
+		// isolation levels rely on the lock, so promote it to an exclusive one.
+		locking = &tree.LockingItem{
+			Strength:   tree.ForUpdate,
+			Targets:    locking.Targets,
+			WaitPolicy: locking.WaitPolicy,
+		}
+	}
 
 	// Raise error if row-level locking is part of a read-only transaction.
 	if locking != nil && locking.Strength > tree.ForNone && b.evalCtx.TxnReadOnly {
@@ -2588,6 +2610,7 @@ var boundedStalenessAllowList = map[opt.Operator]struct{}{
 	opt.SortOp:             {},
 	opt.OrdinalityOp:       {},
 	opt.Max1RowOp:          {},
+	opt.BarrierOp:          {},
 	opt.ProjectSetOp:       {},
 	opt.WindowOp:           {},
 	opt.ExplainOp:          {},
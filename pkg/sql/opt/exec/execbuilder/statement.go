@@ -52,11 +52,18 @@ func (b *Builder) buildCreateTable(ct *memo.CreateTableExpr) (execPlan, error) {
 func (b *Builder) buildCreateView(cv *memo.CreateViewExpr) (execPlan, error) {
 	md := b.mem.Metadata()
 	schema := md.Schema(cv.Schema)
-	cols := make(colinfo.ResultColumns, len(cv.Columns))
+	cols := make(colinfo.ResultColumns, len(cv.Columns), len(cv.Columns)+len(cv.IncrementalColumns))
 	for i := range cols {
 		cols[i].Name = cv.Columns[i].Alias
 		cols[i].Typ = md.ColumnMeta(cv.Columns[i].ID).Type
 	}
+	for _, c := range cv.IncrementalColumns {
+		cols = append(cols, colinfo.ResultColumn{
+			Name:   c.Alias,
+			Typ:    md.ColumnMeta(c.ID).Type,
+			Hidden: true,
+		})
+	}
 	root, err := b.factory.ConstructCreateView(
 		schema,
 		cv.ViewName,
@@ -65,6 +72,7 @@ func (b *Builder) buildCreateView(cv *memo.CreateViewExpr) (execPlan, error) {
 		cv.Persistence,
 		cv.Materialized,
 		cv.ViewQuery,
+		cv.IncrementalViewQuery,
 		cols,
 		cv.Deps,
 		cv.TypeDeps,
@@ -72,6 +80,18 @@ func (b *Builder) buildCreateView(cv *memo.CreateViewExpr) (execPlan, error) {
 	return execPlan{root: root}, err
 }
 
+func (b *Builder) buildCreateFunction(cf *memo.CreateFunctionExpr) (execPlan, error) {
+	schema := b.mem.Metadata().Schema(cf.Schema)
+	root, err := b.factory.ConstructCreateFunction(schema, cf.Syntax, cf.Deps)
+	return execPlan{root: root}, err
+}
+
+func (b *Builder) buildCreateTrigger(ct *memo.CreateTriggerExpr) (execPlan, error) {
+	table := b.mem.Metadata().Table(ct.Table)
+	root, err := b.factory.ConstructCreateTrigger(table, ct.Syntax)
+	return execPlan{root: root}, err
+}
+
 func (b *Builder) buildExplainOpt(explain *memo.ExplainExpr) (execPlan, error) {
 	fmtFlags := memo.ExprFmtHideAll
 	switch {
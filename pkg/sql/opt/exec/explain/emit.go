@@ -99,8 +99,17 @@ func Emit(plan *Plan, ob *OutputBuilder, spanFormatFn SpanFormatFn) error {
 	}
 
 	for i := range plan.Cascades {
-		ob.EnterMetaNode("fk-cascade")
-		ob.Attr("fk", plan.Cascades[i].FKName)
+		switch {
+		case plan.Cascades[i].Trigger:
+			ob.EnterMetaNode("after-trigger")
+			ob.Attr("trigger", plan.Cascades[i].FKName)
+		case plan.Cascades[i].IncrementalView:
+			ob.EnterMetaNode("view-maintenance")
+			ob.Attr("view", plan.Cascades[i].FKName)
+		default:
+			ob.EnterMetaNode("fk-cascade")
+			ob.Attr("fk", plan.Cascades[i].FKName)
+		}
 		if buffer := plan.Cascades[i].Buffer; buffer != nil {
 			ob.Attr("input", buffer.(*Node).args.(*bufferArgs).Label)
 		}
@@ -282,9 +291,11 @@ var nodeNames = [...]string{
 	cancelSessionsOp:       "cancel sessions",
 	controlJobsOp:          "control jobs",
 	controlSchedulesOp:     "control schedules",
+	createFunctionOp:       "create function",
 	createStatisticsOp:     "create statistics",
 	createTableOp:          "create table",
 	createTableAsOp:        "create table as",
+	createTriggerOp:        "create trigger",
 	createViewOp:           "create view",
 	deleteOp:               "delete",
 	deleteRangeOp:          "delete range",
@@ -865,6 +876,8 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 		createTableOp,
 		createTableAsOp,
 		createViewOp,
+		createFunctionOp,
+		createTriggerOp,
 		sequenceSelectOp,
 		saveTableOp,
 		errorIfRowsOp,
@@ -183,8 +183,9 @@ func getResultColumns(
 		}
 		return colinfo.ShowTraceColumns, nil
 
-	case createTableOp, createTableAsOp, createViewOp, controlJobsOp, controlSchedulesOp,
-		cancelQueriesOp, cancelSessionsOp, createStatisticsOp, errorIfRowsOp, deleteRangeOp:
+	case createTableOp, createTableAsOp, createViewOp, createFunctionOp, createTriggerOp,
+		controlJobsOp, controlSchedulesOp, cancelQueriesOp, cancelSessionsOp, createStatisticsOp,
+		errorIfRowsOp, deleteRangeOp:
 		// These operations produce no columns.
 		return nil, nil
 
@@ -210,9 +210,17 @@ type ApplyJoinPlanRightSideFn func(ef Factory, leftRow tree.Datums) (Plan, error
 // ConstructBuffer as an input; it should only be triggered if this buffer is
 // not empty.
 type Cascade struct {
-	// FKName is the name of the foreign key constraint.
+	// FKName is the name of the foreign key constraint, the name of the trigger
+	// if Trigger is true, or the name of the view if IncrementalView is true.
 	FKName string
 
+	// Trigger is true if the query implements a row-level trigger.
+	Trigger bool
+
+	// IncrementalView is true if the query maintains an incrementally
+	// maintained materialized view. In that case FKName is the name of the view.
+	IncrementalView bool
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
@@ -268,6 +276,26 @@ type InsertFastPathFKCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableCheck contains information about a FK or uniqueness check that
+// enforces a deferrable constraint (see ConstructErrorIfRows).
+type DeferrableCheck struct {
+	// ConstraintName is the name of the constraint, as used by SET CONSTRAINTS.
+	ConstraintName string
+
+	// InitiallyDeferred is true if the constraint is deferred unless SET
+	// CONSTRAINTS ... IMMEDIATE is used.
+	InitiallyDeferred bool
+
+	// Check is a query that checks the entire table for violations of the
+	// constraint. It returns the key values of a violating row, or no rows if
+	// the constraint holds.
+	Check string
+
+	// MkErr creates the error for a violation, given the key values returned
+	// by Check.
+	MkErr MkErrFn
+}
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
@@ -0,0 +1,186 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+func TestTryFilterTSVectorIndex(t *testing.T) {
+	semaCtx := tree.MakeSemaContext()
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := tree.NewTestingEvalContext(st)
+
+	tc := testcat.New()
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t (v TSVECTOR, s STRING, INVERTED INDEX (v))",
+	); err != nil {
+		t.Fatal(err)
+	}
+	var f norm.Factory
+	f.Init(evalCtx, tc)
+	md := f.Metadata()
+	tn := tree.NewUnqualifiedTableName("t")
+	tab := md.AddTable(tc.Table(tn), tn)
+	tsvectorOrd := 1
+
+	testCases := []struct {
+		filters          string
+		ok               bool
+		tight            bool
+		unique           bool
+		remainingFilters string
+	}{
+		// If we can create an inverted filter with the given filter expression and
+		// index, ok=true. If the spans in the resulting inverted index constraint
+		// do not have duplicate primary keys, unique=true. If the spans are tight,
+		// tight=true and remainingFilters="". Otherwise, tight is false and
+		// remainingFilters contains some or all of the original filters.
+		{
+			filters: "v @@ 'cat'",
+			ok:      true,
+			tight:   true,
+			unique:  true,
+		},
+		{
+			// The indexed column can be on either side of @@.
+			filters: "'cat'::TSQUERY @@ v",
+			ok:      true,
+			tight:   true,
+			unique:  true,
+		},
+		{
+			filters: "v @@ 'cat & rat'",
+			ok:      true,
+			tight:   true,
+			unique:  true,
+		},
+		{
+			filters: "v @@ 'cat | rat'",
+			ok:      true,
+			tight:   true,
+			unique:  false,
+		},
+		{
+			// Prefix terms are supported.
+			filters: "v @@ 'ca:*'",
+			ok:      true,
+			tight:   true,
+			unique:  false,
+		},
+		{
+			// The positions of the lexemes are not in the index, so phrase
+			// queries are not tight.
+			filters:          "v @@ 'cat <-> rat'",
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: "v @@ 'cat <-> rat'",
+		},
+		{
+			// Neither are the weights of the lexemes.
+			filters:          "v @@ 'cat:A'",
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: "v @@ 'cat:A'",
+		},
+		{
+			// A negated term can be evaluated as the operand of a &.
+			filters:          "v @@ 'cat & !rat'",
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: "v @@ 'cat & !rat'",
+		},
+		{
+			// But not on its own.
+			filters: "v @@ '!cat'",
+			ok:      false,
+		},
+		{
+			filters:          "v @@ 'cat' AND v @@ 'rat <-> fat'",
+			ok:               true,
+			tight:            false,
+			unique:           true,
+			remainingFilters: "v @@ 'rat <-> fat'",
+		},
+		{
+			filters: "v @@ 'cat' OR v @@ 'rat'",
+			ok:      true,
+			tight:   true,
+			unique:  false,
+		},
+		{
+			// The query must be a constant.
+			filters: "v @@ s::TSQUERY",
+			ok:      false,
+		},
+		{
+			// The index can't be used to evaluate a match against a string.
+			filters: "s @@ 'cat'",
+			ok:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("test case: %v", tc)
+		filters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.filters)
+
+		// We're not testing that the correct SpanExpression is returned here;
+		// that is tested in the tsearch package. This is just testing that we
+		// are constraining the index when we expect to and we have the correct
+		// values for tight, unique, and remainingFilters.
+		spanExpr, _, remainingFilters, _, ok := invertedidx.TryFilterInvertedIndex(
+			evalCtx,
+			&f,
+			filters,
+			nil, /* optionalFilters */
+			tab,
+			md.Table(tab).Index(tsvectorOrd),
+			nil, /* computedColumns */
+		)
+		if tc.ok != ok {
+			t.Fatalf("expected %v, got %v", tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+
+		if tc.tight != spanExpr.Tight {
+			t.Fatalf("expected tight=%v, but got %v", tc.tight, spanExpr.Tight)
+		}
+		if tc.unique != spanExpr.Unique {
+			t.Fatalf("expected unique=%v, but got %v", tc.unique, spanExpr.Unique)
+		}
+
+		if remainingFilters == nil {
+			if tc.remainingFilters != "" {
+				t.Fatalf("expected remainingFilters=%s, got <nil>", tc.remainingFilters)
+			}
+			continue
+		}
+		if tc.remainingFilters == "" {
+			t.Fatalf("expected remainingFilters=<nil>, got %v", remainingFilters)
+		}
+		expRemainingFilters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.remainingFilters)
+		if remainingFilters.String() != expRemainingFilters.String() {
+			t.Errorf("expected remainingFilters=%v, got %v", expRemainingFilters, remainingFilters)
+		}
+	}
+}
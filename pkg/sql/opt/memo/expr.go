@@ -997,6 +997,20 @@ type FKCascade struct {
 	// It is empty if the mutation is a deletion. Empty if the cascade does not
 	// require input.
 	NewValues opt.ColList
+
+	// Trigger is true if the cascading query implements a row-level trigger
+	// rather than a foreign key action; in that case FKName is the name of the
+	// trigger, and OldValues and NewValues contain the columns of the mutated
+	// table that are available to the trigger. Like a cascade, the trigger query
+	// is built and run once for all the rows of the mutation input.
+	Trigger bool
+
+	// IncrementalView is true if the cascading query maintains an incrementally
+	// maintained materialized view which depends on the mutated table; in that
+	// case FKName is the name of the view, and OldValues and NewValues contain
+	// the columns of the mutated table, as for triggers. The query is run once
+	// for all the rows of the mutation input.
+	IncrementalView bool
 }
 
 // CascadeBuilder is an interface used to construct a cascading query for a
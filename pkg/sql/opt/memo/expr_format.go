@@ -608,6 +608,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 				f.formatArbiterIndexes(tp, t.ArbiterIndexes, t.Table)
 				f.formatArbiterConstraints(tp, t.ArbiterConstraints, t.Table)
 				f.formatColList(e, tp, "canary column:", opt.ColList{t.CanaryCol})
+				if t.MergeDeleteCol != 0 {
+					f.formatColList(e, tp, "merge delete column:", opt.ColList{t.MergeDeleteCol})
+				}
 				f.formatOptionalColList(e, tp, "fetch columns:", t.FetchCols)
 				f.formatMutationCols(e, tp, "insert-mapping:", t.InsertCols, t.Table)
 				f.formatMutationCols(e, tp, "update-mapping:", t.UpdateCols, t.Table)
@@ -688,6 +691,12 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			n.Child(f.Buffer.String())
 		}
 
+	case *CreateFunctionExpr:
+		tp.Child(t.Syntax.String())
+
+	case *CreateTriggerExpr:
+		tp.Child(t.Syntax.String())
+
 	case *CreateStatisticsExpr:
 		tp.Child(t.Syntax.String())
 
@@ -1100,14 +1109,25 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 
 	case *UniqueChecksItem:
 		tab := f.Memo.metadata.TableMeta(t.Table)
-		constraint := tab.Table.Unique(t.CheckOrdinal)
 		fmt.Fprintf(f.Buffer, ": %s(", tab.Alias.ObjectName)
-		for i := 0; i < constraint.ColumnCount(); i++ {
-			if i > 0 {
-				f.Buffer.WriteByte(',')
+		if t.Exclusion {
+			constraint := tab.Table.ExclusionConstraint(t.CheckOrdinal)
+			for i := 0; i < constraint.ColumnCount(); i++ {
+				if i > 0 {
+					f.Buffer.WriteByte(',')
+				}
+				col := tab.Table.Column(constraint.ColumnOrdinal(tab.Table, i))
+				fmt.Fprintf(f.Buffer, "%s %s", string(col.ColName()), constraint.Operator(i))
+			}
+		} else {
+			constraint := tab.Table.Unique(t.CheckOrdinal)
+			for i := 0; i < constraint.ColumnCount(); i++ {
+				if i > 0 {
+					f.Buffer.WriteByte(',')
+				}
+				col := tab.Table.Column(constraint.ColumnOrdinal(tab.Table, i))
+				f.Buffer.WriteString(string(col.ColName()))
 			}
-			col := tab.Table.Column(constraint.ColumnOrdinal(tab.Table, i))
-			f.Buffer.WriteString(string(col.ColName()))
 		}
 		f.Buffer.WriteByte(')')
 
@@ -1327,7 +1347,14 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 	if len(p.FKCascades) > 0 {
 		c := tp.Childf("cascades")
 		for i := range p.FKCascades {
-			c.Child(p.FKCascades[i].FKName)
+			switch {
+			case p.FKCascades[i].Trigger:
+				c.Childf("%s (after trigger)", p.FKCascades[i].FKName)
+			case p.FKCascades[i].IncrementalView:
+				c.Childf("%s (view maintenance)", p.FKCascades[i].FKName)
+			default:
+				c.Child(p.FKCascades[i].FKName)
+			}
 		}
 	}
 }
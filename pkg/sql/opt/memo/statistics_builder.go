@@ -412,6 +412,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 	case opt.WithOp:
 		return sb.colStat(colSet, e.Child(1).(RelExpr))
 
+	case opt.BarrierOp:
+		return sb.colStat(colSet, e.Child(0).(RelExpr))
+
 	case opt.FakeRelOp:
 		rel := e.Relational()
 		return sb.colStatLeaf(colSet, &rel.Stats, &rel.FuncDeps, rel.NotNullCols)
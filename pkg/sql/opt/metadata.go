@@ -16,6 +16,7 @@ import (
 	"math/bits"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -98,6 +99,18 @@ type Metadata struct {
 	userDefinedTypes      map[oid.Oid]struct{}
 	userDefinedTypesSlice []*types.T
 
+	// userDefinedFunctions contains the overloads of all user-defined functions
+	// inlined in this query, which are checked for changes by
+	// CheckDependencies.
+	userDefinedFunctions      map[oid.Oid]struct{}
+	userDefinedFunctionsSlice []*tree.Overload
+
+	// rowLevelSecurityRoles is non-nil if the query accesses a table with
+	// row-level security enabled. It contains the roles of the user for which
+	// the query was built, since the policies that apply to the query depend on
+	// them. CheckDependencies verifies that the current user has the same roles.
+	rowLevelSecurityRoles []security.SQLUsername
+
 	// deps stores information about all data source objects depended on by the
 	// query, as well as the privileges required to access them. The objects are
 	// deduplicated: any name/object pair shows up at most once.
@@ -203,7 +216,9 @@ func (md *Metadata) Init() {
 func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 	if len(md.schemas) != 0 || len(md.cols) != 0 || len(md.tables) != 0 ||
 		len(md.sequences) != 0 || len(md.deps) != 0 || len(md.views) != 0 ||
-		len(md.userDefinedTypes) != 0 || len(md.userDefinedTypesSlice) != 0 {
+		len(md.userDefinedTypes) != 0 || len(md.userDefinedTypesSlice) != 0 ||
+		len(md.userDefinedFunctions) != 0 || len(md.userDefinedFunctionsSlice) != 0 ||
+		len(md.rowLevelSecurityRoles) != 0 {
 		panic(errors.AssertionFailedf("CopyFrom requires empty destination"))
 	}
 	md.schemas = append(md.schemas, from.schemas...)
@@ -220,6 +235,17 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 		}
 	}
 
+	if len(from.userDefinedFunctionsSlice) > 0 {
+		if md.userDefinedFunctions == nil {
+			md.userDefinedFunctions = make(map[oid.Oid]struct{}, len(from.userDefinedFunctionsSlice))
+		}
+		for i := range from.userDefinedFunctionsSlice {
+			fn := from.userDefinedFunctionsSlice[i]
+			md.userDefinedFunctions[fn.Oid] = struct{}{}
+			md.userDefinedFunctionsSlice = append(md.userDefinedFunctionsSlice, fn)
+		}
+	}
+
 	if cap(md.tables) >= len(from.tables) {
 		md.tables = md.tables[:len(from.tables)]
 	} else {
@@ -233,6 +259,7 @@ func (md *Metadata) CopyFrom(from *Metadata, copyScalarFn func(Expr) Expr) {
 	md.sequences = append(md.sequences, from.sequences...)
 	md.deps = append(md.deps, from.deps...)
 	md.views = append(md.views, from.views...)
+	md.rowLevelSecurityRoles = from.rowLevelSecurityRoles
 	md.currUniqueID = from.currUniqueID
 
 	// We cannot copy the bound expressions; they must be rebuilt in the new memo.
@@ -332,9 +359,53 @@ func (md *Metadata) CheckDependencies(
 			return false, nil
 		}
 	}
+	// Check that all of the user-defined functions inlined in the query have
+	// not changed.
+	for _, fn := range md.userDefinedFunctionsSlice {
+		toCheck, err := catalog.ResolveFunctionByOID(ctx, fn.Oid)
+		if err != nil {
+			// Handle when the function no longer exists.
+			if pgerror.GetPGCode(err) == pgcode.UndefinedFunction {
+				return false, nil
+			}
+			return false, err
+		}
+		if fn.Version != toCheck.Version {
+			return false, nil
+		}
+	}
+	// Check that the row-level security policies that were applied to the
+	// query are still the ones that apply to the current user.
+	if md.rowLevelSecurityRoles != nil {
+		roles, err := catalog.UserRoles(ctx)
+		if err != nil {
+			return false, err
+		}
+		if len(roles) != len(md.rowLevelSecurityRoles) {
+			return false, nil
+		}
+		for i := range roles {
+			if roles[i] != md.rowLevelSecurityRoles[i] {
+				return false, nil
+			}
+		}
+	}
 	return true, nil
 }
 
+// SetRowLevelSecurityRoles records that the query accesses a table with
+// row-level security enabled, and that it was built for a user with the given
+// roles, as returned by cat.Catalog.UserRoles.
+func (md *Metadata) SetRowLevelSecurityRoles(roles []security.SQLUsername) {
+	md.rowLevelSecurityRoles = roles
+}
+
+// RowLevelSecurityRoles returns the roles recorded by SetRowLevelSecurityRoles,
+// or nil if the query does not access a table with row-level security enabled.
+func (md *Metadata) RowLevelSecurityRoles() []security.SQLUsername {
+	return md.rowLevelSecurityRoles
+}
+
 // AddSchema indexes a new reference to a schema used by the query.
 func (md *Metadata) AddSchema(sch cat.Schema) SchemaID {
 	md.schemas = append(md.schemas, sch)
@@ -366,6 +437,18 @@ func (md *Metadata) AllUserDefinedTypes() []*types.T {
 	return md.userDefinedTypesSlice
 }
 
+// AddUserDefinedFunction adds the overload of a user-defined function inlined
+// in this query to the metadata.
+func (md *Metadata) AddUserDefinedFunction(fn *tree.Overload) {
+	if md.userDefinedFunctions == nil {
+		md.userDefinedFunctions = make(map[oid.Oid]struct{})
+	}
+	if _, ok := md.userDefinedFunctions[fn.Oid]; !ok {
+		md.userDefinedFunctions[fn.Oid] = struct{}{}
+		md.userDefinedFunctionsSlice = append(md.userDefinedFunctionsSlice, fn)
+	}
+}
+
 // AddTable indexes a new reference to a table within the query. Separate
 // references to the same table are assigned different table ids (e.g.  in a
 // self-join query). All columns are added to the metadata. If mutation columns
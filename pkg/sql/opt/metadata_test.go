@@ -16,6 +16,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
@@ -90,6 +91,8 @@ func TestMetadata(t *testing.T) {
 		t.Fatalf("unexpected types")
 	}
 
+	md.SetRowLevelSecurityRoles([]security.SQLUsername{security.PublicRoleName()})
+
 	md.AddDependency(opt.DepByName(&tab.TabName), tab, privilege.CREATE)
 	depsUpToDate, err := md.CheckDependencies(context.Background(), testCat)
 	if err == nil || depsUpToDate {
@@ -138,6 +141,10 @@ func TestMetadata(t *testing.T) {
 		t.Fatalf("expected partial index predicate to be copied")
 	}
 
+	if r := mdNew.RowLevelSecurityRoles(); len(r) != 1 || r[0] != security.PublicRoleName() {
+		t.Fatalf("unexpected row-level security roles")
+	}
+
 	if mdNew.Sequence(seqID).(*testcat.Sequence).SeqID != 100 {
 		t.Fatalf("unexpected sequence")
 	}
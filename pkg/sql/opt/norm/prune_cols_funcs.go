@@ -77,6 +77,13 @@ func (c *CustomFuncs) neededMutationCols(
 	if private.CanaryCol != 0 {
 		cols.Add(private.CanaryCol)
 	}
+	if private.MergeDeleteCol != 0 {
+		cols.Add(private.MergeDeleteCol)
+	}
+	for i := range private.FKCascades {
+		addCols(opt.OptionalColList(private.FKCascades[i].OldValues))
+		addCols(opt.OptionalColList(private.FKCascades[i].NewValues))
+	}
 
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
@@ -145,6 +152,20 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Retain any FetchCols that provide the old values of the rows to row-level
+	// triggers.
+	var triggerCols opt.ColSet
+	for i := range private.FKCascades {
+		if private.FKCascades[i].Trigger {
+			triggerCols.UnionWith(private.FKCascades[i].OldValues.ToSet())
+		}
+	}
+	for ord, col := range private.FetchCols {
+		if col != 0 && triggerCols.Contains(col) {
+			cols.Add(tabMeta.MetaID.ColumnID(ord))
+		}
+	}
+
 	switch op {
 	case opt.UpdateOp, opt.UpsertOp:
 		// Determine set of target table columns that need to be updated.
@@ -221,8 +242,11 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 				cols.UnionWith(fkCols)
 			}
 		}
+	}
 
-	case opt.DeleteOp:
+	// An Upsert built for a MERGE statement with a DELETE clause deletes rows,
+	// so it needs the same columns as a Delete.
+	if op == opt.DeleteOp || (op == opt.UpsertOp && private.MergeDeleteCol != 0) {
 		// Add in all strict key columns from all indexes, since these are needed
 		// to compose the keys of rows to delete. Include mutation indexes, since
 		// it is necessary to delete rows even from indexes that are being added
@@ -557,6 +581,10 @@ func DerivePruneCols(e memo.RelExpr) opt.ColSet {
 			relProps.Rule.PruneCols = relProps.OutputCols.Difference(groupingColSet)
 		}
 
+	case opt.BarrierOp:
+		// Any pruneable input columns can potentially be pruned.
+		relProps.Rule.PruneCols = DerivePruneCols(e.Child(0).(memo.RelExpr))
+
 	case opt.LimitOp, opt.OffsetOp:
 		// Any pruneable input columns can potentially be pruned, as long as
 		// they're not used as an ordering column.
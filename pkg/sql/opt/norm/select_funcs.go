@@ -413,3 +413,33 @@ func (c *CustomFuncs) addConjuncts(
 	}
 	return filters, true
 }
+
+// IsLeakproofFilter returns true if the given filter is leakproof: it cannot
+// raise an error or otherwise reveal anything about the values it is evaluated
+// on.
+func (c *CustomFuncs) IsLeakproofFilter(item *memo.FiltersItem) bool {
+	return item.ScalarProps().VolatilitySet.IsLeakProof()
+}
+
+// ExtractLeakproofFilters returns the leakproof filters of the given list.
+func (c *CustomFuncs) ExtractLeakproofFilters(filters memo.FiltersExpr) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if c.IsLeakproofFilter(&filters[i]) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
+
+// ExtractLeakyFilters is the opposite of ExtractLeakproofFilters: it returns
+// the filters of the given list which are not leakproof.
+func (c *CustomFuncs) ExtractLeakyFilters(filters memo.FiltersExpr) memo.FiltersExpr {
+	newFilters := make(memo.FiltersExpr, 0, len(filters))
+	for i := range filters {
+		if !c.IsLeakproofFilter(&filters[i]) {
+			newFilters = append(newFilters, filters[i])
+		}
+	}
+	return newFilters
+}
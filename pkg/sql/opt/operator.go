@@ -142,6 +142,7 @@ var ComparisonOpReverseMap = map[Operator]tree.ComparisonOperatorSymbol{
 	JsonSomeExistsOp: tree.JSONSomeExists,
 	JsonAllExistsOp:  tree.JSONAllExists,
 	OverlapsOp:       tree.Overlaps,
+	TSMatchesOp:      tree.TSMatches,
 	BBoxCoversOp:     tree.RegMatch,
 	BBoxIntersectsOp: tree.Overlaps,
 }
@@ -14,6 +14,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/delegate"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -114,10 +115,35 @@ type Builder struct {
 	// (if any).
 	subquery *subquery
 
+	// udf contains the state of the user-defined function whose body is
+	// currently being built (if any).
+	udf *udfContext
+
+	// trigger contains the state of the row-level trigger whose body is
+	// currently being built (if any).
+	trigger *triggerContext
+
 	// If set, we are processing a view definition; in this case, catalog caches
 	// are disabled and certain statements (like mutations) are disallowed.
 	insideViewDef bool
 
+	// If set, the row-level security policies of tables are not applied. This
+	// is the case for the cascades of foreign keys, which are not subject to
+	// row-level security.
+	skipRowLevelSecurity bool
+
+	// If set, we are building a query that maintains an incrementally
+	// maintained materialized view. Such queries can mutate the view, and do
+	// not check privileges, since they are run on behalf of the mutations of
+	// the tables the view depends on.
+	maintainingIncrementalView bool
+
+	// userRoles and isAdmin describe the current user. They are initialized
+	// when the statement first accesses a table with row-level security
+	// enabled; see rowLevelSecurityApplies.
+	userRoles []security.SQLUsername
+	isAdmin   bool
+
 	// If set, we are collecting view dependencies in viewDeps. This can only
 	// happen inside view definitions.
 	//
@@ -267,9 +293,9 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
-			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
-			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions:
+		case *tree.Delete, *tree.Insert, *tree.Merge, *tree.Update, *tree.CreateTable, *tree.CreateView,
+			*tree.CreateFunction, *tree.CreateTrigger, *tree.Split, *tree.Unsplit, *tree.Relocate,
+			*tree.RelocateRange, *tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions:
 			panic(pgerror.Newf(
 				pgcode.Syntax, "%s cannot be used inside a view definition", stmt.StatementTag(),
 			))
@@ -293,6 +319,11 @@ func (b *Builder) buildStmt(
 			return b.buildInsert(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.Update:
 		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
 			return b.buildUpdate(stmt, inScope)
@@ -304,6 +335,12 @@ func (b *Builder) buildStmt(
 	case *tree.CreateView:
 		return b.buildCreateView(stmt, inScope)
 
+	case *tree.CreateFunction:
+		return b.buildCreateFunction(stmt, inScope)
+
+	case *tree.CreateTrigger:
+		return b.buildCreateTrigger(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
@@ -0,0 +1,161 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (outScope *scope) {
+	b.DisableMemoReuse = true
+	tn := cf.Name.ToTableName()
+	sch, _ := b.resolveSchemaForCreate(&tn)
+	schID := b.factory.Metadata().AddSchema(sch)
+
+	var lang tree.FunctionLanguage
+	var body tree.FunctionBody
+	var bodyIdx int
+	volatility := tree.FunctionVolatile
+	var seenLang, seenBody, seenVolatility, seenLeakProof, seenNullInput bool
+	for i, option := range cf.Options {
+		var seen *bool
+		switch t := option.(type) {
+		case tree.FunctionLanguage:
+			lang, seen = t, &seenLang
+		case tree.FunctionBody:
+			body, bodyIdx, seen = t, i, &seenBody
+		case tree.FunctionVolatility:
+			volatility, seen = t, &seenVolatility
+		case tree.FunctionLeakProof:
+			seen = &seenLeakProof
+		case tree.FunctionNullInputBehavior:
+			seen = &seenNullInput
+		}
+		if *seen {
+			panic(pgerror.New(pgcode.Syntax, "conflicting or redundant options"))
+		}
+		*seen = true
+	}
+	if !seenBody {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "no function body specified"))
+	}
+	if !seenLang {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "no language specified"))
+	}
+	switch lang {
+	case tree.FunctionLangSQL:
+	case "plpgsql":
+		panic(unimplemented.NewWithIssue(17511, "PL/pgSQL functions are not supported"))
+	default:
+		panic(pgerror.Newf(pgcode.UndefinedObject, "language %q does not exist", lang))
+	}
+
+	// Resolve the types of the arguments and the return type. The resolved types
+	// are stored in the AST so that the execution of the statement does not need
+	// to resolve them again.
+	paramScope := b.allocScope()
+	for i := range cf.Args {
+		arg := &cf.Args[i]
+		if arg.Name != "" {
+			for j := 0; j < i; j++ {
+				if cf.Args[j].Name == arg.Name {
+					panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+						"parameter name %q used more than once", arg.Name))
+				}
+			}
+		}
+		typ := b.resolveFunctionType(arg.Type)
+		arg.Type = typ
+		b.synthesizeColumn(paramScope, udfParamColName(string(arg.Name), i), typ, nil /* expr */, nil /* scalar */)
+	}
+	retTyp := b.resolveFunctionType(cf.ReturnType)
+	cf.ReturnType = retTyp
+
+	stmt, err := parser.ParseOne(string(body))
+	if err != nil {
+		panic(pgerror.Wrap(err, pgcode.InvalidFunctionDefinition, "invalid function body"))
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		panic(unimplemented.NewWithIssuef(17511,
+			"%s statements are not supported in function bodies", stmt.AST.StatementTag()))
+	}
+
+	// We build the body to check it semantically, to verify that it matches the
+	// declared volatility of the function, to get the fully resolved names into
+	// the AST, and to collect the dependencies of the function in b.viewDeps.
+	// The result is not otherwise used.
+	b.trackViewDeps = true
+	b.qualifyDataSourceNamesInAST = true
+	defer func() {
+		b.trackViewDeps = false
+		b.viewDeps = nil
+		b.viewTypeDeps = util.FastIntSet{}
+		b.qualifyDataSourceNamesInAST = false
+	}()
+	numTables := len(b.factory.Metadata().AllTables())
+	bodyScope := b.buildUDFBody(sel, 0 /* fnOID */, paramScope.cols, retTyp)
+
+	vols := bodyScope.expr.Relational().VolatilitySet
+	switch volatility {
+	case tree.FunctionImmutable:
+		if vols.HasVolatile() {
+			panic(pgerror.Newf(pgcode.InvalidParameterValue,
+				"volatile statement not allowed in immutable function: %s", body))
+		}
+		if vols.HasStable() {
+			panic(pgerror.Newf(pgcode.InvalidParameterValue,
+				"stable statement not allowed in immutable function: %s", body))
+		}
+		if len(b.factory.Metadata().AllTables()) > numTables {
+			panic(pgerror.New(pgcode.InvalidParameterValue,
+				"referencing relations is not allowed in immutable function"))
+		}
+	case tree.FunctionStable:
+		if vols.HasVolatile() {
+			panic(pgerror.Newf(pgcode.InvalidParameterValue,
+				"volatile statement not allowed in stable function: %s", body))
+		}
+	}
+
+	cf.Options[bodyIdx] = tree.FunctionBody(tree.AsStringWithFlags(sel, tree.FmtParsable))
+
+	outScope = b.allocScope()
+	outScope.expr = b.factory.ConstructCreateFunction(
+		&memo.CreateFunctionPrivate{
+			Schema: schID,
+			Syntax: cf,
+			Deps:   b.viewDeps,
+		},
+	)
+	return outScope
+}
+
+// resolveFunctionType resolves the type of an argument or the return type of a
+// user-defined function.
+func (b *Builder) resolveFunctionType(ref tree.ResolvableTypeReference) *types.T {
+	typ, err := tree.ResolveType(b.ctx, ref, b.semaCtx.GetTypeResolver())
+	if err != nil {
+		panic(err)
+	}
+	if typ.UserDefined() {
+		panic(unimplemented.NewWithIssue(17511,
+			"user-defined types are not supported in function signatures"))
+	}
+	return typ
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+func (b *Builder) buildCreateTrigger(ct *tree.CreateTrigger, inScope *scope) (outScope *scope) {
+	b.DisableMemoReuse = true
+	tab, _ := b.resolveTable(&ct.Table, privilege.CREATE)
+	if tab.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"%q is a virtual table and cannot have triggers", tree.ErrString(&ct.Table)))
+	}
+	if tab.IsMaterializedView() {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"%q is a materialized view and cannot have triggers", tree.ErrString(&ct.Table)))
+	}
+	tabID := b.factory.Metadata().AddTable(tab, &ct.Table)
+
+	stmt, err := parser.ParseOne(ct.Body)
+	if err != nil {
+		panic(pgerror.Wrap(err, pgcode.InvalidObjectDefinition, "invalid trigger body"))
+	}
+	switch stmt.AST.(type) {
+	case *tree.Insert, *tree.Update, *tree.Delete, *tree.Select:
+	default:
+		panic(unimplemented.NewWithIssuef(28296,
+			"%s statements are not supported in trigger bodies", stmt.AST.StatementTag()))
+	}
+	var beforeBody *tree.Select
+	if ct.ActionTime == tree.TriggerBefore {
+		// The body of a BEFORE trigger computes the new row, so it must be a
+		// query.
+		var ok bool
+		if beforeBody, ok = stmt.AST.(*tree.Select); !ok {
+			panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"body of BEFORE trigger must be a SELECT statement"))
+		}
+		for _, event := range ct.Events {
+			if event == tree.TriggerEventDelete {
+				panic(unimplemented.NewWithIssue(28296, "BEFORE DELETE triggers are not supported"))
+			}
+		}
+	}
+
+	// We build the body for every event to check it semantically (including
+	// references to OLD and NEW), and to get the fully resolved names into the
+	// AST. The result is not otherwise used.
+	b.qualifyDataSourceNamesInAST = true
+	defer func() {
+		b.qualifyDataSourceNamesInAST = false
+	}()
+	for _, event := range ct.Events {
+		var oldCols, newCols triggerCols
+		if event != tree.TriggerEventInsert {
+			oldCols = b.makeTriggerRowCols(tab)
+		}
+		if event != tree.TriggerEventDelete {
+			newCols = b.makeTriggerRowCols(tab)
+		}
+		if beforeBody != nil {
+			rowScope := b.allocScope()
+			rowScope.appendTriggerRowCols(b.factory.Metadata(), oldCols, newCols)
+			b.buildBeforeTriggerBody(
+				tab, beforeTriggerCols(tab), beforeBody, ct.When, event, rowScope, oldCols, newCols,
+			)
+			continue
+		}
+		binding := b.makeTriggerBinding(oldCols, newCols)
+		b.buildTriggerBody(stmt.AST, ct.When, event, binding, oldCols, newCols)
+	}
+
+	ct.Body = tree.AsStringWithFlags(stmt.AST, tree.FmtParsable)
+
+	outScope = b.allocScope()
+	outScope.expr = b.factory.ConstructCreateTrigger(
+		&memo.CreateTriggerPrivate{
+			Table:  tabID,
+			Syntax: ct,
+		},
+	)
+	return outScope
+}
+
+// makeTriggerRowCols adds a column to the metadata for each ordinary column of
+// the given table, and returns them as the old or new values of the rows
+// modified by a trigger.
+func (b *Builder) makeTriggerRowCols(tab cat.Table) triggerCols {
+	md := b.factory.Metadata()
+	var res triggerCols
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() != cat.Ordinary {
+			continue
+		}
+		res.names = append(res.names, col.ColName())
+		res.ids = append(res.ids, md.AddColumn(string(col.ColName()), col.DatumType()))
+	}
+	return res
+}
+
+// makeTriggerBinding creates a dummy binding that produces rows with the given
+// columns. It is used to build the body of a trigger as part of a CREATE
+// TRIGGER statement, when there is no mutation input.
+func (b *Builder) makeTriggerBinding(oldCols, newCols triggerCols) opt.WithID {
+	var bindingProps props.Relational
+	bindingProps.Populated = true
+	bindingProps.OutputCols = oldCols.ids.ToSet().Union(newCols.ids.ToSet())
+	bindingProps.Stats = props.Statistics{Available: true, RowCount: 1}
+
+	binding := b.factory.Memo().NextWithID()
+	b.factory.Metadata().AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+		Props: &bindingProps,
+	}))
+	return binding
+}
@@ -12,6 +12,10 @@ package optbuilder
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -23,6 +27,13 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 	schID := b.factory.Metadata().AddSchema(sch)
 	viewName := tree.MakeTableNameFromPrefix(resName, tree.Name(cv.Name.Object()))
 
+	var params paramparse.ViewStorageParamObserver
+	if err := paramparse.ApplyStorageParameters(
+		b.ctx, b.semaCtx, b.evalCtx, cv.Params, &params,
+	); err != nil {
+		panic(err)
+	}
+
 	// We build the select statement to:
 	//  - check the statement semantically,
 	//  - get the fully resolved names into the AST, and
@@ -56,6 +67,28 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		}
 	}
 
+	// For an incrementally maintained view, build the query extended with the
+	// hidden columns that are used to maintain the view instead, so that the
+	// dependencies of the hidden columns are tracked as well.
+	var incrementalViewQuery string
+	var incrementalCols physical.Presentation
+	if params.Incremental {
+		if defScope.expr.Relational().VolatilitySet.HasVolatile() {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"volatile functions are not supported in incrementally maintained views"))
+		}
+		incrementalSource := b.buildIncrementalViewQuery(cv.AsSource)
+		b.viewDeps = nil
+		b.viewTypeDeps = util.FastIntSet{}
+		incrementalScope := b.buildStmtAtRoot(incrementalSource, nil /* desiredTypes */)
+		incrementalViewQuery = tree.AsStringWithFlags(incrementalSource, tree.FmtParsable)
+		ip := incrementalScope.makePhysicalProps().Presentation
+		for i := range p {
+			ip[i].Alias = p[i].Alias
+		}
+		p, incrementalCols = ip[:len(p)], ip[len(p):]
+	}
+
 	// If the type of any column that this view references is user
 	// defined, add a type dependency between this view and the UDT.
 	if b.trackViewDeps {
@@ -77,16 +110,18 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 	outScope = b.allocScope()
 	outScope.expr = b.factory.ConstructCreateView(
 		&memo.CreateViewPrivate{
-			Schema:       schID,
-			ViewName:     &viewName,
-			IfNotExists:  cv.IfNotExists,
-			Replace:      cv.Replace,
-			Persistence:  cv.Persistence,
-			Materialized: cv.Materialized,
-			ViewQuery:    tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
-			Columns:      p,
-			Deps:         b.viewDeps,
-			TypeDeps:     b.viewTypeDeps,
+			Schema:               schID,
+			ViewName:             &viewName,
+			IfNotExists:          cv.IfNotExists,
+			Replace:              cv.Replace,
+			Persistence:          cv.Persistence,
+			Materialized:         cv.Materialized,
+			ViewQuery:            tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
+			Columns:              p,
+			Deps:                 b.viewDeps,
+			TypeDeps:             b.viewTypeDeps,
+			IncrementalViewQuery: incrementalViewQuery,
+			IncrementalColumns:   incrementalCols,
 		},
 	)
 	return outScope
@@ -898,6 +898,7 @@ func buildCascadeHelper(
 ) (_ memo.RelExpr, err error) {
 	factory := factoryI.(*norm.Factory)
 	b := New(ctx, semaCtx, evalCtx, catalog, factory, nil /* stmt */)
+	b.skipRowLevelSecurity = true
 
 	// Enact panic handling similar to Builder.Build().
 	defer func() {
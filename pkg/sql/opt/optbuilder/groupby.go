@@ -96,6 +96,12 @@ type groupby struct {
 	// projects that expression.
 	groupStrs groupByStrSet
 
+	// nullGroupStrs contains a string representation of each grouping
+	// expression of a GROUP BY clause with GROUPING SETS, ROLLUP or CUBE that
+	// is not part of the grouping set being built, mapped to its type. These
+	// expressions are NULL in the rows produced for the grouping set.
+	nullGroupStrs map[string]*types.T
+
 	// buildingGroupingCols is true while the grouping columns are being built.
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
@@ -325,14 +331,24 @@ func (b *Builder) constructGroupBy(
 // buildGroupingColumns builds the grouping columns and adds them to the
 // groupby scopes that will be used to build the aggregation expression.
 // Returns the slice of grouping columns.
-func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope, fromScope *scope) {
+//
+// If gs is not nil, the grouping columns are the expressions of the given
+// grouping set rather than the GROUP BY clause of sel.
+func (b *Builder) buildGroupingColumns(
+	sel *tree.SelectClause, gs *groupingSet, projectionsScope, fromScope *scope,
+) {
 	if fromScope.groupby == nil {
 		fromScope.initGrouping()
 	}
 	g := fromScope.groupby
 
 	// The "from" columns are visible to any grouping expressions.
-	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
+	if gs == nil {
+		b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
+	} else {
+		b.buildGroupingList(gs.exprs, sel.Exprs, projectionsScope, fromScope)
+		b.buildNullGroupingList(gs.all, sel.Exprs, projectionsScope, fromScope)
+	}
 
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
@@ -513,6 +529,27 @@ func (b *Builder) buildGroupingList(
 	g.buildingGroupingCols = false
 }
 
+// buildNullGroupingList populates nullGroupStrs with the given grouping
+// expressions of a GROUP BY clause with GROUPING SETS, ROLLUP or CUBE. It must
+// be called after buildGroupingList has built the grouping columns of the
+// current grouping set, whose expressions are skipped.
+func (b *Builder) buildNullGroupingList(
+	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope *scope, fromScope *scope,
+) {
+	g := fromScope.groupby
+	g.nullGroupStrs = make(map[string]*types.T, len(groupBy))
+	for _, e := range groupBy {
+		exprs, _ := b.resolveGrouping(e, selects, projectionsScope, fromScope)
+		for _, e := range exprs {
+			exprStr := symbolicExprStr(e)
+			if _, ok := g.groupStrs[exprStr]; ok {
+				continue
+			}
+			g.nullGroupStrs[exprStr] = e.ResolvedType()
+		}
+	}
+}
+
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
 // groupStrs and to the aggInScope.
@@ -530,9 +567,35 @@ func (b *Builder) buildGroupingList(
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
 ) {
+	exprs, alias := b.resolveGrouping(groupBy, selects, projectionsScope, fromScope)
+
+	// Finally, build each of the GROUP BY columns.
+	for _, e := range exprs {
+		// If a grouping column has already been added, don't add it again.
+		// GROUP BY a, a is semantically equivalent to GROUP BY a.
+		exprStr := symbolicExprStr(e)
+		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			continue
+		}
+
+		// Save a representation of the GROUP BY expression for validation of the
+		// SELECT and HAVING expressions. This enables queries such as:
+		//   SELECT x+y FROM t GROUP BY x+y
+		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
+		b.buildScalar(e, fromScope, aggInScope, col, nil)
+		fromScope.groupby.groupStrs[exprStr] = col
+	}
+}
+
+// resolveGrouping resolves the types of the expressions of a GROUP BY item,
+// expanding stars and flattening tuples. If the item refers to a SELECT
+// expression by index or by alias, the SELECT expression is resolved instead,
+// and its alias is returned.
+func (b *Builder) resolveGrouping(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) (exprs []tree.TypedExpr, alias string) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
-	alias := ""
 
 	// Comment below pasted from PostgreSQL (findTargetListEntrySQL92 in
 	// src/backend/parser/parse_clause.c).
@@ -619,25 +682,8 @@ func (b *Builder) buildGrouping(
 	fromScope.context = exprKindGroupBy
 
 	// Resolve types, expand stars, and flatten tuples.
-	exprs := b.expandStarAndResolveType(groupBy, fromScope)
-	exprs = flattenTuples(exprs)
-
-	// Finally, build each of the GROUP BY columns.
-	for _, e := range exprs {
-		// If a grouping column has already been added, don't add it again.
-		// GROUP BY a, a is semantically equivalent to GROUP BY a.
-		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
-			continue
-		}
-
-		// Save a representation of the GROUP BY expression for validation of the
-		// SELECT and HAVING expressions. This enables queries such as:
-		//   SELECT x+y FROM t GROUP BY x+y
-		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
-		b.buildScalar(e, fromScope, aggInScope, col, nil)
-		fromScope.groupby.groupStrs[exprStr] = col
-	}
+	exprs = b.expandStarAndResolveType(groupBy, fromScope)
+	return flattenTuples(exprs), alias
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -0,0 +1,357 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// This file contains builder code for GROUP BY clauses with GROUPING SETS,
+// ROLLUP and CUBE.
+//
+// Such a GROUP BY clause is expanded into a list of grouping sets, and the
+// query is built as the UNION ALL of one aggregation per grouping set. The FROM
+// and WHERE clauses are built once, and their result is shared by the
+// aggregations through a With binding. In the rows produced for a grouping
+// set, the grouping expressions of the other grouping sets are NULL. For
+// example:
+//
+//   SELECT a, b, sum(c) FROM t WHERE d > 0 GROUP BY ROLLUP (a, b)
+//
+// is built as:
+//
+//   WITH input AS (SELECT * FROM t WHERE d > 0)
+//   SELECT a, b, sum(c) FROM input GROUP BY a, b
+//   UNION ALL
+//   SELECT a, NULL, sum(c) FROM input GROUP BY a
+//   UNION ALL
+//   SELECT NULL, NULL, sum(c) FROM input
+//
+// Each aggregation is an ordinary GroupBy or ScalarGroupBy operator, so the
+// grouping sets run on the vectorized hash and ordered aggregators like any
+// other aggregation; no execution support specific to grouping sets is needed.
+//
+// The GROUPING function returns a bit mask of the arguments that are not part
+// of the grouping set of a row, so it is built as a constant for each grouping
+// set.
+
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+// clause can be expanded to. It is the same limit as in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements of a CUBE. It is the same
+// limit as in Postgres.
+const maxCubeElements = 12
+
+// groupingSet is one of the grouping sets of a GROUP BY clause with GROUPING
+// SETS, ROLLUP or CUBE.
+type groupingSet struct {
+	// exprs are the grouping expressions of the set.
+	exprs tree.GroupBy
+
+	// all are the grouping expressions of all the sets of the GROUP BY clause.
+	// The ones that are not part of this set are NULL in the rows produced for
+	// this set.
+	all tree.GroupBy
+}
+
+// hasGroupingSets returns true if the given GROUP BY clause contains GROUPING
+// SETS, ROLLUP or CUBE.
+func hasGroupingSets(groupBy tree.GroupBy) bool {
+	for _, e := range groupBy {
+		if _, ok := e.(*tree.GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// expandGroupingSets returns the grouping sets of the given GROUP BY clause,
+// which is the cross product of the grouping sets of each of its items.
+func expandGroupingSets(groupBy tree.GroupBy) []tree.GroupBy {
+	sets := []tree.GroupBy{nil}
+	for _, e := range groupBy {
+		itemSets := groupingSetsOfItem(e)
+		if len(sets)*len(itemSets) > maxGroupingSets {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"too many grouping sets present (maximum %d)", maxGroupingSets))
+		}
+		product := make([]tree.GroupBy, 0, len(sets)*len(itemSets))
+		for _, set := range sets {
+			for _, itemSet := range itemSets {
+				newSet := make(tree.GroupBy, 0, len(set)+len(itemSet))
+				newSet = append(newSet, set...)
+				newSet = append(newSet, itemSet...)
+				product = append(product, newSet)
+			}
+		}
+		sets = product
+	}
+	return sets
+}
+
+// groupingSetsOfItem returns the grouping sets of a single GROUP BY item. An
+// item that is an ordinary expression has a single grouping set.
+func groupingSetsOfItem(e tree.Expr) []tree.GroupBy {
+	gs, ok := e.(*tree.GroupingSet)
+	if !ok {
+		return []tree.GroupBy{groupingSetElement(e)}
+	}
+
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		// ROLLUP (a, b) is GROUPING SETS ((a, b), (a), ()).
+		sets := make([]tree.GroupBy, 0, len(gs.Exprs)+1)
+		for i := len(gs.Exprs); i >= 0; i-- {
+			var set tree.GroupBy
+			for _, e := range gs.Exprs[:i] {
+				set = append(set, groupingSetElement(e)...)
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		// CUBE (a, b) is GROUPING SETS ((a, b), (a), (b), ()).
+		n := len(gs.Exprs)
+		if n > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		sets := make([]tree.GroupBy, 0, 1<<n)
+		for mask := 1<<n - 1; mask >= 0; mask-- {
+			var set tree.GroupBy
+			for i, e := range gs.Exprs {
+				if mask&(1<<(n-1-i)) != 0 {
+					set = append(set, groupingSetElement(e)...)
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	case tree.GroupingSets:
+		var sets []tree.GroupBy
+		for _, e := range gs.Exprs {
+			sets = append(sets, groupingSetsOfItem(e)...)
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %v", gs.Type))
+	}
+}
+
+// groupingSetElement returns the grouping expressions of an element of a
+// grouping set. A parenthesized list of expressions is grouped together, and
+// () is the empty grouping set.
+func groupingSetElement(e tree.Expr) tree.GroupBy {
+	if t, ok := e.(*tree.Tuple); ok && !t.Row {
+		return tree.GroupBy(t.Exprs)
+	}
+	return tree.GroupBy{e}
+}
+
+// buildGroupingSets builds a SELECT clause whose GROUP BY clause contains
+// GROUPING SETS, ROLLUP or CUBE, as the UNION ALL of one aggregation per
+// grouping set. The FROM and WHERE clauses of sel have been built in
+// fromScope.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildGroupingSets(
+	sel *tree.SelectClause,
+	fromScope *scope,
+	locking lockingSpec,
+	desiredTypes []*types.T,
+	inScope *scope,
+) (outScope *scope) {
+	if sel.DistinctOn != nil {
+		panic(unimplemented.NewWithIssue(46280, "DISTINCT ON with grouping sets is not supported"))
+	}
+
+	sets := expandGroupingSets(sel.GroupBy)
+	var all tree.GroupBy
+	for _, set := range sets {
+		all = append(all, set...)
+	}
+
+	// The input rows are shared by the aggregations of all the grouping sets.
+	var input *cteSource
+	if len(sets) > 1 {
+		id := b.factory.Memo().NextWithID()
+		b.factory.Metadata().AddWithBinding(id, fromScope.expr)
+		input = &cteSource{
+			id:   id,
+			name: tree.AliasClause{Alias: "grouping_sets_input"},
+			expr: fromScope.expr,
+		}
+	}
+
+	// DISTINCT applies to the rows of all the grouping sets, so it is built on
+	// top of the UNION ALL.
+	setSel := *sel
+	setSel.Distinct = false
+	for i := range sets {
+		gs := &groupingSet{exprs: sets[i], all: all}
+		setFromScope := fromScope
+		if input != nil {
+			setFromScope = b.buildGroupingSetInput(input, fromScope, inScope)
+		}
+		setScope := b.buildSelectClauseWithGroupingSet(
+			&setSel, setFromScope, gs, nil /* orderBy */, locking, desiredTypes,
+		)
+		if outScope == nil {
+			outScope = setScope
+			// Propagate the types of the first grouping set to the others, if we
+			// didn't already have desired types.
+			if len(desiredTypes) == 0 {
+				desiredTypes = outScope.makeColumnTypes()
+			}
+			continue
+		}
+		outScope = b.buildSetOp(tree.UnionOp, true /* all */, inScope, outScope, setScope)
+	}
+
+	if input != nil {
+		// Like a CTE, the binding is built at the root unless it is correlated.
+		if fromScope.expr.Relational().OuterCols.Empty() {
+			b.addCTE(input)
+		} else {
+			outScope.expr = b.buildWiths(outScope.expr, cteSources{input})
+		}
+	}
+
+	if sel.Distinct {
+		outScope.expr = b.constructDistinct(outScope)
+	}
+	return outScope
+}
+
+// buildGroupingSetInput returns a scope that reads the input rows of a
+// grouping set from the given With binding. The scope has the same columns as
+// fromScope, the scope in which the input was built, with new column IDs.
+func (b *Builder) buildGroupingSetInput(input *cteSource, fromScope, inScope *scope) *scope {
+	outScope := inScope.push()
+	outScope.windowDefs = fromScope.windowDefs
+	outScope.cols = make([]scopeColumn, len(fromScope.cols))
+	for i := range fromScope.cols {
+		col := fromScope.cols[i]
+		col.id = b.factory.Metadata().AddColumn(string(col.name.ReferenceName()), col.typ)
+		col.scalar = nil
+		outScope.cols[i] = col
+	}
+	outScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
+		With:    input.id,
+		Name:    string(input.name.Alias),
+		InCols:  fromScope.colList(),
+		OutCols: outScope.colList(),
+		ID:      b.factory.Metadata().NextUniqueID(),
+	})
+	return outScope
+}
+
+// groupingInfo stores information about a call to the GROUPING function.
+type groupingInfo struct {
+	*tree.FuncExpr
+
+	// args are the typed arguments of the function, which must be grouping
+	// expressions.
+	args []tree.TypedExpr
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingInfo) Eval(_ *tree.EvalContext) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingInfo must be replaced before evaluation"))
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+var _ tree.Expr = &groupingInfo{}
+var _ tree.TypedExpr = &groupingInfo{}
+
+// isGroupingFn returns true if the given function call is a call to the
+// GROUPING function.
+func isGroupingFn(f *tree.FuncExpr) bool {
+	name, ok := f.Func.FunctionReference.(*tree.UnresolvedName)
+	return ok && name.NumParts == 1 && name.Parts[0] == "grouping"
+}
+
+// replaceGroupingFn returns a groupingInfo that can be used to replace a call
+// to the GROUPING function. The arguments are resolved in this scope.
+func (s *scope) replaceGroupingFn(f *tree.FuncExpr) tree.Expr {
+	if s.inAgg {
+		panic(errGroupingArgs)
+	}
+	// The result is a bit mask with one bit per argument.
+	if len(f.Exprs) > 31 {
+		panic(pgerror.New(pgcode.TooManyArguments, "GROUPING must have fewer than 32 arguments"))
+	}
+	info := &groupingInfo{FuncExpr: f, args: make([]tree.TypedExpr, len(f.Exprs))}
+	for i, e := range f.Exprs {
+		info.args[i] = s.resolveType(e, types.Any)
+	}
+	return info
+}
+
+// buildGroupingFn builds a call to the GROUPING function, which is a constant
+// for the grouping set being built. Each argument corresponds to a bit of the
+// result, with the last argument in the least significant bit. The bit is set
+// if the argument is not part of the grouping set.
+func (b *Builder) buildGroupingFn(
+	g *groupingInfo, inScope, outScope *scope, outCol *scopeColumn,
+) opt.ScalarExpr {
+	if !inScope.inGroupingContext() || inScope.inAgg {
+		panic(errGroupingArgs)
+	}
+	var res tree.DInt
+	for _, arg := range g.args {
+		res <<= 1
+		exprStr := symbolicExprStr(arg)
+		if _, ok := inScope.groupby.groupStrs[exprStr]; ok {
+			continue
+		}
+		if _, ok := inScope.groupby.nullGroupStrs[exprStr]; ok {
+			res |= 1
+			continue
+		}
+		panic(errGroupingArgs)
+	}
+	out := b.factory.ConstructConstVal(tree.NewDInt(res), types.Int)
+	return b.finishBuildScalar(g, out, inScope, outScope, outCol)
+}
+
+var errGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
@@ -0,0 +1,847 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// This file contains methods that create and maintain incrementally
+// maintained materialized views:
+//
+//   CREATE MATERIALIZED VIEW v WITH (incremental = true) AS SELECT ...
+//
+// The query of such a view is restricted to filters, projections and inner
+// joins of tables, optionally grouped and aggregated with count and sum.
+// Instead of being recomputed by REFRESH MATERIALIZED VIEW, the view is
+// updated by every mutation of the tables it depends on, using the rows
+// modified by the mutation.
+//
+// When the view is created, its query is extended with hidden columns that are
+// needed to maintain it. For a view without aggregates, these are the primary
+// key columns of each table in the FROM clause, which identify the view rows
+// derived from a given table row:
+//
+//   SELECT a.x, b.y FROM a JOIN b ON a.k = b.k
+//     ==> SELECT a.x, b.y, a.k AS crdb_internal_ivm_key_1_1,
+//                b.k AS crdb_internal_ivm_key_2_1 FROM a JOIN b ON a.k = b.k
+//
+// For a view with aggregates, these are the number of rows of each group, and
+// the number of non-NULL arguments of each sum, which determines whether the
+// sum is NULL:
+//
+//   SELECT g, sum(v) FROM t GROUP BY g
+//     ==> SELECT g, sum(v), count(*) AS crdb_internal_ivm_count,
+//                count(v) AS crdb_internal_ivm_count_2 FROM t GROUP BY g
+//
+// The views are maintained by queries that are planned in the same way as FK
+// cascades: the mutation input is buffered, and the old and new values of the
+// modified rows are exposed to the queries as the crdb_internal_ivm_old and
+// crdb_internal_ivm_new data sources. The queries evaluate the view query with
+// the mutated table replaced by one of these data sources:
+//
+//  - for a view without aggregates, the view rows derived from the old rows are
+//    deleted, and the view rows derived from the new rows are inserted;
+//  - for a view with aggregates, the changes of the counts and sums of each
+//    group are computed from the new rows and the negated old rows. They are
+//    added to the existing groups of the view, the groups that do not exist yet
+//    are inserted, and the groups whose count drops to zero are deleted.
+//
+// Each of these steps is a separate cascade, so that each step observes the
+// writes of the previous ones.
+//
+// A view is also maintained while it is being created, so that the writes to
+// its tables are not blocked by its backfill; see
+// SchemaChanger.backfillIncrementalView.
+
+const (
+	ivmKeyColPrefix = "crdb_internal_ivm_key_"
+	ivmCountColName = "crdb_internal_ivm_count"
+	ivmOldRowsName  = "crdb_internal_ivm_old"
+	ivmNewRowsName  = "crdb_internal_ivm_new"
+)
+
+// incrementalViewColKind describes the expression of a column of an
+// incrementally maintained view.
+type incrementalViewColKind int
+
+const (
+	// ivmPlain is an expression of a view without aggregates.
+	ivmPlain incrementalViewColKind = iota
+	// ivmGroup is a grouping expression of a view with aggregates.
+	ivmGroup
+	// ivmCountRows is count(*).
+	ivmCountRows
+	// ivmCount is count(<expr>).
+	ivmCount
+	// ivmSum is sum(<expr>).
+	ivmSum
+)
+
+// incrementalViewCol describes an expression in the SELECT list of the query of
+// an incrementally maintained view.
+type incrementalViewCol struct {
+	kind incrementalViewColKind
+
+	// expr is the expression, or the argument of the aggregate function.
+	expr tree.Expr
+
+	// countCol is the ordinal of the hidden column that counts the non-NULL
+	// arguments of a sum. It is only set for ivmSum columns of the extended
+	// query of the view.
+	countCol int
+}
+
+// incrementalView is the analyzed query of an incrementally maintained view.
+type incrementalView struct {
+	// sel is the query of the view, in which any GROUP BY ordinals are replaced
+	// with the corresponding expressions.
+	sel *tree.SelectClause
+
+	// tables contains the tables in the FROM clause, in the order in which they
+	// appear in the query.
+	tables []*tree.AliasedTableExpr
+
+	// cols describes the expressions in the SELECT list.
+	cols []incrementalViewCol
+
+	// aggregate is true if the view has a GROUP BY clause or aggregates.
+	aggregate bool
+}
+
+// analyzeIncrementalView checks that the given query can be maintained
+// incrementally, and describes its structure. An error is raised if the query
+// is not supported.
+func (b *Builder) analyzeIncrementalView(stmt *tree.Select) *incrementalView {
+	unsupported := func(what string) {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported in incrementally maintained views", what))
+	}
+	switch {
+	case stmt.With != nil:
+		unsupported("WITH")
+	case stmt.OrderBy != nil:
+		unsupported("ORDER BY")
+	case stmt.Limit != nil:
+		unsupported("LIMIT")
+	case stmt.Locking != nil:
+		unsupported("FOR UPDATE")
+	}
+	sel, ok := stmt.Select.(*tree.SelectClause)
+	if !ok {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"incrementally maintained views must be defined by a simple SELECT query"))
+	}
+	switch {
+	case sel.Distinct || sel.DistinctOn != nil:
+		unsupported("DISTINCT")
+	case sel.Having != nil:
+		unsupported("HAVING")
+	case sel.Window != nil:
+		unsupported("WINDOW")
+	case sel.From.AsOf.Expr != nil:
+		unsupported("AS OF SYSTEM TIME")
+	}
+
+	checkExpr := func(expr tree.Expr) {
+		_, err := tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+			switch t := expr.(type) {
+			case *tree.Subquery:
+				unsupported("a subquery")
+			case *tree.UnresolvedName:
+				if t.NumParts > 2 {
+					unsupported(fmt.Sprintf("column reference %s", tree.ErrString(t)))
+				}
+			case *tree.FuncExpr:
+				def, err := t.Func.Resolve(b.semaCtx.SearchPath)
+				if err != nil {
+					return false, nil, err
+				}
+				switch {
+				case t.WindowDef != nil || isWindow(def):
+					unsupported("a window function")
+				case isGenerator(def):
+					unsupported("a set-returning function")
+				case isAggregate(def):
+					unsupported(fmt.Sprintf("aggregate function %s() inside an expression", def.Name))
+				}
+			}
+			return true, expr, nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	v := &incrementalView{}
+	var walk func(t tree.TableExpr)
+	walk = func(t tree.TableExpr) {
+		switch t := t.(type) {
+		case *tree.AliasedTableExpr:
+			switch t.Expr.(type) {
+			case *tree.TableName:
+			case *tree.Subquery:
+				unsupported("a subquery")
+			default:
+				unsupported(fmt.Sprintf("data source %s", tree.ErrString(t.Expr)))
+			}
+			if t.Ordinality {
+				unsupported("WITH ORDINALITY")
+			}
+			if t.As.Cols != nil {
+				unsupported("a column alias list")
+			}
+			v.tables = append(v.tables, t)
+		case *tree.ParenTableExpr:
+			walk(t.Expr)
+		case *tree.JoinTableExpr:
+			if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+				unsupported(fmt.Sprintf("%s JOIN", t.JoinType))
+			}
+			walk(t.Left)
+			walk(t.Right)
+			if on, ok := t.Cond.(*tree.OnJoinCond); ok {
+				checkExpr(on.Expr)
+			}
+		default:
+			unsupported(fmt.Sprintf("data source %s", tree.ErrString(t)))
+		}
+	}
+	for _, t := range sel.From.Tables {
+		walk(t)
+	}
+	if sel.Where != nil {
+		checkExpr(sel.Where.Expr)
+	}
+
+	v.cols = make([]incrementalViewCol, len(sel.Exprs))
+	for i := range sel.Exprs {
+		expr := sel.Exprs[i].Expr
+		if f, ok := expr.(*tree.FuncExpr); ok && f.WindowDef == nil {
+			def, err := f.Func.Resolve(b.semaCtx.SearchPath)
+			if err != nil {
+				panic(err)
+			}
+			if isAggregate(def) {
+				v.aggregate = true
+				v.cols[i] = analyzeIncrementalViewAggregate(f, def, unsupported)
+				if v.cols[i].expr != nil {
+					checkExpr(v.cols[i].expr)
+				}
+				continue
+			}
+		}
+		checkExpr(expr)
+		v.cols[i] = incrementalViewCol{kind: ivmPlain, expr: expr}
+	}
+
+	v.sel = sel
+	if len(sel.GroupBy) > 0 {
+		res := *sel
+		res.GroupBy = make(tree.GroupBy, len(sel.GroupBy))
+		for i, g := range sel.GroupBy {
+			if col := colIndex(len(sel.Exprs), g, "GROUP BY"); col != -1 {
+				g = sel.Exprs[col].Expr
+			}
+			checkExpr(g)
+			res.GroupBy[i] = g
+		}
+		v.sel = &res
+		v.aggregate = true
+	}
+	if !v.aggregate {
+		return v
+	}
+
+	// Every expression in the SELECT list that is not an aggregate must be a
+	// grouping expression, and every grouping expression must be in the SELECT
+	// list, so that the groups of the view can be identified.
+	for i := range v.cols {
+		if v.cols[i].kind != ivmPlain {
+			continue
+		}
+		str := tree.AsStringWithFlags(v.cols[i].expr, tree.FmtParsable)
+		for _, g := range v.sel.GroupBy {
+			if str == tree.AsStringWithFlags(g, tree.FmtParsable) {
+				v.cols[i].kind = ivmGroup
+				break
+			}
+		}
+		if v.cols[i].kind != ivmGroup {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"expression %s must be a GROUP BY expression in incrementally maintained views", str))
+		}
+	}
+	for _, g := range v.sel.GroupBy {
+		str := tree.AsStringWithFlags(g, tree.FmtParsable)
+		found := false
+		for i := range v.cols {
+			found = found || (v.cols[i].kind == ivmGroup &&
+				str == tree.AsStringWithFlags(v.cols[i].expr, tree.FmtParsable))
+		}
+		if !found {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"GROUP BY expression %s must appear in the SELECT list of incrementally maintained views", str))
+		}
+	}
+
+	// Find the hidden columns that count the non-NULL arguments of the sums.
+	for i := range v.cols {
+		if v.cols[i].kind != ivmSum {
+			continue
+		}
+		name := tree.UnrestrictedName(fmt.Sprintf("%s_%d", ivmCountColName, i+1))
+		for j := range sel.Exprs {
+			if sel.Exprs[j].As == name {
+				v.cols[i].countCol = j
+			}
+		}
+	}
+	return v
+}
+
+// analyzeIncrementalViewAggregate describes an aggregate function in the
+// SELECT list of an incrementally maintained view. Only count and sum are
+// supported.
+func analyzeIncrementalViewAggregate(
+	f *tree.FuncExpr, def *tree.FunctionDefinition, unsupported func(what string),
+) incrementalViewCol {
+	switch {
+	case f.Type == tree.DistinctFuncType:
+		unsupported(fmt.Sprintf("%s(DISTINCT ...)", def.Name))
+	case f.Filter != nil:
+		unsupported("FILTER")
+	case f.OrderBy != nil:
+		unsupported("ORDER BY in an aggregate function")
+	}
+	switch def.Name {
+	case "count":
+		if _, ok := f.Exprs[0].(tree.UnqualifiedStar); ok {
+			return incrementalViewCol{kind: ivmCountRows}
+		}
+		return incrementalViewCol{kind: ivmCount, expr: f.Exprs[0]}
+	case "sum":
+		return incrementalViewCol{kind: ivmSum, expr: f.Exprs[0]}
+	}
+	unsupported(fmt.Sprintf("aggregate function %s()", def.Name))
+	return incrementalViewCol{}
+}
+
+// incrementalViewTableName returns the name by which the columns of the given
+// table can be referenced in the query of an incrementally maintained view.
+func incrementalViewTableName(t *tree.AliasedTableExpr) tree.Name {
+	if t.As.Alias != "" {
+		return t.As.Alias
+	}
+	return t.Expr.(*tree.TableName).ObjectName
+}
+
+// buildIncrementalViewQuery returns the query of an incrementally maintained
+// view, extended with the hidden columns that are needed to maintain it. The
+// data source names in the given query must be fully qualified.
+func (b *Builder) buildIncrementalViewQuery(stmt *tree.Select) *tree.Select {
+	v := b.analyzeIncrementalView(stmt)
+
+	res := *v.sel
+	res.Exprs = append(tree.SelectExprs(nil), v.sel.Exprs...)
+	ids := make(map[cat.StableID]struct{}, len(v.tables))
+	names := make(map[tree.Name]struct{}, len(v.tables))
+	for i, t := range v.tables {
+		tn := t.Expr.(*tree.TableName)
+		ds, _, err := b.catalog.ResolveDataSource(b.ctx, cat.Flags{AvoidDescriptorCaches: true}, tn)
+		if err != nil {
+			panic(err)
+		}
+		tab, ok := ds.(cat.Table)
+		if !ok || tab.IsVirtualTable() || tab.IsMaterializedView() {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"incrementally maintained views can only select from tables, but %s is not a table",
+				tree.ErrString(tn)))
+		}
+		name := incrementalViewTableName(t)
+		_, dupID := ids[tab.ID()]
+		_, dupName := names[name]
+		if dupID {
+			name = tab.Name()
+		}
+		if dupID || dupName {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"incrementally maintained views cannot refer to table %s more than once",
+				tree.ErrString(&name)))
+		}
+		ids[tab.ID()] = struct{}{}
+		names[name] = struct{}{}
+
+		if v.aggregate {
+			continue
+		}
+		primaryIndex := tab.Index(cat.PrimaryIndex)
+		for j, n := 0, primaryIndex.KeyColumnCount(); j < n; j++ {
+			col := primaryIndex.Column(j)
+			res.Exprs = append(res.Exprs, tree.SelectExpr{
+				Expr: &tree.UnresolvedName{
+					NumParts: 2,
+					Parts:    tree.NameParts{string(col.ColName()), string(name)},
+				},
+				As: tree.UnrestrictedName(fmt.Sprintf("%s%d_%d", ivmKeyColPrefix, i+1, j+1)),
+			})
+		}
+	}
+
+	if v.aggregate {
+		res.Exprs = append(res.Exprs, tree.SelectExpr{
+			Expr: &tree.FuncExpr{Func: tree.WrapFunction("count"), Exprs: tree.Exprs{tree.StarExpr()}},
+			As:   ivmCountColName,
+		})
+		for i := range v.cols {
+			if v.cols[i].kind == ivmSum {
+				res.Exprs = append(res.Exprs, tree.SelectExpr{
+					Expr: &tree.FuncExpr{Func: tree.WrapFunction("count"), Exprs: tree.Exprs{v.cols[i].expr}},
+					As:   tree.UnrestrictedName(fmt.Sprintf("%s_%d", ivmCountColName, i+1)),
+				})
+			}
+		}
+	}
+	return &tree.Select{Select: &res}
+}
+
+// parseIncrementalView parses and analyzes the extended query of the given
+// incrementally maintained view.
+func (b *Builder) parseIncrementalView(view cat.Table) *incrementalView {
+	stmt, err := parser.ParseOne(view.IncrementalViewQuery())
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err,
+			"failed to parse query of incrementally maintained view %q", view.Name()))
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		panic(errors.AssertionFailedf("unexpected query of view %q: %s", view.Name(), stmt.SQL))
+	}
+	return b.analyzeIncrementalView(sel)
+}
+
+// incrementalViewStep is one of the steps of the maintenance of an
+// incrementally maintained view; see the comment at the top of the file.
+type incrementalViewStep int
+
+const (
+	// ivmDeleteRows deletes the rows of a view without aggregates that are
+	// derived from the old rows.
+	ivmDeleteRows incrementalViewStep = iota
+	// ivmInsertRows inserts the rows of a view without aggregates that are
+	// derived from the new rows.
+	ivmInsertRows
+	// ivmUpdateGroups applies the changes of the counts and sums to the existing
+	// groups of a view with aggregates.
+	ivmUpdateGroups
+	// ivmInsertGroups inserts the groups of a view with aggregates that do not
+	// exist yet.
+	ivmInsertGroups
+	// ivmDeleteGroups deletes the groups of a view with aggregates whose count
+	// dropped to zero.
+	ivmDeleteGroups
+)
+
+// buildIncrementalViewMaintenance adds cascades that maintain the
+// incrementally maintained views which depend on the mutated table, using the
+// old and new values of the modified rows, if they are available.
+func (mb *mutationBuilder) buildIncrementalViewMaintenance(withOld, withNew bool) {
+	if mb.tab.IncrementalViewCount() == 0 {
+		return
+	}
+
+	oldCols, newCols := mb.mutatedCols(withOld, withNew)
+	hasOld, hasNew := len(oldCols.ids) > 0, len(newCols.ids) > 0
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	keyCol := primaryIndex.Column(0).ColName()
+
+	for i, n := 0, mb.tab.IncrementalViewCount(); i < n; i++ {
+		view := resolveIncrementalView(mb.b.ctx, mb.b.catalog, mb.tab.IncrementalView(i))
+		v := mb.b.parseIncrementalView(view)
+
+		var steps []incrementalViewStep
+		switch {
+		case !v.aggregate:
+			if hasOld {
+				steps = append(steps, ivmDeleteRows)
+			}
+			if hasNew {
+				steps = append(steps, ivmInsertRows)
+			}
+		case len(v.sel.GroupBy) == 0:
+			// A view with aggregates but no GROUP BY always has a single row.
+			steps = append(steps, ivmUpdateGroups)
+		default:
+			steps = append(steps, ivmUpdateGroups)
+			if hasNew {
+				steps = append(steps, ivmInsertGroups)
+			}
+			if hasOld {
+				steps = append(steps, ivmDeleteGroups)
+			}
+		}
+
+		mb.ensureWithID()
+		for _, step := range steps {
+			mb.cascades = append(mb.cascades, memo.FKCascade{
+				FKName: string(view.Name()),
+				Builder: &incrementalViewBuilder{
+					viewID:   view.ID(),
+					tableID:  mb.tab.ID(),
+					step:     step,
+					keyCol:   keyCol,
+					oldNames: oldCols.names,
+					newNames: newCols.names,
+				},
+				WithID:          mb.withID,
+				OldValues:       oldCols.ids,
+				NewValues:       newCols.ids,
+				IncrementalView: true,
+			})
+		}
+	}
+}
+
+// resolveIncrementalView returns the incrementally maintained view with the
+// given ID. A view that is being created cannot be leased, but it must still be
+// maintained: its backfill only discards the changes made to the view by the
+// mutations that it observes (see SchemaChanger.backfillIncrementalView). In
+// that case, the descriptor of the view is read without using the caches.
+func resolveIncrementalView(ctx context.Context, catalog cat.Catalog, id cat.StableID) cat.Table {
+	ds, isAdding, err := catalog.ResolveDataSourceByID(ctx, cat.Flags{}, id)
+	if err != nil && isAdding {
+		ds, _, err = catalog.ResolveDataSourceByID(ctx, cat.Flags{AvoidDescriptorCaches: true}, id)
+	}
+	if err != nil {
+		panic(err)
+	}
+	return ds.(cat.Table)
+}
+
+// incrementalViewBuilder is a memo.CascadeBuilder implementation for one of the
+// steps of the maintenance of an incrementally maintained view.
+type incrementalViewBuilder struct {
+	viewID  cat.StableID
+	tableID cat.StableID
+	step    incrementalViewStep
+
+	// keyCol is the first primary key column of the mutated table. The old
+	// values of an upsert are NULL for the inserted rows; they are filtered out
+	// using this column.
+	keyCol tree.Name
+
+	// oldNames and newNames are the names of the columns that correspond 1-to-1
+	// to the oldValues and newValues passed to Build.
+	oldNames []tree.Name
+	newNames []tree.Name
+}
+
+var _ memo.CascadeBuilder = &incrementalViewBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (vb *incrementalViewBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		if len(oldValues) != len(vb.oldNames) || len(newValues) != len(vb.newNames) {
+			panic(errors.AssertionFailedf(
+				"expected %d old and %d new values, got %d and %d",
+				len(vb.oldNames), len(vb.newNames), len(oldValues), len(newValues),
+			))
+		}
+		view := resolveIncrementalView(ctx, catalog, vb.viewID)
+		sql := vb.maintenanceSQL(ctx, catalog, view, b.parseIncrementalView(view), len(oldValues) > 0)
+		stmt, err := parser.ParseOne(sql)
+		if err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err,
+				"failed to parse maintenance query of view %q", view.Name()))
+		}
+
+		// Construct a dummy operator as the binding.
+		b.factory.Metadata().AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		b.maintainingIncrementalView = true
+		outScope := b.buildIncrementalViewStmt(
+			stmt.AST, binding,
+			makeTriggerPresentation(vb.oldNames, oldValues),
+			makeTriggerPresentation(vb.newNames, newValues),
+		)
+		return outScope.expr
+	})
+}
+
+// buildIncrementalViewStmt builds a statement that maintains an incrementally
+// maintained view. The old and new values of the modified rows are read from
+// the given binding, and can be referenced by the statement through the
+// crdb_internal_ivm_old and crdb_internal_ivm_new data sources.
+func (b *Builder) buildIncrementalViewStmt(
+	stmt tree.Statement, binding opt.WithID, oldCols, newCols physical.Presentation,
+) (outScope *scope) {
+	inScope := b.allocScope()
+	inScope.atRoot = true
+	inScope.ctes = make(map[string]*cteSource)
+	if len(oldCols) > 0 {
+		inScope.ctes[ivmOldRowsName] = &cteSource{
+			id: binding, name: tree.AliasClause{Alias: ivmOldRowsName}, cols: oldCols,
+		}
+	}
+	if len(newCols) > 0 {
+		inScope.ctes[ivmNewRowsName] = &cteSource{
+			id: binding, name: tree.AliasClause{Alias: ivmNewRowsName}, cols: newCols,
+		}
+	}
+	return b.buildStmt(stmt, nil /* desiredTypes */, inScope)
+}
+
+// maintenanceSQL returns the statement that implements the step of the
+// maintenance of the given view. See the comment at the top of the file.
+func (vb *incrementalViewBuilder) maintenanceSQL(
+	ctx context.Context, catalog cat.Catalog, view cat.Table, v *incrementalView, hasOld bool,
+) string {
+	// Find the mutated table in the FROM clause.
+	table := -1
+	for i, t := range v.tables {
+		tn := *t.Expr.(*tree.TableName)
+		ds, _, err := catalog.ResolveDataSource(ctx, cat.Flags{}, &tn)
+		if err != nil {
+			panic(err)
+		}
+		if ds.ID() == vb.tableID {
+			table = i
+		}
+	}
+	if table == -1 {
+		panic(errors.AssertionFailedf("view %q does not refer to table %d", view.Name(), vb.tableID))
+	}
+
+	// Find the names of the view columns that correspond to the expressions in
+	// the SELECT list, which excludes the primary key of the view.
+	var viewCols tree.NameList
+	primaryIndex := view.Index(cat.PrimaryIndex)
+	for i, n := 0, view.ColumnCount(); i < n; i++ {
+		col := view.Column(i)
+		if col.Kind() != cat.Ordinary {
+			continue
+		}
+		isKey := false
+		for j, m := 0, primaryIndex.KeyColumnCount(); j < m; j++ {
+			isKey = isKey || primaryIndex.Column(j).Ordinal() == i
+		}
+		if !isKey {
+			viewCols = append(viewCols, col.ColName())
+		}
+	}
+	if len(viewCols) != len(v.cols) {
+		panic(errors.AssertionFailedf(
+			"view %q has %d columns, but its query has %d", view.Name(), len(viewCols), len(v.cols),
+		))
+	}
+
+	viewRef := fmt.Sprintf("[%d AS v]", view.ID())
+	viewCol := func(i int) string {
+		return "v." + tree.AsStringWithFlags(&viewCols[i], tree.FmtParsable)
+	}
+	fmtNode := func(n tree.NodeFormatter) string {
+		return tree.AsStringWithFlags(n, tree.FmtParsable)
+	}
+
+	switch vb.step {
+	case ivmDeleteRows:
+		var viewKeys, rowKeys []string
+		prefix := fmt.Sprintf("%s%d_", ivmKeyColPrefix, table+1)
+		for i := range v.sel.Exprs {
+			if strings.HasPrefix(string(v.sel.Exprs[i].As), prefix) {
+				viewKeys = append(viewKeys, viewCol(i))
+				col := tree.Name(v.sel.Exprs[i].Expr.(*tree.UnresolvedName).Parts[0])
+				rowKeys = append(rowKeys, fmtNode(&col))
+			}
+		}
+		return fmt.Sprintf("DELETE FROM %s WHERE (%s) IN (SELECT %s FROM %s)",
+			viewRef, strings.Join(viewKeys, ", "), strings.Join(rowKeys, ", "), ivmOldRowsName)
+
+	case ivmInsertRows:
+		sel := *v.sel
+		sel.From = vb.fromWithRows(v, table, false /* old */)
+		return fmt.Sprintf("INSERT INTO %s (%s) %s", viewRef, fmtNode(&viewCols), fmtNode(&sel))
+	}
+
+	// The remaining steps maintain a view with aggregates, using the changes of
+	// the counts and sums of each group. The changes are computed by a query d
+	// with a column c<i> for each view column i.
+	var branches []string
+	for _, old := range []bool{false, true} {
+		if old && !hasOld {
+			continue
+		}
+		sign := ""
+		if old {
+			sign = "-"
+		}
+		exprs := make([]string, len(v.cols))
+		for i, c := range v.cols {
+			switch c.kind {
+			case ivmGroup:
+				exprs[i] = fmtNode(c.expr)
+			case ivmCountRows:
+				exprs[i] = sign + "1"
+			case ivmCount:
+				exprs[i] = fmt.Sprintf("CASE WHEN (%s) IS NULL THEN 0 ELSE %s1 END", fmtNode(c.expr), sign)
+			case ivmSum:
+				exprs[i] = fmt.Sprintf("%s(%s)", sign, fmtNode(c.expr))
+			}
+			exprs[i] = fmt.Sprintf("%s AS c%d", exprs[i], i)
+		}
+		from := vb.fromWithRows(v, table, old)
+		branch := fmt.Sprintf("SELECT %s FROM %s", strings.Join(exprs, ", "), fmtNode(&from.Tables))
+		if v.sel.Where != nil {
+			branch += " WHERE " + fmtNode(v.sel.Where.Expr)
+		}
+		branches = append(branches, branch)
+	}
+	exprs := make([]string, len(v.cols))
+	var groupCols, groupMatch []string
+	countCol := -1
+	for i, c := range v.cols {
+		switch c.kind {
+		case ivmGroup:
+			exprs[i] = fmt.Sprintf("c%d", i)
+			groupCols = append(groupCols, exprs[i])
+			groupMatch = append(groupMatch, fmt.Sprintf("%s IS NOT DISTINCT FROM d.c%d", viewCol(i), i))
+		case ivmCountRows, ivmCount:
+			exprs[i] = fmt.Sprintf("COALESCE(sum_int(c%d), 0) AS c%d", i, i)
+		case ivmSum:
+			exprs[i] = fmt.Sprintf("sum(c%d) AS c%d", i, i)
+		}
+		if v.sel.Exprs[i].As == ivmCountColName {
+			countCol = i
+		}
+	}
+	delta := fmt.Sprintf("SELECT %s FROM (%s) AS delta",
+		strings.Join(exprs, ", "), strings.Join(branches, " UNION ALL "))
+	if len(groupCols) > 0 {
+		delta += " GROUP BY " + strings.Join(groupCols, ", ")
+	}
+	var where string
+	if len(groupMatch) > 0 {
+		where = " WHERE " + strings.Join(groupMatch, " AND ")
+	}
+
+	switch vb.step {
+	case ivmUpdateGroups:
+		var sets []string
+		for i, c := range v.cols {
+			name := fmtNode(&viewCols[i])
+			switch c.kind {
+			case ivmCountRows, ivmCount:
+				sets = append(sets, fmt.Sprintf("%s = %s + d.c%d", name, viewCol(i), i))
+			case ivmSum:
+				// The sum is NULL if there are no non-NULL arguments.
+				sets = append(sets, fmt.Sprintf(
+					"%[1]s = CASE WHEN %[2]s + d.c%[3]d = 0 THEN NULL "+
+						"WHEN %[4]s IS NULL THEN d.c%[5]d WHEN d.c%[5]d IS NULL THEN %[4]s "+
+						"ELSE %[4]s + d.c%[5]d END",
+					name, viewCol(c.countCol), c.countCol, viewCol(i), i,
+				))
+			}
+		}
+		return fmt.Sprintf("UPDATE %s SET %s FROM (%s) AS d%s",
+			viewRef, strings.Join(sets, ", "), delta, where)
+
+	case ivmInsertGroups:
+		cols := make([]string, len(v.cols))
+		for i := range cols {
+			cols[i] = fmt.Sprintf("d.c%d", i)
+		}
+		return fmt.Sprintf(
+			"INSERT INTO %s (%s) SELECT %s FROM (%s) AS d WHERE d.c%d > 0 AND NOT EXISTS (SELECT 1 FROM %s%s)",
+			viewRef, fmtNode(&viewCols), strings.Join(cols, ", "), delta, countCol, viewRef, where,
+		)
+
+	case ivmDeleteGroups:
+		return fmt.Sprintf("DELETE FROM %s USING (%s) AS d%s AND %s = 0",
+			viewRef, delta, where, viewCol(countCol))
+	}
+	panic(errors.AssertionFailedf("unexpected step %d", vb.step))
+}
+
+// fromWithRows returns the FROM clause of the query of the view, in which the
+// mutated table is replaced with its old or new rows.
+func (vb *incrementalViewBuilder) fromWithRows(
+	v *incrementalView, table int, old bool,
+) tree.From {
+	alias := tree.AliasClause{Alias: incrementalViewTableName(v.tables[table])}
+	var rows tree.TableExpr
+	if old {
+		// Filter out the old values of the rows inserted by an upsert.
+		oldRows := tree.MakeUnqualifiedTableName(ivmOldRowsName)
+		rows = &tree.AliasedTableExpr{
+			Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: &tree.Select{
+				Select: &tree.SelectClause{
+					Exprs: tree.SelectExprs{tree.StarSelectExpr()},
+					From:  tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{Expr: &oldRows}}},
+					Where: tree.NewWhere(tree.AstWhere, &tree.IsNotNullExpr{
+						Expr: &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{string(vb.keyCol)}},
+					}),
+				},
+			}}},
+			As: alias,
+		}
+	} else {
+		newRows := tree.MakeUnqualifiedTableName(ivmNewRowsName)
+		rows = &tree.AliasedTableExpr{Expr: &newRows, As: alias}
+	}
+
+	n := 0
+	var replace func(t tree.TableExpr) tree.TableExpr
+	replace = func(t tree.TableExpr) tree.TableExpr {
+		switch t := t.(type) {
+		case *tree.AliasedTableExpr:
+			n++
+			if n-1 == table {
+				return rows
+			}
+		case *tree.ParenTableExpr:
+			return &tree.ParenTableExpr{Expr: replace(t.Expr)}
+		case *tree.JoinTableExpr:
+			res := *t
+			res.Left = replace(t.Left)
+			res.Right = replace(t.Right)
+			return &res
+		}
+		return t
+	}
+	tables := make(tree.TableExprs, len(v.sel.From.Tables))
+	for i := range tables {
+		tables[i] = replace(v.sel.From.Tables[i])
+	}
+	return tree.From{Tables: tables}
+}
@@ -355,6 +355,9 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //      values specified for them.
 //   4. Each update value is the same as the corresponding insert value.
 //   5. There are no inbound foreign keys containing non-key columns.
+//   6. Row-level security does not apply to the table.
+//   7. There are no incrementally maintained views that depend on the table.
+//      They are maintained using the old values of the rows.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -366,6 +369,16 @@ func (mb *mutationBuilder) needExistingRows() bool {
 		return true
 	}
 
+	// Row-level security policies are applied to the existing rows.
+	if mb.b.rowLevelSecurityApplies(mb.tab) {
+		return true
+	}
+
+	// Incrementally maintained views are updated using the existing rows.
+	if mb.tab.IncrementalViewCount() > 0 {
+		return true
+	}
+
 	// If there are any implicit partitioning columns in the primary index,
 	// these columns will need to be fetched.
 	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
@@ -672,6 +685,10 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert(isUpsert bool) {
 	} else {
 		// Add assignment casts for default column values.
 		mb.addAssignmentCasts(mb.insertColIDs)
+
+		// BEFORE triggers see the default column values, and can modify the row
+		// before computed columns are added.
+		mb.buildBeforeTriggers(tree.TriggerEventInsert)
 	}
 
 	// Now add all computed columns.
@@ -701,8 +718,14 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForInsert()
 
+	mb.buildExclusionChecksForInsert()
+
 	mb.buildFKChecksForInsert()
 
+	mb.buildIncrementalViewMaintenance(false /* withOld */, true /* withNew */)
+
+	mb.buildRowTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
@@ -898,6 +921,11 @@ func (mb *mutationBuilder) setUpsertCols(insertCols tree.NameList) {
 // buildUpsert constructs an Upsert operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
+	if mb.tab.TriggerCount() > 0 {
+		panic(unimplemented.NewWithIssue(28296,
+			"UPSERT and INSERT ... ON CONFLICT are not supported on tables with triggers"))
+	}
+
 	// Merge input insert and update columns using CASE expressions.
 	mb.projectUpsertColumns()
 
@@ -928,8 +956,12 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 
 	mb.buildUniqueChecksForUpsert()
 
+	mb.buildExclusionChecksForUpsert()
+
 	mb.buildFKChecksForUpsert()
 
+	mb.buildIncrementalViewMaintenance(true /* withOld */, true /* withNew */)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
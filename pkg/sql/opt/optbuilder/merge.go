@@ -0,0 +1,596 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// duplicateMergeErrText is error text used when a row is modified twice by a
+// MERGE statement.
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement. MERGE is built as an
+// Upsert operator, in a similar way to INSERT ... ON CONFLICT DO UPDATE. The
+// source is left-joined to the target table using the ON condition (an inner
+// join is used if there are no WHEN NOT MATCHED ... INSERT clauses), and the
+// fetched primary key column of the target table is used as the canary column.
+// For example:
+//
+//   CREATE TABLE abc (a INT PRIMARY KEY, b INT, c INT)
+//   MERGE INTO abc USING xyz ON a = x
+//   WHEN MATCHED AND y > 0 THEN UPDATE SET b = y
+//   WHEN MATCHED THEN DELETE
+//   WHEN NOT MATCHED THEN INSERT VALUES (x, y, z)
+//
+// This would create an input expression similar to this SQL:
+//
+//   SELECT
+//     x AS ins_a, y AS ins_b, z AS ins_c,
+//     a AS fetch_a, b AS fetch_b, c AS fetch_c,
+//     CASE WHEN action = 1 THEN y ELSE b END AS upd_b,
+//     action = 2 AS merge_delete
+//   FROM (
+//     SELECT *, CASE
+//       WHEN a IS NOT NULL AND y > 0 THEN 1
+//       WHEN a IS NOT NULL THEN 2
+//       WHEN a IS NULL THEN 3
+//       ELSE 0
+//     END AS action
+//     FROM xyz LEFT JOIN abc ON a = x
+//   )
+//   WHERE action != 0
+//
+// The action column identifies the first WHEN clause that applies to each
+// row, and rows to which no clause applies are filtered out. Each row of the
+// target table can be matched by at most one row of the source; otherwise an
+// error is raised. If the merge_delete column is true, the Upsert operator
+// deletes the existing row instead of updating it.
+//
+// RETURNING is not supported, and neither are tables with row-level triggers.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on, check the permissions. Existing rows
+	// are always read to find the matching rows.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	var hasUpdate, hasDelete bool
+	for _, when := range merge.Whens {
+		switch when.Action {
+		case tree.MergeActionUpdate:
+			hasUpdate = true
+		case tree.MergeActionDelete:
+			hasDelete = true
+		}
+	}
+	if merge.HasInsert() {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+
+	if tab.TriggerCount() > 0 {
+		panic(unimplemented.NewWithIssue(28296,
+			"MERGE is not supported on tables with triggers"))
+	}
+	if tab.IncrementalViewCount() > 0 {
+		panic(unimplemented.Newf("merge-incremental-view",
+			"MERGE is not supported on tables with incrementally maintained views"))
+	}
+	if b.rowLevelSecurityApplies(tab) {
+		panic(unimplemented.Newf("merge-row-level-security",
+			"MERGE is not supported on tables with row-level security policies"))
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, false /* simpleInsert */)
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// Build the join of the source and the target table, and the action column
+	// that selects the WHEN clause for each row.
+	actionCol := mb.buildInputForMerge(inScope, merge)
+
+	// Build the values of the INSERT, UPDATE and DELETE actions.
+	mb.addMergeActionCols(merge.Whens, actionCol)
+
+	// Add default and computed columns for the inserted rows.
+	if merge.HasInsert() {
+		mb.addSynthesizedColsForMergeInsert()
+	}
+
+	// Add computed columns for the updated rows.
+	if hasUpdate {
+		mb.addSynthesizedColsForUpdate()
+	}
+
+	// Build the final upsert statement.
+	mb.buildUpsert(nil /* returning */)
+
+	return mb.outScope
+}
+
+// buildInputForMerge joins the source of a MERGE statement to the target table
+// using the ON condition, and projects an action column with the (1-based)
+// ordinal of the first WHEN clause that applies to each row. Rows to which no
+// clause applies, or to which a DO NOTHING clause applies, have an action of 0
+// and are filtered out. It returns the ID of the action column.
+func (mb *mutationBuilder) buildInputForMerge(inScope *scope, merge *tree.Merge) opt.ColumnID {
+	var indexFlags *tree.IndexFlags
+	if source, ok := merge.Table.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
+		indexFlags = source.IndexFlags
+		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
+	}
+
+	sourceScope := mb.b.buildDataSource(merge.Source, nil /* indexFlags */, noRowLocking, inScope)
+
+	// Fetch columns from the target table, including mutation columns. See the
+	// comment in buildInputForUpdate.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations:       true,
+			includeSystem:          true,
+			includeInverted:        false,
+			includeVirtualComputed: true,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+	)
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	// Check that the same table name is not used on both sides.
+	mb.b.validateJoinTableNames(sourceScope, mb.fetchScope)
+
+	// The expressions of the statement should reject aggregates, generators,
+	// etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	scalarProps.Require("MERGE", tree.RejectSpecial)
+
+	mb.outScope = inScope.push()
+	mb.outScope.appendColumnsFromScope(sourceScope)
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+	mb.outScope.context = exprKindOn
+	on := mb.b.buildScalar(
+		mb.outScope.resolveAndRequireType(merge.On, types.Bool), mb.outScope, nil, nil, nil,
+	)
+	mb.outScope.context = exprKindNone
+	filters := memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(on)}
+	if merge.HasInsert() {
+		mb.outScope.expr = mb.b.factory.ConstructLeftJoin(
+			sourceScope.expr, mb.fetchScope.expr, filters, memo.EmptyJoinPrivate,
+		)
+	} else {
+		mb.outScope.expr = mb.b.factory.ConstructInnerJoin(
+			sourceScope.expr, mb.fetchScope.expr, filters, memo.EmptyJoinPrivate,
+		)
+	}
+
+	// The first primary key column is the canary column: after the left join,
+	// it is null if the source row does not match any existing row.
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	mb.canaryColID = mb.fetchColIDs[primaryIndex.Column(0).Ordinal()]
+
+	// Project the action column.
+	whens := make(memo.ScalarListExpr, 0, len(merge.Whens))
+	for i, when := range merge.Whens {
+		var cond opt.ScalarExpr = mb.b.factory.ConstructIs(
+			mb.b.factory.ConstructVariable(mb.canaryColID), memo.NullSingleton,
+		)
+		if when.Matched {
+			cond = mb.b.factory.ConstructIsNot(
+				mb.b.factory.ConstructVariable(mb.canaryColID), memo.NullSingleton,
+			)
+		}
+		if when.Cond != nil {
+			texpr := mb.outScope.resolveAndRequireType(when.Cond, types.Bool)
+			cond = mb.b.factory.ConstructAnd(
+				cond, mb.b.buildScalar(texpr, mb.outScope, nil, nil, nil),
+			)
+		}
+		action := i + 1
+		if when.Action == tree.MergeActionDoNothing {
+			action = 0
+		}
+		whens = append(whens, mb.b.factory.ConstructWhen(cond, mergeActionConst(mb.b, action)))
+	}
+	caseExpr := mb.b.factory.ConstructCase(memo.TrueSingleton, whens, mergeActionConst(mb.b, 0))
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	actionCol := mb.b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("merge_action"), types.Int, nil, caseExpr,
+	).id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Filter out the rows that are not affected by the statement.
+	filter := mb.b.factory.ConstructNe(
+		mb.b.factory.ConstructVariable(actionCol), mergeActionConst(mb.b, 0),
+	)
+	mb.outScope.expr = mb.b.factory.ConstructSelect(
+		mb.outScope.expr, memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(filter)},
+	)
+
+	// Ensure that each existing row is affected at most once. Source rows that
+	// don't match any existing row have null primary key columns, and are
+	// always distinct.
+	var pkCols opt.ColSet
+	for i, n := 0, primaryIndex.KeyColumnCount(); i < n; i++ {
+		pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+	}
+	mb.outScope.ordering = nil
+	mb.outScope = mb.b.buildDistinctOn(
+		pkCols, mb.outScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+	)
+
+	return actionCol
+}
+
+// mergeActionConst returns a constant for the given value of the action column
+// of a MERGE statement.
+func mergeActionConst(b *Builder, action int) opt.ScalarExpr {
+	return b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(action)), types.Int)
+}
+
+// addMergeActionCols projects the insert and update columns for the INSERT and
+// UPDATE clauses of a MERGE statement, as well as the delete column for its
+// DELETE clauses. Each column is a CASE expression over the action column, so
+// that every clause can specify different values. Columns that are updated by
+// some UPDATE clauses keep their existing values for the rows affected by the
+// other clauses. Columns that are inserted by some INSERT clauses get their
+// default values for the rows inserted by the other clauses.
+func (mb *mutationBuilder) addMergeActionCols(whens tree.MergeWhens, actionCol opt.ColumnID) {
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	scalarProps.Require("MERGE", tree.RejectSpecial)
+
+	// Build the value of each target column in each clause.
+	n := mb.tab.ColumnCount()
+	numInserts := 0
+	insertVals := make([][]opt.ScalarExpr, len(whens))
+	updateVals := make([][]opt.ScalarExpr, len(whens))
+	var insertOrds, updateOrds util.FastIntSet
+	var deleteActions []int
+	for i, when := range whens {
+		switch when.Action {
+		case tree.MergeActionInsert:
+			numInserts++
+			insertVals[i] = make([]opt.ScalarExpr, n)
+			for j, ord := range mb.mergeInsertTargetOrds(when) {
+				insertVals[i][ord] = mb.buildMergeValue(when.Values[j], ord, false /* isUpdate */)
+				insertOrds.Add(ord)
+			}
+
+		case tree.MergeActionUpdate:
+			updateVals[i] = make([]opt.ScalarExpr, n)
+			var ords util.FastIntSet
+			addVal := func(name tree.Name, expr tree.Expr) {
+				ord := mb.mergeTargetColOrd(name)
+				if ords.Contains(ord) {
+					panic(pgerror.Newf(pgcode.Syntax,
+						"multiple assignments to the same column %q", name))
+				}
+				ords.Add(ord)
+				updateVals[i][ord] = mb.buildMergeValue(expr, ord, true /* isUpdate */)
+				updateOrds.Add(ord)
+			}
+			for _, set := range when.Exprs {
+				if !set.Tuple {
+					addVal(set.Names[0], set.Expr)
+					continue
+				}
+				t, ok := set.Expr.(*tree.Tuple)
+				if !ok {
+					panic(unimplemented.Newf("merge-set-subquery",
+						"source for a multiple-column UPDATE item in MERGE must be a ROW() expression; "+
+							"not supported: %T", set.Expr))
+				}
+				if len(set.Names) != len(t.Exprs) {
+					panic(pgerror.Newf(pgcode.Syntax,
+						"number of columns (%d) does not match number of values (%d)",
+						len(set.Names), len(t.Exprs)))
+				}
+				for j := range set.Names {
+					addVal(set.Names[j], t.Exprs[j])
+				}
+			}
+
+		case tree.MergeActionDelete:
+			deleteActions = append(deleteActions, i+1)
+		}
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	action := mb.b.factory.ConstructVariable(actionCol)
+
+	// Project the insert columns.
+	for ord, ok := insertOrds.Next(0); ok; ord, ok = insertOrds.Next(ord + 1) {
+		tabCol := mb.tab.Column(ord)
+		var val opt.ScalarExpr
+		if numInserts == 1 {
+			for i := range whens {
+				if insertVals[i] != nil {
+					val = insertVals[i][ord]
+				}
+			}
+		} else {
+			caseWhens := make(memo.ScalarListExpr, 0, numInserts)
+			for i := range whens {
+				if insertVals[i] == nil {
+					continue
+				}
+				v := insertVals[i][ord]
+				if v == nil {
+					// This clause doesn't specify the column, so use its default
+					// value.
+					v = mb.buildMergeValue(tree.DefaultVal{}, ord, false /* isUpdate */)
+				}
+				caseWhens = append(caseWhens, mb.b.factory.ConstructWhen(
+					mb.b.factory.ConstructEq(action, mergeActionConst(mb.b, i+1)), v,
+				))
+			}
+			val = mb.b.factory.ConstructCase(
+				memo.TrueSingleton, caseWhens, mb.b.factory.ConstructNull(tabCol.DatumType()),
+			)
+		}
+		colName := scopeColName("").WithMetadataName(
+			fmt.Sprintf("merge_insert_%s", tabCol.ColName()),
+		)
+		scopeCol := mb.b.synthesizeColumn(projectionsScope, colName, tabCol.DatumType(), nil, val)
+		mb.insertColIDs[ord] = scopeCol.id
+	}
+
+	// Project the update columns.
+	for ord, ok := updateOrds.Next(0); ok; ord, ok = updateOrds.Next(ord + 1) {
+		tabCol := mb.tab.Column(ord)
+		var caseWhens memo.ScalarListExpr
+		for i := range whens {
+			if updateVals[i] == nil || updateVals[i][ord] == nil {
+				continue
+			}
+			caseWhens = append(caseWhens, mb.b.factory.ConstructWhen(
+				mb.b.factory.ConstructEq(action, mergeActionConst(mb.b, i+1)), updateVals[i][ord],
+			))
+		}
+		val := mb.b.factory.ConstructCase(
+			memo.TrueSingleton, caseWhens, mb.b.factory.ConstructVariable(mb.fetchColIDs[ord]),
+		)
+		colName := scopeColName(tabCol.ColName()).WithMetadataName(
+			fmt.Sprintf("%s_new", tabCol.ColName()),
+		)
+		scopeCol := mb.b.synthesizeColumn(projectionsScope, colName, tabCol.DatumType(), nil, val)
+		mb.updateColIDs[ord] = scopeCol.id
+	}
+
+	// Project the delete column.
+	if len(deleteActions) > 0 {
+		var val opt.ScalarExpr
+		for _, a := range deleteActions {
+			eq := mb.b.factory.ConstructEq(action, mergeActionConst(mb.b, a))
+			if val == nil {
+				val = eq
+			} else {
+				val = mb.b.factory.ConstructOr(val, eq)
+			}
+		}
+		colName := scopeColName("").WithMetadataName("merge_delete")
+		mb.mergeDeleteColID = mb.b.synthesizeColumn(projectionsScope, colName, types.Bool, nil, val).id
+	}
+
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// mergeInsertTargetOrds returns the ordinals of the table columns that are the
+// target of the given INSERT clause of a MERGE statement, in the same order as
+// its values.
+func (mb *mutationBuilder) mergeInsertTargetOrds(when *tree.MergeWhen) []int {
+	if when.Values == nil {
+		// INSERT DEFAULT VALUES.
+		return nil
+	}
+
+	ords := make([]int, 0, len(when.Values))
+	if when.Columns != nil {
+		var seen util.FastIntSet
+		for _, name := range when.Columns {
+			ord := mb.mergeTargetColOrd(name)
+			if seen.Contains(ord) {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"multiple assignments to the same column %q", name))
+			}
+			seen.Add(ord)
+			ords = append(ords, ord)
+		}
+	} else {
+		// Values are implicitly assigned to the visible columns of the table, in
+		// the same order they appear in the table schema.
+		for i, n := 0, mb.tab.ColumnCount(); i < n && len(ords) < len(when.Values); i++ {
+			col := mb.tab.Column(i)
+			if col.Kind() != cat.Ordinary || col.Visibility() != cat.Visible {
+				continue
+			}
+			ords = append(ords, mb.mergeTargetColOrd(col.ColName()))
+		}
+	}
+	mb.checkNumCols(len(ords), len(when.Values))
+	return ords
+}
+
+// mergeTargetColOrd returns the ordinal of the table column with the given
+// name, which is inserted or updated by a MERGE statement. It raises an error
+// if the column cannot be written.
+func (mb *mutationBuilder) mergeTargetColOrd(name tree.Name) int {
+	ord := findPublicTableColumnByName(mb.tab, name)
+	if ord == -1 {
+		panic(colinfo.NewUndefinedColumnError(string(name)))
+	}
+	tabCol := mb.tab.Column(ord)
+	if tabCol.Kind() == cat.System {
+		panic(pgerror.Newf(pgcode.InvalidColumnReference, "cannot modify system column %q", name))
+	}
+	if tabCol.IsMutation() {
+		panic(makeBackfillError(tabCol.ColName()))
+	}
+	if tabCol.IsComputed() {
+		panic(schemaexpr.CannotWriteToComputedColError(string(tabCol.ColName())))
+	}
+	return ord
+}
+
+// buildMergeValue builds the scalar expression for a value that is inserted
+// into or updated in the given table column by a MERGE statement.
+func (mb *mutationBuilder) buildMergeValue(expr tree.Expr, ord int, isUpdate bool) opt.ScalarExpr {
+	tabCol := mb.tab.Column(ord)
+	if _, ok := expr.(tree.DefaultVal); ok {
+		expr = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+	} else if tabCol.IsGeneratedAlwaysAsIdentity() {
+		colName := string(tabCol.ColName())
+		if isUpdate {
+			panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(colName))
+		}
+		panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(colName))
+	}
+
+	texpr := mb.outScope.resolveType(expr, tabCol.DatumType())
+	checkDatumTypeFitsColumnType(tabCol, texpr.ResolvedType())
+	return mb.b.buildScalar(texpr, mb.outScope, nil, nil, nil)
+}
+
+// addSynthesizedColsForMergeInsert adds the default and computed columns for
+// the rows inserted by a MERGE statement. The insert columns are projected
+// after the join with the target table, so the names of the other columns are
+// hidden while the computed expressions are built.
+//
+// The insert columns are only used for the rows that don't match an existing
+// row. For the other rows, they are replaced by the existing values, so that
+// the execution engine does not report spurious NOT NULL violations.
+func (mb *mutationBuilder) addSynthesizedColsForMergeInsert() {
+	// Make the insert columns accessible by the names of the table columns.
+	names := make([]scopeColumnName, len(mb.outScope.cols))
+	for i := range mb.outScope.cols {
+		col := &mb.outScope.cols[i]
+		names[i] = col.name
+		if ord, ok := mb.insertColOrd(col.id); ok {
+			col.name = scopeColName(mb.tab.Column(ord).ColName())
+		} else {
+			col.clearName()
+		}
+	}
+
+	mb.addSynthesizedColsForInsert(true /* isUpsert */)
+
+	// Restore the names of the columns that existed before, and hide the names
+	// of the synthesized columns.
+	for i := range mb.outScope.cols {
+		if i < len(names) {
+			mb.outScope.cols[i].name = names[i]
+		} else {
+			mb.outScope.cols[i].clearName()
+		}
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		insertColID, fetchColID := mb.insertColIDs[i], mb.fetchColIDs[i]
+		if insertColID == 0 || fetchColID == 0 {
+			continue
+		}
+		caseExpr := mb.b.factory.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{
+				mb.b.factory.ConstructWhen(
+					mb.b.factory.ConstructIs(
+						mb.b.factory.ConstructVariable(mb.canaryColID),
+						memo.NullSingleton,
+					),
+					mb.b.factory.ConstructVariable(insertColID),
+				),
+			},
+			mb.b.factory.ConstructVariable(fetchColID),
+		)
+		colName := scopeColName("").WithMetadataName(
+			fmt.Sprintf("merge_insert_%s", mb.tab.Column(i).ColName()),
+		)
+		typ := mb.md.ColumnMeta(insertColID).Type
+		mb.insertColIDs[i] = mb.b.synthesizeColumn(projectionsScope, colName, typ, nil, caseExpr).id
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	mb.targetColList = make(opt.ColList, 0, mb.tab.ColumnCount())
+	mb.targetColSet = opt.ColSet{}
+}
+
+// insertColOrd returns the ordinal of the table column into which the given
+// column is inserted, if any.
+func (mb *mutationBuilder) insertColOrd(colID opt.ColumnID) (ord int, ok bool) {
+	for i, id := range mb.insertColIDs {
+		if id != 0 && id == colID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// buildMergeNewValsScan is similar to buildCheckInputScan with
+// checkInputScanNewVals, but it excludes the rows that are deleted by a MERGE
+// statement, since they have no new values.
+func (mb *mutationBuilder) buildMergeNewValsScan(tabOrdinals []int) *scope {
+	newRowsScope, _ := mb.buildCheckInputScan(checkInputScanNewVals, tabOrdinals)
+	withScan := newRowsScope.expr.(*memo.WithScanExpr)
+
+	// Scan the delete column as well, and filter out the deleted rows.
+	deleteCol := mb.md.AddColumn("merge_delete", types.Bool)
+	private := withScan.WithScanPrivate
+	private.InCols = append(private.InCols[:len(private.InCols):len(private.InCols)], mb.mergeDeleteColID)
+	private.OutCols = append(private.OutCols[:len(private.OutCols):len(private.OutCols)], deleteCol)
+	private.ID = mb.md.NextUniqueID()
+
+	f := mb.b.factory
+	filter := f.ConstructFiltersItem(f.ConstructNot(f.ConstructVariable(deleteCol)))
+	newRowsScope.expr = f.ConstructProject(
+		f.ConstructSelect(f.ConstructWithScan(&private), memo.FiltersExpr{filter}),
+		nil, /* projections */
+		newRowsScope.colSet(),
+	)
+	return newRowsScope
+}
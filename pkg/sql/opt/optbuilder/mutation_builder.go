@@ -133,6 +133,11 @@ type mutationBuilder struct {
 	// an insert; otherwise it's an update.
 	canaryColID opt.ColumnID
 
+	// mergeDeleteColID is the ID of the boolean column that is true for the
+	// existing rows that are deleted by a MERGE statement. It is 0 if the
+	// statement is not a MERGE with a WHEN MATCHED THEN DELETE clause.
+	mergeDeleteColID opt.ColumnID
+
 	// arbiters is the set of indexes and unique constraints that are used to
 	// detect conflicts for UPSERT and INSERT ON CONFLICT statements.
 	arbiters arbiterSet
@@ -296,6 +301,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		noRowLocking,
 		inScope,
 	)
+	mb.b.addRowLevelSecurityFilter(mb.tab, tree.PolicyCommandUpdate, mb.fetchScope)
 
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -331,6 +337,13 @@ func (mb *mutationBuilder) buildInputForUpdate(
 	// WHERE
 	mb.b.buildWhere(where, mb.outScope)
 
+	// Ensure that there is at most one row in the joined output for every row
+	// in the table. This must happen before LIMIT, so that the limit applies to
+	// the number of updated rows.
+	if fromClausePresent {
+		mb.buildDistinctOnPrimaryKey()
+	}
+
 	// SELECT + ORDER BY (which may add projected expressions)
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
@@ -344,31 +357,21 @@ func (mb *mutationBuilder) buildInputForUpdate(
 	}
 
 	mb.outScope = projectionsScope
+}
 
-	// Build a distinct on to ensure there is at most one row in the joined output
-	// for every row in the table.
-	if fromClausePresent {
-		var pkCols opt.ColSet
-
-		// We need to ensure that the join has a maximum of one row for every row
-		// in the table and we ensure this by constructing a distinct on the primary
-		// key columns.
-		primaryIndex := mb.tab.Index(cat.PrimaryIndex)
-		for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
-			// If the primary key column is hidden, then we don't need to use it
-			// for the distinct on.
-			// TODO(radu): this logic seems fragile, is it assuming that only an
-			// implicit `rowid` column can be a hidden PK column?
-			if col := primaryIndex.Column(i); col.Visibility() != cat.Hidden {
-				pkCols.Add(mb.fetchColIDs[col.Ordinal()])
-			}
-		}
-
-		if !pkCols.Empty() {
-			mb.outScope = mb.b.buildDistinctOn(
-				pkCols, mb.outScope, false /* nullsAreDistinct */, "" /* errorOnDup */)
-		}
-	}
+// buildDistinctOnPrimaryKey wraps the input of an UPDATE ... FROM or DELETE ...
+// USING statement in a distinct on the primary key columns of the table, so
+// that a row which matches several rows of the joined tables is only updated
+// or deleted (and counted and returned) once. Hidden primary key columns, such
+// as rowid, are included, since they are the only way to tell the rows apart.
+func (mb *mutationBuilder) buildDistinctOnPrimaryKey() {
+	var pkCols opt.ColSet
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+	}
+	mb.outScope = mb.b.buildDistinctOn(
+		pkCols, mb.outScope, false /* nullsAreDistinct */, "" /* errorOnDup */)
 }
 
 // buildInputForDelete constructs a Select expression from the fields in
@@ -381,9 +384,17 @@ func (mb *mutationBuilder) buildInputForUpdate(
 //   LIMIT <limit>
 //
 // All columns from the table to update are added to fetchColList.
+// If a USING clause is defined, we build out each of the table expressions
+// required and JOIN them together with the table being deleted from, in the
+// same way as the FROM clause of an UPDATE.
 // TODO(andyk): Do needed column analysis to project fewer columns if possible.
 func (mb *mutationBuilder) buildInputForDelete(
-	inScope *scope, texpr tree.TableExpr, where *tree.Where, limit *tree.Limit, orderBy tree.OrderBy,
+	inScope *scope,
+	texpr tree.TableExpr,
+	using tree.TableExprs,
+	where *tree.Where,
+	limit *tree.Limit,
+	orderBy tree.OrderBy,
 ) {
 	var indexFlags *tree.IndexFlags
 	if source, ok := texpr.(*tree.AliasedTableExpr); ok && source.IndexFlags != nil {
@@ -412,11 +423,49 @@ func (mb *mutationBuilder) buildInputForDelete(
 		noRowLocking,
 		inScope,
 	)
-	mb.outScope = mb.fetchScope
+	mb.b.addRowLevelSecurityFilter(mb.tab, tree.PolicyCommandDelete, mb.fetchScope)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	// If there is a USING clause present, we must join all the tables
+	// together with the table being deleted from.
+	usingClausePresent := len(using) > 0
+	if usingClausePresent {
+		usingScope := mb.b.buildFromTables(using, noRowLocking, inScope)
+
+		// Check that the same table name is not used multiple times.
+		mb.b.validateJoinTableNames(mb.fetchScope, usingScope)
+
+		// The USING table columns can be accessed by the RETURNING clause of the
+		// query and so we have to make them accessible.
+		mb.extraAccessibleCols = usingScope.cols
+
+		// Add the columns in the USING scope.
+		// We create a new scope so that fetchScope is not modified. It will be
+		// used later to build partial index predicate expressions, and we do
+		// not want ambiguities with column names in the USING clause.
+		mb.outScope = mb.fetchScope.replace()
+		mb.outScope.appendColumnsFromScope(mb.fetchScope)
+		mb.outScope.appendColumnsFromScope(usingScope)
+
+		left := mb.fetchScope.expr
+		right := usingScope.expr
+		mb.outScope.expr = mb.b.factory.ConstructInnerJoin(left, right, memo.TrueFilter, memo.EmptyJoinPrivate)
+	} else {
+		mb.outScope = mb.fetchScope
+	}
 
 	// WHERE
 	mb.b.buildWhere(where, mb.outScope)
 
+	// Ensure that there is at most one row in the joined output for every row
+	// in the table. This must happen before LIMIT, so that the limit applies to
+	// the number of deleted rows.
+	if usingClausePresent {
+		mb.buildDistinctOnPrimaryKey()
+	}
+
 	// SELECT + ORDER BY (which may add projected expressions)
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
@@ -430,9 +479,6 @@ func (mb *mutationBuilder) buildInputForDelete(
 	}
 
 	mb.outScope = projectionsScope
-
-	// Set list of columns that will be fetched by the input expression.
-	mb.setFetchColIDs(mb.outScope.cols)
 }
 
 // addTargetColsByName adds one target column for each of the names in the given
@@ -879,9 +925,19 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 		mutationCols := mb.mutationColumnIDs()
 
 		for i, n := 0, mb.tab.CheckCount(); i < n; i++ {
-			expr, err := parser.ParseExpr(mb.tab.Check(i).Constraint)
-			if err != nil {
-				panic(err)
+			var expr tree.Expr
+			rowLevelSecurity := mb.tab.Check(i).RowLevelSecurity
+			if rowLevelSecurity {
+				// The row-level security check is built from the policies that
+				// apply to the current user, if any.
+				if expr = mb.rowLevelSecurityCheckExpr(isUpdate); expr == nil {
+					continue
+				}
+			} else {
+				var err error
+				if expr, err = parser.ParseExpr(mb.tab.Check(i).Constraint); err != nil {
+					panic(err)
+				}
 			}
 
 			texpr := mb.outScope.resolveAndRequireType(expr, types.Bool)
@@ -899,8 +955,10 @@ func (mb *mutationBuilder) addCheckConstraintCols(isUpdate bool) {
 			// If the mutation is not an UPDATE, track the synthesized check
 			// columns in checkColIDS. If the mutation is an UPDATE, only track
 			// the check columns if the columns referenced in the check
-			// expression are being mutated.
-			if !isUpdate || referencedCols.Intersects(mutationCols) {
+			// expression are being mutated. The row-level security check is
+			// always tracked, since its expression may differ from the one the
+			// existing row was filtered by.
+			if !isUpdate || rowLevelSecurity || referencedCols.Intersects(mutationCols) {
 				mb.checkColIDs[i] = scopeCol.id
 			}
 		}
@@ -1049,6 +1107,7 @@ func (mb *mutationBuilder) makeMutationPrivate(needResults bool) *memo.MutationP
 		FetchCols:           checkEmptyList(mb.fetchColIDs),
 		UpdateCols:          checkEmptyList(mb.updateColIDs),
 		CanaryCol:           mb.canaryColID,
+		MergeDeleteCol:      mb.mergeDeleteColID,
 		ArbiterIndexes:      mb.arbiters.IndexOrdinals(),
 		ArbiterConstraints:  mb.arbiters.UniqueConstraintOrdinals(),
 		CheckCols:           checkEmptyList(mb.checkColIDs),
@@ -301,6 +301,10 @@ func (mb *mutationBuilder) buildLeftJoinForUpsertArbiter(
 		noRowLocking,
 		inScope,
 	)
+	// Existing rows that are not visible to the current user cannot be
+	// updated. Since they are filtered out, a conflict with one of them causes
+	// a duplicate key error instead.
+	mb.b.addRowLevelSecurityFilter(mb.tab, tree.PolicyCommandUpdate, mb.fetchScope)
 	// Set fetchColIDs to reference the columns created for the fetch values.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
@@ -0,0 +1,222 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/errors"
+)
+
+// buildExclusionChecksForInsert builds the check queries which enforce the
+// exclusion constraints of the table for an insert.
+func (mb *mutationBuilder) buildExclusionChecksForInsert() {
+	for i, n := 0, mb.tab.ExclusionConstraintCount(); i < n; i++ {
+		mb.buildExclusionCheck(i)
+	}
+}
+
+// buildExclusionChecksForUpdate builds the check queries which enforce the
+// exclusion constraints of the table for an update. Like for an upsert, the
+// check is only needed if the columns of the constraint, or the columns
+// referenced by its predicate, are updated.
+func (mb *mutationBuilder) buildExclusionChecksForUpdate() {
+	for i, n := 0, mb.tab.ExclusionConstraintCount(); i < n; i++ {
+		if mb.exclusionColsUpdated(i) {
+			mb.buildExclusionCheck(i)
+		}
+	}
+}
+
+// buildExclusionChecksForUpsert builds the check queries which enforce the
+// exclusion constraints of the table for an upsert. The rows which are
+// inserted always need to be checked, so the check is built like for an
+// insert.
+func (mb *mutationBuilder) buildExclusionChecksForUpsert() {
+	mb.buildExclusionChecksForInsert()
+}
+
+// exclusionColsUpdated returns true if any of the columns of the given
+// exclusion constraint, or any of the columns referenced by its predicate, are
+// being updated (according to updateColIDs).
+func (mb *mutationBuilder) exclusionColsUpdated(ord int) bool {
+	ec := mb.tab.ExclusionConstraint(ord)
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		if mb.updateColIDs[ec.ColumnOrdinal(mb.tab, i)] != 0 {
+			return true
+		}
+	}
+	if pred, isPartial := mb.parseExclusionConstraintPredicateExpr(ord); isPartial {
+		typedPred := mb.fetchScope.resolveAndRequireType(pred, types.Bool)
+		var predCols opt.ColSet
+		mb.b.buildScalar(typedPred, mb.fetchScope, nil, nil, &predCols)
+		for colID, ok := predCols.Next(0); ok; colID, ok = predCols.Next(colID + 1) {
+			if mb.updateColIDs[mb.md.ColumnMeta(colID).Table.ColumnOrdinal(colID)] != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseExclusionConstraintPredicateExpr parses the predicate of the given
+// exclusion constraint. It returns false if the constraint is not partial.
+func (mb *mutationBuilder) parseExclusionConstraintPredicateExpr(ord int) (tree.Expr, bool) {
+	predStr, isPartial := mb.tab.ExclusionConstraint(ord).Predicate()
+	if !isPartial {
+		return nil, false
+	}
+	expr, err := parser.ParseExpr(predStr)
+	if err != nil {
+		panic(err)
+	}
+	return expr, true
+}
+
+// buildExclusionCheck adds a check query for the given exclusion constraint to
+// the unique checks of the mutation. Exclusion constraints are a
+// generalization of unique constraints: two rows conflict if every column of
+// the constraint satisfies the operator of the column (like = or &&) with the
+// values of the two rows. The check is therefore built like the insertion
+// check of a UNIQUE WITHOUT INDEX constraint, as a self semi-join of the new
+// rows with the rows of the table:
+//
+//   SELECT new.a, new.b FROM [new rows] AS new
+//   WHERE EXISTS (
+//     SELECT * FROM tab
+//     WHERE new.a = tab.a AND new.b && tab.b AND (new.pk1, new.pk2) != (tab.pk1, tab.pk2)
+//   )
+//
+// The conditions on the columns of the constraint can be used to look up the
+// conflicting rows in an index on the columns, including inverted indexes for
+// operators like &&.
+func (mb *mutationBuilder) buildExclusionCheck(ord int) {
+	f := mb.b.factory
+	ec := mb.tab.ExclusionConstraint(ord)
+
+	// If all the operators are equalities and the primary key columns are
+	// columns of the constraint, the primary index already prevents conflicts.
+	var ecOrds util.FastIntSet
+	allEq := true
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		ecOrds.Add(ec.ColumnOrdinal(mb.tab, i))
+		if ec.Operator(i) != tree.EQ {
+			allEq = false
+		}
+	}
+	pkOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
+	if allEq && pkOrds.SubsetOf(ecOrds) {
+		return
+	}
+
+	// Rows with a NULL value for a column of the constraint never conflict with
+	// other rows, so no check is needed if one of the columns is always NULL.
+	for tabOrd, ok := ecOrds.Next(0); ok; tabOrd, ok = ecOrds.Next(tabOrd + 1) {
+		if memo.OutputColumnIsAlwaysNull(mb.outScope.expr, mb.mapToReturnColID(tabOrd)) {
+			return
+		}
+	}
+
+	mb.ensureWithID()
+	tabMeta := mb.b.addTable(mb.tab, tree.NewUnqualifiedTableName(mb.tab.Name()))
+	scanOrdinals := tableOrdinals(tabMeta.Table, columnKinds{
+		includeMutations:       false,
+		includeSystem:          false,
+		includeInverted:        false,
+		includeVirtualComputed: true,
+	})
+	scanScope := mb.b.buildScan(
+		tabMeta,
+		scanOrdinals,
+		&tree.IndexFlags{IgnoreUniqueWithoutIndexKeys: true},
+		noRowLocking,
+		mb.b.allocScope(),
+	)
+	withScanScope, _ := mb.buildCheckInputScan(checkInputScanNewVals, scanOrdinals)
+
+	// Build the join filters:
+	//   (new_a = existing_a) AND (new_b && existing_b) AND ...
+	semiJoinFilters := make(memo.FiltersExpr, 0, ec.ColumnCount()+3)
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		tabOrd := ec.ColumnOrdinal(mb.tab, i)
+		left := f.ConstructVariable(withScanScope.cols[tabOrd].id)
+		right := f.ConstructVariable(scanScope.cols[tabOrd].id)
+		var cond opt.ScalarExpr
+		switch ec.Operator(i) {
+		case tree.EQ:
+			cond = f.ConstructEq(left, right)
+		case tree.Overlaps:
+			cond = f.ConstructOverlaps(left, right)
+		default:
+			panic(errors.AssertionFailedf(
+				"unsupported exclusion constraint operator %s", ec.Operator(i),
+			))
+		}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(cond))
+	}
+
+	// If the constraint is partial, only the new and existing rows which
+	// satisfy the predicate can conflict.
+	if pred, isPartial := mb.parseExclusionConstraintPredicateExpr(ord); isPartial {
+		typedPred := withScanScope.resolveAndRequireType(pred, types.Bool)
+		withScanPred := mb.b.buildScalar(typedPred, withScanScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(withScanPred))
+
+		typedPred = scanScope.resolveAndRequireType(pred, types.Bool)
+		scanPred := mb.b.buildScalar(typedPred, scanScope, nil, nil, nil)
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(scanPred))
+	}
+
+	// Prevent rows from conflicting with themselves. Unlike for uniqueness
+	// checks, all the primary key columns are used, since a row can overlap
+	// with itself:
+	//    (new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...
+	var pkFilter opt.ScalarExpr
+	for i, ok := pkOrds.Next(0); ok; i, ok = pkOrds.Next(i + 1) {
+		pkFilterLocal := f.ConstructNe(
+			f.ConstructVariable(withScanScope.cols[i].id),
+			f.ConstructVariable(scanScope.cols[i].id),
+		)
+		if pkFilter == nil {
+			pkFilter = pkFilterLocal
+		} else {
+			pkFilter = f.ConstructOr(pkFilter, pkFilterLocal)
+		}
+	}
+	semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(pkFilter))
+
+	semiJoin := f.ConstructSemiJoin(
+		withScanScope.expr, scanScope.expr, semiJoinFilters, memo.EmptyJoinPrivate,
+	)
+
+	// The key columns are shown in the error message if there is a conflict.
+	// They are in the order of the columns of the constraint.
+	keyCols := make(opt.ColList, 0, ec.ColumnCount())
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		keyCols = append(keyCols, withScanScope.cols[ec.ColumnOrdinal(mb.tab, i)].id)
+	}
+	project := f.ConstructProject(semiJoin, nil /* projections */, keyCols.ToSet())
+
+	mb.uniqueChecks = append(mb.uniqueChecks, f.ConstructUniqueChecksItem(
+		project, &memo.UniqueChecksItemPrivate{
+			Table:        mb.tabID,
+			CheckOrdinal: ord,
+			Exclusion:    true,
+			KeyCols:      keyCols,
+			OpName:       mb.opName,
+		},
+	))
+}
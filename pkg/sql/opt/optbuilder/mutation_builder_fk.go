@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -396,8 +397,9 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 	for i := 0; i < numInbound; i++ {
 		// Verify that at least one FK column is updated by the Upsert; columns that
 		// are not updated can get new values (through the insert path) but existing
-		// values are never removed.
-		if !mb.inboundFKColsUpdated(i) {
+		// values are never removed, unless the rows are deleted by a MERGE.
+		updated := mb.inboundFKColsUpdated(i)
+		if !updated && mb.mergeDeleteColID == 0 {
 			continue
 		}
 
@@ -405,6 +407,18 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 			continue
 		}
 
+		if mb.mergeDeleteColID != 0 {
+			// The cascades below only handle the rows that are updated.
+			deleteAction := h.fk.DeleteReferenceAction()
+			updateAction := h.fk.UpdateReferenceAction()
+			if (deleteAction != tree.Restrict && deleteAction != tree.NoAction) ||
+				(updated && updateAction != tree.Restrict && updateAction != tree.NoAction) {
+				panic(unimplemented.Newf("merge-fk-cascade",
+					"MERGE with a DELETE action is not supported on tables referenced by "+
+						"foreign keys with cascading actions (foreign key %q)", h.fk.Name()))
+			}
+		}
+
 		if a := h.fk.UpdateReferenceAction(); a != tree.Restrict && a != tree.NoAction {
 			telemetry.Inc(sqltelemetry.ForeignKeyCascadesUseCounter)
 			builder := newOnUpdateCascadeBuilder(mb.tab, i, h.otherTab, a)
@@ -442,7 +456,13 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 		// would filter out have all-null fetched values anyway and will never match
 		// in the semi join.
 		oldRowsScope, _ := mb.buildCheckInputScan(checkInputScanFetchedVals, h.tabOrdinals)
-		newRowsScope, _ := mb.buildCheckInputScan(checkInputScanNewVals, h.tabOrdinals)
+		var newRowsScope *scope
+		if mb.mergeDeleteColID != 0 {
+			// Rows deleted by a MERGE have no "new" values.
+			newRowsScope = mb.buildMergeNewValsScan(h.tabOrdinals)
+		} else {
+			newRowsScope, _ = mb.buildCheckInputScan(checkInputScanNewVals, h.tabOrdinals)
+		}
 		colsForOldRow := oldRowsScope.colList()
 		colsForNewRow := newRowsScope.colList()
 
@@ -0,0 +1,630 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// This file contains methods that plan the row-level triggers of the mutated
+// table.
+//
+// AFTER triggers are planned in the same way as FK cascades: the mutation
+// input is buffered, and each trigger is a "potential" future query that is
+// built by a CascadeBuilder. The query runs once for all the modified rows,
+// which are exposed to the body through the crdb_internal_trigger_rows data
+// source. The old and new values of a row can be referenced as OLD.<column>
+// and NEW.<column>:
+//
+//   CREATE TRIGGER tr AFTER UPDATE ON t FOR EACH ROW
+//     WHEN (OLD.v <> NEW.v)
+//     AS 'INSERT INTO audit VALUES (NEW.k, OLD.v, NEW.v)'
+//
+// The body is rewritten so that it is evaluated for every modified row (see
+// addTriggerRows). INSERT and SELECT bodies produce their rows once for every
+// modified row. UPDATE and DELETE bodies join the modified rows, so a row that
+// is targeted by several modified rows is only updated or deleted once.
+//
+// The body of a BEFORE trigger is a SELECT that returns the new value of the
+// row, with one column for every visible, non-computed column of the table. It
+// is planned as a correlated subquery of the mutation input, and its result
+// replaces the values that are inserted or updated. If the body returns no
+// rows, the row is skipped:
+//
+//   CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW
+//     AS 'SELECT NEW.k, lower(NEW.v) WHERE NEW.v IS NOT NULL'
+//
+// BEFORE DELETE triggers are not supported.
+
+// triggerRowsName is the name of the data source through which the body of a
+// trigger reads the old and new values of the modified rows.
+const triggerRowsName = "crdb_internal_trigger_rows"
+
+// triggerBodyName is the alias of the body of a trigger when it is evaluated as
+// a subquery for every modified row.
+const triggerBodyName = "crdb_internal_trigger_body"
+
+// buildRowTriggers adds a cascade for every AFTER row-level trigger of the
+// mutated table that fires on the given event.
+func (mb *mutationBuilder) buildRowTriggers(event tree.TriggerEvent) {
+	if mb.tab.TriggerCount() == 0 {
+		return
+	}
+
+	oldCols, newCols := mb.mutatedCols(
+		event != tree.TriggerEventInsert, event != tree.TriggerEventDelete,
+	)
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		t := mb.tab.Trigger(i)
+		if t.ActionTime() != tree.TriggerAfter || !t.FiresOn(event) {
+			continue
+		}
+		mb.ensureWithID()
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName: t.Name(),
+			Builder: &rowTriggerBuilder{
+				trigger:  t,
+				event:    event,
+				oldNames: oldCols.names,
+				newNames: newCols.names,
+			},
+			WithID:    mb.withID,
+			OldValues: oldCols.ids,
+			NewValues: newCols.ids,
+			Trigger:   true,
+		})
+	}
+}
+
+// buildBeforeTriggers replaces the inserted or updated values of the mutation
+// input with the rows returned by the BEFORE row-level triggers of the mutated
+// table that fire on the given event. It must be called once the input has
+// values for all the non-computed columns, and before computed columns are
+// synthesized.
+func (mb *mutationBuilder) buildBeforeTriggers(event tree.TriggerEvent) {
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		t := mb.tab.Trigger(i)
+		if t.ActionTime() != tree.TriggerBefore || !t.FiresOn(event) {
+			continue
+		}
+		mb.buildBeforeTrigger(t, event)
+	}
+}
+
+// buildBeforeTrigger wraps the mutation input in an inner apply join with the
+// body of the given BEFORE trigger, and replaces the inserted or updated values
+// with the columns returned by the body.
+func (mb *mutationBuilder) buildBeforeTrigger(t cat.Trigger, event tree.TriggerEvent) {
+	sel, ok := parseTriggerBody(t).(*tree.Select)
+	if !ok {
+		panic(errors.AssertionFailedf("unexpected body of BEFORE trigger %q", t.Name()))
+	}
+	oldCols, newCols := mb.mutatedCols(event != tree.TriggerEventInsert, true /* withNew */)
+	rowScope := mb.b.allocScope()
+	rowScope.appendTriggerRowCols(mb.md, oldCols, newCols)
+	ords := beforeTriggerCols(mb.tab)
+	bodyScope := mb.b.buildBeforeTriggerBody(
+		mb.tab, ords, sel, parseTriggerWhen(t), event, rowScope, oldCols, newCols,
+	)
+
+	colIDs := mb.insertColIDs
+	if event == tree.TriggerEventUpdate {
+		colIDs = mb.updateColIDs
+	}
+	projectionScope := mb.outScope.replace()
+	projectionScope.appendColumnsFromScope(mb.outScope)
+	for i, ord := range ords {
+		col := &bodyScope.cols[i]
+		col.name = scopeColName(mb.tab.Column(ord).ColName())
+		projectionScope.appendColumn(col)
+		colIDs[ord] = col.id
+
+		// Every column of the row is written, since the trigger can modify any of
+		// them.
+		if tabColID := mb.tabID.ColumnID(ord); !mb.targetColSet.Contains(tabColID) {
+			mb.targetColList = append(mb.targetColList, tabColID)
+			mb.targetColSet.Add(tabColID)
+		}
+	}
+
+	// The body returns a single row for every input row, or no rows if the input
+	// row must be skipped.
+	projectionScope.expr = mb.b.factory.ConstructInnerJoinApply(
+		mb.outScope.expr,
+		mb.b.factory.ConstructMax1Row(bodyScope.expr, fmt.Sprintf(
+			"more than one row returned by BEFORE trigger %q", t.Name(),
+		)),
+		memo.TrueFilter,
+		memo.EmptyJoinPrivate,
+	)
+	mb.outScope = projectionScope
+
+	mb.addAssignmentCasts(colIDs)
+	mb.disambiguateColumns()
+}
+
+// beforeTriggerCols returns the ordinals of the columns of the given table that
+// are returned by the body of a BEFORE trigger: the visible, non-computed
+// ordinary columns, in table order.
+func beforeTriggerCols(tab cat.Table) []int {
+	var ords []int
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible && !col.IsComputed() {
+			ords = append(ords, i)
+		}
+	}
+	return ords
+}
+
+// buildBeforeTriggerBody builds the body of a BEFORE trigger as a correlated
+// subquery, in which the old and new values of the row are the outer columns
+// of rowScope. If when is not nil, the subquery returns the new value of the
+// row unchanged if the condition is not true.
+//
+// An error is raised if the body doesn't return one column for every column
+// in ords, or if a column can't be assigned to the corresponding table column.
+func (b *Builder) buildBeforeTriggerBody(
+	tab cat.Table,
+	ords []int,
+	sel *tree.Select,
+	when tree.Expr,
+	event tree.TriggerEvent,
+	rowScope *scope,
+	oldCols, newCols triggerCols,
+) (outScope *scope) {
+	if when != nil {
+		// SELECT * FROM (<body>) AS crdb_internal_trigger_body WHERE <when>
+		// UNION ALL
+		// SELECT NEW.c1, ..., NEW.cn WHERE (<when>) IS DISTINCT FROM true
+		unchanged := make(tree.SelectExprs, len(ords))
+		for i, ord := range ords {
+			unchanged[i].Expr = &tree.ColumnItem{
+				TableName:  &tree.UnresolvedObjectName{NumParts: 1, Parts: [3]string{"new"}},
+				ColumnName: tab.Column(ord).ColName(),
+			}
+		}
+		sel = &tree.Select{Select: &tree.UnionClause{
+			Type: tree.UnionOp,
+			Left: &tree.Select{Select: &tree.SelectClause{
+				Exprs: tree.SelectExprs{tree.StarSelectExpr()},
+				From: tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{
+					Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: sel}},
+					As:   tree.AliasClause{Alias: triggerBodyName},
+				}}},
+				Where: tree.NewWhere(tree.AstWhere, when),
+			}},
+			Right: &tree.Select{Select: &tree.SelectClause{
+				Exprs: unchanged,
+				Where: tree.NewWhere(tree.AstWhere, &tree.ComparisonExpr{
+					Operator: tree.MakeComparisonOperator(tree.IsDistinctFrom),
+					Left:     &tree.ParenExpr{Expr: when},
+					Right:    tree.DBoolTrue,
+				}),
+			}},
+			All: true,
+		}}
+	}
+
+	desiredTypes := make([]*types.T, len(ords))
+	for i, ord := range ords {
+		desiredTypes[i] = tab.Column(ord).DatumType()
+	}
+
+	prevTrigger := b.trigger
+	b.trigger = &triggerContext{event: event, oldCols: oldCols.names, newCols: newCols.names}
+	defer func() {
+		b.trigger = prevTrigger
+	}()
+	outScope = b.buildStmt(sel, desiredTypes, rowScope.push())
+
+	if len(outScope.cols) != len(ords) {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"body of BEFORE trigger must return %d columns, but returns %d",
+			len(ords), len(outScope.cols)))
+	}
+	for i, ord := range ords {
+		col := tab.Column(ord)
+		typ := outScope.cols[i].typ
+		if !typ.Identical(col.DatumType()) &&
+			!tree.ValidCast(typ, col.DatumType(), tree.CastContextAssignment) {
+			panic(sqlerrors.NewInvalidAssignmentCastError(typ, col.DatumType(), string(col.ColName())))
+		}
+	}
+	return outScope
+}
+
+// triggerCols contains the columns of the mutated table that are available to
+// a trigger, along with their names.
+type triggerCols struct {
+	names []tree.Name
+	ids   opt.ColList
+}
+
+// mutatedCols returns the ordinary columns of the mutated table that contain
+// the old values of the modified rows if withOld is true, and the columns that
+// contain their new values if withNew is true.
+func (mb *mutationBuilder) mutatedCols(withOld, withNew bool) (oldCols, newCols triggerCols) {
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		col := mb.tab.Column(i)
+		if col.Kind() != cat.Ordinary {
+			continue
+		}
+		if withOld {
+			if id := mb.fetchColIDs[i]; id != 0 {
+				oldCols.names = append(oldCols.names, col.ColName())
+				oldCols.ids = append(oldCols.ids, id)
+			}
+		}
+		if withNew {
+			if id := mb.mapToReturnColID(i); id != 0 {
+				newCols.names = append(newCols.names, col.ColName())
+				newCols.ids = append(newCols.ids, id)
+			}
+		}
+	}
+	return oldCols, newCols
+}
+
+// rowTriggerBuilder is a memo.CascadeBuilder implementation for AFTER row-level
+// triggers. The cascading query is the body of the trigger, which reads the old
+// and new values of the modified rows from the crdb_internal_trigger_rows data
+// source.
+type rowTriggerBuilder struct {
+	trigger cat.Trigger
+	event   tree.TriggerEvent
+
+	// oldNames and newNames are the names of the columns that correspond 1-to-1
+	// to the oldValues and newValues passed to Build.
+	oldNames []tree.Name
+	newNames []tree.Name
+}
+
+var _ memo.CascadeBuilder = &rowTriggerBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *rowTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		if len(oldValues) != len(tb.oldNames) || len(newValues) != len(tb.newNames) {
+			panic(errors.AssertionFailedf(
+				"expected %d old and %d new values, got %d and %d",
+				len(tb.oldNames), len(tb.newNames), len(oldValues), len(newValues),
+			))
+		}
+
+		// Construct a dummy operator as the binding.
+		b.factory.Metadata().AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		outScope := b.buildTriggerBody(
+			parseTriggerBody(tb.trigger), parseTriggerWhen(tb.trigger), tb.event, binding,
+			triggerCols{names: tb.oldNames, ids: oldValues},
+			triggerCols{names: tb.newNames, ids: newValues},
+		)
+		return outScope.expr
+	})
+}
+
+// parseTriggerBody parses the body of the given trigger.
+func parseTriggerBody(t cat.Trigger) tree.Statement {
+	stmt, err := parser.ParseOne(t.Body())
+	if err != nil {
+		panic(pgerror.Wrapf(err, pgcode.Syntax, "failed to parse body of trigger %q", t.Name()))
+	}
+	return stmt.AST
+}
+
+// parseTriggerWhen parses the WHEN condition of the given trigger. It returns
+// nil if the trigger has no condition.
+func parseTriggerWhen(t cat.Trigger) tree.Expr {
+	w, ok := t.When()
+	if !ok {
+		return nil
+	}
+	when, err := parser.ParseExpr(w)
+	if err != nil {
+		panic(pgerror.Wrapf(err, pgcode.Syntax, "failed to parse condition of trigger %q", t.Name()))
+	}
+	return when
+}
+
+// triggerContext contains the state of the row-level trigger whose body is
+// currently being built.
+type triggerContext struct {
+	event tree.TriggerEvent
+
+	// oldCols and newCols are the names of the columns of the old and new values
+	// of the rows. They are nil if the values are not available to the trigger.
+	oldCols []tree.Name
+	newCols []tree.Name
+}
+
+// buildTriggerBody builds the body of an AFTER row-level trigger. The old and
+// new values of the rows are read from the given binding, and the body is
+// evaluated for each of them (see addTriggerRows). If when is not nil, the
+// body only has an effect for the rows for which the condition is true.
+func (b *Builder) buildTriggerBody(
+	stmt tree.Statement,
+	when tree.Expr,
+	event tree.TriggerEvent,
+	binding opt.WithID,
+	oldCols, newCols triggerCols,
+) (outScope *scope) {
+	stmt = addTriggerRows(stmt, when)
+
+	// The body of the trigger is a root statement, in which the old and new
+	// values of the rows are visible like a CTE.
+	inScope := b.allocScope()
+	inScope.atRoot = true
+	inScope.ctes = map[string]*cteSource{
+		triggerRowsName: {
+			id:   binding,
+			name: tree.AliasClause{Alias: triggerRowsName},
+			cols: makeTriggerRowsPresentation(oldCols, newCols),
+		},
+	}
+
+	prevTrigger := b.trigger
+	prevCTEs := b.ctes
+	b.trigger = &triggerContext{event: event, oldCols: oldCols.names, newCols: newCols.names}
+	b.ctes = nil
+	defer func() {
+		b.trigger = prevTrigger
+		b.ctes = prevCTEs
+	}()
+	outScope = b.buildStmt(stmt, nil /* desiredTypes */, inScope)
+	outScope.expr = b.buildWiths(outScope.expr, b.ctes)
+	return outScope
+}
+
+// rowReference returns a reference to the column of the
+// crdb_internal_trigger_rows data source that contains the given column of the
+// old or new row, if c is a reference of the form OLD.<column> or
+// NEW.<column>. Otherwise, it returns nil. An error is raised if the row is not
+// available for the event of the trigger, e.g. NEW in a DELETE trigger.
+func (t *triggerContext) rowReference(s *scope, c *tree.ColumnItem) *tree.ColumnItem {
+	if c.TableName == nil || c.TableName.NumParts != 1 {
+		return nil
+	}
+	row := c.TableName.Parts[0]
+	var cols []tree.Name
+	switch row {
+	case "old":
+		cols = t.oldCols
+	case "new":
+		cols = t.newCols
+	default:
+		return nil
+	}
+	if cols == nil {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"%s is not available in %s triggers", strings.ToUpper(row), t.event))
+	}
+	found := false
+	for _, name := range cols {
+		if name == c.ColumnName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		panic(colinfo.NewUndefinedColumnError(string(c.ColumnName)))
+	}
+
+	res := &tree.ColumnItem{
+		TableName:  &tree.UnresolvedObjectName{NumParts: 1, Parts: [3]string{triggerRowsName}},
+		ColumnName: triggerColName(row, c.ColumnName),
+	}
+	// The modified rows are not in scope in some clauses of the body, such as
+	// the ON CONFLICT clause of an INSERT.
+	if _, err := colinfo.ResolveColumnItem(s.builder.ctx, s, res); err != nil {
+		if sqlerrors.IsUndefinedColumnError(err) {
+			panic(unimplemented.NewWithIssuef(28296,
+				"%s cannot be referenced in this clause of a trigger body", tree.ErrString(c)))
+		}
+		panic(err)
+	}
+	return res
+}
+
+// triggerColName returns the name of the column of the
+// crdb_internal_trigger_rows data source that contains the given column of the
+// old or new row.
+func triggerColName(row string, col tree.Name) tree.Name {
+	return tree.Name(row + "." + string(col))
+}
+
+// addTriggerRows returns a copy of the body of an AFTER trigger that is
+// evaluated for every row of the crdb_internal_trigger_rows data source for
+// which the given condition is true (or for every row, if when is nil):
+//
+//   INSERT INTO t VALUES (NEW.k)
+//     ==> INSERT INTO t SELECT NEW.k FROM crdb_internal_trigger_rows WHERE <when>
+//
+//   INSERT INTO t SELECT NEW.k, v FROM u
+//     ==> INSERT INTO t SELECT crdb_internal_trigger_body.*
+//         FROM crdb_internal_trigger_rows,
+//              LATERAL (SELECT NEW.k, v FROM u) AS crdb_internal_trigger_body
+//         WHERE <when>
+//
+//   UPDATE t SET v = NEW.v WHERE k = NEW.k
+//     ==> UPDATE t SET v = NEW.v FROM crdb_internal_trigger_rows
+//         WHERE (k = NEW.k) AND (<when>)
+//
+// DELETE statements are rewritten like UPDATE statements, with a USING clause.
+func addTriggerRows(stmt tree.Statement, when tree.Expr) tree.Statement {
+	switch t := stmt.(type) {
+	case *tree.Insert:
+		if t.DefaultValues() {
+			panic(unimplemented.NewWithIssue(28296,
+				"INSERT ... DEFAULT VALUES is not supported in trigger bodies"))
+		}
+		res := *t
+		res.Rows = selectForTriggerRows(t.Rows, when)
+		return &res
+
+	case *tree.Update:
+		res := *t
+		res.From = append(tree.TableExprs{triggerRowsSource()}, t.From...)
+		res.Where = andTriggerCondition(t.Where, when)
+		return &res
+
+	case *tree.Delete:
+		res := *t
+		res.Using = append(tree.TableExprs{triggerRowsSource()}, t.Using...)
+		res.Where = andTriggerCondition(t.Where, when)
+		return &res
+
+	case *tree.Select:
+		return selectForTriggerRows(t, when)
+
+	default:
+		panic(errors.AssertionFailedf("unexpected trigger body %T", stmt))
+	}
+}
+
+// selectForTriggerRows returns a query that evaluates the given query for every
+// row of the crdb_internal_trigger_rows data source for which the given
+// condition is true. The rows of a VALUES clause are turned into projections,
+// so that their expressions are typed using the target columns of an INSERT.
+func selectForTriggerRows(sel *tree.Select, when tree.Expr) *tree.Select {
+	if v, ok := sel.Select.(*tree.ValuesClause); ok &&
+		sel.With == nil && sel.OrderBy == nil && sel.Limit == nil {
+		var res *tree.Select
+		for _, row := range v.Rows {
+			exprs := make(tree.SelectExprs, len(row))
+			for i := range row {
+				exprs[i].Expr = row[i]
+			}
+			clause := &tree.Select{Select: &tree.SelectClause{
+				Exprs: exprs,
+				From:  tree.From{Tables: tree.TableExprs{triggerRowsSource()}},
+				Where: tree.NewWhere(tree.AstWhere, when),
+			}}
+			if res == nil {
+				res = clause
+			} else {
+				res = &tree.Select{Select: &tree.UnionClause{
+					Type: tree.UnionOp, Left: res, Right: clause, All: true,
+				}}
+			}
+		}
+		return res
+	}
+
+	return &tree.Select{Select: &tree.SelectClause{
+		Exprs: tree.SelectExprs{{Expr: &tree.AllColumnsSelector{
+			TableName: &tree.UnresolvedObjectName{NumParts: 1, Parts: [3]string{triggerBodyName}},
+		}}},
+		From: tree.From{Tables: tree.TableExprs{
+			triggerRowsSource(),
+			&tree.AliasedTableExpr{
+				Expr:    &tree.Subquery{Select: &tree.ParenSelect{Select: sel}},
+				Lateral: true,
+				As:      tree.AliasClause{Alias: triggerBodyName},
+			},
+		}},
+		Where: tree.NewWhere(tree.AstWhere, when),
+	}}
+}
+
+// triggerRowsSource returns a reference to the crdb_internal_trigger_rows data
+// source.
+func triggerRowsSource() tree.TableExpr {
+	tn := tree.MakeUnqualifiedTableName(triggerRowsName)
+	return &tree.AliasedTableExpr{Expr: &tn}
+}
+
+// andTriggerCondition returns a WHERE clause that is the conjunction of the
+// given clause and condition.
+func andTriggerCondition(where *tree.Where, when tree.Expr) *tree.Where {
+	if when == nil {
+		return where
+	}
+	if where == nil {
+		return tree.NewWhere(tree.AstWhere, when)
+	}
+	return tree.NewWhere(tree.AstWhere, &tree.AndExpr{
+		Left:  &tree.ParenExpr{Expr: where.Expr},
+		Right: &tree.ParenExpr{Expr: when},
+	})
+}
+
+// makeTriggerRowsPresentation returns the presentation of the
+// crdb_internal_trigger_rows data source of a trigger.
+func makeTriggerRowsPresentation(oldCols, newCols triggerCols) physical.Presentation {
+	res := make(physical.Presentation, 0, len(oldCols.ids)+len(newCols.ids))
+	for i := range oldCols.ids {
+		res = append(res, opt.AliasedColumn{
+			Alias: string(triggerColName("old", oldCols.names[i])), ID: oldCols.ids[i],
+		})
+	}
+	for i := range newCols.ids {
+		res = append(res, opt.AliasedColumn{
+			Alias: string(triggerColName("new", newCols.names[i])), ID: newCols.ids[i],
+		})
+	}
+	return res
+}
+
+// appendTriggerRowCols adds the columns that contain the old and new values of
+// a row to the scope, with the names they have in the
+// crdb_internal_trigger_rows data source.
+func (s *scope) appendTriggerRowCols(md *opt.Metadata, oldCols, newCols triggerCols) {
+	tn := tree.MakeUnqualifiedTableName(triggerRowsName)
+	for _, c := range makeTriggerRowsPresentation(oldCols, newCols) {
+		s.cols = append(s.cols, scopeColumn{
+			name:  scopeColName(tree.Name(c.Alias)),
+			table: tn,
+			typ:   md.ColumnMeta(c.ID).Type,
+			id:    c.ID,
+		})
+	}
+}
+
+// makeTriggerPresentation returns a presentation that aliases the given columns
+// with the given names.
+func makeTriggerPresentation(names []tree.Name, cols opt.ColList) physical.Presentation {
+	if len(cols) == 0 {
+		return nil
+	}
+	res := make(physical.Presentation, len(cols))
+	for i := range cols {
+		res[i] = opt.AliasedColumn{Alias: string(names[i]), ID: cols[i]}
+	}
+	return res
+}
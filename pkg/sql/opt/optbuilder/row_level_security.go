@@ -0,0 +1,190 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// rowLevelSecurityApplies returns true if the rows of the given table are
+// restricted by its row-level security policies for the current user. This is
+// the case if row-level security is enabled for the table, and the user is
+// neither an admin nor, unless row-level security is forced, the owner of the
+// table (or a member of the owner role).
+func (b *Builder) rowLevelSecurityApplies(tab cat.Table) bool {
+	enabled, forced := tab.RowLevelSecurity()
+	if !enabled || b.insideViewDef || b.skipRowLevelSecurity {
+		return false
+	}
+	if b.userRoles == nil {
+		roles, err := b.catalog.UserRoles(b.ctx)
+		if err != nil {
+			panic(err)
+		}
+		isAdmin, err := b.catalog.HasAdminRole(b.ctx)
+		if err != nil {
+			panic(err)
+		}
+		b.userRoles, b.isAdmin = roles, isAdmin
+
+		// The policies that apply to the statement depend on the roles of the
+		// user, so the memo cannot be reused by a user with different roles.
+		b.factory.Metadata().SetRowLevelSecurityRoles(roles)
+	}
+	if b.isAdmin {
+		return false
+	}
+	return forced || !b.hasUserRole(tab.Owner())
+}
+
+// hasUserRole returns true if the current user is the given role, or is a
+// member of it. It can only be called after rowLevelSecurityApplies has
+// initialized the roles of the user.
+func (b *Builder) hasUserRole(role security.SQLUsername) bool {
+	for _, r := range b.userRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRowLevelSecurityExpr combines the expressions of the policies of the
+// table which apply to the given command and to the current user. A row passes
+// the resulting expression if it passes at least one of the permissive
+// policies and all of the restrictive policies. If no permissive policy
+// applies, no row passes.
+//
+// If withCheck is false, the USING expressions of the policies are combined.
+// Otherwise, the WITH CHECK expressions are combined, and the USING
+// expression is used for the policies which do not have a WITH CHECK
+// expression.
+func (b *Builder) buildRowLevelSecurityExpr(
+	tab cat.Table, command tree.PolicyCommand, withCheck bool,
+) tree.Expr {
+	var permissive, restrictive tree.Expr
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		policy := tab.Policy(i)
+		if !policy.AppliesTo(command) || !b.policyAppliesToUser(policy) {
+			continue
+		}
+		str, ok := policy.Using()
+		if withCheck {
+			if check, hasCheck := policy.WithCheck(); hasCheck {
+				str, ok = check, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		expr, err := parser.ParseExpr(str)
+		if err != nil {
+			panic(err)
+		}
+		expr = &tree.ParenExpr{Expr: expr}
+		switch {
+		case policy.Restrictive() && restrictive != nil:
+			restrictive = &tree.AndExpr{Left: restrictive, Right: expr}
+		case policy.Restrictive():
+			restrictive = expr
+		case permissive != nil:
+			permissive = &tree.OrExpr{Left: permissive, Right: expr}
+		default:
+			permissive = expr
+		}
+	}
+	if permissive == nil {
+		return tree.DBoolFalse
+	}
+	if restrictive != nil {
+		return &tree.AndExpr{Left: permissive, Right: restrictive}
+	}
+	return permissive
+}
+
+// policyAppliesToUser returns true if the policy applies to one of the roles
+// of the current user.
+func (b *Builder) policyAppliesToUser(policy cat.Policy) bool {
+	for i, n := 0, policy.RoleCount(); i < n; i++ {
+		if b.hasUserRole(policy.Role(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// addRowLevelSecurityFilter wraps the expression of the given scope, which
+// scans the given table, with a Select that only keeps the rows visible to the
+// current user for the given command, according to the USING expressions of
+// the row-level security policies of the table. It does nothing if row-level
+// security does not apply to the table.
+//
+// The Select is wrapped in a Barrier, which makes the filter a security
+// barrier: the other predicates of the query are only evaluated on the rows
+// which pass it, unless they are leakproof. Otherwise, a predicate which
+// raises an error or calls a function that reveals its arguments could reveal
+// the rows hidden by the policies.
+//
+// This is used for the rows read by SELECT statements, and for the rows
+// fetched by UPDATE, DELETE and UPSERT statements. It is not used for the
+// scans that enforce constraints, such as foreign key and uniqueness checks.
+func (b *Builder) addRowLevelSecurityFilter(
+	tab cat.Table, command tree.PolicyCommand, tableScope *scope,
+) {
+	if !b.rowLevelSecurityApplies(tab) {
+		return
+	}
+	expr := b.buildRowLevelSecurityExpr(tab, command, false /* withCheck */)
+	texpr := tableScope.resolveAndRequireType(expr, types.Bool)
+	filter := b.buildScalar(texpr, tableScope, nil, nil, nil)
+	tableScope.expr = b.factory.ConstructBarrier(b.factory.ConstructSelect(
+		tableScope.expr,
+		memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	))
+}
+
+// rowLevelSecurityCheckExpr returns the expression of the row-level security
+// check of the table, which is evaluated on the rows written by the mutation.
+// The rows must pass the WITH CHECK expressions of the INSERT policies if they
+// are inserted, or of the UPDATE policies if they are updated. It returns nil
+// if row-level security does not apply to the table.
+func (mb *mutationBuilder) rowLevelSecurityCheckExpr(isUpdate bool) tree.Expr {
+	if !mb.b.rowLevelSecurityApplies(mb.tab) {
+		return nil
+	}
+	if isUpdate {
+		return mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandUpdate, true /* withCheck */)
+	}
+	insertCheck := mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandInsert, true /* withCheck */)
+	if mb.canaryColID == 0 {
+		return insertCheck
+	}
+
+	// For an UPSERT, the canary column is null if the row is inserted, and not
+	// null if it is updated.
+	updateCheck := mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandUpdate, true /* withCheck */)
+	return &tree.CaseExpr{
+		Whens: []*tree.When{{
+			Cond: &tree.ComparisonExpr{
+				Operator: tree.MakeComparisonOperator(tree.IsNotDistinctFrom),
+				Left:     mb.outScope.getColumn(mb.canaryColID),
+				Right:    tree.DNull,
+			},
+			Val: insertCheck,
+		}},
+		Else: updateCheck,
+	}
+}
@@ -15,12 +15,14 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -69,6 +71,11 @@ func (b *Builder) buildScalar(
 			// necessary.
 			return b.finishBuildScalarRef(col, inScope.groupby.aggOutScope, outScope, outCol, colRefs)
 		}
+		// Grouping expressions that are not part of the current grouping set
+		// are NULL.
+		if typ, ok := inScope.groupby.nullGroupStrs[symbolicExprStr(scalar)]; ok {
+			return b.finishBuildScalar(scalar, b.factory.ConstructNull(typ), inScope, outScope, outCol)
+		}
 	}
 
 	switch t := scalar.(type) {
@@ -116,6 +123,9 @@ func (b *Builder) buildScalar(
 	case *windowInfo:
 		return b.finishBuildScalarRef(t.col, inScope, outScope, outCol, colRefs)
 
+	case *groupingInfo:
+		return b.buildGroupingFn(t, inScope, outScope, outCol)
+
 	case *tree.AndExpr:
 		left := b.buildScalar(tree.ReType(t.TypedLeft(), types.Bool), inScope, nil, nil, colRefs)
 		right := b.buildScalar(tree.ReType(t.TypedRight(), types.Bool), inScope, nil, nil, colRefs)
@@ -253,8 +263,12 @@ func (b *Builder) buildScalar(
 
 	case *tree.CastExpr:
 		texpr := t.Expr.(tree.TypedExpr)
-		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
-		out = b.factory.ConstructCast(arg, t.ResolvedType())
+		if typ := t.ResolvedType(); typ.IsDomain() {
+			out = b.buildDomainCast(texpr, typ, inScope, colRefs)
+		} else {
+			arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
+			out = b.factory.ConstructCast(arg, typ)
+		}
 
 	case *tree.CoalesceExpr:
 		args := make(memo.ScalarListExpr, len(t.Exprs))
@@ -502,6 +516,10 @@ func (b *Builder) buildFunction(
 		panic(errors.AssertionFailedf("window function should have been replaced"))
 	}
 
+	if o := f.ResolvedOverload(); o != nil && o.IsUDF {
+		return b.buildUDF(f, inScope, outScope, outCol, colRefs)
+	}
+
 	args := make(memo.ScalarListExpr, len(f.Exprs))
 	for i, pexpr := range f.Exprs {
 		args[i] = b.buildScalar(pexpr.(tree.TypedExpr), inScope, nil, nil, colRefs)
@@ -586,6 +604,99 @@ func (b *Builder) buildRangeCond(
 	return out
 }
 
+// buildDomainCast builds a cast of the given expression to a domain. The
+// expression is cast to the base type of the domain, and the constraints of the
+// domain are asserted on the result:
+//
+//   crdb_internal.assert_domain_check(
+//     crdb_internal.assert_domain_check(
+//       v, (check1 with VALUE = v) IS NOT FALSE, 'domain', 'check1'
+//     ),
+//     v IS NOT NULL, 'domain', NULL
+//   )::domain
+//
+// where v is the input cast to the base type. So that the input is evaluated
+// only once, v is a column: either the input itself if it is a column
+// reference, or a column projected on top of inScope.expr. A constant input is
+// inlined instead. A non-constant input that references no columns of
+// inScope.expr is inlined as well, since there is no input to project it on,
+// unless it is volatile, in which case the checks could observe a different
+// value than the one returned and the cast is rejected.
+func (b *Builder) buildDomainCast(
+	texpr tree.TypedExpr, typ *types.T, inScope *scope, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	baseType := typ.DomainBaseType()
+	baseCast := &tree.CastExpr{Expr: texpr, Type: baseType, SyntaxMode: tree.CastShort}
+
+	var refs opt.ColSet
+	base := b.factory.ConstructCast(b.buildScalar(texpr, inScope, nil, nil, &refs), baseType)
+	if colRefs != nil {
+		colRefs.UnionWith(refs)
+	}
+
+	// Determine the column that holds the value, if any.
+	var valueCol opt.ColumnID
+	switch {
+	case opt.IsConstValueOp(base):
+	case base.Op() == opt.VariableOp:
+		valueCol = base.(*memo.VariableExpr).Col
+	case !inScope.inGroupingContext() && inScope.expr != nil && !refs.Empty() &&
+		refs.SubsetOf(inScope.expr.Relational().OutputCols):
+		valueCol = b.factory.Metadata().AddColumn("domain_value", baseType)
+		inScope.expr = b.factory.ConstructProject(
+			inScope.expr,
+			memo.ProjectionsExpr{b.factory.ConstructProjectionsItem(base, valueCol)},
+			inScope.expr.Relational().OutputCols,
+		)
+	default:
+		var p props.Shared
+		memo.BuildSharedProps(base, &p, b.evalCtx)
+		if p.VolatilitySet.HasVolatile() {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot cast a volatile expression to domain %s in this context", typ.SQLString()))
+		}
+	}
+
+	// Build the checks against a reference to the value column, or against the
+	// inlined cast if there is no such column.
+	var value tree.TypedExpr = baseCast
+	checkScope := inScope
+	if valueCol != 0 {
+		checkScope = inScope.push()
+		checkScope.cols = append(checkScope.cols, scopeColumn{
+			name: scopeColName("domain_value"),
+			typ:  baseType,
+			id:   valueCol,
+		})
+		value = &checkScope.cols[len(checkScope.cols)-1]
+	}
+	checks, err := schemaexpr.MakeDomainChecks(typ, value)
+	if err != nil {
+		panic(err)
+	}
+	domainName := tree.NewDString(typ.TypeMeta.Name.Basename())
+	var expr tree.Expr = value
+	for i := range checks {
+		ok := checks[i].Expr
+		var constraintName tree.Expr = tree.DNull
+		if !checks[i].NotNull {
+			// The value satisfies a CHECK constraint if the result is NULL.
+			ok = &tree.ComparisonExpr{
+				Operator: tree.MakeComparisonOperator(tree.IsDistinctFrom),
+				Left:     ok,
+				Right:    tree.DBoolFalse,
+			}
+			constraintName = tree.NewDString(checks[i].Name)
+		}
+		expr = &tree.FuncExpr{
+			Func:  tree.WrapFunction("crdb_internal.assert_domain_check"),
+			Exprs: tree.Exprs{expr, ok, domainName, constraintName},
+		}
+	}
+	out := b.buildScalar(checkScope.resolveType(expr, types.Any), checkScope, nil, nil, nil)
+	return b.factory.ConstructCast(out, typ)
+}
+
 // checkSubqueryOuterCols uses the subquery outer columns to update the given
 // set of column references and the set of outer columns for any enclosing
 // subuqery. It also performs the following checks:
@@ -720,6 +831,8 @@ func (b *Builder) constructComparison(
 			return b.factory.ConstructBBoxIntersects(left, right)
 		}
 		return b.factory.ConstructOverlaps(left, right)
+	case tree.TSMatches:
+		return b.factory.ConstructTSMatches(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", log.Safe(cmp.Operator)))
 }
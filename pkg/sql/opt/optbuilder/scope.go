@@ -975,6 +975,13 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		return s.VisitPre(vn)
 
 	case *tree.ColumnItem:
+		// Inside the body of a trigger, OLD.<column> and NEW.<column> refer to
+		// the old and new values of the row that fired the trigger.
+		if trigger := s.builder.trigger; trigger != nil {
+			if col := trigger.rowReference(s, t); col != nil {
+				return s.VisitPre(col)
+			}
+		}
 		colI, resolveErr := colinfo.ResolveColumnItem(s.builder.ctx, s, t)
 		if resolveErr != nil {
 			if sqlerrors.IsUndefinedColumnError(resolveErr) {
@@ -989,8 +996,25 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		}
 		return false, colI.(*scopeColumn)
 
+	case *tree.Placeholder:
+		// Inside the body of a user-defined function, placeholders refer to the
+		// arguments of the function by position.
+		if udf := s.builder.udf; udf != nil {
+			if int(t.Idx) >= len(udf.params) {
+				panic(pgerror.Newf(pgcode.UndefinedParameter, "there is no parameter $%d", t.Idx+1))
+			}
+			return false, &udf.params[t.Idx]
+		}
+
 	case *tree.FuncExpr:
-		def, err := t.Func.Resolve(s.builder.semaCtx.SearchPath)
+		if isGroupingFn(t) {
+			expr = s.replaceGroupingFn(t)
+			break
+		}
+
+		def, err := t.Func.ResolveWithResolver(
+			s.builder.ctx, s.builder.semaCtx.SearchPath, s.builder.semaCtx.FunctionResolver,
+		)
 		if err != nil {
 			panic(err)
 		}
@@ -112,7 +112,7 @@ func (b *Builder) buildDataSource(
 		switch t := ds.(type) {
 		case cat.Table:
 			tabMeta := b.addTable(t, &resName)
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations:       false,
@@ -122,6 +122,8 @@ func (b *Builder) buildDataSource(
 				}),
 				indexFlags, locking, inScope,
 			)
+			b.addRowLevelSecurityFilter(t, tree.PolicyCommandSelect, outScope)
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -396,6 +398,11 @@ func (b *Builder) buildScanFromTableRef(
 			panic(pgerror.Newf(pgcode.Syntax,
 				"an explicit list of column IDs must include at least one column"))
 		}
+		// The row-level security policies may refer to any column of the table.
+		if b.rowLevelSecurityApplies(tab) {
+			panic(unimplemented.Newf("table-ref-row-level-security",
+				"an explicit list of column IDs is not supported for tables with row-level security policies"))
+		}
 		ordinals = resolveNumericColumnRefs(tab, ref.Columns)
 	} else {
 		ordinals = tableOrdinals(tab, columnKinds{
@@ -408,7 +415,9 @@ func (b *Builder) buildScanFromTableRef(
 
 	tn := tree.MakeUnqualifiedTableName(tab.Name())
 	tabMeta := b.addTable(tab, &tn)
-	return b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope)
+	outScope = b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope)
+	b.addRowLevelSecurityFilter(tab, tree.PolicyCommandSelect, outScope)
+	return outScope
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -505,6 +514,15 @@ func (b *Builder) buildScan(
 		outScope.expr = b.factory.ConstructScan(&private)
 
 		// Note: virtual tables should not be collected as view dependencies.
+		// Foreign tables can be dropped, so they are collected.
+		if b.trackViewDeps && tab.IsForeignTable() {
+			dep := opt.ViewDep{DataSource: tab}
+			dep.ColumnIDToOrd = make(map[opt.ColumnID]int)
+			for i, col := range outScope.cols {
+				dep.ColumnIDToOrd[col.id] = ordinals[i]
+			}
+			b.viewDeps = append(b.viewDeps, dep)
+		}
 		return outScope
 	}
 
@@ -984,6 +1002,28 @@ func (b *Builder) buildSelectClause(
 	b.processWindowDefs(sel, fromScope)
 	b.buildWhere(sel.Where, fromScope)
 
+	if hasGroupingSets(sel.GroupBy) {
+		// The ORDER BY clause is built on top of the grouping sets by the caller,
+		// since the output scope has no ordering.
+		return b.buildGroupingSets(sel, fromScope, locking, desiredTypes, inScope)
+	}
+	return b.buildSelectClauseWithGroupingSet(
+		sel, fromScope, nil /* gs */, orderBy, locking, desiredTypes,
+	)
+}
+
+// buildSelectClauseWithGroupingSet builds the rest of a select clause whose
+// FROM and WHERE clauses have been built in fromScope. If gs is not nil, the
+// aggregation groups by the expressions of the given grouping set rather than
+// the GROUP BY clause of sel.
+func (b *Builder) buildSelectClauseWithGroupingSet(
+	sel *tree.SelectClause,
+	fromScope *scope,
+	gs *groupingSet,
+	orderBy tree.OrderBy,
+	locking lockingSpec,
+	desiredTypes []*types.T,
+) (outScope *scope) {
 	projectionsScope := fromScope.replace()
 
 	// This is where the magic happens. When this call reaches an aggregate
@@ -1004,7 +1044,7 @@ func (b *Builder) buildSelectClause(
 		// Grouping columns must be built before building the projection list so
 		// we can check that any column references that appear in the SELECT list
 		// outside of aggregate functions are present in the grouping list.
-		b.buildGroupingColumns(sel, projectionsScope, fromScope)
+		b.buildGroupingColumns(sel, gs, projectionsScope, fromScope)
 		having = b.buildHaving(havingExpr, fromScope)
 	}
 
@@ -1022,6 +1062,9 @@ func (b *Builder) buildSelectClause(
 		outScope = fromScope
 	}
 
+	if gs != nil && len(fromScope.windows) > 0 {
+		panic(unimplemented.NewWithIssue(46280, "window functions with grouping sets are not supported"))
+	}
 	b.buildWindow(outScope, fromScope)
 	b.validateLockingInFrom(sel, locking, fromScope)
 
@@ -0,0 +1,200 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// udfContext contains the state of the user-defined function whose body is
+// currently being built.
+type udfContext struct {
+	// oid is the OID of the function. It is zero when the body is built as part
+	// of a CREATE FUNCTION statement.
+	oid oid.Oid
+
+	// params contains the columns that hold the values of the arguments of the
+	// function. They can be referenced by name, or by position using a
+	// placeholder ($1, $2, ...).
+	params []scopeColumn
+
+	// parent is the context of the enclosing function, if the function is
+	// called from the body of another function.
+	parent *udfContext
+}
+
+// buildUDF inlines a call to a user-defined function. The body of the function
+// is built as a correlated subquery that is joined with a single row containing
+// the values of the arguments:
+//
+//   SELECT f(a, b) FROM t
+//     ==> SELECT (SELECT <body> FROM (SELECT a AS x, b AS y) LIMIT 1) FROM t
+//
+// where x and y are the names of the arguments of f. The optimizer then
+// decorrelates the subquery, and can apply its normalization rules to the body
+// of the function like it would for any other query.
+//
+// See Builder.buildStmt for a description of the remaining input and return
+// values.
+func (b *Builder) buildUDF(
+	f *tree.FuncExpr, inScope, outScope *scope, outCol *scopeColumn, colRefs *opt.ColSet,
+) (out opt.ScalarExpr) {
+	o := f.ResolvedOverload()
+	if b.insideViewDef {
+		panic(unimplemented.NewWithIssue(17511, "user-defined functions are not supported in views"))
+	}
+	for c := b.udf; c != nil; c = c.parent {
+		if c.oid == o.Oid {
+			panic(unimplemented.NewWithIssue(17511, "recursive user-defined function calls are not supported"))
+		}
+	}
+	b.factory.Metadata().AddUserDefinedFunction(o)
+
+	stmt, err := parser.ParseOne(o.Body)
+	if err != nil {
+		panic(pgerror.Wrapf(err, pgcode.Syntax, "failed to parse body of function %q", f.Func.String()))
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		panic(errors.AssertionFailedf("expected SELECT statement in body of function %q", f.Func.String()))
+	}
+
+	// Project the arguments of the function into a single row. Each argument is
+	// cast to the type of the corresponding parameter if necessary.
+	argTypes := o.Types.(tree.ArgTypes)
+	paramScope := b.allocScope()
+	for i, pexpr := range f.Exprs {
+		texpr := pexpr.(tree.TypedExpr)
+		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
+		typ := argTypes[i].Typ
+		if !texpr.ResolvedType().Identical(typ) {
+			arg = b.factory.ConstructCast(arg, typ)
+		}
+		b.synthesizeColumn(paramScope, udfParamColName(argTypes[i].Name, i), typ, nil /* expr */, arg)
+	}
+	one := b.factory.ConstructValues(memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
+		Cols: opt.ColList{},
+		ID:   b.factory.Metadata().NextUniqueID(),
+	})
+	params := b.constructProject(one, paramScope.cols)
+
+	// A function that returns NULL on NULL input is not evaluated if any of its
+	// arguments is NULL. Filtering out the row of arguments causes the subquery
+	// to return no rows, and therefore NULL.
+	if !o.CalledOnNullInput && len(paramScope.cols) > 0 {
+		filters := make(memo.FiltersExpr, len(paramScope.cols))
+		for i := range paramScope.cols {
+			filters[i] = b.factory.ConstructFiltersItem(b.factory.ConstructIsNot(
+				b.factory.ConstructVariable(paramScope.cols[i].id), memo.NullSingleton,
+			))
+		}
+		params = b.factory.ConstructSelect(params, filters)
+	}
+
+	retTyp := f.ResolvedType()
+	bodyScope := b.buildUDFBody(sel, o.Oid, paramScope.cols, retTyp)
+
+	// Only the first row of the body is returned.
+	body := b.factory.ConstructLimit(
+		bodyScope.expr,
+		b.factory.ConstructConst(tree.NewDInt(1), types.Int),
+		bodyScope.makeOrderingChoice(),
+	)
+	input := b.factory.ConstructInnerJoinApply(params, body, memo.TrueFilter, memo.EmptyJoinPrivate)
+
+	// Project the result of the body, cast to the return type of the function if
+	// necessary.
+	resCol := &bodyScope.cols[0]
+	var res opt.ScalarExpr = b.factory.ConstructVariable(resCol.id)
+	if !resCol.typ.Identical(retTyp) {
+		res = b.factory.ConstructCast(res, retTyp)
+	}
+	projScope := b.allocScope()
+	b.synthesizeColumn(projScope, scopeColName(""), retTyp, nil /* expr */, res)
+	input = b.constructProject(input, projScope.cols)
+
+	out = b.factory.ConstructSubquery(input, &memo.SubqueryPrivate{
+		OriginalExpr: &tree.Subquery{Select: &tree.ParenSelect{Select: sel}},
+	})
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
+// buildUDFBody builds the body of a user-defined function, with the given
+// columns bound to the parameters of the function. The body must return a
+// single column, whose type must be equivalent to retTyp.
+func (b *Builder) buildUDFBody(
+	sel *tree.Select, fnOID oid.Oid, params []scopeColumn, retTyp *types.T,
+) (bodyScope *scope) {
+	// The body of the function is not a subquery of the statement that calls
+	// it, and cannot refer to any of its columns.
+	paramScope := b.allocScope()
+	paramScope.cols = make([]scopeColumn, len(params))
+	for i := range params {
+		paramScope.cols[i] = params[i]
+		paramScope.cols[i].scalar = nil
+	}
+
+	prevSubquery := b.subquery
+	prevUDF := b.udf
+	prevCTEs := b.ctes
+	b.subquery = nil
+	b.udf = &udfContext{oid: fnOID, params: paramScope.cols, parent: prevUDF}
+	b.ctes = nil
+	defer func() {
+		b.subquery = prevSubquery
+		b.udf = prevUDF
+		b.ctes = prevCTEs
+	}()
+	// The restrictions of the context in which the function is called do not
+	// apply to its body.
+	defer b.semaCtx.Properties.Restore(b.semaCtx.Properties)
+	b.semaCtx.Properties.Require("", 0 /* rejectFlags */)
+
+	bodyScope = b.buildStmt(sel, []*types.T{retTyp}, paramScope.push())
+	bodyScope.expr = b.buildWiths(bodyScope.expr, b.ctes)
+
+	if len(bodyScope.cols) != 1 {
+		panic(errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"return type mismatch in function declared to return %s", retTyp),
+			"Final statement must return exactly one column.",
+		))
+	}
+	if typ := bodyScope.cols[0].typ; typ.Family() != types.UnknownFamily && !typ.Equivalent(retTyp) {
+		panic(errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"return type mismatch in function declared to return %s", retTyp),
+			fmt.Sprintf("Actual return type is %s.", typ),
+		))
+	}
+	return bodyScope
+}
+
+// udfParamColName returns the name of the column holding the value of the
+// i-th parameter of a user-defined function. Unnamed parameters can only be
+// referenced by position, so their column is anonymous.
+func udfParamColName(name string, i int) scopeColumnName {
+	if name == "" {
+		return scopeColName("").WithMetadataName(fmt.Sprintf("$%d", i+1))
+	}
+	return scopeColName(tree.Name(name))
+}
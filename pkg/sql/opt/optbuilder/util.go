@@ -553,11 +553,17 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
-	if tab.IsMaterializedView() {
+	// We can't mutate materialized views, unless we are maintaining one.
+	if tab.IsMaterializedView() && !b.maintainingIncrementalView {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
+	// Foreign tables are read-only, since their rows are read from external
+	// storage.
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate foreign table %q", tab.Name()))
+	}
+
 	return tab, depName, alias, columns
 }
 
@@ -629,7 +635,13 @@ func (b *Builder) resolveDataSourceRef(
 		// Avoid taking table leases when we're creating a view.
 		flags.AvoidDescriptorCaches = true
 	}
-	ds, _, err := b.catalog.ResolveDataSourceByID(b.ctx, flags, cat.StableID(ref.TableID))
+	ds, isAdding, err := b.catalog.ResolveDataSourceByID(b.ctx, flags, cat.StableID(ref.TableID))
+	if err != nil && isAdding && b.maintainingIncrementalView {
+		// The maintained view may still be being created; see
+		// resolveIncrementalView.
+		flags.AvoidDescriptorCaches = true
+		ds, _, err = b.catalog.ResolveDataSourceByID(b.ctx, flags, cat.StableID(ref.TableID))
+	}
 	if err != nil {
 		panic(pgerror.Wrapf(err, pgcode.UndefinedObject, "%s", tree.ErrString(ref)))
 	}
@@ -644,7 +656,7 @@ func (b *Builder) resolveDataSourceRef(
 // dependency to the metadata, so that the privileges can be re-checked on reuse
 // of the memo.
 func (b *Builder) checkPrivilege(name opt.MDDepName, ds cat.DataSource, priv privilege.Kind) {
-	if !(priv == privilege.SELECT && b.skipSelectPrivilegeChecks) {
+	if !(priv == privilege.SELECT && b.skipSelectPrivilegeChecks) && !b.maintainingIncrementalView {
 		err := b.catalog.CheckPrivilege(b.ctx, ds, priv)
 		if err != nil {
 			panic(err)
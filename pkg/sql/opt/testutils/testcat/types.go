@@ -68,3 +68,8 @@ func (tc *Catalog) ResolveType(
 func (tc *Catalog) ResolveTypeByOID(context.Context, oid.Oid) (*types.T, error) {
 	return nil, errors.Newf("ResolveTypeByOID not supported in the test catalog")
 }
+
+// ResolveFunctionByOID is part of the cat.Catalog interface.
+func (tc *Catalog) ResolveFunctionByOID(context.Context, oid.Oid) (*tree.Overload, error) {
+	return nil, errors.Newf("ResolveFunctionByOID not supported in the test catalog")
+}
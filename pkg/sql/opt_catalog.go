@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/config"
@@ -260,6 +261,13 @@ func (oc *optCatalog) ResolveType(
 	return oc.planner.ResolveType(ctx, name)
 }
 
+// ResolveFunctionByOID is part of the cat.Catalog interface.
+func (oc *optCatalog) ResolveFunctionByOID(
+	ctx context.Context, oid oid.Oid,
+) (*tree.Overload, error) {
+	return oc.planner.ResolveFunctionByOID(ctx, oid)
+}
+
 func getDescFromCatalogObjectForPermissions(o cat.Object) (catalog.Descriptor, error) {
 	switch t := o.(type) {
 	case *optSchema:
@@ -378,6 +386,24 @@ func (oc *optCatalog) RoleExists(ctx context.Context, role security.SQLUsername)
 	return RoleExists(ctx, oc.planner.ExecCfg(), oc.planner.Txn(), role)
 }
 
+// UserRoles is part of the cat.Catalog interface.
+func (oc *optCatalog) UserRoles(ctx context.Context) ([]security.SQLUsername, error) {
+	user := oc.planner.User()
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]security.SQLUsername, 0, len(memberOf)+2)
+	roles = append(roles, user, security.PublicRoleName())
+	for role := range memberOf {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Normalized() < roles[j].Normalized()
+	})
+	return roles, nil
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -421,6 +447,11 @@ func (oc *optCatalog) dataSourceForTable(
 		// optVirtualTable.id for more information).
 		return newOptVirtualTable(ctx, oc, desc, name)
 	}
+	if desc.IsForeignTable() {
+		// Foreign tables have no indexes or statistics, so they are planned like
+		// virtual tables.
+		return newOptVirtualTable(ctx, oc, desc, name)
+	}
 
 	// Even if we have a cached data source, we still have to cross-check that
 	// statistics and the zone config haven't changed.
@@ -632,6 +663,20 @@ type optTable struct {
 
 	uniqueConstraints []optUniqueConstraint
 
+	// triggers contains the row-level triggers defined on this table.
+	triggers []optTrigger
+
+	// policies contains the row-level security policies defined on this table.
+	policies []optPolicy
+
+	// exclusionConstraints contains the exclusion constraints defined on this
+	// table.
+	exclusionConstraints []optExclusionConstraint
+
+	// incrementalViews contains the IDs of the incrementally maintained
+	// materialized views which depend on this table.
+	incrementalViews []cat.StableID
+
 	outboundFKs []optForeignKeyConstraint
 	inboundFKs  []optForeignKeyConstraint
 
@@ -763,15 +808,53 @@ func newOptTable(
 	for i := range ot.desc.GetUniqueWithoutIndexConstraints() {
 		u := &ot.desc.GetUniqueWithoutIndexConstraints()[i]
 		ot.uniqueConstraints = append(ot.uniqueConstraints, optUniqueConstraint{
-			name:         u.Name,
-			table:        ot.ID(),
-			columns:      u.ColumnIDs,
-			predicate:    u.Predicate,
-			withoutIndex: true,
-			validity:     u.Validity,
+			name:          u.Name,
+			table:         ot.ID(),
+			columns:       u.ColumnIDs,
+			predicate:     u.Predicate,
+			withoutIndex:  true,
+			validity:      u.Validity,
+			deferrability: u.Deferrability,
 		})
 	}
 
+	// Add the row-level triggers.
+	ot.triggers = make([]optTrigger, len(ot.desc.GetTriggers()))
+	for i := range ot.triggers {
+		ot.triggers[i] = optTrigger{desc: &ot.desc.GetTriggers()[i]}
+	}
+
+	// Add the row-level security policies.
+	ot.policies = make([]optPolicy, len(ot.desc.GetPolicies()))
+	for i := range ot.policies {
+		ot.policies[i] = optPolicy{desc: &ot.desc.GetPolicies()[i]}
+	}
+
+	// Add the exclusion constraints.
+	ot.exclusionConstraints = make([]optExclusionConstraint, len(ot.desc.GetExclusionConstraints()))
+	for i := range ot.exclusionConstraints {
+		ot.exclusionConstraints[i] = optExclusionConstraint{
+			table: ot.ID(),
+			desc:  &ot.desc.GetExclusionConstraints()[i],
+		}
+	}
+
+	// Add the incrementally maintained materialized views. A view may have
+	// several back-references to the table, one for each time it refers to it.
+	for _, ref := range ot.desc.GetDependedOnBy() {
+		if !ref.Incremental {
+			continue
+		}
+		id := cat.StableID(ref.ID)
+		found := false
+		for _, existing := range ot.incrementalViews {
+			found = found || existing == id
+		}
+		if !found {
+			ot.incrementalViews = append(ot.incrementalViews, id)
+		}
+	}
+
 	// Build the indexes.
 	ot.indexes = make([]optIndex, 1+len(secondaryIndexes))
 
@@ -855,6 +938,7 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			deferrability:     fk.Deferrability,
 		})
 		return nil
 	})
@@ -869,6 +953,7 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			deferrability:     fk.Deferrability,
 		})
 		return nil
 	})
@@ -879,8 +964,22 @@ func newOptTable(
 		ot.families[i].init(ot, &desc.GetFamilies()[i+1])
 	}
 
+	// Synthesize the check constraints which enforce the constraints of the
+	// domains of the columns. They must be in the order expected by
+	// checkMutationInput.
+	domainChecks, err := makeDomainChecks(desc)
+	if err != nil {
+		return nil, err
+	}
+	synthesizedChecks := make([]cat.CheckConstraint, 0, len(domainChecks))
+	for i := range domainChecks {
+		synthesizedChecks = append(synthesizedChecks, cat.CheckConstraint{
+			Constraint: tree.Serialize(domainChecks[i].Expr),
+			Validated:  true,
+		})
+	}
+
 	// Synthesize any check constraints for user defined types.
-	var synthesizedChecks []cat.CheckConstraint
 	for i := 0; i < ot.ColumnCount(); i++ {
 		col := ot.Column(i)
 		if col.IsMutation() {
@@ -906,13 +1005,21 @@ func newOptTable(
 	}
 	// Move all existing and synthesized checks into the opt table.
 	activeChecks := desc.ActiveChecks()
-	ot.checkConstraints = make([]cat.CheckConstraint, 0, len(activeChecks)+len(synthesizedChecks))
+	ot.checkConstraints = make([]cat.CheckConstraint, 0, len(activeChecks)+1+len(synthesizedChecks))
 	for i := range activeChecks {
 		ot.checkConstraints = append(ot.checkConstraints, cat.CheckConstraint{
 			Constraint: activeChecks[i].Expr,
 			Validated:  activeChecks[i].Validity == descpb.ConstraintValidity_Validated,
 		})
 	}
+	// The row-level security check immediately follows the active checks, so
+	// that the execution engine can find it when reporting violations.
+	if desc.GetRowLevelSecurity() {
+		ot.checkConstraints = append(ot.checkConstraints, cat.CheckConstraint{
+			Constraint:       "true",
+			RowLevelSecurity: true,
+		})
+	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
 	// Add stats last, now that other metadata is initialized.
@@ -1026,11 +1133,21 @@ func (ot *optTable) IsVirtualTable() bool {
 	return false
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optTable) IsForeignTable() bool {
+	return false
+}
+
 // IsMaterializedView implements the cat.Table interface.
 func (ot *optTable) IsMaterializedView() bool {
 	return ot.desc.MaterializedView()
 }
 
+// IncrementalViewQuery is part of the cat.Table interface.
+func (ot *optTable) IncrementalViewQuery() string {
+	return ot.desc.GetIncrementalViewQuery()
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optTable) ColumnCount() int {
 	return len(ot.columns)
@@ -1135,6 +1252,56 @@ func (ot *optTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &ot.uniqueConstraints[i]
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return &ot.triggers[i]
+}
+
+// RowLevelSecurity is part of the cat.Table interface.
+func (ot *optTable) RowLevelSecurity() (enabled, forced bool) {
+	return ot.desc.GetRowLevelSecurity(), ot.desc.GetForceRowLevelSecurity()
+}
+
+// Owner is part of the cat.Table interface.
+func (ot *optTable) Owner() security.SQLUsername {
+	return ot.desc.GetPrivileges().Owner()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.policies)
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) cat.Policy {
+	return &ot.policies[i]
+}
+
+// ExclusionConstraintCount is part of the cat.Table interface.
+func (ot *optTable) ExclusionConstraintCount() int {
+	return len(ot.exclusionConstraints)
+}
+
+// ExclusionConstraint is part of the cat.Table interface.
+func (ot *optTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	return &ot.exclusionConstraints[i]
+}
+
+// IncrementalViewCount is part of the cat.Table interface.
+func (ot *optTable) IncrementalViewCount() int {
+	return len(ot.incrementalViews)
+}
+
+// IncrementalView is part of the cat.Table interface.
+func (ot *optTable) IncrementalView(i int) cat.StableID {
+	return ot.incrementalViews[i]
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optTable) Zone() cat.Zone {
 	return ot.zone
@@ -1601,8 +1768,9 @@ type optUniqueConstraint struct {
 	columns   []descpb.ColumnID
 	predicate string
 
-	withoutIndex bool
-	validity     descpb.ConstraintValidity
+	withoutIndex  bool
+	validity      descpb.ConstraintValidity
+	deferrability descpb.ConstraintDeferrability
 }
 
 var _ cat.UniqueConstraint = &optUniqueConstraint{}
@@ -1650,6 +1818,148 @@ func (u *optUniqueConstraint) Validated() bool {
 	return u.validity == descpb.ConstraintValidity_Validated
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return descpb.ConstraintDeferrabilityType[u.deferrability]
+}
+
+// optTrigger implements cat.Trigger and represents a row-level trigger.
+type optTrigger struct {
+	desc *descpb.TriggerDescriptor
+}
+
+var _ cat.Trigger = &optTrigger{}
+
+// Name is part of the cat.Trigger interface.
+func (t *optTrigger) Name() string {
+	return t.desc.Name
+}
+
+// ActionTime is part of the cat.Trigger interface.
+func (t *optTrigger) ActionTime() tree.TriggerActionTime {
+	if t.desc.ActionTime == descpb.TriggerDescriptor_BEFORE {
+		return tree.TriggerBefore
+	}
+	return tree.TriggerAfter
+}
+
+// FiresOn is part of the cat.Trigger interface.
+func (t *optTrigger) FiresOn(event tree.TriggerEvent) bool {
+	switch event {
+	case tree.TriggerEventInsert:
+		return t.desc.FiresOn(descpb.TriggerDescriptor_INSERT)
+	case tree.TriggerEventUpdate:
+		return t.desc.FiresOn(descpb.TriggerDescriptor_UPDATE)
+	case tree.TriggerEventDelete:
+		return t.desc.FiresOn(descpb.TriggerDescriptor_DELETE)
+	}
+	return false
+}
+
+// When is part of the cat.Trigger interface.
+func (t *optTrigger) When() (string, bool) {
+	return t.desc.When, t.desc.When != ""
+}
+
+// Body is part of the cat.Trigger interface.
+func (t *optTrigger) Body() string {
+	return t.desc.Body
+}
+
+// optPolicy implements cat.Policy and represents a row-level security policy.
+type optPolicy struct {
+	desc *descpb.PolicyDescriptor
+}
+
+var _ cat.Policy = &optPolicy{}
+
+// Name is part of the cat.Policy interface.
+func (p *optPolicy) Name() string {
+	return p.desc.Name
+}
+
+// AppliesTo is part of the cat.Policy interface.
+func (p *optPolicy) AppliesTo(command tree.PolicyCommand) bool {
+	switch command {
+	case tree.PolicyCommandSelect:
+		return p.desc.AppliesTo(descpb.PolicyDescriptor_SELECT)
+	case tree.PolicyCommandInsert:
+		return p.desc.AppliesTo(descpb.PolicyDescriptor_INSERT)
+	case tree.PolicyCommandUpdate:
+		return p.desc.AppliesTo(descpb.PolicyDescriptor_UPDATE)
+	case tree.PolicyCommandDelete:
+		return p.desc.AppliesTo(descpb.PolicyDescriptor_DELETE)
+	}
+	return false
+}
+
+// Restrictive is part of the cat.Policy interface.
+func (p *optPolicy) Restrictive() bool {
+	return p.desc.Restrictive
+}
+
+// RoleCount is part of the cat.Policy interface.
+func (p *optPolicy) RoleCount() int {
+	return len(p.desc.Roles)
+}
+
+// Role is part of the cat.Policy interface.
+func (p *optPolicy) Role(i int) security.SQLUsername {
+	return security.MakeSQLUsernameFromPreNormalizedString(p.desc.Roles[i])
+}
+
+// Using is part of the cat.Policy interface.
+func (p *optPolicy) Using() (string, bool) {
+	return p.desc.UsingExpr, p.desc.UsingExpr != ""
+}
+
+// WithCheck is part of the cat.Policy interface.
+func (p *optPolicy) WithCheck() (string, bool) {
+	return p.desc.WithCheckExpr, p.desc.WithCheckExpr != ""
+}
+
+// optExclusionConstraint implements cat.ExclusionConstraint and represents an
+// exclusion constraint.
+type optExclusionConstraint struct {
+	table cat.StableID
+	desc  *descpb.ExclusionConstraint
+}
+
+var _ cat.ExclusionConstraint = &optExclusionConstraint{}
+
+// Name is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Name() string {
+	return e.desc.Name
+}
+
+// ColumnCount is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) ColumnCount() int {
+	return len(e.desc.ColumnIDs)
+}
+
+// ColumnOrdinal is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) ColumnOrdinal(tab cat.Table, i int) int {
+	if tab.ID() != e.table {
+		panic(errors.AssertionFailedf(
+			"invalid table %d passed to ColumnOrdinal (expected %d)",
+			tab.ID(), e.table,
+		))
+	}
+	optTab := convertTableToOptTable(tab)
+	ord, _ := optTab.lookupColumnOrdinal(e.desc.ColumnIDs[i])
+	return ord
+}
+
+// Operator is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Operator(i int) tree.ComparisonOperatorSymbol {
+	return descpb.ExclusionConstraintOperatorType[e.desc.Operators[i]]
+}
+
+// Predicate is part of the cat.ExclusionConstraint interface.
+func (e *optExclusionConstraint) Predicate() (string, bool) {
+	return e.desc.Predicate, e.desc.Predicate != ""
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1663,10 +1973,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        descpb.ForeignKeyReference_Match
-	deleteAction descpb.ForeignKeyReference_Action
-	updateAction descpb.ForeignKeyReference_Action
+	validity      descpb.ConstraintValidity
+	match         descpb.ForeignKeyReference_Match
+	deleteAction  descpb.ForeignKeyReference_Action
+	updateAction  descpb.ForeignKeyReference_Action
+	deferrability descpb.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1738,6 +2049,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return descpb.ForeignKeyReferenceActionType[fk.updateAction]
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return descpb.ConstraintDeferrabilityType[fk.deferrability]
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -1783,7 +2099,9 @@ func newOptVirtualTable(
 ) (*optVirtualTable, error) {
 	// Calculate the stable ID (see the comment for optVirtualTable.id).
 	id := cat.StableID(desc.GetID())
-	if name.Catalog() != "" {
+	// Foreign tables belong to a single database, so they have a single
+	// instance.
+	if name.Catalog() != "" && !desc.IsForeignTable() {
 		// TODO(radu): it's unfortunate that we have to lookup the schema again.
 		found, prefix, err := oc.planner.LookupSchema(ctx, name.Catalog(), name.Schema())
 		if err != nil {
@@ -1920,11 +2238,21 @@ func (ot *optVirtualTable) IsVirtualTable() bool {
 	return true
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optVirtualTable) IsForeignTable() bool {
+	return ot.desc.IsForeignTable()
+}
+
 // IsMaterializedView implements the cat.Table interface.
 func (ot *optVirtualTable) IsMaterializedView() bool {
 	return false
 }
 
+// IncrementalViewQuery is part of the cat.Table interface.
+func (ot *optVirtualTable) IncrementalViewQuery() string {
+	return ""
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optVirtualTable) ColumnCount() int {
 	return len(ot.columns)
@@ -2030,6 +2358,56 @@ func (ot *optVirtualTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("no unique constraints"))
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// RowLevelSecurity is part of the cat.Table interface.
+func (ot *optVirtualTable) RowLevelSecurity() (enabled, forced bool) {
+	return false, false
+}
+
+// Owner is part of the cat.Table interface.
+func (ot *optVirtualTable) Owner() security.SQLUsername {
+	return security.NodeUserName()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
+// ExclusionConstraintCount is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionConstraintCount() int {
+	return 0
+}
+
+// ExclusionConstraint is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionConstraint(i int) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("no exclusion constraints"))
+}
+
+// IncrementalViewCount is part of the cat.Table interface.
+func (ot *optVirtualTable) IncrementalViewCount() int {
+	return 0
+}
+
+// IncrementalView is part of the cat.Table interface.
+func (ot *optVirtualTable) IncrementalView(i int) cat.StableID {
+	panic(errors.AssertionFailedf("no incremental views"))
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optVirtualTable) Zone() cat.Zone {
 	panic(errors.AssertionFailedf("no zone"))
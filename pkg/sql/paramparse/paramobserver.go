@@ -102,18 +102,9 @@ func (a *TableStorageParamObserver) Apply(
 	case `fillfactor`:
 		return applyFillFactorStorageParam(evalCtx, key, datum)
 	case `autovacuum_enabled`:
-		var boolVal bool
-		if stringVal, err := DatumAsString(evalCtx, key, datum); err == nil {
-			boolVal, err = ParseBoolVar(key, stringVal)
-			if err != nil {
-				return err
-			}
-		} else {
-			s, err := GetSingleBool(key, datum)
-			if err != nil {
-				return err
-			}
-			boolVal = bool(*s)
+		boolVal, err := datumAsBool(evalCtx, key, datum)
+		if err != nil {
+			return err
 		}
 		if !boolVal && evalCtx != nil {
 			evalCtx.ClientNoticeSender.BufferClientNotice(
@@ -154,6 +145,50 @@ func (a *TableStorageParamObserver) Apply(
 	return errors.Errorf("invalid storage parameter %q", key)
 }
 
+// datumAsBool parses a boolean storage parameter, which may be given either as
+// a boolean or as a string such as 'on' or 'false'.
+func datumAsBool(evalCtx *tree.EvalContext, key string, datum tree.Datum) (bool, error) {
+	if stringVal, err := DatumAsString(evalCtx, key, datum); err == nil {
+		return ParseBoolVar(key, stringVal)
+	}
+	s, err := GetSingleBool(key, datum)
+	if err != nil {
+		return false, err
+	}
+	return bool(*s), nil
+}
+
+// ViewStorageParamObserver observes storage parameters for materialized
+// views.
+type ViewStorageParamObserver struct {
+	// Incremental is set if the view is maintained incrementally as the tables
+	// it depends on are mutated, rather than only when it is refreshed.
+	Incremental bool
+}
+
+var _ StorageParamObserver = (*ViewStorageParamObserver)(nil)
+
+// Apply implements the StorageParamObserver interface.
+func (a *ViewStorageParamObserver) Apply(
+	evalCtx *tree.EvalContext, key string, datum tree.Datum,
+) error {
+	switch key {
+	case `incremental`:
+		boolVal, err := datumAsBool(evalCtx, key, datum)
+		if err != nil {
+			return err
+		}
+		a.Incremental = boolVal
+		return nil
+	}
+	return errors.Errorf("invalid storage parameter %q", key)
+}
+
+// RunPostChecks implements the StorageParamObserver interface.
+func (a *ViewStorageParamObserver) RunPostChecks() error {
+	return nil
+}
+
 // IndexStorageParamObserver observes storage parameters for indexes.
 type IndexStorageParamObserver struct {
 	IndexDesc *descpb.IndexDescriptor
@@ -147,8 +147,28 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE TABLE blah AS SELECT 1 ??`, `SELECT`},
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`CREATE DOMAIN blah AS INT ??`, `CREATE DOMAIN`},
 		{`DROP TYPE ??`, `DROP TYPE`},
 
+		{`CREATE SERVER ??`, `CREATE SERVER`},
+		{`CREATE SERVER blah FOREIGN DATA WRAPPER ??`, `CREATE SERVER`},
+
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE blah (x INT) ??`, `CREATE FOREIGN TABLE`},
+
+		{`CREATE FUNCTION ??`, `CREATE FUNCTION`},
+		{`CREATE OR REPLACE FUNCTION blah(??`, `CREATE FUNCTION`},
+		{`DROP FUNCTION ??`, `DROP FUNCTION`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE TRIGGER tr AFTER INSERT ON t ??`, `CREATE TRIGGER`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+
+		{`CREATE POLICY ??`, `CREATE POLICY`},
+		{`CREATE POLICY p ON ??`, `CREATE POLICY`},
+		{`DROP POLICY ??`, `DROP POLICY`},
+
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA bli ??`, `CREATE SCHEMA`},
@@ -232,6 +252,17 @@ func TestContextualHelp(t *testing.T) {
 		{`UPSERT INTO blah VALUES (1) ??`, `VALUES`},
 		{`UPSERT INTO blah TABLE foo ??`, `TABLE`},
 
+		{`MERGE ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ON true ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ON true WHEN MATCHED THEN ??`, `MERGE`},
+
+		{`LISTEN ??`, `LISTEN`},
+		{`LISTEN foo ??`, `LISTEN`},
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
+		{`UNLISTEN ??`, `UNLISTEN`},
+		{`UNLISTEN * ??`, `UNLISTEN`},
+
 		{`UPDATE blah ??`, `UPDATE`},
 		{`UPDATE blah SET ??`, `UPDATE`},
 		{`UPDATE blah SET x = 3 WHERE true ??`, `UPDATE`},
@@ -407,6 +438,8 @@ func TestContextualHelp(t *testing.T) {
 
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
 		{`SET TIME ??`, `SET SESSION`},
 		{`SET TIME ZONE 'UTC' ??`, `SET SESSION`},
 		{`SET blah TO ??`, `SET SESSION`},
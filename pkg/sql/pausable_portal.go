@@ -0,0 +1,423 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessionphase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/sslocal"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+// pausablePortal is used to execute a portal whose execution can be suspended
+// when it reaches the row count limit of an Execute message, and resumed by a
+// later Execute message for the same portal, while other statements and
+// portals are executed in the same transaction in the meantime.
+//
+// The statement of the portal is executed by execStmtInOpenState on a
+// separate goroutine, with the pausablePortal as its result. When the row
+// count limit is reached, the goroutine blocks in AddRow and hands control
+// back to the connExecutor goroutine, which completes the Execute command and
+// goes on processing commands. The flow of the statement and its memory
+// accounts stay alive until either the portal is executed again, at which
+// point the goroutine resumes producing rows into the result of the new
+// Execute command, or the portal is closed, at which point the flow is drained
+// and the goroutine finishes. Only one of the two goroutines runs at a time,
+// so they don't need any synchronization beyond these handoffs.
+//
+// The transaction is used by other statements while the portal is suspended,
+// so the statement is planned and executed with its own planner, and its plan
+// doesn't use leaf txns. While the goroutine of the portal runs, the
+// connExecutor uses the stats collector and phase times of the portal, and
+// the transaction reads at the sequence number established when the statement
+// of the portal started, so that rows written by the statements executed
+// while the portal is suspended are not returned by the portal.
+type pausablePortal struct {
+	ex *connExecutor
+
+	// planner is used instead of the planner of the connExecutor, which is
+	// reused by the statements executed while the portal is suspended.
+	planner planner
+
+	// statsCollector and phaseTimes are used instead of those of the
+	// connExecutor while the goroutine of the portal runs, so that the
+	// statistics of the statement of the portal are not reset by the
+	// statements executed while it is suspended. They are set when the
+	// execution of the portal starts. The statistics are recorded into the
+	// stats collector of the connExecutor, and so into the statistics of the
+	// transaction.
+	statsCollector *sslocal.StatsCollector
+	phaseTimes     *sessionphase.Times
+
+	// readSeqNum is the sequence number at which the statement of the portal
+	// reads. It is set by the goroutine of the portal when the statement
+	// starts executing, and restored whenever the portal is resumed.
+	readSeqNum enginepb.TxnSeq
+	// readSeqNumSet is true once readSeqNum has been set.
+	readSeqNumSet bool
+
+	// res is the result of the Execute command which is currently executing
+	// the portal. It is nil once the portal has been closed. It is only set by
+	// the connExecutor goroutine before handing control to the goroutine of the
+	// portal (see connExecutor.execPortal).
+	res RestrictedCommandResult
+
+	// err is the error set on the portal once it has been closed.
+	err error
+
+	// state is only accessed by the connExecutor goroutine.
+	state pausablePortalState
+
+	// resumeCh is used by the connExecutor goroutine to hand control to the
+	// goroutine of the portal, when the portal is executed again or closed.
+	resumeCh chan struct{}
+
+	// outcomeCh is used by the goroutine of the portal to hand control back to
+	// the connExecutor goroutine, when the execution of the portal is suspended
+	// or finished.
+	outcomeCh chan pausablePortalOutcome
+}
+
+type pausablePortalState int
+
+const (
+	pausablePortalNotStarted pausablePortalState = iota
+	pausablePortalSuspended
+	pausablePortalFinished
+)
+
+// pausablePortalOutcome is sent by the goroutine of a pausablePortal to the
+// connExecutor goroutine. If suspended is false, the execution of the portal
+// has finished and the other fields are the results of execStmtInOpenState.
+type pausablePortalOutcome struct {
+	suspended bool
+	ev        fsm.Event
+	payload   fsm.EventPayload
+	err       error
+}
+
+var _ RestrictedCommandResult = &pausablePortal{}
+
+// newPausablePortal creates a pausablePortal. Its execution is started by the
+// first call to execStmtInOpenState.
+func (ex *connExecutor) newPausablePortal(ctx context.Context) *pausablePortal {
+	pp := &pausablePortal{
+		ex:        ex,
+		resumeCh:  make(chan struct{}),
+		outcomeCh: make(chan pausablePortalOutcome),
+	}
+	ex.initPlanner(ctx, &pp.planner)
+	pp.planner.pausablePortal = true
+	return pp
+}
+
+// canPausePortal returns true if the execution of the given portal can be
+// suspended when it reaches the row count limit of an Execute message. This is
+// only the case for read-only queries in explicit transactions which don't
+// take a new read snapshot for every statement. Other portals are executed to
+// completion by their first Execute message (see pgwire.limitedCommandResult).
+func (ex *connExecutor) canPausePortal(portal PreparedPortal) bool {
+	os, ok := ex.machine.CurState().(stateOpen)
+	if !ok || os.ImplicitTxn.Get() || ex.executorType == executorTypeInternal {
+		return false
+	}
+	if ex.state.mu.txn.IsolationLevel().PerStatementReadSnapshot() {
+		return false
+	}
+	return isReadOnlySelect(portal.Stmt.AST)
+}
+
+// isReadOnlySelect returns true if the statement is a SELECT statement whose
+// common table expressions are SELECT statements as well.
+func isReadOnlySelect(stmt tree.Statement) bool {
+	sel, ok := stmt.(*tree.Select)
+	if !ok {
+		return false
+	}
+	if sel.With != nil {
+		for _, cte := range sel.With.CTEList {
+			if !isReadOnlySelect(cte.Stmt) {
+				return false
+			}
+		}
+	}
+	if paren, ok := sel.Select.(*tree.ParenSelect); ok {
+		return isReadOnlySelect(paren.Select)
+	}
+	return true
+}
+
+// execStmtInOpenState is like connExecutor.execStmtInOpenState, but the
+// execution of the statement is suspended when the row count limit of the
+// result is reached. res must be the pausablePortal itself, whose result must
+// have been set to the result of the Execute command. It is called every time
+// the portal is executed; the first call starts the execution of the
+// statement, and the subsequent calls resume it. If the execution is
+// suspended, nil is returned, and the portal must not be exhausted.
+func (pp *pausablePortal) execStmtInOpenState(
+	ctx context.Context,
+	parserStmt parser.Statement,
+	prepared *PreparedStatement,
+	pinfo *tree.PlaceholderInfo,
+	res RestrictedCommandResult,
+) (fsm.Event, fsm.EventPayload, error) {
+	if res != pp {
+		return nil, nil, errors.AssertionFailedf("unexpected result for pausable portal: %T", res)
+	}
+	var outcome pausablePortalOutcome
+	switch pp.state {
+	case pausablePortalNotStarted:
+		ex := pp.ex
+		pp.phaseTimes = ex.phaseTimes.Clone()
+		pp.statsCollector = sslocal.NewStatsCollector(
+			ex.server.cfg.Settings,
+			ex.statsCollector,
+			pp.phaseTimes,
+			ex.server.cfg.SQLStatsTestingKnobs,
+		)
+		var err error
+		outcome, err = pp.run(ctx, func() error {
+			// The goroutine outlives the span of the command which starts the
+			// execution, so it uses a span of its own.
+			ctx, sp := tracing.ForkSpan(ctx, "pausable portal")
+			if err := ex.server.cfg.DistSQLPlanner.stopper.RunAsyncTask(
+				ctx, "pausable-portal", func(ctx context.Context) {
+					defer sp.Finish()
+					ev, payload, err := ex.execStmtInOpenState(ctx, parserStmt, prepared, pinfo, res)
+					pp.outcomeCh <- pausablePortalOutcome{ev: ev, payload: payload, err: err}
+				},
+			); err != nil {
+				sp.Finish()
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			pp.state = pausablePortalFinished
+			return nil, nil, err
+		}
+	case pausablePortalSuspended:
+		var err error
+		if outcome, err = pp.run(ctx, pp.resume); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, errors.AssertionFailedf("executing a finished pausable portal")
+	}
+	if outcome.suspended {
+		pp.state = pausablePortalSuspended
+		return nil, nil, nil
+	}
+	pp.state = pausablePortalFinished
+	return outcome.ev, outcome.payload, outcome.err
+}
+
+// run hands control to the goroutine of the portal, which is started or
+// resumed by the given function, and returns the outcome sent by the goroutine
+// when it hands control back. While the goroutine runs, the connExecutor uses
+// the stats collector and phase times of the portal, and the transaction reads
+// at the sequence number of the portal.
+func (pp *pausablePortal) run(
+	ctx context.Context, startOrResume func() error,
+) (pausablePortalOutcome, error) {
+	ex := pp.ex
+	txn := ex.state.mu.txn
+	if pp.readSeqNumSet {
+		prevReadSeqNum := txn.GetReadSeqNum()
+		if err := txn.SetReadSeqNum(pp.readSeqNum); err != nil {
+			return pausablePortalOutcome{}, err
+		}
+		defer func() {
+			if err := txn.SetReadSeqNum(prevReadSeqNum); err != nil {
+				log.Warningf(ctx, "error restoring read sequence number: %v", err)
+			}
+		}()
+	}
+	prevStatsCollector, prevPhaseTimes := ex.statsCollector, ex.phaseTimes
+	ex.statsCollector, ex.phaseTimes = pp.statsCollector, pp.phaseTimes
+	defer func() {
+		ex.statsCollector, ex.phaseTimes = prevStatsCollector, prevPhaseTimes
+	}()
+	if err := startOrResume(); err != nil {
+		return pausablePortalOutcome{}, err
+	}
+	return <-pp.outcomeCh, nil
+}
+
+// resume hands control to the suspended goroutine of the portal.
+func (pp *pausablePortal) resume() error {
+	pp.resumeCh <- struct{}{}
+	return nil
+}
+
+// pinReadSeqNum records the current read sequence number of the transaction
+// as the one at which the statement of the portal reads. It is called by the
+// goroutine of the portal once the statement has established its read
+// snapshot.
+func (pp *pausablePortal) pinReadSeqNum() {
+	pp.readSeqNum = pp.ex.state.mu.txn.GetReadSeqNum()
+	pp.readSeqNumSet = true
+}
+
+// finished returns true if the execution of the portal has finished, either
+// because all its rows were returned or because it was closed.
+func (pp *pausablePortal) finished() bool {
+	return pp.state == pausablePortalFinished
+}
+
+// close closes the portal. If its execution is suspended, the goroutine of
+// the portal is resumed without a result, so that the flow is drained and
+// cleaned up. It must be called before the transaction is finished.
+func (pp *pausablePortal) close(ctx context.Context) {
+	if pp.state != pausablePortalSuspended {
+		pp.state = pausablePortalFinished
+		return
+	}
+	pp.res = nil
+	outcome, err := pp.run(ctx, pp.resume)
+	if err != nil {
+		// The goroutine of the portal still needs to finish.
+		_ = pp.resume()
+		outcome = <-pp.outcomeCh
+	}
+	pp.state = pausablePortalFinished
+	if err == nil {
+		err = outcome.err
+	}
+	if err != nil {
+		log.Warningf(ctx, "error closing suspended portal: %v", err)
+	}
+}
+
+// closePausedPortals closes the suspended portals of the session, which are
+// then exhausted. It is called before the transaction changes state, since the
+// flows of the portals use the transaction.
+func (ex *connExecutor) closePausedPortals(ctx context.Context) {
+	for name, portal := range ex.extraTxnState.prepStmtsNamespace.portals {
+		if pp := portal.pausablePortal; pp != nil && pp.state == pausablePortalSuspended {
+			pp.close(ctx)
+			ex.exhaustPortal(name)
+		}
+	}
+}
+
+// AddRow is part of the RestrictedCommandResult interface. When the row count
+// limit of the current result is reached, it blocks until the portal is
+// executed again, or closed, in which case ErrLimitedResultClosed is returned
+// so that the flow is drained.
+func (pp *pausablePortal) AddRow(ctx context.Context, row tree.Datums) error {
+	if pp.res == nil {
+		return ErrLimitedResultClosed
+	}
+	err := pp.res.AddRow(ctx, row)
+	if !errors.Is(err, ErrPortalSuspended) {
+		return err
+	}
+	pp.outcomeCh <- pausablePortalOutcome{suspended: true}
+	<-pp.resumeCh
+	if pp.res == nil {
+		return ErrLimitedResultClosed
+	}
+	return nil
+}
+
+// AddBatch is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) AddBatch(context.Context, coldata.Batch) error {
+	return errors.AssertionFailedf("AddBatch not supported by pausable portals")
+}
+
+// SupportsAddBatch is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) SupportsAddBatch() bool {
+	return false
+}
+
+// SetError is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) SetError(err error) {
+	if pp.res == nil {
+		pp.err = err
+		return
+	}
+	pp.res.SetError(err)
+}
+
+// Err is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) Err() error {
+	if pp.res == nil {
+		return pp.err
+	}
+	return pp.res.Err()
+}
+
+// BufferParamStatusUpdate is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) BufferParamStatusUpdate(param string, val string) {
+	if pp.res != nil {
+		pp.res.BufferParamStatusUpdate(param, val)
+	}
+}
+
+// BufferNotice is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) BufferNotice(notice pgnotice.Notice) {
+	if pp.res != nil {
+		pp.res.BufferNotice(notice)
+	}
+}
+
+// SetColumns is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
+	if pp.res != nil {
+		pp.res.SetColumns(ctx, cols)
+	}
+}
+
+// ResetStmtType is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) ResetStmtType(stmt tree.Statement) {
+	if pp.res != nil {
+		pp.res.ResetStmtType(stmt)
+	}
+}
+
+// IncrementRowsAffected is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) IncrementRowsAffected(ctx context.Context, n int) {
+	if pp.res != nil {
+		pp.res.IncrementRowsAffected(ctx, n)
+	}
+}
+
+// RowsAffected is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) RowsAffected() int {
+	if pp.res == nil {
+		return 0
+	}
+	return pp.res.RowsAffected()
+}
+
+// DisableBuffering is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) DisableBuffering() {
+	if pp.res != nil {
+		pp.res.DisableBuffering()
+	}
+}
+
+// TruncateForRetry is part of the RestrictedCommandResult interface.
+func (pp *pausablePortal) TruncateForRetry(ctx context.Context) bool {
+	// The rows returned by previous Execute commands cannot be truncated.
+	return false
+}
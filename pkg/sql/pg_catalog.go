@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -593,6 +594,7 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
@@ -617,6 +619,9 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 		} else if table.IsSequence() {
 			relKind = relKindSequence
 			relAm = oidZero
+		} else if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
 		}
 		relPersistence := relPersistencePermanent
 		if table.IsTemporary() {
@@ -771,7 +776,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -827,6 +831,7 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrability := descpb.ConstraintDeferrability_NotDeferrable
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -870,6 +875,7 @@ func populateTableConstraints(
 			if r, ok := fkMatchMap[con.FK.Match]; ok {
 				confmatchtype = r
 			}
+			deferrability = con.FK.Deferrability
 			if conkey, err = colIDArrayToDatum(con.FK.OriginColumnIDs); err != nil {
 				return err
 			}
@@ -915,6 +921,7 @@ func populateTableConstraints(
 				oid = h.UniqueWithoutIndexConstraintOid(
 					db.GetID(), scName, table.GetID(), con.UniqueWithoutIndexConstraint,
 				)
+				deferrability = con.UniqueWithoutIndexConstraint.Deferrability
 				f.WriteString("UNIQUE WITHOUT INDEX (")
 				colNames, err := table.NamesForColumnIDs(con.UniqueWithoutIndexConstraint.ColumnIDs)
 				if err != nil {
@@ -956,15 +963,36 @@ func populateTableConstraints(
 				validity = " NOT VALID"
 			}
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
+
+		case descpb.ConstraintTypeExclusion:
+			oid = h.ExclusionConstraintOid(db.GetID(), scName, table.GetID(), con.ExclusionConstraint)
+			contype = conTypeExclusion
+			if conkey, err = colIDArrayToDatum(con.ExclusionConstraint.ColumnIDs); err != nil {
+				return err
+			}
+			f := tree.NewFmtCtx(tree.FmtSimple)
+			if err := showExclusionConstraintElems(table, con.ExclusionConstraint, f); err != nil {
+				return err
+			}
+			if con.ExclusionConstraint.Predicate != "" {
+				pred, err := schemaexpr.FormatExprForDisplay(ctx, table, con.ExclusionConstraint.Predicate, p.SemaCtx(), p.SessionData(), tree.FmtPGCatalog)
+				if err != nil {
+					return err
+				}
+				f.WriteString(fmt.Sprintf(" WHERE (%s)", pred))
+			}
+			condef = tree.NewDString(f.CloseAndGetString())
 		}
+		condeferrable := tree.MakeDBool(tree.DBool(deferrability != descpb.ConstraintDeferrability_NotDeferrable))
+		condeferred := tree.MakeDBool(tree.DBool(deferrability == descpb.ConstraintDeferrability_InitiallyDeferred))
 
 		if err := addRow(
 			oid,                  // oid
 			dNameOrNull(conName), // conname
 			namespaceOid,         // connamespace
 			contype,              // contype
-			tree.DBoolFalse,      // condeferrable
-			tree.DBoolFalse,      // condeferred
+			condeferrable,        // condeferrable
+			condeferred,          // condeferred
 			tree.MakeDBool(tree.DBool(!con.Unvalidated)), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1640,36 +1668,86 @@ https://www.postgresql.org/docs/9.5/catalog-pg-extension.html`,
 }
 
 var pgCatalogForeignDataWrapperTable = virtualSchemaTable{
-	comment: `foreign data wrappers (empty - feature does not exist)
+	comment: `foreign data wrappers
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-data-wrapper.html`,
 	schema: vtable.PGCatalogForeignDataWrapper,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign data wrappers are not supported.
-		return nil
+		// The only foreign data wrapper is the built-in one which reads from
+		// external storage.
+		h := makeOidHasher()
+		return addRow(
+			h.ForeignDataWrapperOid(externalStorageForeignDataWrapper), // oid
+			tree.NewDName(externalStorageForeignDataWrapper),           // fdwname
+			h.UserOid(security.RootUserName()),                         // fdwowner
+			oidZero,                                                    // fdwhandler
+			oidZero,                                                    // fdwvalidator
+			tree.DNull,                                                 // fdwacl
+			tree.DNull,                                                 // fdwoptions
+		)
 	},
-	unimplemented: true,
 }
 
 var pgCatalogForeignServerTable = virtualSchemaTable{
-	comment: `foreign servers (empty - feature does not exist)
+	comment: `foreign servers
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-server.html`,
 	schema: vtable.PGCatalogForeignServer,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign servers are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for _, server := range db.DatabaseDesc().ForeignServers {
+					// The URI of the server may contain credentials.
+					uri, err := cloud.SanitizeExternalStorageURI(server.URI, nil /* extraParams */)
+					if err != nil {
+						return err
+					}
+					options := tree.NewDArray(types.String)
+					if err := options.Append(tree.NewDString("uri=" + uri)); err != nil {
+						return err
+					}
+					if err := addRow(
+						h.ForeignServerOid(db.GetID(), server.Name), // oid
+						tree.NewDName(server.Name),                  // srvname
+						getOwnerOID(db),                             // srvowner
+						h.ForeignDataWrapperOid(server.Wrapper),     // srvfdw
+						tree.DNull,                                  // srvtype
+						tree.DNull,                                  // srvversion
+						tree.DNull,                                  // srvacl
+						options,                                     // srvoptions
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogForeignTableTable = virtualSchemaTable{
-	comment: `foreign tables (empty  - feature does not exist)
+	comment: `foreign tables
 https://www.postgresql.org/docs/9.5/catalog-pg-foreign-table.html`,
 	schema: vtable.PGCatalogForeignTable,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Foreign tables are not supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, _ string, table catalog.TableDescriptor) error {
+				if !table.IsForeignTable() {
+					return nil
+				}
+				foreignTable := table.GetForeignTable()
+				options := tree.NewDArray(types.String)
+				for _, opt := range foreignTable.Options {
+					if err := options.Append(tree.NewDString(opt.Key + "=" + opt.Value)); err != nil {
+						return err
+					}
+				}
+				return addRow(
+					tableOid(table.GetID()),                             // ftrelid
+					h.ForeignServerOid(db.GetID(), foreignTable.Server), // ftserver
+					options, // ftoptions
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 func makeZeroedOidVector(size int) (tree.Datum, error) {
@@ -2659,7 +2737,6 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -2787,23 +2864,42 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typOid := typ.Oid()
 	typname := typ.PGName()
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		// Domains share the representation of their base type, but have an OID
+		// of their own and no array type.
+		typOid = typ.DomainOID()
+		typname = typ.TypeMeta.Name.Basename()
+		typType = typTypeDomain
+		typArray = oidZero
+		typBaseType = tree.NewDOid(tree.DInt(typ.Oid()))
+		if domainData := typ.TypeMeta.DomainData; domainData != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(domainData.NotNull))
+			if domainData.Default != "" {
+				typDefault = tree.NewDString(domainData.Default)
+			}
+		}
+	}
 
 	return addRow(
-		tree.NewDOid(tree.DInt(typ.Oid())), // oid
-		tree.NewDName(typname),             // typname
-		nspOid,                             // typnamespace
-		owner,                              // typowner
-		typLen(typ),                        // typlen
-		typByVal(typ),                      // typbyval (is it fixedlen or not)
-		typType,                            // typtype
-		cat,                                // typcategory
-		tree.DBoolFalse,                    // typispreferred
-		tree.DBoolTrue,                     // typisdefined
-		typDelim,                           // typdelim
-		oidZero,                            // typrelid
-		typElem,                            // typelem
-		typArray,                           // typarray
+		tree.NewDOid(tree.DInt(typOid)), // oid
+		tree.NewDName(typname),          // typname
+		nspOid,                          // typnamespace
+		owner,                           // typowner
+		typLen(typ),                     // typlen
+		typByVal(typ),                   // typbyval (is it fixedlen or not)
+		typType,                         // typtype
+		cat,                             // typcategory
+		tree.DBoolFalse,                 // typispreferred
+		tree.DBoolTrue,                  // typisdefined
+		typDelim,                        // typdelim
+		oidZero,                         // typrelid
+		typElem,                         // typelem
+		typArray,                        // typarray
 
 		// regproc references
 		h.RegProc(builtinPrefix+"in"),   // typinput
@@ -2816,13 +2912,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -3950,6 +4046,8 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.TimestampTZFamily: typCategoryDateTime,
 	types.ArrayFamily:       typCategoryArray,
 	types.TupleFamily:       typCategoryPseudo,
+	types.TSQueryFamily:     typCategoryUserDefined,
+	types.TSVectorFamily:    typCategoryUserDefined,
 	types.OidFamily:         typCategoryNumeric,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
@@ -4174,6 +4272,9 @@ const (
 	enumEntryTypeTag
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
+	exclusionConstraintTypeTag
+	foreignDataWrapperTypeTag
+	foreignServerTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -4282,6 +4383,30 @@ func (h oidHasher) UniqueWithoutIndexConstraintOid(
 	return h.getOid()
 }
 
+func (h oidHasher) ExclusionConstraintOid(
+	dbID descpb.ID, scName string, tableID descpb.ID, c *descpb.ExclusionConstraint,
+) *tree.DOid {
+	h.writeTypeTag(exclusionConstraintTypeTag)
+	h.writeDB(dbID)
+	h.writeSchema(scName)
+	h.writeTable(tableID)
+	h.writeStr(c.Name)
+	return h.getOid()
+}
+
+func (h oidHasher) ForeignDataWrapperOid(name string) *tree.DOid {
+	h.writeTypeTag(foreignDataWrapperTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) ForeignServerOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(foreignServerTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func (h oidHasher) UniqueConstraintOid(
 	dbID descpb.ID, scName string, tableID descpb.ID, indexID descpb.IndexID,
 ) *tree.DOid {
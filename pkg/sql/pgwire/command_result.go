@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -59,6 +60,7 @@ type commandResult struct {
 	buffer struct {
 		notices            []pgnotice.Notice
 		paramStatusUpdates []paramStatusUpdate
+		notifications      []pgnotify.Notification
 	}
 
 	err error
@@ -139,6 +141,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, notification := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(notification); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
@@ -242,6 +250,23 @@ func (r *commandResult) DisableBuffering() {
 	r.bufferingDisabled = true
 }
 
+// TruncateForRetry is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) TruncateForRetry(ctx context.Context) bool {
+	r.assertNotReleased()
+	cl := r.conn.LockCommunication()
+	defer cl.Close()
+	if cl.ClientPos() >= r.pos {
+		return false
+	}
+	cl.RTrim(ctx, r.pos)
+	r.err = nil
+	r.rowsAffected = 0
+	r.buffer.notices = nil
+	r.buffer.paramStatusUpdates = nil
+	r.buffer.notifications = nil
+	return true
+}
+
 // BufferParamStatusUpdate is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) BufferParamStatusUpdate(param string, val string) {
 	r.buffer.paramStatusUpdates = append(
@@ -255,6 +280,12 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.SyncResult and sql.NotificationResult
+// interfaces.
+func (r *commandResult) BufferNotification(notification pgnotify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, notification)
+}
+
 // SetColumns is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
@@ -355,6 +386,7 @@ func (c *conn) newCommandResult(
 	limit int,
 	portalName string,
 	implicitTxn bool,
+	pausable bool,
 ) sql.CommandResult {
 	r := c.allocCommandResult()
 	*r = commandResult{
@@ -376,6 +408,7 @@ func (c *conn) newCommandResult(
 		limit:         limit,
 		portalName:    portalName,
 		implicitTxn:   implicitTxn,
+		pausable:      pausable,
 		commandResult: r,
 	}
 }
@@ -390,29 +423,31 @@ func (c *conn) newMiscResult(pos sql.CmdPos, typ completionMsgType) *commandResu
 	return r
 }
 
-// limitedCommandResult is a commandResult that has a limit, after which calls
-// to AddRow will block until the associated client connection asks for more
-// rows. It essentially implements the "execute portal with limit" part of the
-// Postgres protocol.
+// limitedCommandResult is a commandResult that has a limit, after which the
+// execution of the portal is suspended until the associated client connection
+// asks for more rows. It essentially implements the "execute portal with
+// limit" part of the Postgres protocol.
 //
-// This design is known to be flawed. It only supports a specific subset of the
-// protocol. We only allow a portal suspension in an explicit transaction where
-// the suspended portal is completely exhausted before any other pgwire command
-// is executed, otherwise an error is produced. You cannot, for example,
-// interleave portal executions (a portal must be executed to completion before
-// another can be executed). It also breaks the software layering by adding an
-// additional state machine here, instead of teaching the state machine in the
-// sql package about portals.
+// If the portal is pausable, reaching the limit completes the Execute command
+// with a "portal suspended" message, and AddRow returns
+// sql.ErrPortalSuspended so that the connExecutor suspends the execution of
+// the portal until it is executed again (see sql.pausablePortal). This allows
+// other commands, including the execution of other portals, to be interleaved
+// with the executions of the portal.
 //
-// This has been done because refactoring the executor to be able to correctly
-// suspend a portal will require a lot of work, and we wanted to move
-// forward. The work included is things like auditing all of the defers and
-// post-execution stuff (like stats collection) to have it only execute once
-// per statement instead of once per portal.
+// Otherwise, calls to AddRow block at the limit until the client asks for more
+// rows. This design is known to be flawed. It only supports a specific subset
+// of the protocol: the suspended portal must be completely exhausted before any
+// other pgwire command is executed, otherwise an error is produced. It also
+// breaks the software layering by adding an additional state machine here,
+// instead of teaching the state machine in the sql package about portals. It
+// is only used for the portals that the connExecutor cannot suspend, such as
+// the portals of mutations.
 type limitedCommandResult struct {
 	*commandResult
 	portalName  string
 	implicitTxn bool
+	pausable    bool
 
 	seenTuples int
 	// If set, an error will be sent to the client if more rows are produced than
@@ -431,6 +466,14 @@ func (r *limitedCommandResult) AddRow(ctx context.Context, row tree.Datums) erro
 		// If we've seen up to the limit of rows, send a "portal suspended" message
 		// and wait for another exec portal message.
 		r.conn.bufferPortalSuspended()
+		if r.pausable {
+			// The portal suspended message replaces the command complete
+			// message, and the connExecutor suspends the execution of the
+			// portal.
+			r.typ = noCompletionMsg
+			r.seenTuples = 0
+			return sql.ErrPortalSuspended
+		}
 		if err := r.conn.Flush(r.pos); err != nil {
 			return err
 		}
@@ -444,6 +487,15 @@ func (r *limitedCommandResult) AddRow(ctx context.Context, row tree.Datums) erro
 	return nil
 }
 
+// TruncateForRetry is part of the sql.RestrictedCommandResult interface.
+func (r *limitedCommandResult) TruncateForRetry(ctx context.Context) bool {
+	if !r.commandResult.TruncateForRetry(ctx) {
+		return false
+	}
+	r.seenTuples = 0
+	return true
+}
+
 // SupportsAddBatch is part of the sql.RestrictedCommandResult interface.
 // TODO(yuzefovich): implement limiting behavior for AddBatch.
 func (r *limitedCommandResult) SupportsAddBatch() bool {
@@ -505,6 +557,11 @@ func (r *limitedCommandResult) moreResultsNeeded(ctx context.Context) error {
 			if err := r.conn.Flush(r.pos); err != nil {
 				return err
 			}
+		case sql.SendNotifications:
+			// Notifications are not sent while a transaction is open, so there is
+			// nothing to do. They will be sent with the next Sync outside of the
+			// transaction.
+			r.conn.stmtBuf.AdvanceOne()
 		default:
 			// If the portal is immediately followed by a COMMIT, we can proceed and
 			// let the portal be destroyed at the end of the transaction.
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -97,6 +98,10 @@ type conn struct {
 	readBuf    pgwirebase.ReadBuffer
 	msgBuilder writeBuffer
 
+	// copyOutFieldBuf is used to format the fields of the rows sent by the
+	// Copy-out subprotocol in the text and CSV formats, before they are escaped.
+	copyOutFieldBuf writeBuffer
+
 	// vecsScratch is a scratch space used by bufferBatch.
 	vecsScratch coldata.TypedVecs
 
@@ -197,6 +202,7 @@ func newConn(
 	c.writerState.fi.buf = &c.writerState.buf
 	c.writerState.fi.lastFlushed = -1
 	c.msgBuilder.init(metrics.BytesOutCount)
+	c.copyOutFieldBuf.init(metrics.BytesOutCount)
 
 	return c
 }
@@ -687,6 +693,14 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return writeErrFields(ctx, c.sv, noticeErr, &c.msgBuilder, &c.writerState.buf)
 }
 
+func (c *conn) bufferNotification(n pgnotify.Notification) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(n.PID)
+	c.msgBuilder.writeTerminatedString(n.Channel)
+	c.msgBuilder.writeTerminatedString(n.Payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context, sqlServer *sql.Server, onDefaultIntSizeChange func(newSize int32),
 ) (sql.ConnectionHandler, error) {
@@ -784,10 +798,12 @@ func (c *conn) handleSimpleQuery(
 	}
 
 	for i := range stmts {
-		// The CopyFrom statement is special. We need to detect it so we can hand
-		// control of the connection, through the stmtBuf, to a copyMachine, and
-		// block this network routine until control is passed back.
-		if cp, ok := stmts[i].AST.(*tree.CopyFrom); ok {
+		// The CopyFrom and CopyTo statements are special. We need to detect them
+		// so we can hand control of the connection, through the stmtBuf, to the
+		// connExecutor running the copy, and block this network routine until
+		// control is passed back.
+		switch stmts[i].AST.(type) {
+		case *tree.CopyFrom, *tree.CopyTo:
 			if len(stmts) != 1 {
 				// NOTE(andrei): I don't know if Postgres supports receiving a COPY
 				// together with other statements in the "simple" protocol, but I'd
@@ -802,7 +818,13 @@ func (c *conn) handleSimpleQuery(
 			}
 			copyDone := sync.WaitGroup{}
 			copyDone.Add(1)
-			if err := c.stmtBuf.Push(ctx, sql.CopyIn{Conn: c, Stmt: cp, CopyDone: &copyDone}); err != nil {
+			var cmd sql.Command
+			if cp, ok := stmts[i].AST.(*tree.CopyFrom); ok {
+				cmd = sql.CopyIn{Conn: c, Stmt: cp, CopyDone: &copyDone}
+			} else {
+				cmd = sql.CopyOut{Conn: c, Stmt: stmts[i].AST.(*tree.CopyTo), CopyDone: &copyDone}
+			}
+			if err := c.stmtBuf.Push(ctx, cmd); err != nil {
 				return err
 			}
 			copyDone.Wait()
@@ -912,6 +934,10 @@ func (c *conn) handleParse(
 		// https://www.postgresql.org/message-id/flat/CAMsr%2BYGvp2wRx9pPSxaKFdaObxX8DzWse%2BOkWk2xpXSvT0rq-g%40mail.gmail.com#CAMsr+YGvp2wRx9pPSxaKFdaObxX8DzWse+OkWk2xpXSvT0rq-g@mail.gmail.com
 		return c.stmtBuf.Push(ctx, sql.SendError{Err: fmt.Errorf("CopyFrom not supported in extended protocol mode")})
 	}
+	if _, ok := stmt.AST.(*tree.CopyTo); ok {
+		// COPY TO is not supported in the extended protocol for the same reasons.
+		return c.stmtBuf.Push(ctx, sql.SendError{Err: fmt.Errorf("CopyTo not supported in extended protocol mode")})
+	}
 
 	return c.stmtBuf.Push(
 		ctx,
@@ -1134,6 +1160,161 @@ func (c *conn) BeginCopyIn(
 	return c.msgBuilder.finishMsg(c.conn)
 }
 
+// copyBinarySignature starts the header of the data sent in the binary format
+// by the Copy-out subprotocol. It is followed by the flags field and by the
+// length of the header extension area.
+const copyBinarySignature = "PGCOPY\n\377\r\n\000"
+
+// BeginCopyOut is part of the pgwirebase.Conn interface.
+func (c *conn) BeginCopyOut(
+	ctx context.Context, columns []colinfo.ResultColumn, opts *pgwirebase.CopyOutOptions,
+) error {
+	format := pgwirebase.FormatText
+	if opts.Format == tree.CopyFormatBinary {
+		format = pgwirebase.FormatBinary
+	}
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyOutResponse)
+	c.msgBuilder.writeByte(byte(format))
+	c.msgBuilder.putInt16(int16(len(columns)))
+	for range columns {
+		c.msgBuilder.putInt16(int16(format))
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		return err
+	}
+	if opts.Format == tree.CopyFormatBinary {
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		c.msgBuilder.writeString(copyBinarySignature)
+		// The flags field and the length of the header extension area.
+		c.msgBuilder.putInt32(0)
+		c.msgBuilder.putInt32(0)
+		if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+			return err
+		}
+	}
+	return c.maybeFlushCopyOut()
+}
+
+// SendCopyOutRow is part of the pgwirebase.Conn interface.
+func (c *conn) SendCopyOutRow(
+	ctx context.Context, row tree.Datums, typs []*types.T, opts *pgwirebase.CopyOutOptions,
+) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+	if opts.Format == tree.CopyFormatBinary {
+		// A tuple of the binary format is encoded like the fields of a DataRow
+		// message.
+		c.msgBuilder.putInt16(int16(len(row)))
+		for i, d := range row {
+			c.msgBuilder.writeBinaryDatum(ctx, d, opts.Location, typs[i])
+		}
+	} else {
+		for i, d := range row {
+			if i > 0 {
+				c.msgBuilder.writeByte(opts.Delimiter)
+			}
+			if d == tree.DNull {
+				c.msgBuilder.writeString(opts.Null)
+				continue
+			}
+			// Format the datum as in a DataRow message and strip the length prefix.
+			c.copyOutFieldBuf.reset()
+			writeTextDatumNotNull(&c.copyOutFieldBuf, d, opts.Conv, opts.Location, typs[i])
+			if c.copyOutFieldBuf.err != nil {
+				return c.copyOutFieldBuf.err
+			}
+			field := c.copyOutFieldBuf.wrapped.Bytes()[4:]
+			if opts.Format == tree.CopyFormatCSV {
+				writeCopyCSVField(&c.msgBuilder, field, opts.Delimiter, opts.Null)
+			} else {
+				writeCopyTextField(&c.msgBuilder, field, opts.Delimiter)
+			}
+		}
+		c.msgBuilder.writeByte('\n')
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		return err
+	}
+	return c.maybeFlushCopyOut()
+}
+
+// SendCopyDone is part of the pgwirebase.Conn interface.
+func (c *conn) SendCopyDone(ctx context.Context, opts *pgwirebase.CopyOutOptions) error {
+	if opts.Format == tree.CopyFormatBinary {
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		c.msgBuilder.putInt16(-1)
+		if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+			return err
+		}
+	}
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDone)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
+// maybeFlushCopyOut writes the messages of the Copy-out subprotocol buffered so
+// far to the network connection if they exceeded
+// sessionArgs.ConnResultsBufferSize. Unlike maybeFlush, it doesn't update the
+// flushInfo: the connExecutor does not retry COPY statements.
+func (c *conn) maybeFlushCopyOut() error {
+	if int64(c.writerState.buf.Len()) <= c.sessionArgs.ConnResultsBufferSize {
+		return nil
+	}
+	if _ /* n */, err := c.writerState.buf.WriteTo(c.conn); err != nil {
+		c.setErr(err)
+		return err
+	}
+	return nil
+}
+
+// writeCopyTextField writes field to b, escaped for the text format of COPY.
+//
+// See: https://www.postgresql.org/docs/current/sql-copy.html#id-1.9.3.55.9.2
+func writeCopyTextField(b *writeBuffer, field []byte, delim byte) {
+	for _, ch := range field {
+		switch ch {
+		case '\\':
+			b.writeString(`\\`)
+		case '\b':
+			b.writeString(`\b`)
+		case '\f':
+			b.writeString(`\f`)
+		case '\n':
+			b.writeString(`\n`)
+		case '\r':
+			b.writeString(`\r`)
+		case '\t':
+			b.writeString(`\t`)
+		case '\v':
+			b.writeString(`\v`)
+		default:
+			if ch == delim {
+				b.writeByte('\\')
+			}
+			b.writeByte(ch)
+		}
+	}
+}
+
+// writeCopyCSVField writes field to b for the CSV format of COPY. The field is
+// quoted if it contains special characters or if it could be mistaken for
+// NULL.
+//
+// See: https://www.postgresql.org/docs/current/sql-copy.html#id-1.9.3.55.9.3
+func writeCopyCSVField(b *writeBuffer, field []byte, delim byte, null string) {
+	if string(field) != null && !bytes.ContainsAny(field, "\"\r\n") &&
+		bytes.IndexByte(field, delim) < 0 {
+		b.write(field)
+		return
+	}
+	b.writeByte('"')
+	for _, ch := range field {
+		if ch == '"' {
+			b.writeByte('"')
+		}
+		b.writeByte(ch)
+	}
+	b.writeByte('"')
+}
+
 // SendCommandComplete is part of the pgwirebase.Conn interface.
 func (c *conn) SendCommandComplete(tag []byte) error {
 	c.bufferCommandComplete(tag)
@@ -1248,10 +1429,10 @@ func cookTag(
 			tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
 		}
 
-	case tree.CopyIn:
+	case tree.CopyIn, tree.CopyOut:
 		// Nothing to do. The CommandComplete message has been sent elsewhere.
-		panic(errors.AssertionFailedf("CopyIn statements should have been handled elsewhere " +
-			"and not produce results"))
+		panic(errors.AssertionFailedf("CopyIn and CopyOut statements should have been handled " +
+			"elsewhere and not produce results"))
 	default:
 		panic(errors.AssertionFailedf("unexpected result type %v", stmtType))
 	}
@@ -1608,8 +1789,9 @@ func (c *conn) CreateStatementResult(
 	limit int,
 	portalName string,
 	implicitTxn bool,
+	pausable bool,
 ) sql.CommandResult {
-	return c.newCommandResult(descOpt, pos, stmt, formatCodes, conv, location, limit, portalName, implicitTxn)
+	return c.newCommandResult(descOpt, pos, stmt, formatCodes, conv, location, limit, portalName, implicitTxn, pausable)
 }
 
 // CreateSyncResult is part of the sql.ClientComm interface.
@@ -1622,6 +1804,11 @@ func (c *conn) CreateFlushResult(pos sql.CmdPos) sql.FlushResult {
 	return c.newMiscResult(pos, flush)
 }
 
+// CreateNotificationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateNotificationResult(pos sql.CmdPos) sql.NotificationResult {
+	return c.newMiscResult(pos, flush)
+}
+
 // CreateDrainResult is part of the sql.ClientComm interface.
 func (c *conn) CreateDrainResult(pos sql.CmdPos) sql.DrainResult {
 	return c.newMiscResult(pos, noCompletionMsg)
@@ -1664,6 +1851,11 @@ func (c *conn) CreateCopyInResult(pos sql.CmdPos) sql.CopyInResult {
 	return c.newMiscResult(pos, noCompletionMsg)
 }
 
+// CreateCopyOutResult is part of the sql.ClientComm interface.
+func (c *conn) CreateCopyOutResult(pos sql.CmdPos) sql.CopyOutResult {
+	return c.newMiscResult(pos, noCompletionMsg)
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
@@ -0,0 +1,23 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+// Notification is an asynchronous notification sent with NOTIFY or
+// pg_notify() and delivered to the sessions listening on its channel.
+type Notification struct {
+	// Channel is the name of the channel the notification was sent on.
+	Channel string
+	// Payload is the optional payload string of the notification.
+	Payload string
+	// PID identifies the sender of the notification. It is the ID of the SQL
+	// instance the notifying session was connected to.
+	PID int32
+}
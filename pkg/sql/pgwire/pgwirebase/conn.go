@@ -12,12 +12,17 @@ package pgwirebase
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
 // Conn exposes some functionality of a pgwire network connection to be
-// used by the Copy-in subprotocol implemented in the sql package.
+// used by the Copy-in and Copy-out subprotocols implemented in the sql
+// package.
 type Conn interface {
 	// Rd returns a reader to be used to consume bytes from the connection.
 	// This reader can be used with a pgwirebase.ReadBuffer for reading messages.
@@ -31,7 +36,34 @@ type Conn interface {
 	// the columns that are expected for the rows to be inserted.
 	BeginCopyIn(ctx context.Context, columns []colinfo.ResultColumn, format FormatCode) error
 
+	// BeginCopyOut sends the server message initiating the Copy-out
+	// subprotocol (COPY ... TO STDOUT). This message informs the client about
+	// the columns of the rows that will be sent. In the binary format, it is
+	// followed by the header of the copied data.
+	BeginCopyOut(ctx context.Context, columns []colinfo.ResultColumn, opts *CopyOutOptions) error
+
+	// SendCopyOutRow sends a CopyData message holding row, encoded according to
+	// opts. typs are the types of the columns of the row.
+	SendCopyOutRow(ctx context.Context, row tree.Datums, typs []*types.T, opts *CopyOutOptions) error
+
+	// SendCopyDone ends the Copy-out subprotocol. In the binary format, the
+	// trailer of the copied data is sent first.
+	SendCopyDone(ctx context.Context, opts *CopyOutOptions) error
+
 	// SendCommandComplete sends a serverMsgCommandComplete with the given
 	// payload.
 	SendCommandComplete(tag []byte) error
 }
+
+// CopyOutOptions describes how the rows sent by the Copy-out subprotocol are
+// encoded.
+type CopyOutOptions struct {
+	Format tree.CopyFormat
+	// Delimiter separates the fields of a row in the text and CSV formats.
+	Delimiter byte
+	// Null is the representation of NULL in the text and CSV formats.
+	Null string
+	// Conv and Location are the session settings used to encode the datums.
+	Conv     sessiondatapb.DataConversionConfig
+	Location *time.Location
+}
@@ -48,6 +48,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 	// Nodes that define their own schema.
 	case *delayedNode:
 		return n.columns
+	case *foreignScanNode:
+		return n.resultColumns
 	case *groupNode:
 		return n.columns
 	case *joinNode:
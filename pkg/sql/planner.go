@@ -102,6 +102,14 @@ type extendedEvalContext struct {
 
 	SchemaChangerState *SchemaChangerState
 
+	// DeferredConstraints refers to the state of deferrable constraints in
+	// extraTxnState of sql.connExecutor.
+	DeferredConstraints *deferredConstraintState
+
+	// Listens refers to the LISTEN and UNLISTEN state of sql.connExecutor. It
+	// is nil for the internal executor.
+	Listens *listenState
+
 	SchemaChangeInternalExecutor *InternalExecutor
 }
 
@@ -214,6 +222,12 @@ type planner struct {
 	// isPreparing is true if this planner is currently preparing.
 	isPreparing bool
 
+	// pausablePortal is true if this planner is used to execute a portal whose
+	// execution can be suspended and resumed (see pausablePortal). The plan
+	// must then not use leaf transactions, since other statements can use the
+	// transaction while the portal is suspended.
+	pausablePortal bool
+
 	// curPlan collects the properties of the current plan being prepared. This state
 	// is undefined at the beginning of the planning of each new statement, and cannot
 	// be reused for an old prepared statement after a new statement has been prepared.
@@ -357,6 +371,7 @@ func newInternalPlanner(
 	p.semaCtx.IntervalStyleEnabled = sd.IntervalStyleEnabled
 	p.semaCtx.DateStyleEnabled = sd.DateStyleEnabled
 	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 	p.semaCtx.DateStyle = sd.GetDateStyle()
 	p.semaCtx.IntervalStyle = sd.GetIntervalStyle()
 
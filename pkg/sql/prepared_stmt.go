@@ -129,6 +129,13 @@ type PreparedPortal struct {
 	// meaning that any additional attempts to execute it should return no
 	// rows.
 	exhausted bool
+
+	// pausablePortal, if set, is used to execute the portal so that its
+	// execution can be suspended and resumed by successive Execute messages.
+	// It is shared by the copies of the portal, and is closed when the portal
+	// is deleted or when the transaction changes state, rather than by
+	// close().
+	pausablePortal *pausablePortal
 }
 
 // makePreparedPortal creates a new PreparedPortal.
@@ -59,6 +59,8 @@ const (
 	Table ObjectType = "table"
 	// Type represents a type object.
 	Type ObjectType = "type"
+	// Function represents a function object.
+	Function ObjectType = "function"
 )
 
 // Predefined sets of privileges.
@@ -70,6 +72,7 @@ var (
 	TablePrivileges  = List{ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE, ZONECONFIG}
 	SchemaPrivileges = List{ALL, GRANT, CREATE, USAGE}
 	TypePrivileges   = List{ALL, GRANT, USAGE}
+	FuncPrivileges   = List{ALL}
 )
 
 // PGIncompatibleDBPrivileges represents the privileges CockroachDB
@@ -251,6 +254,8 @@ func GetValidPrivilegesForObject(objectType ObjectType) List {
 		return DBPrivileges
 	case Type:
 		return TypePrivileges
+	case Function:
+		return FuncPrivileges
 	case Any:
 		return AllPrivileges
 	default:
@@ -89,6 +89,9 @@ func (p *planner) RenameTable(ctx context.Context, n *tree.RenameTable) (planNod
 			)
 		}
 	}
+	if err := p.checkNoDependentFunctions(ctx, tableDesc, oldTn.String(), "rename"); err != nil {
+		return nil, err
+	}
 
 	return &renameTableNode{n: n, oldTn: &oldTn, newTn: &newTn, tableDesc: tableDesc}, nil
 }
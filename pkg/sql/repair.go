@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
@@ -129,7 +130,7 @@ func (p *planner) UnsafeUpsertDescriptor(
 			newVersion, existingVersion, existingVersion+1)
 	}
 
-	tbl, db, typ, schema := descpb.FromDescriptor(&desc)
+	tbl, db, typ, schema, function := descpb.FromDescriptor(&desc)
 	switch md := mut.(type) {
 	case *tabledesc.Mutable:
 		md.TableDescriptor = *tbl
@@ -139,6 +140,8 @@ func (p *planner) UnsafeUpsertDescriptor(
 		md.DatabaseDescriptor = *db
 	case *typedesc.Mutable:
 		md.TypeDescriptor = *typ
+	case *funcdesc.Mutable:
+		md.FunctionDescriptor = *function
 	case nil:
 		b := catalogkv.NewBuilder(&desc)
 		if b == nil {
@@ -159,6 +162,8 @@ func (p *planner) UnsafeUpsertDescriptor(
 		objectType = privilege.Type
 	case catalog.Schema:
 		objectType = privilege.Schema
+	case catalog.Function:
+		objectType = privilege.Function
 	}
 
 	// Check that the descriptor ID is less than the counter used for creating new
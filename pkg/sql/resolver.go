@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
@@ -505,6 +506,114 @@ func (p *planner) ResolveTypeByOID(ctx context.Context, oid oid.Oid) (*types.T,
 	return desc.MakeTypesT(ctx, &name, p)
 }
 
+// ResolveFunction implements the tree.FunctionReferenceResolver interface.
+func (p *planner) ResolveFunction(
+	ctx context.Context, name *tree.UnresolvedName, path sessiondata.SearchPath,
+) (*tree.FunctionDefinition, error) {
+	fnName, scName, dbName := name.Parts[0], name.Parts[1], name.Parts[2]
+	if dbName == "" {
+		dbName = p.CurrentDatabase()
+	}
+	var scNames []string
+	if scName != "" {
+		scNames = []string{scName}
+	} else {
+		iter := path.Iter()
+		for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+			scNames = append(scNames, scName)
+		}
+	}
+	var overloads []*tree.Overload
+	if dbName != "" {
+		for _, scName := range scNames {
+			found, prefix, err := p.LookupSchema(ctx, dbName, scName)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+			// Functions in the synthetic public schema are mapped on the
+			// database descriptor.
+			var fn descpb.SchemaDescriptor_Function
+			var ok bool
+			if prefix.Schema.SchemaKind() == catalog.SchemaPublic {
+				fn, ok = prefix.Database.GetPublicSchemaFunction(fnName)
+			} else {
+				fn, ok = prefix.Schema.GetFunction(fnName)
+			}
+			if !ok {
+				continue
+			}
+			if p.contextDatabaseID != descpb.InvalidID && prefix.Database.GetID() != p.contextDatabaseID {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"cross database function references are not supported: %s", name.String())
+			}
+			for _, o := range fn.Overloads {
+				fnDesc, err := p.Descriptors().GetImmutableFunctionByID(
+					ctx, p.txn, o.ID, tree.ObjectLookupFlags{CommonLookupFlags: p.CommonLookupFlags(true /* required */)},
+				)
+				if err != nil {
+					return nil, err
+				}
+				if err := p.canResolveDescUnderSchema(ctx, prefix.Schema, fnDesc); err != nil {
+					return nil, err
+				}
+				overload := fnDesc.ToOverload()
+				// Overloads found earlier in the search path shadow the ones with the
+				// same argument types found later.
+				shadowed := false
+				for _, prev := range overloads {
+					if typesIdentical(prev.Types.Types(), overload.Types.Types()) {
+						shadowed = true
+						break
+					}
+				}
+				if !shadowed {
+					overloads = append(overloads, overload)
+				}
+			}
+		}
+	}
+	if len(overloads) == 0 {
+		return nil, pgerror.Newf(pgcode.UndefinedFunction, "unknown function: %s()", name.String())
+	}
+	return tree.NewUDFFunctionDefinition(fnName, overloads), nil
+}
+
+// typesIdentical returns whether the two type lists are made of identical
+// types.
+func typesIdentical(a, b []*types.T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Identical(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveFunctionByOID implements the tree.FunctionReferenceResolver
+// interface.
+func (p *planner) ResolveFunctionByOID(ctx context.Context, oid oid.Oid) (*tree.Overload, error) {
+	id, err := funcdesc.UserDefinedFunctionOIDToID(oid)
+	if err != nil {
+		return nil, err
+	}
+	flags := tree.ObjectLookupFlags{CommonLookupFlags: p.CommonLookupFlags(true /* required */)}
+	flags.IncludeDropped = true
+	fnDesc, err := p.Descriptors().GetImmutableFunctionByID(ctx, p.txn, id, flags)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.Dropped() {
+		return nil, pgerror.Newf(pgcode.UndefinedFunction, "function %q is being dropped", fnDesc.GetName())
+	}
+	return fnDesc.ToOverload(), nil
+}
+
 // ObjectLookupFlags is part of the resolver.SchemaResolver interface.
 func (p *planner) ObjectLookupFlags(required, requireMutable bool) tree.ObjectLookupFlags {
 	flags := p.CommonLookupFlags(required)
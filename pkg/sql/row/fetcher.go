@@ -607,6 +607,32 @@ func (rf *Fetcher) StartInconsistentScan(
 	return rf.StartScanFrom(ctx, &f, traceKV)
 }
 
+// StartIncrementalScan initializes and starts a scan of the latest revisions
+// as of endTime of the rows in the given spans that were written after
+// startTime. Deleted rows are not returned.
+//
+// Only the column families of a row that changed are read, so this should only
+// be used with tables with a single column family.
+func (rf *Fetcher) StartIncrementalScan(
+	ctx context.Context,
+	db *kv.DB,
+	startTime, endTime hlc.Timestamp,
+	spans roachpb.Spans,
+	traceKV bool,
+) error {
+	if len(spans) == 0 {
+		return errors.AssertionFailedf("no spans")
+	}
+	if rf.reverse {
+		return errors.AssertionFailedf("incremental scans cannot be reversed")
+	}
+	if log.V(1) {
+		log.Infof(ctx, "starting incremental scan of (%s, %s]", startTime, endTime)
+	}
+	f := NewIncrementalKVFetcher(db, spans, startTime, endTime, nil /* onDelete */)
+	return rf.StartScanFrom(ctx, f, traceKV)
+}
+
 func (rf *Fetcher) rowLimitToKeyLimit(rowLimitHint rowinfra.RowLimit) rowinfra.KeyLimit {
 	if rowLimitHint == 0 {
 		return 0
@@ -333,3 +333,120 @@ func (f *BackupSSTKVFetcher) nextBatch(
 func (f *BackupSSTKVFetcher) close(context.Context) {
 	f.iter.Close()
 }
+
+// incrementalFetcherTargetFileSize is the target size of the SSTs returned by
+// the ExportRequests of an incrementalKVFetcher, which bounds the size of its
+// batches.
+const incrementalFetcherTargetFileSize = 16 << 20 // 16 MiB
+
+// incrementalKVFetcher is a KVBatchFetcher that returns the latest revision of
+// the keys in a set of spans that changed in (startTime, endTime]. It reads
+// them with ExportRequests, which scan the spans using MVCCIncrementalIterator
+// the same way incremental backups do. Deleted keys cannot be returned as
+// values, so they are passed to onDelete instead, if it is set.
+type incrementalKVFetcher struct {
+	db        *kv.DB
+	spans     roachpb.Spans
+	startTime hlc.Timestamp
+	endTime   hlc.Timestamp
+	onDelete  func(key roachpb.Key) error
+}
+
+var _ KVBatchFetcher = &incrementalKVFetcher{}
+
+// NewIncrementalKVFetcher returns a KVBatchFetcher for the latest revisions as
+// of endTime of the keys in the given spans that changed after startTime. The
+// keys that were deleted are not returned: onDelete, if not nil, is called
+// with each of them instead.
+func NewIncrementalKVFetcher(
+	db *kv.DB,
+	spans roachpb.Spans,
+	startTime, endTime hlc.Timestamp,
+	onDelete func(key roachpb.Key) error,
+) KVBatchFetcher {
+	return &incrementalKVFetcher{
+		db:        db,
+		spans:     append(roachpb.Spans(nil), spans...),
+		startTime: startTime,
+		endTime:   endTime,
+		onDelete:  onDelete,
+	}
+}
+
+// nextBatch implements the KVBatchFetcher interface.
+func (f *incrementalKVFetcher) nextBatch(
+	ctx context.Context,
+) (ok bool, kvs []roachpb.KeyValue, batchResponse []byte, err error) {
+	for len(f.spans) > 0 {
+		span := f.spans[0]
+		// The sentinel TargetBytes value of 1 makes the ExportRequest paginate
+		// after a single SST.
+		header := roachpb.Header{Timestamp: f.endTime, TargetBytes: 1}
+		req := &roachpb.ExportRequest{
+			RequestHeader:                       roachpb.RequestHeaderFromSpan(span),
+			StartTime:                           f.startTime,
+			MVCCFilter:                          roachpb.MVCCFilter_Latest,
+			EnableTimeBoundIteratorOptimization: true,
+			TargetFileSize:                      incrementalFetcherTargetFileSize,
+			ReturnSST:                           true,
+		}
+		res, pErr := kv.SendWrappedWith(ctx, f.db.NonTransactionalSender(), header, req)
+		if pErr != nil {
+			return false, nil, nil, errors.Wrapf(pErr.GoError(), "fetching changes for %s", span)
+		}
+		resp := res.(*roachpb.ExportResponse)
+		if resp.ResumeSpan != nil {
+			f.spans[0] = *resp.ResumeSpan
+		} else {
+			f.spans = f.spans[1:]
+		}
+		for _, file := range resp.Files {
+			if kvs, err = appendSSTKVs(kvs, file.SST, f.onDelete); err != nil {
+				return false, nil, nil, err
+			}
+		}
+		if len(kvs) > 0 {
+			return true, kvs, nil, nil
+		}
+	}
+	return false, nil, nil, nil
+}
+
+func (f *incrementalKVFetcher) close(context.Context) {}
+
+// appendSSTKVs appends the keys of an SST returned by an ExportRequest that
+// are not deleted to kvs. The deleted keys are passed to onDelete, if it is
+// not nil.
+func appendSSTKVs(
+	kvs []roachpb.KeyValue, sst []byte, onDelete func(key roachpb.Key) error,
+) ([]roachpb.KeyValue, error) {
+	iter, err := storage.NewMemSSTIterator(sst, false /* verify */)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for iter.SeekGE(storage.NilKey); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			return nil, err
+		} else if !ok {
+			return kvs, nil
+		}
+		key := iter.UnsafeKey()
+		unsafeValue := iter.UnsafeValue()
+		if len(unsafeValue) == 0 {
+			if onDelete != nil {
+				if err := onDelete(append(roachpb.Key(nil), key.Key...)); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		kvs = append(kvs, roachpb.KeyValue{
+			Key: append(roachpb.Key(nil), key.Key...),
+			Value: roachpb.Value{
+				RawBytes:  append([]byte(nil), unsafeValue...),
+				Timestamp: key.Timestamp,
+			},
+		})
+	}
+}
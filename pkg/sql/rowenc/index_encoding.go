@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/unique"
 	"github.com/cockroachdb/errors"
 )
@@ -596,12 +597,13 @@ func EncodeInvertedIndexPrefixKeys(
 }
 
 // EncodeInvertedIndexTableKeys produces one inverted index key per element in
-// the input datum, which should be a container (either JSON or Array). For
-// JSON, "element" means unique path through the document. Each output key is
-// prefixed by inKey, and is guaranteed to be lexicographically sortable, but
-// not guaranteed to be round-trippable during decoding. If the input Datum
-// is (SQL) NULL, no inverted index keys will be produced, because inverted
-// indexes cannot and do not need to satisfy the predicate col IS NULL.
+// the input datum, which should be a container (either JSON, Array or
+// TSVector). For JSON, "element" means unique path through the document, and
+// for TSVector it means lexeme. Each output key is prefixed by inKey, and is
+// guaranteed to be lexicographically sortable, but not guaranteed to be
+// round-trippable during decoding. If the input Datum is (SQL) NULL, no
+// inverted index keys will be produced, because inverted indexes cannot and do
+// not need to satisfy the predicate col IS NULL.
 //
 // This function does not return keys for empty arrays or for NULL array
 // elements unless the version is at least
@@ -623,6 +625,9 @@ func EncodeInvertedIndexTableKeys(
 		return json.EncodeInvertedIndexKeys(inKey, val.(*tree.DJSON).JSON)
 	case types.ArrayFamily:
 		return encodeArrayInvertedIndexTableKeys(val.(*tree.DArray), inKey, version, false /* excludeNulls */)
+	case types.TSVectorFamily:
+		// Like JSON, the keys of a TSVector don't depend on the version.
+		return tsearch.EncodeInvertedIndexKeys(inKey, val.(*tree.DTSVector).TSVector), nil
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType())
 }
@@ -102,8 +102,12 @@ func (sp *bulkRowWriter) work(ctx context.Context) error {
 	var g ctxgroup.Group
 
 	semaCtx := tree.MakeSemaContext()
+	var targetColNames tree.NameList
+	for _, name := range sp.spec.TargetColumns {
+		targetColNames = append(targetColNames, tree.Name(name))
+	}
 	conv, err := row.NewDatumRowConverter(
-		ctx, &semaCtx, sp.tableDesc, nil /* targetColNames */, sp.EvalCtx, kvCh, nil,
+		ctx, &semaCtx, sp.tableDesc, targetColNames, sp.EvalCtx, kvCh, nil,
 		/* seqChunkProvider */ sp.flowCtx.GetRowMetrics(),
 	)
 	if err != nil {
@@ -113,6 +117,12 @@ func (sp *bulkRowWriter) work(ctx context.Context) error {
 		panic("uninitialized session data")
 	}
 
+	if sp.spec.Transactional {
+		// The transaction cannot be used concurrently with the input, so the kvs
+		// are written by the goroutine that reads the input.
+		return sp.convertLoop(ctx, kvCh, conv)
+	}
+
 	g = ctxgroup.WithContext(ctx)
 	g.GoCtx(func(ctx context.Context) error {
 		return sp.ingestLoop(ctx, kvCh)
@@ -178,6 +188,30 @@ func (sp *bulkRowWriter) ingestLoop(ctx context.Context, kvCh chan row.KVBatch)
 	return nil
 }
 
+// writeInTxn writes the kv batches that are buffered in kvCh in the
+// transaction of the flow.
+func (sp *bulkRowWriter) writeInTxn(ctx context.Context, kvCh chan row.KVBatch) error {
+	txn := sp.flowCtx.Txn
+	for {
+		select {
+		case kvBatch := <-kvCh:
+			b := txn.NewBatch()
+			for i := range kvBatch.KVs {
+				kv := &kvBatch.KVs[i]
+				// The table is emptied before the rows are written, so an existing
+				// key is a duplicate.
+				b.CPut(kv.Key, &kv.Value, nil /* expValue */)
+				sp.summary.DataSize += int64(len(kv.Key) + len(kv.Value.RawBytes))
+			}
+			if err := txn.Run(ctx, b); err != nil {
+				return row.ConvertBatchError(ctx, sp.tableDesc, b)
+			}
+		default:
+			return nil
+		}
+	}
+}
+
 func (sp *bulkRowWriter) convertLoop(
 	ctx context.Context, kvCh chan row.KVBatch, conv *row.DatumRowConverter,
 ) error {
@@ -226,6 +260,11 @@ func (sp *bulkRowWriter) convertLoop(
 				return err
 			}
 			atomic.AddInt64(&sp.batchIdxAtomic, 1)
+			if sp.spec.Transactional {
+				if err := sp.writeInTxn(ctx, kvCh); err != nil {
+					return err
+				}
+			}
 		}
 		if rows < 1 {
 			break
@@ -234,6 +273,11 @@ func (sp *bulkRowWriter) convertLoop(
 		if err := conv.SendBatch(ctx); err != nil {
 			return err
 		}
+		if sp.spec.Transactional {
+			if err := sp.writeInTxn(ctx, kvCh); err != nil {
+				return err
+			}
+		}
 
 		if done {
 			break
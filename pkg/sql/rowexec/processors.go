@@ -296,6 +296,25 @@ func NewProcessor(
 		return NewParquetWriterProcessor(flowCtx, processorID, *core.ParquetWriter, inputs[0],
 			outputs[0])
 	}
+	if core.ExportManifestWriter != nil {
+		if err := checkNumInOut(inputs, outputs, 1, 1); err != nil {
+			return nil, err
+		}
+		if NewExportManifestWriterProcessor == nil {
+			return nil, errors.New("ExportManifestWriter processor unimplemented")
+		}
+		return NewExportManifestWriterProcessor(flowCtx, processorID, *core.ExportManifestWriter,
+			inputs[0], outputs[0])
+	}
+	if core.ForeignScan != nil {
+		if err := checkNumInOut(inputs, outputs, 0, 1); err != nil {
+			return nil, err
+		}
+		if NewForeignScanProcessor == nil {
+			return nil, errors.New("ForeignScan processor unimplemented")
+		}
+		return NewForeignScanProcessor(flowCtx, processorID, *core.ForeignScan, post, outputs[0])
+	}
 	if core.BulkRowWriter != nil {
 		if err := checkNumInOut(inputs, outputs, 1, 1); err != nil {
 			return nil, err
@@ -400,6 +419,12 @@ var NewCSVWriterProcessor func(*execinfra.FlowCtx, int32, execinfrapb.CSVWriterS
 // NewParquetWriterProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewParquetWriterProcessor func(*execinfra.FlowCtx, int32, execinfrapb.ParquetWriterSpec, execinfra.RowSource, execinfra.RowReceiver) (execinfra.Processor, error)
 
+// NewExportManifestWriterProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
+var NewExportManifestWriterProcessor func(*execinfra.FlowCtx, int32, execinfrapb.ExportManifestWriterSpec, execinfra.RowSource, execinfra.RowReceiver) (execinfra.Processor, error)
+
+// NewForeignScanProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
+var NewForeignScanProcessor func(*execinfra.FlowCtx, int32, execinfrapb.ForeignScanSpec, *execinfrapb.PostProcessSpec, execinfra.RowReceiver) (execinfra.Processor, error)
+
 // NewChangeAggregatorProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewChangeAggregatorProcessor func(*execinfra.FlowCtx, int32, execinfrapb.ChangeAggregatorSpec, *execinfrapb.PostProcessSpec, execinfra.RowReceiver) (execinfra.Processor, error)
 
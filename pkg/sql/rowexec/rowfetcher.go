@@ -48,6 +48,13 @@ type rowFetcher interface {
 		traceKV bool,
 		forceProductionKVBatchSize bool,
 	) error
+	StartIncrementalScan(
+		_ context.Context,
+		_ *kv.DB,
+		startTime, endTime hlc.Timestamp,
+		spans roachpb.Spans,
+		traceKV bool,
+	) error
 
 	NextRow(ctx context.Context) (
 		rowenc.EncDatumRow, catalog.TableDescriptor, catalog.Index, error)
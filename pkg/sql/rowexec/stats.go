@@ -150,6 +150,20 @@ func (c *rowFetcherStatCollector) StartInconsistentScan(
 	return err
 }
 
+// StartIncrementalScan is part of the rowFetcher interface.
+func (c *rowFetcherStatCollector) StartIncrementalScan(
+	ctx context.Context,
+	db *kv.DB,
+	startTime, endTime hlc.Timestamp,
+	spans roachpb.Spans,
+	traceKV bool,
+) error {
+	start := timeutil.Now()
+	err := c.Fetcher.StartIncrementalScan(ctx, db, startTime, endTime, spans, traceKV)
+	c.startScanStallTime += timeutil.Since(start)
+	return err
+}
+
 // getInputStats is a utility function to check whether the given input is
 // collecting stats, returning true and the stats if so. If false is returned,
 // the input is not collecting stats.
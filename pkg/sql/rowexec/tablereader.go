@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/optional"
 	"github.com/cockroachdb/errors"
@@ -44,6 +45,9 @@ type tableReader struct {
 	// See TableReaderSpec.MaxTimestampAgeNanos.
 	maxTimestampAge time.Duration
 
+	// See TableReaderSpec.IncrementalFrom.
+	incrementalFrom hlc.Timestamp
+
 	ignoreMisplannedRanges bool
 
 	// fetcher wraps a row.Fetcher, allowing the tableReader to add a stat
@@ -102,6 +106,7 @@ func newTableReader(
 	tr.parallelize = spec.Parallelize
 	tr.batchBytesLimit = batchBytesLimit
 	tr.maxTimestampAge = time.Duration(spec.MaxTimestampAgeNanos)
+	tr.incrementalFrom = spec.IncrementalFrom
 
 	tableDesc := spec.BuildTableDescriptor()
 	invertedColumn := tabledesc.FindInvertedColumn(tableDesc, spec.InvertedColumn)
@@ -211,7 +216,12 @@ func (tr *tableReader) startScan(ctx context.Context) error {
 	}
 	log.VEventf(ctx, 1, "starting scan with limitBatches %t", limitBatches)
 	var err error
-	if tr.maxTimestampAge == 0 {
+	if !tr.incrementalFrom.IsEmpty() {
+		err = tr.fetcher.StartIncrementalScan(
+			ctx, tr.FlowCtx.Cfg.DB, tr.incrementalFrom, tr.FlowCtx.Txn.ReadTimestamp(),
+			tr.Spans, tr.FlowCtx.TraceKV,
+		)
+	} else if tr.maxTimestampAge == 0 {
 		err = tr.fetcher.StartScan(
 			ctx, tr.FlowCtx.Txn, tr.Spans, bytesLimit, tr.limitHint,
 			tr.FlowCtx.TraceKV,
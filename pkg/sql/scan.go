@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
 )
 
@@ -109,6 +110,11 @@ type scanNode struct {
 	// order for this optimization to work, the DistSQL planner must create a
 	// local plan.
 	localityOptimized bool
+
+	// incrementalFrom, if set, restricts the scan to the rows that were written
+	// after it. It is set by incremental EXPORTs (see
+	// execinfrapb.TableReaderSpec.IncrementalFrom).
+	incrementalFrom hlc.Timestamp
 }
 
 // scanColumnsConfig controls the "schema" of a scan node.
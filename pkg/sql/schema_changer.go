@@ -242,11 +242,49 @@ func (sc *SchemaChanger) refreshMaterializedView(
 	// data only to the new desired indexes. In SchemaChanger.done(), we'll swap
 	// the indexes from the old versions into the new ones.
 	tableToRefresh := refresh.TableWithNewIndexes(table)
-	return sc.backfillQueryIntoTable(ctx, tableToRefresh, table.ViewQuery, refresh.AsOf(), "refreshView")
+	query, targetColumns := materializedViewBackfillQuery(table)
+	return sc.backfillQueryIntoTable(
+		ctx, tableToRefresh, query, targetColumns, refresh.AsOf(), false, /* transactional */
+		"refreshView",
+	)
+}
+
+// materializedViewBackfillQuery returns the query whose results are written
+// into the given materialized view, and the columns that they are written to.
+// For an incrementally maintained view, the query also computes the hidden
+// columns that are used to maintain the view, so all the columns of the view
+// except for its primary key are written.
+func materializedViewBackfillQuery(
+	table catalog.TableDescriptor,
+) (query string, targetColumns []string) {
+	if table.GetIncrementalViewQuery() == "" {
+		return table.GetViewQuery(), nil
+	}
+	keyCols := table.GetPrimaryIndex().CollectKeyColumnIDs()
+	for _, col := range table.PublicColumns() {
+		if !keyCols.Contains(col.GetID()) {
+			targetColumns = append(targetColumns, col.GetName())
+		}
+	}
+	return table.GetIncrementalViewQuery(), targetColumns
 }
 
+// backfillQueryIntoTable writes the results of the given query into the
+// given table. The results are written to targetColumns, or to the visible
+// columns of the table if it is empty.
+//
+// The query is normally run as of ts, and its results are ingested at that
+// timestamp. If transactional is set instead, the query is run in an ordinary
+// transaction which first deletes the existing rows of the table, and the
+// results are written in that transaction.
 func (sc *SchemaChanger) backfillQueryIntoTable(
-	ctx context.Context, table catalog.TableDescriptor, query string, ts hlc.Timestamp, desc string,
+	ctx context.Context,
+	table catalog.TableDescriptor,
+	query string,
+	targetColumns []string,
+	ts hlc.Timestamp,
+	transactional bool,
+	desc string,
 ) error {
 	if fn := sc.testingKnobs.RunBeforeQueryBackfill; fn != nil {
 		if err := fn(); err != nil {
@@ -255,7 +293,12 @@ func (sc *SchemaChanger) backfillQueryIntoTable(
 	}
 
 	return sc.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
-		if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
+		if transactional {
+			span := table.TableSpan(sc.execCfg.Codec)
+			if _, err := txn.DelRange(ctx, span.Key, span.EndKey, false /* returnKeys */); err != nil {
+				return err
+			}
+		} else if err := txn.SetFixedTimestamp(ctx, ts); err != nil {
 			return err
 		}
 
@@ -337,13 +380,17 @@ func (sc *SchemaChanger) backfillQueryIntoTable(
 				}
 			}
 
-			isLocal := !getPlanDistribution(
+			// The results can only be written in the transaction on the gateway,
+			// which is the only node that uses the root transaction.
+			isLocal := transactional || !getPlanDistribution(
 				ctx, localPlanner, localPlanner.execCfg.NodeID,
 				localPlanner.extendedEvalCtx.SessionData().DistSQLMode,
 				localPlanner.curPlan.main,
 			).WillDistribute()
 			out := execinfrapb.ProcessorCoreUnion{BulkRowWriter: &execinfrapb.BulkRowWriterSpec{
-				Table: *table.TableDesc(),
+				Table:         *table.TableDesc(),
+				TargetColumns: targetColumns,
+				Transactional: transactional,
 			}}
 
 			PlanAndRunCTAS(ctx, sc.distSQLPlanner, localPlanner,
@@ -372,7 +419,10 @@ func (sc *SchemaChanger) maybeBackfillCreateTableAs(
 	}
 	log.Infof(ctx, "starting backfill for CREATE TABLE AS with query %q", table.GetCreateQuery())
 
-	return sc.backfillQueryIntoTable(ctx, table, table.GetCreateQuery(), table.GetCreateAsOfTime(), "ctasBackfill")
+	return sc.backfillQueryIntoTable(
+		ctx, table, table.GetCreateQuery(), nil /* targetColumns */, table.GetCreateAsOfTime(),
+		false /* transactional */, "ctasBackfill",
+	)
 }
 
 func (sc *SchemaChanger) maybeBackfillMaterializedView(
@@ -381,9 +431,46 @@ func (sc *SchemaChanger) maybeBackfillMaterializedView(
 	if !(table.Adding() && table.MaterializedView()) {
 		return nil
 	}
-	log.Infof(ctx, "starting backfill for CREATE MATERIALIZED VIEW with query %q", table.GetViewQuery())
+	if table.GetIncrementalViewQuery() != "" {
+		return sc.backfillIncrementalView(ctx, table)
+	}
+	query, targetColumns := materializedViewBackfillQuery(table)
+	log.Infof(ctx, "starting backfill for CREATE MATERIALIZED VIEW with query %q", query)
+
+	return sc.backfillQueryIntoTable(
+		ctx, table, query, targetColumns, table.GetCreateAsOfTime(), false, /* transactional */
+		"materializedViewBackfill",
+	)
+}
+
+// backfillIncrementalView populates an incrementally maintained materialized
+// view. Unlike the backfill of other materialized views, it does not block
+// the writes to the tables the view depends on: these writes maintain the view
+// while it is being added.
+//
+// The back-references from the tables to the view are written when the view is
+// created, so once every node has leased the new versions of the tables, every
+// write that commits maintains the view. The view is then populated in a
+// transaction which replaces its contents with the result of its query. The
+// transaction reads the tables and the view at the same timestamp, so it
+// discards the changes to the view made by the writes that its query observes,
+// and the writes that it does not observe are ordered after it and maintain its
+// results.
+func (sc *SchemaChanger) backfillIncrementalView(
+	ctx context.Context, table catalog.TableDescriptor,
+) error {
+	for _, id := range table.GetDependsOn() {
+		if _, err := WaitToUpdateLeases(ctx, sc.leaseMgr, id); err != nil {
+			return err
+		}
+	}
+	query, targetColumns := materializedViewBackfillQuery(table)
+	log.Infof(ctx, "starting backfill for incrementally maintained view with query %q", query)
 
-	return sc.backfillQueryIntoTable(ctx, table, table.GetViewQuery(), table.GetCreateAsOfTime(), "materializedViewBackfill")
+	return sc.backfillQueryIntoTable(
+		ctx, table, query, targetColumns, hlc.Timestamp{}, true, /* transactional */
+		"incrementalViewBackfill",
+	)
 }
 
 // maybe make a table PUBLIC if it's in the ADD state.
@@ -1699,6 +1786,20 @@ func (sc *SchemaChanger) maybeDropValidatingConstraint(
 			"attempted to drop constraint %s, but it hadn't been added to the table descriptor yet",
 			constraint.UniqueWithoutIndex().Name,
 		)
+	} else if constraint.IsExclusion() {
+		for j, c := range desc.ExclusionConstraints {
+			if c.Name == constraint.Exclusion().Name {
+				desc.ExclusionConstraints = append(
+					desc.ExclusionConstraints[:j], desc.ExclusionConstraints[j+1:]...,
+				)
+				return nil
+			}
+		}
+		log.Infof(
+			ctx,
+			"attempted to drop constraint %s, but it hadn't been added to the table descriptor yet",
+			constraint.Exclusion().Name,
+		)
 	} else {
 		return errors.AssertionFailedf("unsupported constraint type: %d", constraint.ConstraintToUpdateDesc().ConstraintType)
 	}
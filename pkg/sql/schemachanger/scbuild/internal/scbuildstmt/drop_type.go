@@ -52,6 +52,7 @@ func dropType(b BuildCtx, typ catalog.TypeDescriptor, behavior tree.DropBehavior
 		return
 	case descpb.TypeDescriptor_ENUM:
 		sqltelemetry.IncrementEnumCounter(sqltelemetry.EnumDrop)
+	case descpb.TypeDescriptor_DOMAIN:
 	default:
 		panic(errors.AssertionFailedf("unexpected kind %s for type %q", typ.GetKind(), typ.GetName()))
 	}
@@ -77,11 +78,6 @@ func dropType(b BuildCtx, typ catalog.TypeDescriptor, behavior tree.DropBehavior
 	}
 
 	canDrop(typ)
-	// Get the arrayType type that needs to be dropped as well.
-	arrayType := b.MustReadType(typ.GetArrayTypeID())
-	// Ensure that we can drop the arrayType type as well.
-	canDrop(arrayType)
-	// Create drop elements for both.
 	b.EnqueueDrop(&scpb.Type{TypeID: typ.GetID()})
 	b.EnqueueDrop(&scpb.Namespace{
 		DatabaseID:   typ.GetParentID(),
@@ -89,6 +85,14 @@ func dropType(b BuildCtx, typ catalog.TypeDescriptor, behavior tree.DropBehavior
 		DescriptorID: typ.GetID(),
 		Name:         typ.GetName(),
 	})
+	// Domains don't have an implicit array type.
+	if typ.GetKind() == descpb.TypeDescriptor_DOMAIN {
+		return
+	}
+	// Get the arrayType type that needs to be dropped as well.
+	arrayType := b.MustReadType(typ.GetArrayTypeID())
+	// Ensure that we can drop the arrayType type as well.
+	canDrop(arrayType)
 	b.EnqueueDrop(&scpb.Type{TypeID: arrayType.GetID()})
 	b.EnqueueDrop(&scpb.Namespace{
 		DatabaseID:   arrayType.GetParentID(),
@@ -175,6 +175,22 @@ var generators = map[string]builtinDefinition{
 		),
 	),
 
+	// See https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-INFO-SESSION-TABLE
+	"pg_listening_channels": makeBuiltin(
+		tree.FunctionProperties{
+			Class:            tree.GeneratorClass,
+			Category:         categoryGenerator,
+			DistsqlBlocklist: true,
+		},
+		makeGeneratorOverload(
+			tree.ArgTypes{},
+			types.String,
+			makeListeningChannelsGenerator,
+			"Returns the names of the channels the current session is listening on.",
+			tree.VolatilityVolatile,
+		),
+	),
+
 	"regexp_split_to_table": makeBuiltin(
 		genProps(),
 		makeGeneratorOverload(
@@ -887,6 +903,18 @@ func (s *arrayValueGenerator) Values() (tree.Datums, error) {
 	return tree.Datums{s.array.Array[s.nextIndex]}, nil
 }
 
+func makeListeningChannelsGenerator(
+	ctx *tree.EvalContext, _ tree.Datums,
+) (tree.ValueGenerator, error) {
+	arr := tree.NewDArray(types.String)
+	for _, channel := range ctx.Planner.ListeningChannels() {
+		if err := arr.Append(tree.NewDString(channel)); err != nil {
+			return nil, err
+		}
+	}
+	return &arrayValueGenerator{array: arr}, nil
+}
+
 func makeExpandArrayGenerator(
 	evalCtx *tree.EvalContext, args tree.Datums,
 ) (tree.ValueGenerator, error) {
@@ -0,0 +1,242 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+func initTSearchBuiltins() {
+	// Add all tsearchBuiltins to the Builtins map after a sanity check.
+	for k, v := range tsearchBuiltins {
+		if _, exists := builtins[k]; exists {
+			panic("duplicate builtin: " + k)
+		}
+		builtins[k] = v
+	}
+}
+
+// tsearchBuiltins contains the full text search built-in functions indexed
+// by name.
+//
+// For use in other packages, see AllBuiltinNames and GetBuiltinProperties().
+var tsearchBuiltins = map[string]builtinDefinition{
+	"to_tsvector": makeTSearchConfigBuiltin(
+		"document",
+		types.TSVector,
+		func(config, s string) (tree.Datum, error) {
+			v, err := tsearch.DocumentToTSVector(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSVector(v), nil
+		},
+		"Converts text to a tsvector, normalizing the words according to the given "+
+			"or default text search configuration.",
+	),
+	"to_tsquery": makeTSearchConfigBuiltin(
+		"query",
+		types.TSQuery,
+		func(config, s string) (tree.Datum, error) {
+			q, err := tsearch.ToTSQuery(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		},
+		"Converts the input text into a tsquery, normalizing the words according "+
+			"to the given or default text search configuration. The input must "+
+			"already be formatted like a tsquery, in other words, subsequent "+
+			"tokens must be connected by a tsquery operator (&, |, <->, !).",
+	),
+	"plainto_tsquery": makeTSearchConfigBuiltin(
+		"text",
+		types.TSQuery,
+		func(config, s string) (tree.Datum, error) {
+			q, err := tsearch.PlainToTSQuery(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		},
+		"Converts text to a tsquery, normalizing the words according to the given "+
+			"or default text search configuration. The & operator is inserted "+
+			"between each token in the input.",
+	),
+	"phraseto_tsquery": makeTSearchConfigBuiltin(
+		"text",
+		types.TSQuery,
+		func(config, s string) (tree.Datum, error) {
+			q, err := tsearch.PhraseToTSQuery(config, s)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDTSQuery(q), nil
+		},
+		"Converts text to a tsquery, normalizing the words according to the given "+
+			"or default text search configuration. The <-> operator is inserted "+
+			"between each token in the input.",
+	),
+
+	"ts_match_qv": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"query", types.TSQuery}, {"vector", types.TSVector}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				q, v := tree.MustBeDTSQuery(args[0]), tree.MustBeDTSVector(args[1])
+				return tree.MakeDBool(tree.DBool(tsearch.EvalTSQuery(q.TSQuery, v.TSVector))), nil
+			},
+			Info:       "Returns true if the tsvector matches the tsquery. This is the tsquery @@ tsvector operator.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+	"ts_match_vq": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				v, q := tree.MustBeDTSVector(args[0]), tree.MustBeDTSQuery(args[1])
+				return tree.MakeDBool(tree.DBool(tsearch.EvalTSQuery(q.TSQuery, v.TSVector))), nil
+			},
+			Info:       "Returns true if the tsvector matches the tsquery. This is the tsvector @@ tsquery operator.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	"ts_rank": makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"vector", types.TSVector}, {"query", types.TSQuery}},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsRank(tree.DNull, args[0], args[1], tree.NewDInt(0))
+			},
+			Info:       tsRankInfo,
+			Volatility: tree.VolatilityImmutable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"vector", types.TSVector},
+				{"query", types.TSQuery},
+				{"normalization", types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsRank(tree.DNull, args[0], args[1], args[2])
+			},
+			Info:       tsRankInfo,
+			Volatility: tree.VolatilityImmutable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"weights", types.FloatArray},
+				{"vector", types.TSVector},
+				{"query", types.TSQuery},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsRank(args[0], args[1], args[2], tree.NewDInt(0))
+			},
+			Info:       tsRankInfo,
+			Volatility: tree.VolatilityImmutable,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"weights", types.FloatArray},
+				{"vector", types.TSVector},
+				{"query", types.TSQuery},
+				{"normalization", types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Float4),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tsRank(args[0], args[1], args[2], args[3])
+			},
+			Info:       tsRankInfo,
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+}
+
+const tsRankInfo = "Ranks the tsvector by how well it matches the tsquery, based on " +
+	"the frequency of its matching lexemes. The weights of the D, C, B and A " +
+	"positions default to {0.1, 0.2, 0.4, 1.0}. The normalization is a bit mask " +
+	"specifying how the rank is adjusted to the length of the document: 1 divides " +
+	"it by 1 + the logarithm of the length, 2 by the length, 8 by the number of " +
+	"unique words, 16 by 1 + the logarithm of the number of unique words and " +
+	"32 by itself + 1."
+
+// makeTSearchConfigBuiltin returns the definition of a builtin converting its
+// text argument with fn, according to either the given text search
+// configuration or the default one.
+func makeTSearchConfigBuiltin(
+	argName string, retType *types.T, fn func(config, s string) (tree.Datum, error), info string,
+) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{Category: categoryFullTextSearch},
+		tree.Overload{
+			Types:      tree.ArgTypes{{argName, types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return fn(tsearch.DefaultConfig, string(tree.MustBeDString(args[0])))
+			},
+			Info:       info,
+			Volatility: tree.VolatilityImmutable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"config", types.String}, {argName, types.String}},
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return fn(string(tree.MustBeDString(args[0])), string(tree.MustBeDString(args[1])))
+			},
+			Info:       info,
+			Volatility: tree.VolatilityImmutable,
+		},
+	)
+}
+
+// tsRank implements ts_rank. weights is either DNull, for the default weights,
+// or an array of at least 4 weights, a negative weight standing for the
+// default one.
+func tsRank(weights, vector, query, normalization tree.Datum) (tree.Datum, error) {
+	w := tsearch.DefaultRankWeights
+	if weights != tree.DNull {
+		arr := tree.MustBeDArray(weights)
+		if len(arr.Array) < len(tsearch.DefaultRankWeights) {
+			return nil, pgerror.New(pgcode.ArraySubscript, "array of weight is too short")
+		}
+		w = make([]float64, len(tsearch.DefaultRankWeights))
+		for i := range w {
+			if arr.Array[i] == tree.DNull {
+				return nil, pgerror.New(pgcode.NullValueNotAllowed, "array of weight must not contain nulls")
+			}
+			w[i] = float64(tree.MustBeDFloat(arr.Array[i]))
+			if w[i] < 0 {
+				w[i] = tsearch.DefaultRankWeights[i]
+			}
+		}
+	}
+	rank, err := tsearch.Rank(
+		w,
+		tree.MustBeDTSVector(vector).TSVector,
+		tree.MustBeDTSQuery(query).TSQuery,
+		int(tree.MustBeDInt(normalization)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDFloat(tree.DFloat(rank)), nil
+}
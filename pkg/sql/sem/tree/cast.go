@@ -190,6 +190,8 @@ var castMap = map[oid.Oid]map[oid.Oid]cast{
 		oid.T_timestamp:    {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timestamptz:  {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timetz:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsquery:      {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsvector:     {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_uuid:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_varbit:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_void:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
@@ -238,6 +240,8 @@ var castMap = map[oid.Oid]map[oid.Oid]cast{
 		oid.T_timestamp:    {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timestamptz:  {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timetz:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsquery:      {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsvector:     {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_uuid:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_varbit:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_void:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
@@ -432,6 +436,8 @@ var castMap = map[oid.Oid]map[oid.Oid]cast{
 		oid.T_timestamp:    {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timestamptz:  {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timetz:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsquery:      {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsvector:     {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_uuid:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_varbit:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_void:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
@@ -579,6 +585,8 @@ var castMap = map[oid.Oid]map[oid.Oid]cast{
 		oid.T_timestamp:    {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timestamptz:  {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timetz:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsquery:      {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsvector:     {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_uuid:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_varbit:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_void:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
@@ -629,6 +637,22 @@ var castMap = map[oid.Oid]map[oid.Oid]cast{
 		oid.T_text:    {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
 		oid.T_varchar: {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
 	},
+	oid.T_tsquery: {
+		// Automatic I/O conversions to string types.
+		oid.T_bpchar:  {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_char:    {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_name:    {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_text:    {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_varchar: {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+	},
+	oid.T_tsvector: {
+		// Automatic I/O conversions to string types.
+		oid.T_bpchar:  {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_char:    {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_name:    {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_text:    {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+		oid.T_varchar: {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
+	},
 	oid.T_uuid: {
 		// Automatic I/O conversions to string types.
 		oid.T_bpchar:  {maxContext: CastContextAssignment, origin: contextOriginAutomaticIOConversion},
@@ -681,6 +705,8 @@ var castMap = map[oid.Oid]map[oid.Oid]cast{
 		oid.T_timestamp:    {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timestamptz:  {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_timetz:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsquery:      {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
+		oid.T_tsvector:     {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_uuid:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_varbit:       {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
 		oid.T_void:         {maxContext: CastContextExplicit, origin: contextOriginAutomaticIOConversion},
@@ -1018,6 +1044,8 @@ var validCasts = []castInfo{
 	{from: types.JsonFamily, to: types.StringFamily, volatility: VolatilityImmutable},
 	{from: types.EnumFamily, to: types.StringFamily, volatility: VolatilityImmutable},
 	{from: types.VoidFamily, to: types.StringFamily, volatility: VolatilityImmutable},
+	{from: types.TSQueryFamily, to: types.StringFamily, volatility: VolatilityImmutable},
+	{from: types.TSVectorFamily, to: types.StringFamily, volatility: VolatilityImmutable},
 
 	// Casts to CollatedStringFamily.
 	{from: types.UnknownFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
@@ -1045,6 +1073,8 @@ var validCasts = []castInfo{
 	{from: types.INetFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
 	{from: types.JsonFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
 	{from: types.EnumFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
+	{from: types.TSQueryFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
+	{from: types.TSVectorFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
 
 	// Casts to BytesFamily.
 	{from: types.UnknownFamily, to: types.BytesFamily, volatility: VolatilityImmutable},
@@ -1185,6 +1215,18 @@ var validCasts = []castInfo{
 	// Casts to VoidFamily.
 	{from: types.UnknownFamily, to: types.VoidFamily, volatility: VolatilityImmutable},
 	{from: types.StringFamily, to: types.VoidFamily, volatility: VolatilityImmutable},
+
+	// Casts to TSQueryFamily.
+	{from: types.UnknownFamily, to: types.TSQueryFamily, volatility: VolatilityImmutable},
+	{from: types.StringFamily, to: types.TSQueryFamily, volatility: VolatilityImmutable},
+	{from: types.CollatedStringFamily, to: types.TSQueryFamily, volatility: VolatilityImmutable},
+	{from: types.TSQueryFamily, to: types.TSQueryFamily, volatility: VolatilityImmutable},
+
+	// Casts to TSVectorFamily.
+	{from: types.UnknownFamily, to: types.TSVectorFamily, volatility: VolatilityImmutable},
+	{from: types.StringFamily, to: types.TSVectorFamily, volatility: VolatilityImmutable},
+	{from: types.CollatedStringFamily, to: types.TSVectorFamily, volatility: VolatilityImmutable},
+	{from: types.TSVectorFamily, to: types.TSVectorFamily, volatility: VolatilityImmutable},
 }
 
 type castsMapKey struct {
@@ -1918,6 +1960,10 @@ func performCastWithoutPrecisionTruncation(
 			s = t.String()
 		case *DJSON:
 			s = t.JSON.String()
+		case *DTSQuery:
+			s = t.TSQuery.String()
+		case *DTSVector:
+			s = t.TSVector.String()
 		case *DEnum:
 			s = t.LogicalRep
 		case *DVoid:
@@ -2357,6 +2403,26 @@ func performCastWithoutPrecisionTruncation(
 		case *DString:
 			return DVoidDatum, nil
 		}
+
+	case types.TSQueryFamily:
+		switch d := d.(type) {
+		case *DString:
+			return ParseDTSQuery(string(*d))
+		case *DCollatedString:
+			return ParseDTSQuery(d.Contents)
+		case *DTSQuery:
+			return d, nil
+		}
+
+	case types.TSVectorFamily:
+		switch d := d.(type) {
+		case *DString:
+			return ParseDTSVector(string(*d))
+		case *DCollatedString:
+			return ParseDTSVector(d.Contents)
+		case *DTSVector:
+			return d, nil
+		}
 	}
 
 	return nil, pgerror.Newf(
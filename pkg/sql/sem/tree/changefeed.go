@@ -15,6 +15,13 @@ type CreateChangefeed struct {
 	Targets TargetList
 	SinkURI Expr
 	Options KVOptions
+	// Select, if non-nil, is the list of expressions emitted for each row of
+	// the (single) target table instead of all of its columns. It is only set
+	// by the `CREATE CHANGEFEED ... AS SELECT` form of the statement.
+	Select SelectExprs
+	// Where, if non-nil, restricts the changefeed to the rows of the (single)
+	// target table which satisfy its predicate.
+	Where *Where
 }
 
 var _ Statement = &CreateChangefeed{}
@@ -28,8 +35,15 @@ func (node *CreateChangefeed) Format(ctx *FmtCtx) {
 		// prefix. They're also still EXPERIMENTAL, so they get marked as such.
 		ctx.WriteString("EXPERIMENTAL ")
 	}
-	ctx.WriteString("CHANGEFEED FOR ")
-	ctx.FormatNode(&node.Targets)
+	ctx.WriteString("CHANGEFEED")
+	if node.Select == nil {
+		ctx.WriteString(" FOR ")
+		ctx.FormatNode(&node.Targets)
+		if node.Where != nil {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(node.Where)
+		}
+	}
 	if node.SinkURI != nil {
 		ctx.WriteString(" INTO ")
 		ctx.FormatNode(node.SinkURI)
@@ -38,4 +52,14 @@ func (node *CreateChangefeed) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
 	}
+	if node.Select != nil {
+		ctx.WriteString(" AS SELECT ")
+		ctx.FormatNode(&node.Select)
+		ctx.WriteString(" FROM ")
+		ctx.FormatNode(node.Targets.Tables[0])
+		if node.Where != nil {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(node.Where)
+		}
+	}
 }
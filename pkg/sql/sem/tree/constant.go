@@ -499,6 +499,8 @@ var (
 		types.UUIDArray,
 		types.INet,
 		types.Jsonb,
+		types.TSQuery,
+		types.TSVector,
 		types.VarBit,
 		types.AnyEnum,
 		types.AnyEnumArray,
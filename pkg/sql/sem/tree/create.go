@@ -348,6 +348,201 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// CreateDomain represents a CREATE DOMAIN statement.
+type CreateDomain struct {
+	TypeName *UnresolvedObjectName
+	BaseType ResolvableTypeReference
+	// Default is the default value of the columns of the domain type which
+	// don't have a default value of their own. It is nil if the domain has no
+	// DEFAULT clause.
+	Default Expr
+	// NotNull is true if NULL values are not allowed by the domain.
+	NotNull bool
+	// Checks are the CHECK constraints of the domain. Their expressions refer
+	// to the value being checked as VALUE.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a CHECK constraint of a domain.
+type DomainCheck struct {
+	// Name is empty if the constraint was not named.
+	Name Name
+	Expr Expr
+}
+
+// NewCreateDomain constructs a CREATE DOMAIN statement from the DEFAULT, NULL,
+// NOT NULL and CHECK qualifications of the domain.
+func NewCreateDomain(
+	name *UnresolvedObjectName,
+	baseType ResolvableTypeReference,
+	qualifications []NamedColumnQualification,
+) (*CreateDomain, error) {
+	d := &CreateDomain{
+		TypeName: name,
+		BaseType: baseType,
+	}
+	nullability := SilentNull
+	for _, c := range qualifications {
+		switch t := c.Qualification.(type) {
+		case *ColumnDefault:
+			if d.Default != nil {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"multiple default expressions for domain %q", name.Object())
+			}
+			d.Default = t.Expr
+		case NotNullConstraint:
+			if nullability == Null {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"conflicting NULL/NOT NULL constraints for domain %q", name.Object())
+			}
+			nullability = NotNull
+			d.NotNull = true
+		case NullConstraint:
+			if nullability == NotNull {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"conflicting NULL/NOT NULL constraints for domain %q", name.Object())
+			}
+			nullability = Null
+		case *ColumnCheckConstraint:
+			d.Checks = append(d.Checks, DomainCheck{Name: c.Name, Expr: t.Expr})
+		default:
+			return nil, errors.AssertionFailedf("unexpected domain qualification %T", t)
+		}
+	}
+	return d, nil
+}
+
+var _ Statement = &CreateDomain{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.TypeName)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.BaseType)
+	if node.Default != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.Default)
+	}
+	if node.NotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	for i := range node.Checks {
+		if node.Checks[i].Name != "" {
+			ctx.WriteString(" CONSTRAINT ")
+			ctx.FormatNode(&node.Checks[i].Name)
+		}
+		ctx.WriteString(" CHECK (")
+		ctx.FormatNode(node.Checks[i].Expr)
+		ctx.WriteByte(')')
+	}
+}
+
+func (node *CreateDomain) String() string {
+	return AsString(node)
+}
+
+// ForeignOption is an option of a foreign server or foreign table, like the
+// URI of a server or the format of a table.
+type ForeignOption struct {
+	Key   Name
+	Value string
+}
+
+// Format implements the NodeFormatter interface.
+func (o *ForeignOption) Format(ctx *FmtCtx) {
+	// Option keys never contain PII and should be distinguished for feature
+	// tracking purposes.
+	ctx.WithFlags(ctx.flags&^FmtAnonymize&^FmtMarkRedactionNode, func() {
+		ctx.FormatNode(&o.Key)
+	})
+	ctx.WriteByte(' ')
+	if ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLString(&ctx.Buffer, o.Value)
+	}
+}
+
+// ForeignOptions represents the OPTIONS clause of a CREATE SERVER or CREATE
+// FOREIGN TABLE statement.
+type ForeignOptions []ForeignOption
+
+// Format implements the NodeFormatter interface.
+func (o *ForeignOptions) Format(ctx *FmtCtx) {
+	ctx.WriteString("OPTIONS (")
+	for i := range *o {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*o)[i])
+	}
+	ctx.WriteByte(')')
+}
+
+// CreateServer represents a CREATE SERVER statement.
+type CreateServer struct {
+	Name        Name
+	IfNotExists bool
+	// Wrapper is the foreign data wrapper used to access the server.
+	Wrapper Name
+	Options ForeignOptions
+}
+
+var _ Statement = &CreateServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SERVER ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" FOREIGN DATA WRAPPER ")
+	ctx.FormatNode(&node.Wrapper)
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+func (node *CreateServer) String() string {
+	return AsString(node)
+}
+
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	Table       TableName
+	IfNotExists bool
+	Defs        TableDefs
+	// Server is the foreign server from which the rows of the table are read.
+	Server  Name
+	Options ForeignOptions
+}
+
+var _ Statement = &CreateForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteString(") SERVER ")
+	ctx.FormatNode(&node.Server)
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+func (node *CreateForeignTable) String() string {
+	return AsString(node)
+}
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -362,6 +557,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExclusionConstraintTableDef) tableDef()  {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -439,6 +635,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrable     ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -620,6 +817,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrable = t.Deferrable
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -773,6 +971,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrable)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -899,10 +1098,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table      TableName
+	Col        Name // empty-string means use PK
+	Actions    ReferenceActions
+	Match      CompositeKeyMatchMethod
+	Deferrable ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -985,6 +1185,7 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExclusionConstraintTableDef) constraintTableDef()  {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -993,6 +1194,7 @@ type UniqueConstraintTableDef struct {
 	PrimaryKey   bool
 	WithoutIndex bool
 	IfNotExists  bool
+	Deferrable   ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1037,6 +1239,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrable)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1110,6 +1313,35 @@ func (c CompositeKeyMatchMethod) String() string {
 	return compositeKeyMatchMethodName[c]
 }
 
+// ConstraintDeferrability determines whether the checks of a constraint can be
+// deferred until the end of the transaction with SET CONSTRAINTS.
+type ConstraintDeferrability int
+
+// The values for ConstraintDeferrability.
+const (
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+var constraintDeferrabilityName = [...]string{
+	ConstraintNotDeferrable:      "NOT DEFERRABLE",
+	ConstraintInitiallyImmediate: "DEFERRABLE INITIALLY IMMEDIATE",
+	ConstraintInitiallyDeferred:  "DEFERRABLE INITIALLY DEFERRED",
+}
+
+func (d ConstraintDeferrability) String() string {
+	return constraintDeferrabilityName[d]
+}
+
+// Format implements the NodeFormatter interface.
+func (d *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if *d != ConstraintNotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.WriteString(d.String())
+	}
+}
+
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
 	Name        Name
@@ -1119,6 +1351,7 @@ type ForeignKeyConstraintTableDef struct {
 	Actions     ReferenceActions
 	Match       CompositeKeyMatchMethod
 	IfNotExists bool
+	Deferrable  ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1149,6 +1382,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrable)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1195,6 +1429,80 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExclusionConstraintTableDef represents an exclusion constraint within a
+// CREATE TABLE statement.
+type ExclusionConstraintTableDef struct {
+	Name Name
+	// Method is the index access method of the USING clause, or empty if the
+	// clause is omitted.
+	Method      Name
+	Elems       ExclusionConstraintElemList
+	Predicate   Expr
+	IfNotExists bool
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Method != "" {
+		ctx.WriteString("USING ")
+		ctx.WriteString(strings.ToUpper(string(node.Method)))
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+}
+
+// ExclusionConstraintElem is an element of an exclusion constraint, along with
+// the operator used to compare its values.
+type ExclusionConstraintElem struct {
+	IndexElem
+	Operator ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.IndexElem)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExclusionConstraintElemList is a list of ExclusionConstraintElem.
+type ExclusionConstraintElemList []ExclusionConstraintElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExclusionConstraintElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1538,12 +1846,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:      *col.References.Table,
+					FromCols:   NameList{col.Name},
+					ToCols:     targetCol,
+					Name:       col.References.ConstraintName,
+					Actions:    col.References.Actions,
+					Match:      col.References.Match,
+					Deferrable: col.References.Deferrable,
 				})
 				col.References.Table = nil
 			}
@@ -1893,6 +2202,8 @@ type CreateView struct {
 	Persistence  Persistence
 	Replace      bool
 	Materialized bool
+	// Params are the storage parameters of a materialized view.
+	Params StorageParams
 }
 
 // Format implements the NodeFormatter interface.
@@ -1925,6 +2236,12 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteByte(')')
 	}
 
+	if node.Params != nil {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.Params)
+		ctx.WriteByte(')')
+	}
+
 	ctx.WriteString(" AS ")
 	ctx.FormatNode(node.AsSource)
 }
@@ -49,6 +49,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -2175,6 +2176,8 @@ var CmpOps = cmpOpFixups(map[ComparisonOperatorSymbol]cmpOpOverload{
 		makeEqFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeEqFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeEqFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeEqFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeEqFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeEqFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeEqFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2231,6 +2234,8 @@ var CmpOps = cmpOpFixups(map[ComparisonOperatorSymbol]cmpOpOverload{
 		makeLtFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeLtFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeLtFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeLtFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeLtFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeLtFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeLtFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2287,6 +2292,8 @@ var CmpOps = cmpOpFixups(map[ComparisonOperatorSymbol]cmpOpOverload{
 		makeLeFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeLeFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeLeFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeLeFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeLeFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeLeFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeLeFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2360,6 +2367,8 @@ var CmpOps = cmpOpFixups(map[ComparisonOperatorSymbol]cmpOpOverload{
 		makeIsFn(types.TimeTZ, types.TimeTZ, VolatilityLeakProof),
 		makeIsFn(types.Timestamp, types.Timestamp, VolatilityLeakProof),
 		makeIsFn(types.TimestampTZ, types.TimestampTZ, VolatilityLeakProof),
+		makeIsFn(types.TSQuery, types.TSQuery, VolatilityImmutable),
+		makeIsFn(types.TSVector, types.TSVector, VolatilityImmutable),
 		makeIsFn(types.Uuid, types.Uuid, VolatilityLeakProof),
 		makeIsFn(types.VarBit, types.VarBit, VolatilityLeakProof),
 
@@ -2418,6 +2427,8 @@ var CmpOps = cmpOpFixups(map[ComparisonOperatorSymbol]cmpOpOverload{
 		makeEvalTupleIn(types.TimeTZ, VolatilityLeakProof),
 		makeEvalTupleIn(types.Timestamp, VolatilityLeakProof),
 		makeEvalTupleIn(types.TimestampTZ, VolatilityLeakProof),
+		makeEvalTupleIn(types.TSQuery, VolatilityLeakProof),
+		makeEvalTupleIn(types.TSVector, VolatilityLeakProof),
 		makeEvalTupleIn(types.Uuid, VolatilityLeakProof),
 		makeEvalTupleIn(types.VarBit, VolatilityLeakProof),
 	},
@@ -2632,6 +2643,41 @@ var CmpOps = cmpOpFixups(map[ComparisonOperatorSymbol]cmpOpOverload{
 			},
 		)...,
 	),
+
+	TSMatches: {
+		&CmpOp{
+			LeftType:  types.TSVector,
+			RightType: types.TSQuery,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(tsearch.EvalTSQuery(
+					MustBeDTSQuery(right).TSQuery, MustBeDTSVector(left).TSVector,
+				))), nil
+			},
+			Volatility: VolatilityImmutable,
+		},
+		&CmpOp{
+			LeftType:  types.TSQuery,
+			RightType: types.TSVector,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				return MakeDBool(DBool(tsearch.EvalTSQuery(
+					MustBeDTSQuery(left).TSQuery, MustBeDTSVector(right).TSVector,
+				))), nil
+			},
+			Volatility: VolatilityImmutable,
+		},
+		&CmpOp{
+			LeftType:  types.String,
+			RightType: types.TSQuery,
+			Fn: func(_ *EvalContext, left Datum, right Datum) (Datum, error) {
+				v, err := tsearch.DocumentToTSVector(tsearch.DefaultConfig, string(MustBeDString(left)))
+				if err != nil {
+					return nil, err
+				}
+				return MakeDBool(DBool(tsearch.EvalTSQuery(MustBeDTSQuery(right).TSQuery, v))), nil
+			},
+			Volatility: VolatilityImmutable,
+		},
+	},
 })
 
 const experimentalBox2DClusterSettingName = "sql.spatial.experimental_box2d_comparison_operators.enabled"
@@ -3233,6 +3279,15 @@ type EvalPlanner interface {
 	// DecodeGist exposes gist functionality to the builtin functions.
 	DecodeGist(gist string) ([]string, error)
 
+	// SendNotification sends a notification on the given channel, like NOTIFY.
+	// The notification is delivered to the listeners when the transaction
+	// commits.
+	SendNotification(ctx context.Context, channel, payload string) error
+
+	// ListeningChannels returns the sorted names of the channels the session
+	// listens on.
+	ListeningChannels() []string
+
 	// QueryRowEx executes the supplied SQL statement and returns a single row, or
 	// nil if no row is found, or an error if more that one row is returned.
 	//
@@ -4640,6 +4695,16 @@ func (t *DBox2D) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DTSQuery) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
+// Eval implements the TypedExpr interface.
+func (t *DTSVector) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DGeography) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -174,6 +174,22 @@ func NewFunctionDefinition(
 	}
 }
 
+// NewUDFFunctionDefinition returns the definition of a user-defined function
+// with the given overloads. Unlike builtins, user-defined functions are given
+// the chance to see NULL arguments; whether they return NULL in that case is
+// determined by the CalledOnNullInput property of each overload.
+func NewUDFFunctionDefinition(name string, def []*Overload) *FunctionDefinition {
+	overloads := make([]overloadImpl, len(def))
+	for i := range def {
+		overloads[i] = def[i]
+	}
+	return &FunctionDefinition{
+		Name:               name,
+		Definition:         overloads,
+		FunctionProperties: FunctionProperties{NullableArgs: true},
+	}
+}
+
 // FunDefs holds pre-allocated FunctionDefinition instances
 // for every builtin function. Initialized by builtins.init().
 var FunDefs map[string]*FunctionDefinition
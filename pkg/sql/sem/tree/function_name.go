@@ -11,11 +11,15 @@
 package tree
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // Function names are used in expressions in the FuncExpr node.
@@ -62,6 +66,50 @@ func (fn *ResolvableFunctionReference) Resolve(
 	}
 }
 
+// ResolveWithResolver is like Resolve, but it also looks up the function
+// through the given resolver if its name isn't the name of a builtin function,
+// which allows resolving user-defined functions. The resolver may be nil.
+func (fn *ResolvableFunctionReference) ResolveWithResolver(
+	ctx context.Context, searchPath sessiondata.SearchPath, resolver FunctionReferenceResolver,
+) (*FunctionDefinition, error) {
+	fd, err := fn.Resolve(searchPath)
+	if err == nil || resolver == nil || pgerror.GetPGCode(err) != pgcode.UndefinedFunction {
+		return fd, err
+	}
+	name, ok := fn.FunctionReference.(*UnresolvedName)
+	if !ok {
+		return nil, err
+	}
+	udf, udfErr := resolver.ResolveFunction(ctx, name, searchPath)
+	if udfErr != nil {
+		if pgerror.GetPGCode(udfErr) == pgcode.UndefinedFunction {
+			// Return the original error, which may suggest a builtin function with
+			// a similar name.
+			return nil, err
+		}
+		return nil, udfErr
+	}
+	fn.FunctionReference = udf
+	return udf, nil
+}
+
+// FunctionReferenceResolver is the interface that provides the ability to
+// resolve the names of user-defined functions, which are not found among the
+// builtin functions.
+type FunctionReferenceResolver interface {
+	// ResolveFunction returns the definition holding the overloads of the
+	// user-defined function with the given name, found through the search path
+	// if the name is unqualified. It returns an error with the UndefinedFunction
+	// code if there is no such function.
+	ResolveFunction(
+		ctx context.Context, name *UnresolvedName, path sessiondata.SearchPath,
+	) (*FunctionDefinition, error)
+
+	// ResolveFunctionByOID returns the overload of a user-defined function with
+	// the given OID.
+	ResolveFunctionByOID(ctx context.Context, oid oid.Oid) (*Overload, error)
+}
+
 // WrapFunction creates a new ResolvableFunctionReference
 // holding a pre-resolved function. Helper for grammar rules.
 func WrapFunction(n string) ResolvableFunctionReference {
@@ -110,6 +110,21 @@ type Overload struct {
 	// DistSQL. One example is when the type information for function arguments
 	// cannot be recovered.
 	DistsqlBlocklist bool
+
+	// IsUDF is set to true when this is the overload of a user-defined
+	// function. Calls to user-defined functions are inlined by the optimizer,
+	// they cannot be evaluated with Fn.
+	IsUDF bool
+	// Body is the SQL statement of a user-defined function.
+	Body string
+	// CalledOnNullInput is set to true when a user-defined function must be
+	// evaluated when some of its arguments are NULL. Otherwise, it returns NULL
+	// when any of its arguments is NULL.
+	CalledOnNullInput bool
+	// Version is the version of the descriptor of a user-defined function. It is
+	// used to detect that the definition of a function used by a cached plan
+	// has changed.
+	Version uint64
 }
 
 // params implements the overloadImpl interface.
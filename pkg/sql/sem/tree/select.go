@@ -695,6 +695,43 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType is the kind of a GroupingSet.
+type GroupingSetType int
+
+// GroupingSetType values.
+const (
+	RollupGroupingSet GroupingSetType = iota
+	CubeGroupingSet
+	GroupingSets
+)
+
+var groupingSetTypeName = [...]string{
+	RollupGroupingSet: "ROLLUP",
+	CubeGroupingSet:   "CUBE",
+	GroupingSets:      "GROUPING SETS",
+}
+
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item of a GROUP BY
+// clause. Each element of a ROLLUP or CUBE is an expression or a parenthesized
+// list of expressions that are grouped together. The elements of GROUPING SETS
+// can additionally be nested grouping sets, or () for the empty grouping set.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
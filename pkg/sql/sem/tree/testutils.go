@@ -86,6 +86,12 @@ func SampleDatum(t *types.T) Datum {
 		return NewDBox2D(*b)
 	case types.GeographyFamily:
 		return NewDGeography(geo.MustParseGeographyFromEWKB([]byte("\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\x3f")))
+	case types.TSQueryFamily:
+		q, _ := ParseDTSQuery("'fat' & 'rat'")
+		return q
+	case types.TSVectorFamily:
+		v, _ := ParseDTSVector("'fat':2 'rat':3")
+		return v
 	case types.GeometryFamily:
 		return NewDGeometry(geo.MustParseGeometryFromEWKB([]byte("\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\xf0\x3f")))
 	default:
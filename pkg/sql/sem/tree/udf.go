@@ -0,0 +1,242 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateFunction represents a CREATE FUNCTION statement.
+type CreateFunction struct {
+	Name       *UnresolvedObjectName
+	Replace    bool
+	Args       FuncArgs
+	ReturnType ResolvableTypeReference
+	Options    FunctionOptions
+}
+
+var _ Statement = &CreateFunction{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("FUNCTION ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Args)
+	ctx.WriteString(") RETURNS ")
+	ctx.FormatTypeReference(node.ReturnType)
+	for _, option := range node.Options {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(option)
+	}
+}
+
+// FuncArg is a single argument in the signature of a CREATE FUNCTION
+// statement. The name may be empty, in which case the argument can only be
+// referenced by position using a placeholder ($1, $2, ...).
+type FuncArg struct {
+	Name Name
+	Type ResolvableTypeReference
+}
+
+// Format implements the NodeFormatter interface.
+func (node *FuncArg) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.FormatTypeReference(node.Type)
+}
+
+// FuncArgs is a list of function arguments.
+type FuncArgs []FuncArg
+
+// Format implements the NodeFormatter interface.
+func (node *FuncArgs) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// FunctionOption is an option of a CREATE FUNCTION statement, such as its
+// language, body or volatility.
+type FunctionOption interface {
+	NodeFormatter
+	functionOption()
+}
+
+func (FunctionLanguage) functionOption()          {}
+func (FunctionBody) functionOption()              {}
+func (FunctionVolatility) functionOption()        {}
+func (FunctionLeakProof) functionOption()         {}
+func (FunctionNullInputBehavior) functionOption() {}
+
+// FunctionOptions is a list of options of a CREATE FUNCTION statement.
+type FunctionOptions []FunctionOption
+
+// FunctionLanguage is the LANGUAGE option of a function. The name is
+// normalized to lower case by the parser.
+type FunctionLanguage string
+
+// FunctionLangSQL is the only language supported for user-defined functions.
+const FunctionLangSQL FunctionLanguage = "sql"
+
+// Format implements the NodeFormatter interface.
+func (node FunctionLanguage) Format(ctx *FmtCtx) {
+	ctx.WriteString("LANGUAGE ")
+	// NB: we do not anonymize the language name because it is always one of a
+	// handful of well-known identifiers.
+	ctx.WriteString(string(node))
+}
+
+// FunctionBody is the AS option of a function, containing the text of the
+// function body.
+type FunctionBody string
+
+// Format implements the NodeFormatter interface.
+func (node FunctionBody) Format(ctx *FmtCtx) {
+	ctx.WriteString("AS ")
+	if ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, string(node), ctx.flags.EncodeFlags())
+	}
+}
+
+// FunctionVolatility is the volatility option of a function.
+type FunctionVolatility int
+
+const (
+	// FunctionVolatile is the default volatility of a function.
+	FunctionVolatile FunctionVolatility = iota
+	// FunctionStable indicates that the function cannot modify the database
+	// and returns the same result for the same arguments within a single
+	// statement.
+	FunctionStable
+	// FunctionImmutable indicates that the function cannot modify the
+	// database and always returns the same result for the same arguments.
+	FunctionImmutable
+)
+
+// Format implements the NodeFormatter interface.
+func (node FunctionVolatility) Format(ctx *FmtCtx) {
+	switch node {
+	case FunctionVolatile:
+		ctx.WriteString("VOLATILE")
+	case FunctionStable:
+		ctx.WriteString("STABLE")
+	case FunctionImmutable:
+		ctx.WriteString("IMMUTABLE")
+	}
+}
+
+// FunctionLeakProof is the [NOT] LEAKPROOF option of a function.
+type FunctionLeakProof bool
+
+// Format implements the NodeFormatter interface.
+func (node FunctionLeakProof) Format(ctx *FmtCtx) {
+	if !node {
+		ctx.WriteString("NOT ")
+	}
+	ctx.WriteString("LEAKPROOF")
+}
+
+// FunctionNullInputBehavior is the option of a function that determines its
+// behavior when any of its arguments is NULL.
+type FunctionNullInputBehavior int
+
+const (
+	// FunctionCalledOnNullInput indicates that the function is evaluated
+	// normally when some of its arguments are NULL. This is the default.
+	FunctionCalledOnNullInput FunctionNullInputBehavior = iota
+	// FunctionReturnsNullOnNullInput indicates that the function returns NULL
+	// without being evaluated when any of its arguments are NULL.
+	FunctionReturnsNullOnNullInput
+	// FunctionStrict is an alias of FunctionReturnsNullOnNullInput.
+	FunctionStrict
+)
+
+// Format implements the NodeFormatter interface.
+func (node FunctionNullInputBehavior) Format(ctx *FmtCtx) {
+	switch node {
+	case FunctionCalledOnNullInput:
+		ctx.WriteString("CALLED ON NULL INPUT")
+	case FunctionReturnsNullOnNullInput:
+		ctx.WriteString("RETURNS NULL ON NULL INPUT")
+	case FunctionStrict:
+		ctx.WriteString("STRICT")
+	}
+}
+
+// FuncObj identifies a function, and optionally one of its overloads, in a
+// DROP FUNCTION statement.
+type FuncObj struct {
+	FuncName *UnresolvedObjectName
+	// Args contains the argument types of the overload. It is nil if no
+	// argument list was specified, in which case the function name must
+	// identify a single overload.
+	Args []ResolvableTypeReference
+}
+
+// Format implements the NodeFormatter interface.
+func (node *FuncObj) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.FuncName)
+	if node.Args != nil {
+		ctx.WriteByte('(')
+		for i := range node.Args {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatTypeReference(node.Args[i])
+		}
+		ctx.WriteByte(')')
+	}
+}
+
+// FuncObjs is a list of functions in a DROP FUNCTION statement.
+type FuncObjs []FuncObj
+
+// Format implements the NodeFormatter interface.
+func (node *FuncObjs) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// DropFunction represents a DROP FUNCTION statement.
+type DropFunction struct {
+	Functions    FuncObjs
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropFunction{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP FUNCTION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Functions)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -79,6 +79,9 @@ func ShowCreateTable(
 	if desc.IsTemporary() {
 		f.WriteString("TEMP ")
 	}
+	if desc.IsForeignTable() {
+		f.WriteString("FOREIGN ")
+	}
 	f.WriteString("TABLE ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
@@ -170,6 +173,17 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	// Foreign tables have no partitioning or locality, only a server.
+	if desc.IsForeignTable() {
+		showForeignTableClause(desc, f)
+		if !displayOptions.IgnoreComments {
+			if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
+				return "", err
+			}
+		}
+		return f.CloseAndGetString(), nil
+	}
+
 	if err := ShowCreatePartitioning(
 		a, p.ExecCfg().Codec, desc, desc.GetPrimaryIndex(), desc.GetPrimaryIndex().GetPartitioning(), &f.Buffer, 0 /* indent */, 0, /* colOffset */
 	); err != nil {
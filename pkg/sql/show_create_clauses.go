@@ -357,6 +357,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(fk.OnUpdate.String())
 	}
+	if fk.Deferrability != descpb.ConstraintDeferrability_NotDeferrable {
+		buf.WriteByte(' ')
+		buf.WriteString(descpb.ConstraintDeferrabilityType[fk.Deferrability].String())
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -553,6 +557,22 @@ func ShowCreatePartitioning(
 	return nil
 }
 
+// showForeignTableClause creates the SERVER clause of the CREATE statement of a
+// foreign table, writing it to tree.FmtCtx f.
+func showForeignTableClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
+	foreignTable := desc.GetForeignTable()
+	f.WriteString(" SERVER ")
+	f.FormatNameP(&foreignTable.Server)
+	if len(foreignTable.Options) > 0 {
+		opts := make(tree.ForeignOptions, len(foreignTable.Options))
+		for i, opt := range foreignTable.Options {
+			opts[i] = tree.ForeignOption{Key: tree.Name(opt.Key), Value: opt.Value}
+		}
+		f.WriteByte(' ')
+		f.FormatNode(&opts)
+	}
+}
+
 // showConstraintClause creates the CONSTRAINT clauses for a CREATE statement,
 // writing them to tree.FmtCtx f
 func showConstraintClause(
@@ -594,6 +614,10 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		if c.Deferrability != descpb.ConstraintDeferrability_NotDeferrable {
+			f.WriteByte(' ')
+			f.WriteString(descpb.ConstraintDeferrabilityType[c.Deferrability].String())
+		}
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.Predicate, semaCtx, sessionData, tree.FmtParsable)
@@ -606,6 +630,46 @@ func showConstraintClause(
 			f.WriteString(" NOT VALID")
 		}
 	}
+	exclusionConstraints := desc.GetExclusionConstraints()
+	for i := range exclusionConstraints {
+		c := &exclusionConstraints[i]
+		f.WriteString(",\n\tCONSTRAINT ")
+		formatQuoteNames(&f.Buffer, c.Name)
+		f.WriteString(" ")
+		if err := showExclusionConstraintElems(desc, c, f); err != nil {
+			return err
+		}
+		if c.Predicate != "" {
+			f.WriteString(" WHERE ")
+			pred, err := schemaexpr.FormatExprForDisplay(ctx, desc, c.Predicate, semaCtx, sessionData, tree.FmtParsable)
+			if err != nil {
+				return err
+			}
+			f.WriteString(pred)
+		}
+	}
 	f.WriteString("\n)")
 	return nil
 }
+
+// showExclusionConstraintElems writes the EXCLUDE clause of an exclusion
+// constraint, without its predicate, to tree.FmtCtx f.
+func showExclusionConstraintElems(
+	desc catalog.TableDescriptor, c *descpb.ExclusionConstraint, f *tree.FmtCtx,
+) error {
+	colNames, err := desc.NamesForColumnIDs(c.ColumnIDs)
+	if err != nil {
+		return err
+	}
+	f.WriteString("EXCLUDE (")
+	for i := range colNames {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		f.FormatNameP(&colNames[i])
+		f.WriteString(" WITH ")
+		f.WriteString(descpb.ExclusionConstraintOperatorType[c.Operators[i]].String())
+	}
+	f.WriteString(")")
+	return nil
+}
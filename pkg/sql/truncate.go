@@ -71,11 +71,29 @@ func (t *truncateNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+		if err := checkNotForeignTable(tableDesc); err != nil {
+			return err
+		}
 
 		if err := p.CheckPrivilege(ctx, tableDesc, privilege.DROP); err != nil {
 			return err
 		}
 
+		// Truncating a table would leave the incrementally maintained views that
+		// depend on it out of date.
+		for _, ref := range tableDesc.DependedOnBy {
+			if !ref.Incremental {
+				continue
+			}
+			view, err := p.Descriptors().GetMutableTableVersionByID(ctx, ref.ID, p.txn)
+			if err != nil {
+				return err
+			}
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot truncate table %q because incrementally maintained view %q depends on it",
+				tableDesc.Name, view.Name)
+		}
+
 		toTruncate[tableDesc.ID] = tn.FQString()
 		toTraverse = append(toTraverse, *tableDesc)
 	}
@@ -337,7 +355,8 @@ func checkTableForDisallowedMutationsWithTruncate(desc *tabledesc.Mutable) error
 						"dropped which depends on another object", desc.GetName(), col.GetName())
 			}
 		} else if c := m.AsConstraint(); c != nil {
-			if c.IsCheck() || c.IsNotNull() || c.IsForeignKey() || c.IsUniqueWithoutIndex() {
+			if c.IsCheck() || c.IsNotNull() || c.IsForeignKey() || c.IsUniqueWithoutIndex() ||
+				c.IsExclusion() {
 				return unimplemented.Newf(
 					"TRUNCATE concurrent with ongoing schema change",
 					"cannot perform TRUNCATE on %q which has an ongoing %s "+
@@ -196,6 +196,23 @@ type UserDefinedTypeMetadata struct {
 
 	// enumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
+
+	// DomainData is non-nil iff the metadata is for a domain.
+	DomainData *DomainMetadata
+}
+
+// DomainMetadata is metadata about a domain needed to enforce its constraints.
+type DomainMetadata struct {
+	// Default is the serialized default expression of the domain, or the empty
+	// string if it has none.
+	Default string
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// CheckNames and CheckExprs are the names and serialized expressions of
+	// the CHECK constraints of the domain. The expressions refer to the value
+	// being checked as VALUE.
+	CheckNames []string
+	CheckExprs []string
 }
 
 // EnumMetadata is metadata about an ENUM needed for evaluation.
@@ -463,6 +480,24 @@ var (
 		},
 	}
 
+	// TSQuery is the type of a text search query.
+	TSQuery = &T{
+		InternalType: InternalType{
+			Family: TSQueryFamily,
+			Oid:    oid.T_tsquery,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSVector is the type of a document preprocessed for text search.
+	TSVector = &T{
+		InternalType: InternalType{
+			Family: TSVectorFamily,
+			Oid:    oid.T_tsvector,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -1073,6 +1108,18 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of the given base type for the domain
+// with the given stable type OID. The domain behaves like its base type, except
+// that its constraints are enforced on the values cast or written to it. Note
+// that it does not hydrate cached fields on the type.
+func MakeDomain(base *T, domainOID oid.Oid) *T {
+	internalType := base.InternalType
+	internalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		DomainOID: domainOID,
+	}
+	return &T{InternalType: internalType}
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1311,6 +1358,7 @@ func (t *T) WithoutTypeModifiers() *T {
 		oid.T_name,
 		oid.T_oid,
 		oid.T_regclass, oid.T_regnamespace, oid.T_regproc, oid.T_regprocedure, oid.T_regrole, oid.T_regtype,
+		oid.T_tsquery, oid.T_tsvector,
 		oid.T_unknown,
 		oid.T_uuid,
 		oid.T_void:
@@ -1370,6 +1418,31 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 	}
 }
 
+// IsDomain returns whether or not t is a domain. The OID of a domain is the
+// OID of its base type; the OID of the domain itself is returned by DomainOID.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainOID != 0
+}
+
+// DomainOID returns the OID of the domain, or 0 if t is not a domain.
+func (t *T) DomainOID() oid.Oid {
+	if t.InternalType.UDTMetadata == nil {
+		return 0
+	}
+	return t.InternalType.UDTMetadata.DomainOID
+}
+
+// DomainBaseType returns the base type of the domain. It returns t if it is
+// not a domain.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		return t
+	}
+	internalType := t.InternalType
+	internalType.UDTMetadata = nil
+	return &T{InternalType: internalType}
+}
+
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
@@ -1406,6 +1479,8 @@ var familyNames = map[Family]string{
 	TimestampFamily:      "timestamp",
 	TimestampTZFamily:    "timestamptz",
 	TimeTZFamily:         "timetz",
+	TSQueryFamily:        "tsquery",
+	TSVectorFamily:       "tsvector",
 	TupleFamily:          "tuple",
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
@@ -1724,6 +1799,10 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "timestamp with time zone"
 		}
 		return fmt.Sprintf("timestamp(%d) with time zone", typmod)
+	case TSQueryFamily:
+		return "tsquery"
+	case TSVectorFamily:
+		return "tsvector"
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -1762,6 +1841,9 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -2065,7 +2147,8 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		}
 	}
 	if t.UDTMetadata != nil && other.UDTMetadata != nil {
-		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
+		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID ||
+			t.UDTMetadata.DomainOID != other.UDTMetadata.DomainOID {
 			return false
 		}
 	} else if t.UDTMetadata != nil {
@@ -2678,10 +2761,10 @@ func (t *T) stringTypeSQL() string {
 	return typName
 }
 
-// IsHydrated returns true if this is a user-defined type and the TypeMeta
-// is hydrated.
+// IsHydrated returns true if this is a user-defined type or a domain, and the
+// TypeMeta is hydrated.
 func (t *T) IsHydrated() bool {
-	return t.UserDefined() && t.TypeMeta != (UserDefinedTypeMetadata{})
+	return (t.UserDefined() || t.IsDomain()) && t.TypeMeta != (UserDefinedTypeMetadata{})
 }
 
 var typNameLiterals map[string]*T
@@ -2794,8 +2877,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"money":         -1,
 	"path":          21286,
 	"pg_lsn":        -1,
-	"tsquery":       7821,
-	"tsvector":      7821,
 	"txid_snapshot": -1,
 	"xml":           -1,
 }
@@ -120,6 +120,7 @@ func (v *planVisitor) visitInternal(plan planNode, name string) {
 	switch n := plan.(type) {
 	case *valuesNode:
 	case *scanNode:
+	case *foreignScanNode:
 
 	case *filterNode:
 		n.source.plan = v.visit(n.source.plan)
@@ -216,6 +217,8 @@ func (v *planVisitor) visitInternal(plan planNode, name string) {
 		}
 
 	case *createViewNode:
+	case *createFunctionNode:
+	case *createTriggerNode:
 	case *setVarNode:
 	case *setClusterSettingNode:
 
@@ -363,12 +366,18 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&controlJobsNode{}):                "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):           "control schedules",
 	reflect.TypeOf(&createDatabaseNode{}):             "create database",
+	reflect.TypeOf(&createDomainNode{}):               "create domain",
 	reflect.TypeOf(&createExtensionNode{}):            "create extension",
+	reflect.TypeOf(&createForeignTableNode{}):         "create foreign table",
+	reflect.TypeOf(&createFunctionNode{}):             "create function",
 	reflect.TypeOf(&createIndexNode{}):                "create index",
+	reflect.TypeOf(&createPolicyNode{}):               "create policy",
 	reflect.TypeOf(&createSequenceNode{}):             "create sequence",
+	reflect.TypeOf(&createServerNode{}):               "create server",
 	reflect.TypeOf(&createSchemaNode{}):               "create schema",
 	reflect.TypeOf(&createStatsNode{}):                "create statistics",
 	reflect.TypeOf(&createTableNode{}):                "create table",
+	reflect.TypeOf(&createTriggerNode{}):              "create trigger",
 	reflect.TypeOf(&createTypeNode{}):                 "create type",
 	reflect.TypeOf(&CreateRoleNode{}):                 "create user/role",
 	reflect.TypeOf(&createViewNode{}):                 "create view",
@@ -377,10 +386,13 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&deleteRangeNode{}):                "delete range",
 	reflect.TypeOf(&distinctNode{}):                   "distinct",
 	reflect.TypeOf(&dropDatabaseNode{}):               "drop database",
+	reflect.TypeOf(&dropFunctionNode{}):               "drop function",
 	reflect.TypeOf(&dropIndexNode{}):                  "drop index",
+	reflect.TypeOf(&dropPolicyNode{}):                 "drop policy",
 	reflect.TypeOf(&dropSequenceNode{}):               "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                 "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                  "drop table",
+	reflect.TypeOf(&dropTriggerNode{}):                "drop trigger",
 	reflect.TypeOf(&dropTypeNode{}):                   "drop type",
 	reflect.TypeOf(&DropRoleNode{}):                   "drop user/role",
 	reflect.TypeOf(&dropViewNode{}):                   "drop view",
@@ -390,6 +402,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&explainDDLNode{}):                 "explain ddl",
 	reflect.TypeOf(&exportNode{}):                     "export",
 	reflect.TypeOf(&filterNode{}):                     "filter",
+	reflect.TypeOf(&foreignScanNode{}):                "foreign scan",
 	reflect.TypeOf(&GrantRoleNode{}):                  "grant role",
 	reflect.TypeOf(&groupNode{}):                      "group",
 	reflect.TypeOf(&hookFnNode{}):                     "plugin",
@@ -89,6 +89,23 @@ func (t TxnMeta) Short() redact.SafeString {
 	return redact.SafeString(t.ID.Short())
 }
 
+// ToleratesWriteSkew returns whether transactions running at the isolation
+// level can commit at a timestamp above the timestamp of their reads without
+// first refreshing them to the commit timestamp.
+func (l IsolationLevel) ToleratesWriteSkew() bool {
+	return l == ReadCommitted
+}
+
+// PerStatementReadSnapshot returns whether transactions running at the
+// isolation level read from a new snapshot in each statement, as opposed to
+// reading from a single snapshot for their entire lifetime.
+func (l IsolationLevel) PerStatementReadSnapshot() bool {
+	return l == ReadCommitted
+}
+
+// SafeValue implements the redact.SafeValue interface.
+func (IsolationLevel) SafeValue() {}
+
 // Total returns the range size as the sum of the key and value
 // bytes. This includes all non-live keys and all versioned values.
 func (ms MVCCStats) Total() int64 {
@@ -0,0 +1,139 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// DefaultConfig is the text search configuration used when none is specified.
+// Only the simple configuration is supported for now, whereas Postgres
+// defaults to the english configuration, which also stems the words and
+// ignores the stop words.
+const DefaultConfig = "simple"
+
+// checkConfig returns an error if config is not a supported text search
+// configuration.
+func checkConfig(config string) error {
+	switch config {
+	case "simple", "pg_catalog.simple":
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"text search configuration %q does not exist", config)
+}
+
+// tokenize splits the given text into lexemes according to the simple text
+// search configuration: the words, which are the runs of letters and digits,
+// are lowercased.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// DocumentToTSVector parses the given document into a TSVector according to
+// the given text search configuration. This is the to_tsvector builtin.
+func DocumentToTSVector(config string, document string) (TSVector, error) {
+	if err := checkConfig(config); err != nil {
+		return nil, err
+	}
+	words := tokenize(document)
+	ret := make(TSVector, len(words))
+	for i, w := range words {
+		ret[i] = TSLexeme{Lexeme: w, Positions: []TSPosition{makeTSPosition(i+1, weightD)}}
+	}
+	return ret.normalize(), nil
+}
+
+// ToTSQuery parses the given query text into a TSQuery, normalizing its terms
+// according to the given text search configuration. A term made of several
+// words is replaced by a phrase of them, and a term made of no word is
+// dropped. This is the to_tsquery builtin.
+func ToTSQuery(config string, input string) (TSQuery, error) {
+	if err := checkConfig(config); err != nil {
+		return TSQuery{}, err
+	}
+	q, err := ParseTSQuery(input)
+	if err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: normalizeNode(q.root)}, nil
+}
+
+func normalizeNode(n *tsNode) *tsNode {
+	if n == nil {
+		return nil
+	}
+	switch n.op {
+	case invalid:
+		return joinTerms(tokenize(n.term.lexeme), followedBy, n.term)
+	case not:
+		l := normalizeNode(n.l)
+		if l == nil {
+			return nil
+		}
+		return &tsNode{op: not, l: l}
+	}
+	l, r := normalizeNode(n.l), normalizeNode(n.r)
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	return &tsNode{op: n.op, followedN: n.followedN, l: l, r: r}
+}
+
+// joinTerms returns a query combining terms for the given lexemes with op. The
+// weights and prefix flag of the terms are copied from proto. Returns nil if
+// there are no lexemes.
+func joinTerms(lexemes []string, op tsOperator, proto tsTerm) *tsNode {
+	var ret *tsNode
+	for _, lexeme := range lexemes {
+		term := proto
+		term.lexeme = lexeme
+		n := &tsNode{term: term}
+		if ret == nil {
+			ret = n
+		} else {
+			ret = &tsNode{op: op, l: ret, r: n}
+			if op == followedBy {
+				ret.followedN = 1
+			}
+		}
+	}
+	return ret
+}
+
+// PlainToTSQuery returns a query matching the documents containing all the
+// words of the given text, normalized according to the given text search
+// configuration. This is the plainto_tsquery builtin.
+func PlainToTSQuery(config string, text string) (TSQuery, error) {
+	if err := checkConfig(config); err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: joinTerms(tokenize(text), and, tsTerm{})}, nil
+}
+
+// PhraseToTSQuery returns a query matching the documents containing the words
+// of the given text in sequence, normalized according to the given text search
+// configuration. This is the phraseto_tsquery builtin.
+func PhraseToTSQuery(config string, text string) (TSQuery, error) {
+	if err := checkConfig(config); err != nil {
+		return TSQuery{}, err
+	}
+	return TSQuery{root: joinTerms(tokenize(text), followedBy, tsTerm{})}, nil
+}
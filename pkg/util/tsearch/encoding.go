@@ -0,0 +1,232 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// EncodeTSVector appends the encoding of v to appendTo. The encoding is the
+// number of lexemes, followed for each lexeme by its length, its bytes, its
+// number of positions and its positions, all the integers being uvarints.
+func EncodeTSVector(appendTo []byte, v TSVector) []byte {
+	appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(v)))
+	for _, l := range v {
+		appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(l.Lexeme)))
+		appendTo = append(appendTo, l.Lexeme...)
+		appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(l.Positions)))
+		for _, p := range l.Positions {
+			appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(p))
+		}
+	}
+	return appendTo
+}
+
+// DecodeTSVector decodes a TSVector encoded by EncodeTSVector.
+func DecodeTSVector(b []byte) (TSVector, error) {
+	b, n, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(TSVector, n)
+	for i := range ret {
+		var lexeme string
+		if b, lexeme, err = decodeLexeme(b); err != nil {
+			return nil, err
+		}
+		ret[i].Lexeme = lexeme
+		if b, n, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			ret[i].Positions = make([]TSPosition, n)
+		}
+		for j := range ret[i].Positions {
+			var p uint64
+			if b, p, err = encoding.DecodeUvarintAscending(b); err != nil {
+				return nil, err
+			}
+			ret[i].Positions[j] = TSPosition(p)
+		}
+	}
+	if len(b) != 0 {
+		return nil, errors.AssertionFailedf("%d trailing bytes in encoded tsvector", len(b))
+	}
+	return ret, nil
+}
+
+func decodeLexeme(b []byte) ([]byte, string, error) {
+	b, n, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return nil, "", err
+	}
+	if uint64(len(b)) < n {
+		return nil, "", errors.AssertionFailedf("lexeme of %d bytes in %d bytes", n, len(b))
+	}
+	return b[n:], string(b[:n]), nil
+}
+
+// EncodeTSQuery appends the encoding of q to appendTo. The encoding is the
+// tree of the query in prefix order: each node is encoded as its operator,
+// followed by the lexeme, weights and prefix flag of a term, or by the
+// distance of a followedBy.
+func EncodeTSQuery(appendTo []byte, q TSQuery) []byte {
+	var encodeNode func(n *tsNode)
+	encodeNode = func(n *tsNode) {
+		appendTo = append(appendTo, byte(n.op))
+		switch n.op {
+		case invalid:
+			appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(len(n.term.lexeme)))
+			appendTo = append(appendTo, n.term.lexeme...)
+			flags := n.term.weights
+			if n.term.prefix {
+				flags |= prefixFlag
+			}
+			appendTo = append(appendTo, flags)
+		case not:
+			encodeNode(n.l)
+		default:
+			if n.op == followedBy {
+				appendTo = encoding.EncodeUvarintAscending(appendTo, uint64(n.followedN))
+			}
+			encodeNode(n.l)
+			encodeNode(n.r)
+		}
+	}
+	if q.root != nil {
+		encodeNode(q.root)
+	}
+	return appendTo
+}
+
+// prefixFlag is set in the encoded weights of a term to mark a prefix term.
+const prefixFlag = 1 << 4
+
+// DecodeTSQuery decodes a TSQuery encoded by EncodeTSQuery.
+func DecodeTSQuery(b []byte) (TSQuery, error) {
+	if len(b) == 0 {
+		return TSQuery{}, nil
+	}
+	var decodeNode func() (*tsNode, error)
+	decodeNode = func() (*tsNode, error) {
+		if len(b) == 0 {
+			return nil, errors.AssertionFailedf("truncated tsquery encoding")
+		}
+		n := &tsNode{op: tsOperator(b[0])}
+		b = b[1:]
+		var err error
+		switch n.op {
+		case invalid:
+			if b, n.term.lexeme, err = decodeLexeme(b); err != nil {
+				return nil, err
+			}
+			if len(b) == 0 {
+				return nil, errors.AssertionFailedf("truncated tsquery encoding")
+			}
+			n.term.weights = b[0] &^ prefixFlag
+			n.term.prefix = b[0]&prefixFlag != 0
+			b = b[1:]
+			return n, nil
+		case not:
+			n.l, err = decodeNode()
+			return n, err
+		case and, or, followedBy:
+			if n.op == followedBy {
+				var d uint64
+				if b, d, err = encoding.DecodeUvarintAscending(b); err != nil {
+					return nil, err
+				}
+				n.followedN = int(d)
+			}
+			if n.l, err = decodeNode(); err != nil {
+				return nil, err
+			}
+			n.r, err = decodeNode()
+			return n, err
+		}
+		return nil, errors.AssertionFailedf("unknown tsquery operator %d", n.op)
+	}
+	root, err := decodeNode()
+	if err != nil {
+		return TSQuery{}, err
+	}
+	if len(b) != 0 {
+		return TSQuery{}, errors.AssertionFailedf("%d trailing bytes in encoded tsquery", len(b))
+	}
+	return TSQuery{root: root}, nil
+}
+
+// EncodeInvertedIndexKeys returns the inverted index keys of v, one per
+// lexeme. Each key is prefixed by inKey.
+func EncodeInvertedIndexKeys(inKey []byte, v TSVector) [][]byte {
+	keys := make([][]byte, len(v))
+	for i := range v {
+		key := make([]byte, len(inKey), len(inKey)+len(v[i].Lexeme)+3)
+		copy(key, inKey)
+		keys[i] = encoding.EncodeStringAscending(key, v[i].Lexeme)
+	}
+	return keys
+}
+
+// GetInvertedExpr returns the expression representing the spans of an inverted
+// index on a TSVector column that must be scanned to find the rows matching q.
+// A term is evaluated with the span of its lexeme, or with the span of all the
+// lexemes starting with it if it is a prefix term. The & and | operators map to
+// the intersection and union of the spans of their operands. The expression is
+// not tight if the query restricts the weights of a term, or if it holds a
+// <N> operator, since the positions of the lexemes are not in the index.
+//
+// A ! operator can't be evaluated with the index, so an expression over the
+// whole index is returned for a query containing a ! that is not the operand
+// of a &.
+func (q TSQuery) GetInvertedExpr() inverted.Expression {
+	if q.root == nil {
+		// The empty query matches nothing.
+		return &inverted.SpanExpression{Tight: true, Unique: true}
+	}
+	return q.root.getInvertedExpr()
+}
+
+func (n *tsNode) getInvertedExpr() inverted.Expression {
+	switch n.op {
+	case invalid:
+		start := encoding.EncodeStringAscending(nil, n.term.lexeme)
+		var expr *inverted.SpanExpression
+		if n.term.prefix {
+			// The lexemes starting with the term are in [lexeme, lexemeEnd),
+			// where lexemeEnd is lexeme with its last byte incremented. Lexemes
+			// are valid UTF-8, so that byte can't be 0xff.
+			end := []byte(n.term.lexeme)
+			end[len(end)-1]++
+			expr = inverted.ExprForSpan(inverted.Span{
+				Start: start, End: encoding.EncodeStringAscending(nil, string(end)),
+			}, true /* tight */)
+		} else {
+			expr = inverted.ExprForSpan(inverted.MakeSingleValSpan(start), true /* tight */)
+			expr.Unique = true
+		}
+		if n.term.weights != 0 {
+			expr.SetNotTight()
+		}
+		return expr
+	case and:
+		return inverted.And(n.l.getInvertedExpr(), n.r.getInvertedExpr())
+	case or:
+		return inverted.Or(n.l.getInvertedExpr(), n.r.getInvertedExpr())
+	case followedBy:
+		expr := inverted.And(n.l.getInvertedExpr(), n.r.getInvertedExpr())
+		expr.SetNotTight()
+		return expr
+	}
+	return inverted.NonInvertedColExpression{}
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "sort"
+
+// EvalTSQuery returns whether the document represented by v matches q. This is
+// the @@ operator.
+func EvalTSQuery(q TSQuery, v TSVector) bool {
+	if q.root == nil {
+		return false
+	}
+	return q.root.eval(v)
+}
+
+func (n *tsNode) eval(v TSVector) bool {
+	switch n.op {
+	case invalid:
+		for i := v.firstCandidate(&n.term); i < len(v); i++ {
+			if !n.term.matchesLexeme(v[i].Lexeme) {
+				break
+			}
+			if n.term.weights == 0 || len(v[i].Positions) == 0 {
+				return true
+			}
+			for _, p := range v[i].Positions {
+				if n.term.matchesWeight(p.weight()) {
+					return true
+				}
+			}
+		}
+		return false
+	case and:
+		return n.l.eval(v) && n.r.eval(v)
+	case or:
+		return n.l.eval(v) || n.r.eval(v)
+	case not:
+		return !n.l.eval(v)
+	case followedBy:
+		s := n.evalPositions(v)
+		return s.negated || len(s.positions) > 0
+	}
+	return false
+}
+
+// firstCandidate returns the index of the first lexeme of v that can match t.
+// The lexemes matching t are contiguous since the lexemes are sorted.
+func (v TSVector) firstCandidate(t *tsTerm) int {
+	return sort.Search(len(v), func(i int) bool {
+		return v[i].Lexeme >= t.lexeme
+	})
+}
+
+// positionSet is a set of positions of a document. If negated is set, the set
+// holds all the positions except the listed ones. The positions are sorted.
+type positionSet struct {
+	positions []int
+	negated   bool
+}
+
+// anyPosition is the set matching all the positions. It is the set of a term
+// that matches a lexeme stored without positions, since the lexeme could be
+// anywhere in the document.
+var anyPosition = positionSet{negated: true}
+
+// evalPositions returns the set of the positions at which n matches v. The
+// position at which a followedBy matches is the position of its right operand.
+func (n *tsNode) evalPositions(v TSVector) positionSet {
+	switch n.op {
+	case invalid:
+		var ret []int
+		for i := v.firstCandidate(&n.term); i < len(v); i++ {
+			if !n.term.matchesLexeme(v[i].Lexeme) {
+				break
+			}
+			if len(v[i].Positions) == 0 {
+				return anyPosition
+			}
+			for _, p := range v[i].Positions {
+				if n.term.matchesWeight(p.weight()) {
+					ret = append(ret, p.Position())
+				}
+			}
+		}
+		return positionSet{positions: sortAndUniq(ret)}
+	case and:
+		return intersectPositions(n.l.evalPositions(v), n.r.evalPositions(v))
+	case or:
+		l, r := n.l.evalPositions(v), n.r.evalPositions(v)
+		// Use De Morgan's law: l ∪ r = ¬(¬l ∩ ¬r).
+		l.negated, r.negated = !l.negated, !r.negated
+		ret := intersectPositions(l, r)
+		ret.negated = !ret.negated
+		return ret
+	case not:
+		ret := n.l.evalPositions(v)
+		ret.negated = !ret.negated
+		return ret
+	case followedBy:
+		l := n.l.evalPositions(v)
+		shifted := make([]int, len(l.positions))
+		for i, p := range l.positions {
+			shifted[i] = p + n.followedN
+		}
+		l.positions = shifted
+		return intersectPositions(l, n.r.evalPositions(v))
+	}
+	return positionSet{}
+}
+
+func intersectPositions(l, r positionSet) positionSet {
+	switch {
+	case !l.negated && !r.negated:
+		return positionSet{positions: intersectSorted(l.positions, r.positions)}
+	case l.negated && !r.negated:
+		return positionSet{positions: subtractSorted(r.positions, l.positions)}
+	case !l.negated && r.negated:
+		return positionSet{positions: subtractSorted(l.positions, r.positions)}
+	default:
+		return positionSet{positions: unionSorted(l.positions, r.positions), negated: true}
+	}
+}
+
+func sortAndUniq(a []int) []int {
+	sort.Ints(a)
+	ret := a[:0]
+	for i, p := range a {
+		if i == 0 || p != a[i-1] {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+func intersectSorted(a, b []int) []int {
+	var ret []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			ret = append(ret, a[i])
+			i++
+			j++
+		}
+	}
+	return ret
+}
+
+func subtractSorted(a, b []int) []int {
+	var ret []int
+	j := 0
+	for _, p := range a {
+		for j < len(b) && b[j] < p {
+			j++
+		}
+		if j < len(b) && b[j] == p {
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+func unionSorted(a, b []int) []int {
+	ret := make([]int, 0, len(a)+len(b))
+	ret = append(ret, a...)
+	ret = append(ret, b...)
+	return sortAndUniq(ret)
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import "math/rand"
+
+// randomLexemes is the pool of lexemes of the random vectors and queries, so
+// that they often match each other.
+var randomLexemes = []string{"a", "b", "cat", "fat", "rat", "it's", "the end"}
+
+// RandomTSVector generates a random TSVector.
+func RandomTSVector(rng *rand.Rand) TSVector {
+	ret := make(TSVector, rng.Intn(len(randomLexemes)))
+	for i := range ret {
+		ret[i].Lexeme = randomLexemes[rng.Intn(len(randomLexemes))]
+		for j, n := 0, rng.Intn(4); j < n; j++ {
+			ret[i].Positions = append(ret[i].Positions,
+				makeTSPosition(rng.Intn(maxPosition)+1, tsWeight(rng.Intn(len(weightLetters)))))
+		}
+	}
+	return ret.normalize()
+}
+
+// RandomTSQuery generates a random TSQuery.
+func RandomTSQuery(rng *rand.Rand) TSQuery {
+	return TSQuery{root: randomTSNode(rng, 3 /* depth */)}
+}
+
+func randomTSNode(rng *rand.Rand, depth int) *tsNode {
+	if depth == 0 || rng.Intn(3) == 0 {
+		return &tsNode{term: tsTerm{
+			lexeme:  randomLexemes[rng.Intn(len(randomLexemes))],
+			weights: byte(rng.Intn(1 << len(weightLetters))),
+			prefix:  rng.Intn(4) == 0,
+		}}
+	}
+	switch op := tsOperator(rng.Intn(int(followedBy)) + 1); op {
+	case not:
+		return &tsNode{op: not, l: randomTSNode(rng, depth-1)}
+	case followedBy:
+		return &tsNode{
+			op: followedBy, followedN: rng.Intn(3),
+			l: randomTSNode(rng, depth-1), r: randomTSNode(rng, depth-1),
+		}
+	default:
+		return &tsNode{op: op, l: randomTSNode(rng, depth-1), r: randomTSNode(rng, depth-1)}
+	}
+}
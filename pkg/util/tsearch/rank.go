@@ -0,0 +1,203 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// DefaultRankWeights are the weights of the D, C, B and A positions used by
+// Rank when none are specified.
+var DefaultRankWeights = []float64{0.1, 0.2, 0.4, 1.0}
+
+// The normalization options of Rank, which can be combined.
+const (
+	// rankNormLogLength divides the rank by 1 + the logarithm of the document
+	// length.
+	rankNormLogLength = 1 << iota
+	// rankNormLength divides the rank by the document length.
+	rankNormLength
+	// rankNormExtDist divides the rank by the mean harmonic distance between
+	// extents. It is only supported by ts_rank_cd, and is ignored by Rank.
+	rankNormExtDist
+	// rankNormUniq divides the rank by the number of unique words in the
+	// document.
+	rankNormUniq
+	// rankNormLogUniq divides the rank by 1 + the logarithm of the number of
+	// unique words in the document.
+	rankNormLogUniq
+	// rankNormRDivRPlus1 divides the rank by itself + 1.
+	rankNormRDivRPlus1
+)
+
+// Rank returns the relevance of the document represented by v for q, computed
+// from the frequency of the terms of q in v, as in Postgres. weights are the
+// weights of the D, C, B and A positions. This is the ts_rank builtin.
+func Rank(weights []float64, v TSVector, q TSQuery, normalization int) (float32, error) {
+	if len(weights) != len(DefaultRankWeights) {
+		return 0, pgerror.Newf(pgcode.ArraySubscript, "array of weight is too short")
+	}
+	for _, w := range weights {
+		if w > 1 {
+			return 0, pgerror.Newf(pgcode.InvalidParameterValue, "weight out of range")
+		}
+	}
+	if len(v) == 0 || q.root == nil {
+		return 0, nil
+	}
+	terms := uniqTerms(q)
+	var res float64
+	if q.root.op == and || q.root.op == followedBy {
+		res = rankAnd(weights, v, terms)
+	} else {
+		res = rankOr(weights, v, terms)
+	}
+	if res < 0 {
+		res = 1e-20
+	}
+
+	if normalization&rankNormLogLength != 0 {
+		res /= math.Log2(float64(v.length() + 1))
+	}
+	if normalization&rankNormLength != 0 {
+		if l := v.length(); l > 0 {
+			res /= float64(l)
+		}
+	}
+	if normalization&rankNormUniq != 0 {
+		res /= float64(len(v))
+	}
+	if normalization&rankNormLogUniq != 0 {
+		res /= math.Log2(float64(len(v) + 1))
+	}
+	if normalization&rankNormRDivRPlus1 != 0 {
+		res /= res + 1
+	}
+	return float32(res), nil
+}
+
+// uniqTerms returns the distinct terms of q, sorted by lexeme.
+func uniqTerms(q TSQuery) []*tsTerm {
+	terms := q.terms()
+	sort.SliceStable(terms, func(i, j int) bool {
+		return terms[i].lexeme < terms[j].lexeme
+	})
+	ret := terms[:0]
+	for i, t := range terms {
+		if i == 0 || *t != *terms[i-1] {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+// length returns the number of words of the document represented by v; a
+// lexeme without positions counts as a single word.
+func (v TSVector) length() int {
+	var ret int
+	for _, l := range v {
+		if len(l.Positions) == 0 {
+			ret++
+		} else {
+			ret += len(l.Positions)
+		}
+	}
+	return ret
+}
+
+// matchingPositions calls fn with the positions of each lexeme of v matching
+// t. A lexeme without positions is given the single position noPosition.
+func (v TSVector) matchingPositions(t *tsTerm, noPosition TSPosition, fn func([]TSPosition)) {
+	for i := v.firstCandidate(t); i < len(v) && t.matchesLexeme(v[i].Lexeme); i++ {
+		if len(v[i].Positions) == 0 {
+			fn([]TSPosition{noPosition})
+		} else {
+			fn(v[i].Positions)
+		}
+	}
+}
+
+func rankOr(weights []float64, v TSVector, terms []*tsTerm) float64 {
+	var res float64
+	for _, t := range terms {
+		v.matchingPositions(t, makeTSPosition(0, weightD), func(positions []TSPosition) {
+			// The rank of a lexeme is the sum of the weights of its positions
+			// divided by the square of their rank, normalized by the limit of
+			// sum(1/i^2), pi^2/6. As in Postgres, the position with the maximum
+			// weight is counted as the first one.
+			var resj float64
+			wjm, jm := -1.0, 0
+			for j, p := range positions {
+				w := weights[p.weight()]
+				resj += w / float64((j+1)*(j+1))
+				if w > wjm {
+					wjm, jm = w, j
+				}
+			}
+			res += (wjm + resj - wjm/float64((jm+1)*(jm+1))) / 1.64493406685
+		})
+	}
+	if len(terms) > 0 {
+		res /= float64(len(terms))
+	}
+	return res
+}
+
+func rankAnd(weights []float64, v TSVector, terms []*tsTerm) float64 {
+	if len(terms) < 2 {
+		return rankOr(weights, v, terms)
+	}
+	// A lexeme without positions is given the last position, so that it is
+	// considered far from the other lexemes.
+	noPosition := makeTSPosition(maxPosition, weightD)
+	res := -1.0
+	termPositions := make([][]TSPosition, len(terms))
+	for i, t := range terms {
+		v.matchingPositions(t, noPosition, func(positions []TSPosition) {
+			termPositions[i] = positions
+			for k := 0; k < i; k++ {
+				for _, p := range positions {
+					for _, q := range termPositions[k] {
+						dist := p.Position() - q.Position()
+						if dist < 0 {
+							dist = -dist
+						}
+						if dist == 0 {
+							if p != noPosition && q != noPosition {
+								continue
+							}
+							dist = maxPosition + 1
+						}
+						curw := math.Sqrt(weights[p.weight()] * weights[q.weight()] * wordDistance(dist))
+						if res < 0 {
+							res = curw
+						} else {
+							res = 1 - (1-res)*(1-curw)
+						}
+					}
+				}
+			}
+		})
+	}
+	return res
+}
+
+// wordDistance returns the weight of the distance between two words.
+func wordDistance(dist int) float64 {
+	if dist > 100 {
+		return 1e-30
+	}
+	return 1 / (1.005 + 0.05*math.Exp(float64(dist)/1.5-2))
+}
@@ -0,0 +1,348 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// tsOperator is an operator of a TSQuery.
+type tsOperator byte
+
+const (
+	// invalid is the operator of the leaves of a query, which are terms.
+	invalid tsOperator = iota
+	// and is the & operator.
+	and
+	// or is the | operator.
+	or
+	// not is the unary ! operator.
+	not
+	// followedBy is the <N> operator, which matches when its right operand
+	// appears N positions after its left operand. <-> is a shorthand for <1>.
+	followedBy
+)
+
+// precedence returns the precedence of the operator; operators with a higher
+// precedence bind tighter.
+func (o tsOperator) precedence() int {
+	switch o {
+	case or:
+		return 1
+	case and:
+		return 2
+	case followedBy:
+		return 3
+	case not:
+		return 4
+	}
+	return 0
+}
+
+// tsTerm is a lexeme of a query.
+type tsTerm struct {
+	lexeme string
+	// weights is a bitmask of the weights of the positions matched by the
+	// term: bit i is set if weight i is matched. No bits set means all the
+	// weights are matched.
+	weights byte
+	// prefix is set if the term matches all the lexemes starting with lexeme.
+	prefix bool
+}
+
+func (t *tsTerm) matchesLexeme(lexeme string) bool {
+	if t.prefix {
+		return strings.HasPrefix(lexeme, t.lexeme)
+	}
+	return lexeme == t.lexeme
+}
+
+func (t *tsTerm) matchesWeight(w tsWeight) bool {
+	return t.weights == 0 || t.weights&(1<<w) != 0
+}
+
+// tsNode is a node of the tree of a TSQuery.
+type tsNode struct {
+	op tsOperator
+	// term is set if op is invalid.
+	term tsTerm
+	// followedN is the distance of the followedBy operator.
+	followedN int
+	// l is the left operand of a binary operator, or the operand of the not
+	// operator. r is the right operand of a binary operator.
+	l, r *tsNode
+}
+
+// TSQuery is a text search query: a tree of terms combined by the &, |, ! and
+// <N> operators. It is the value of the TSQUERY SQL type.
+//
+// The text representation of a TSQuery is an infix expression, whose terms are
+// lexemes, optionally followed by a colon and the weights they match or a star
+// to match all the lexemes starting with them:
+//
+//   'fat':AB & ( 'rat' | !'cat' ) <-> 'sup':*
+//
+type TSQuery struct {
+	root *tsNode
+}
+
+// ParseTSQuery parses the text representation of a TSQuery.
+func ParseTSQuery(input string) (TSQuery, error) {
+	p := tsQueryParser{tsScanner: tsScanner{input: input}}
+	p.skipSpaces()
+	if p.done() {
+		// The empty query is valid; it never matches.
+		return TSQuery{}, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return TSQuery{}, err
+	}
+	p.skipSpaces()
+	if !p.done() {
+		return TSQuery{}, p.syntaxError()
+	}
+	return TSQuery{root: root}, nil
+}
+
+type tsQueryParser struct {
+	tsScanner
+}
+
+func (p *tsQueryParser) syntaxError() error {
+	return pgerror.Newf(pgcode.Syntax, "syntax error in tsquery: %q", p.input)
+}
+
+func (p *tsQueryParser) parseOr() (*tsNode, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.peek() != '|' {
+			return l, nil
+		}
+		p.pos++
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = &tsNode{op: or, l: l, r: r}
+	}
+}
+
+func (p *tsQueryParser) parseAnd() (*tsNode, error) {
+	l, err := p.parseFollowedBy()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.peek() != '&' {
+			return l, nil
+		}
+		p.pos++
+		r, err := p.parseFollowedBy()
+		if err != nil {
+			return nil, err
+		}
+		l = &tsNode{op: and, l: l, r: r}
+	}
+}
+
+func (p *tsQueryParser) parseFollowedBy() (*tsNode, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.peek() != '<' {
+			return l, nil
+		}
+		p.pos++
+		n := 1
+		if p.peek() == '-' {
+			p.pos++
+		} else {
+			var ok bool
+			if n, ok = p.scanNumber(); !ok {
+				return nil, p.syntaxError()
+			}
+			if n > maxPosition {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"distance in phrase operator should not be greater than %d", maxPosition)
+			}
+		}
+		if p.peek() != '>' {
+			return nil, p.syntaxError()
+		}
+		p.pos++
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = &tsNode{op: followedBy, followedN: n, l: l, r: r}
+	}
+}
+
+func (p *tsQueryParser) parseUnary() (*tsNode, error) {
+	p.skipSpaces()
+	switch p.peek() {
+	case '!':
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &tsNode{op: not, l: operand}, nil
+	case '(':
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.peek() != ')' {
+			return nil, p.syntaxError()
+		}
+		p.pos++
+		return n, nil
+	}
+	if p.done() || isQueryOperator(p.peek()) {
+		return nil, p.syntaxError()
+	}
+	lexeme, ok := p.scanWord(true /* isQuery */)
+	if !ok || lexeme == "" {
+		return nil, p.syntaxError()
+	}
+	term := tsTerm{lexeme: lexeme}
+	if p.peek() == ':' {
+		p.pos++
+		for !p.done() {
+			if w, ok := parseWeight(p.peek()); ok {
+				term.weights |= 1 << w
+			} else if p.peek() == '*' {
+				term.prefix = true
+			} else {
+				break
+			}
+			p.pos++
+		}
+	}
+	return &tsNode{term: term}, nil
+}
+
+// String returns the text representation of q.
+func (q TSQuery) String() string {
+	if q.root == nil {
+		return ""
+	}
+	var buf strings.Builder
+	q.root.format(&buf, 0 /* parentPrecedence */, false /* rightOfFollowedBy */)
+	return buf.String()
+}
+
+// format writes the text representation of n, which is parenthesized if its
+// operator binds less tightly than the operator of its parent. As in Postgres,
+// followedBy is not associative, so the right operand of a followedBy is
+// parenthesized when it is itself a followedBy.
+func (n *tsNode) format(buf *strings.Builder, parentPrecedence int, rightOfFollowedBy bool) {
+	if n.op == invalid {
+		writeLexeme(buf, n.term.lexeme)
+		if n.term.prefix || n.term.weights != 0 {
+			buf.WriteByte(':')
+			if n.term.prefix {
+				buf.WriteByte('*')
+			}
+			for w := weightA; ; w-- {
+				if n.term.weights&(1<<w) != 0 {
+					buf.WriteByte(weightLetters[w])
+				}
+				if w == weightD {
+					break
+				}
+			}
+		}
+		return
+	}
+	precedence := n.op.precedence()
+	parens := precedence < parentPrecedence || (n.op == followedBy && rightOfFollowedBy)
+	if parens {
+		buf.WriteString("( ")
+	}
+	switch n.op {
+	case not:
+		buf.WriteByte('!')
+		n.l.format(buf, precedence, false /* rightOfFollowedBy */)
+	case and, or, followedBy:
+		n.l.format(buf, precedence, false /* rightOfFollowedBy */)
+		switch n.op {
+		case and:
+			buf.WriteString(" & ")
+		case or:
+			buf.WriteString(" | ")
+		case followedBy:
+			if n.followedN == 1 {
+				buf.WriteString(" <-> ")
+			} else {
+				buf.WriteString(" <")
+				buf.WriteString(strconv.Itoa(n.followedN))
+				buf.WriteString("> ")
+			}
+		}
+		n.r.format(buf, precedence, n.op == followedBy)
+	}
+	if parens {
+		buf.WriteString(" )")
+	}
+}
+
+// Size returns the approximate size in bytes of q.
+func (q TSQuery) Size() uintptr {
+	var size func(n *tsNode) uintptr
+	size = func(n *tsNode) uintptr {
+		if n == nil {
+			return 0
+		}
+		return 64 + uintptr(len(n.term.lexeme)) + size(n.l) + size(n.r)
+	}
+	return size(q.root)
+}
+
+// Compare compares q with other, using their text representations.
+func (q TSQuery) Compare(other TSQuery) int {
+	return strings.Compare(q.String(), other.String())
+}
+
+// terms returns the terms of the query, in order of appearance.
+func (q TSQuery) terms() []*tsTerm {
+	var ret []*tsTerm
+	var walk func(n *tsNode)
+	walk = func(n *tsNode) {
+		if n == nil {
+			return
+		}
+		if n.op == invalid {
+			ret = append(ret, &n.term)
+			return
+		}
+		walk(n.l)
+		walk(n.r)
+	}
+	walk(q.root)
+	return ret
+}
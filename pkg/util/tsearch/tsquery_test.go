@@ -0,0 +1,231 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTSQuery(t *testing.T) {
+	testCases := []struct {
+		input string
+		exp   string
+		err   string
+	}{
+		{"", "", ""},
+		{"a", "'a'", ""},
+		{"'a b'", "'a b'", ""},
+		{"a & b | c", "'a' & 'b' | 'c'", ""},
+		{"a & (b | c)", "'a' & ( 'b' | 'c' )", ""},
+		{"a|b&c", "'a' | 'b' & 'c'", ""},
+		{"!a & !(b | c)", "!'a' & !( 'b' | 'c' )", ""},
+		{"!!a", "!!'a'", ""},
+		{"a <-> b <2> c", "'a' <-> 'b' <2> 'c'", ""},
+		{"a <-> (b <-> c)", "'a' <-> ( 'b' <-> 'c' )", ""},
+		{"a & b <-> c", "'a' & 'b' <-> 'c'", ""},
+		{"(a & b) <-> c", "( 'a' & 'b' ) <-> 'c'", ""},
+		{"a:* & b:AB & c:*ca", "'a':* & 'b':AB & 'c':*AC", ""},
+		{`'it''s' & it\&s`, `'it''s' & 'it&s'`, ""},
+
+		{"a &", "", "syntax error in tsquery"},
+		{"a b", "", "syntax error in tsquery"},
+		{"(a", "", "syntax error in tsquery"},
+		{"a)", "", "syntax error in tsquery"},
+		{"a <x> b", "", "syntax error in tsquery"},
+		{"a <-", "", "syntax error in tsquery"},
+		{"''", "", "syntax error in tsquery"},
+		{"a <20000> b", "", "distance in phrase operator should not be greater than 16383"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.input)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, q.String())
+
+			// The text representation and the encoding round trip.
+			q2, err := ParseTSQuery(q.String())
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, q2.String())
+			q3, err := DecodeTSQuery(EncodeTSQuery(nil, q))
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, q3.String())
+		})
+	}
+}
+
+func TestToTSQuery(t *testing.T) {
+	testCases := []struct {
+		fn    func(config, input string) (TSQuery, error)
+		input string
+		exp   string
+	}{
+		{ToTSQuery, "Fat & Rats", "'fat' & 'rats'"},
+		{ToTSQuery, "'fat rats':*B | cat", "'fat':*B <-> 'rats':*B | 'cat'"},
+		{ToTSQuery, "!'--' & a", "'a'"},
+		{ToTSQuery, "'--'", ""},
+		{PlainToTSQuery, "The Fat Rats", "'the' & 'fat' & 'rats'"},
+		{PlainToTSQuery, "", ""},
+		{PhraseToTSQuery, "The Fat Rats", "'the' <-> 'fat' <-> 'rats'"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := tc.fn(DefaultConfig, tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, q.String())
+		})
+	}
+}
+
+func TestEvalTSQuery(t *testing.T) {
+	const doc = "a:1A fat:2,11 cat:3B sat:4 on:5 mat:6 and:7 ate:8 fat:9C rats:10"
+	testCases := []struct {
+		query string
+		exp   bool
+	}{
+		{"", false},
+		{"cat", true},
+		{"dog", false},
+		{"cat & rats", true},
+		{"cat & dog", false},
+		{"cat | dog", true},
+		{"!dog", true},
+		{"!cat", false},
+		{"cat & !dog", true},
+		{"ra:*", true},
+		{"do:*", false},
+		{"cat:B", true},
+		{"cat:AC", false},
+		{"fat:C & a:A", true},
+		{"fat <-> cat", true},
+		{"cat <-> fat", false},
+		{"fat <2> sat", true},
+		{"fat <-> rats", true},
+		{"a <-> fat <-> cat", true},
+		{"fat:C <-> rats", true},
+		{"fat:C <-> cat", false},
+		{"(cat | rats) <-> sat", true},
+		{"(cat | rats) <-> on", false},
+		{"(fat & mat) <-> cat", false},
+		{"fat <-> !cat", true},
+		{"a <-> !fat", false},
+		{"!a <-> fat", true},
+	}
+	v, err := ParseTSVector(doc)
+	require.NoError(t, err)
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, EvalTSQuery(q, v))
+		})
+	}
+
+	// Lexemes without positions match any phrase.
+	v, err = ParseTSVector("a b")
+	require.NoError(t, err)
+	q, err := ParseTSQuery("a <-> b")
+	require.NoError(t, err)
+	require.True(t, EvalTSQuery(q, v))
+}
+
+func TestRank(t *testing.T) {
+	testCases := []struct {
+		doc           string
+		query         string
+		normalization int
+		exp           string
+	}{
+		{"a:1 b:2", "a", 0, "0.06079271"},
+		{"a:1 b:2", "c", 0, "0"},
+		{"a:1A b:2", "a | b", 0, "0.3343599"},
+		{"a:1 b:2", "a & b", 0, "0.09910322"},
+		{"a:1 b:2", "a & b", 32, "0.09016734"},
+		{"a:1 b:2", "a", 2, "0.03039636"},
+		{"", "a", 0, "0"},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%s/%d", tc.doc, tc.query, tc.normalization), func(t *testing.T) {
+			v, err := ParseTSVector(tc.doc)
+			require.NoError(t, err)
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			rank, err := Rank(DefaultRankWeights, v, q, tc.normalization)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, fmt.Sprintf("%.7g", rank))
+		})
+	}
+}
+
+func TestGetInvertedExpr(t *testing.T) {
+	keys := func(doc string) [][]byte {
+		v, err := ParseTSVector(doc)
+		require.NoError(t, err)
+		return EncodeInvertedIndexKeys(nil, v)
+	}
+	testCases := []struct {
+		query string
+		tight bool
+		// matches are the documents whose keys are in the spans.
+		matches    []string
+		nonMatches []string
+	}{
+		{"cat", true, []string{"cat", "fat cat"}, []string{"", "dog", "cats"}},
+		{"cat:*", true, []string{"cat", "cats", "fat catalog"}, []string{"ca", "dog", "cbt"}},
+		{"cat | dog", true, []string{"cat", "dog"}, []string{"rat"}},
+		{"cat & dog", true, []string{"cat dog"}, []string{"cat", "dog"}},
+		{"cat <-> dog", false, []string{"cat dog"}, []string{"cat", "dog"}},
+		{"cat:A", false, []string{"cat"}, []string{"dog"}},
+		{"cat & !dog", false, []string{"cat", "cat dog"}, []string{"dog"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := ParseTSQuery(tc.query)
+			require.NoError(t, err)
+			expr := q.GetInvertedExpr()
+			require.Equal(t, tc.tight, expr.IsTight())
+			spanExpr, ok := expr.(*inverted.SpanExpression)
+			require.True(t, ok)
+			for _, doc := range tc.matches {
+				ok, err := spanExpr.ContainsKeys(keys(doc))
+				require.NoError(t, err)
+				require.True(t, ok, doc)
+			}
+			for _, doc := range tc.nonMatches {
+				ok, err := spanExpr.ContainsKeys(keys(doc))
+				require.NoError(t, err)
+				require.False(t, ok, doc)
+			}
+		})
+	}
+
+	// Queries containing a ! that can't be evaluated with the index.
+	for _, query := range []string{"!cat", "cat | !dog"} {
+		q, err := ParseTSQuery(query)
+		require.NoError(t, err)
+		require.Equal(t, inverted.NonInvertedColExpression{}, q.GetInvertedExpr())
+	}
+
+	// The keys are the encoded lexemes.
+	require.Equal(t,
+		[][]byte{encoding.EncodeStringAscending(nil, "a"), encoding.EncodeStringAscending(nil, "b")},
+		keys("b:2 a:1"),
+	)
+}
@@ -0,0 +1,384 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// tsWeight is the weight of a position of a lexeme in a document. The weights
+// are labeled A to D, from the most to the least important one. D is the
+// default weight, and is omitted from the text representation of a position.
+type tsWeight byte
+
+const (
+	weightD tsWeight = iota
+	weightC
+	weightB
+	weightA
+)
+
+// weightLetters maps weights to the letters labeling them.
+var weightLetters = [...]byte{weightD: 'D', weightC: 'C', weightB: 'B', weightA: 'A'}
+
+// parseWeight returns the weight labeled by the given letter.
+func parseWeight(c byte) (tsWeight, bool) {
+	switch c {
+	case 'A', 'a':
+		return weightA, true
+	case 'B', 'b':
+		return weightB, true
+	case 'C', 'c':
+		return weightC, true
+	case 'D', 'd':
+		return weightD, true
+	}
+	return 0, false
+}
+
+const (
+	// maxPosition is the largest position of a lexeme; larger positions are
+	// silently clamped to it, as in Postgres.
+	maxPosition = 1<<14 - 1
+	// maxNumPositions is the maximum number of positions stored for a lexeme;
+	// the extra positions are silently dropped, as in Postgres.
+	maxNumPositions = 256
+)
+
+// TSPosition is a position of a lexeme in a document, along with its weight.
+// As in Postgres, the position is stored in the lower 14 bits and the weight in
+// the upper 2 bits.
+type TSPosition uint16
+
+func makeTSPosition(pos int, weight tsWeight) TSPosition {
+	if pos > maxPosition {
+		pos = maxPosition
+	}
+	return TSPosition(uint16(weight)<<14 | uint16(pos))
+}
+
+// Position returns the position of the lexeme, starting at 1.
+func (p TSPosition) Position() int {
+	return int(p & maxPosition)
+}
+
+func (p TSPosition) weight() tsWeight {
+	return tsWeight(p >> 14)
+}
+
+// TSLexeme is a lexeme of a TSVector, along with the positions at which it
+// appears in the document. A lexeme can have no positions.
+type TSLexeme struct {
+	Lexeme    string
+	Positions []TSPosition
+}
+
+// TSVector is the representation of a document optimized for text search: the
+// list of its distinct lexemes, sorted by byte order. It is the value of the
+// TSVECTOR SQL type.
+//
+// The text representation of a TSVector is a space separated list of lexemes,
+// each of them optionally followed by a colon and a comma separated list of
+// positions with an optional weight:
+//
+//   'a':1A 'fat':2 'rat':3,5C
+//
+type TSVector []TSLexeme
+
+// ParseTSVector parses the text representation of a TSVector.
+func ParseTSVector(input string) (TSVector, error) {
+	var ret TSVector
+	s := tsScanner{input: input}
+	for {
+		s.skipSpaces()
+		if s.done() {
+			break
+		}
+		lexeme, ok := s.scanWord(false /* isQuery */)
+		if !ok || lexeme == "" {
+			return nil, tsVectorSyntaxError(input)
+		}
+		l := TSLexeme{Lexeme: lexeme}
+		if s.peek() == ':' {
+			s.pos++
+			for {
+				n, ok := s.scanNumber()
+				if !ok {
+					return nil, tsVectorSyntaxError(input)
+				}
+				if n == 0 {
+					return nil, pgerror.Newf(pgcode.Syntax,
+						"wrong position info in tsvector: %q", input)
+				}
+				weight := weightD
+				if w, ok := parseWeight(s.peek()); ok {
+					weight = w
+					s.pos++
+				} else if s.peek() == '*' {
+					s.pos++
+				}
+				l.Positions = append(l.Positions, makeTSPosition(n, weight))
+				if s.peek() != ',' {
+					break
+				}
+				s.pos++
+			}
+		}
+		if !s.done() && !s.atSpace() {
+			return nil, tsVectorSyntaxError(input)
+		}
+		ret = append(ret, l)
+	}
+	return ret.normalize(), nil
+}
+
+func tsVectorSyntaxError(input string) error {
+	return pgerror.Newf(pgcode.Syntax, "syntax error in tsvector: %q", input)
+}
+
+// normalize sorts the lexemes of v, merging the duplicate ones, and sorts their
+// positions, keeping the highest weight of the duplicate positions.
+func (v TSVector) normalize() TSVector {
+	if len(v) == 0 {
+		return v
+	}
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].Lexeme < v[j].Lexeme
+	})
+	ret := v[:1]
+	for _, l := range v[1:] {
+		last := &ret[len(ret)-1]
+		if l.Lexeme == last.Lexeme {
+			last.Positions = append(last.Positions, l.Positions...)
+			continue
+		}
+		ret = append(ret, l)
+	}
+	for i := range ret {
+		ret[i].Positions = normalizePositions(ret[i].Positions)
+	}
+	return ret
+}
+
+func normalizePositions(positions []TSPosition) []TSPosition {
+	if len(positions) == 0 {
+		return nil
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Position() != positions[j].Position() {
+			return positions[i].Position() < positions[j].Position()
+		}
+		return positions[i].weight() > positions[j].weight()
+	})
+	ret := positions[:1]
+	for _, p := range positions[1:] {
+		if p.Position() != ret[len(ret)-1].Position() {
+			ret = append(ret, p)
+		}
+	}
+	if len(ret) > maxNumPositions {
+		ret = ret[:maxNumPositions]
+	}
+	return ret
+}
+
+// String returns the text representation of v.
+func (v TSVector) String() string {
+	var buf strings.Builder
+	for i, l := range v {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		writeLexeme(&buf, l.Lexeme)
+		for j, p := range l.Positions {
+			if j == 0 {
+				buf.WriteByte(':')
+			} else {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(strconv.Itoa(p.Position()))
+			if w := p.weight(); w != weightD {
+				buf.WriteByte(weightLetters[w])
+			}
+		}
+	}
+	return buf.String()
+}
+
+// Size returns the approximate size in bytes of v.
+func (v TSVector) Size() uintptr {
+	var size uintptr
+	for _, l := range v {
+		size += uintptr(len(l.Lexeme)) + uintptr(len(l.Positions))*2 + 40
+	}
+	return size
+}
+
+// Compare compares v with other, ordering the vectors lexeme by lexeme.
+func (v TSVector) Compare(other TSVector) int {
+	for i := 0; i < len(v) && i < len(other); i++ {
+		if c := strings.Compare(v[i].Lexeme, other[i].Lexeme); c != 0 {
+			return c
+		}
+		a, b := v[i].Positions, other[i].Positions
+		for j := 0; j < len(a) && j < len(b); j++ {
+			if a[j] != b[j] {
+				if a[j] < b[j] {
+					return -1
+				}
+				return 1
+			}
+		}
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(v) < len(other):
+		return -1
+	case len(v) > len(other):
+		return 1
+	}
+	return 0
+}
+
+// writeLexeme writes the quoted text representation of a lexeme, in which
+// quotes and backslashes are escaped.
+func writeLexeme(buf *strings.Builder, lexeme string) {
+	buf.WriteByte('\'')
+	for i := 0; i < len(lexeme); i++ {
+		switch c := lexeme[i]; c {
+		case '\'':
+			buf.WriteString("''")
+		case '\\':
+			buf.WriteString(`\\`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte('\'')
+}
+
+// tsScanner scans the text representation of TSVectors and TSQueries.
+type tsScanner struct {
+	input string
+	pos   int
+}
+
+func (s *tsScanner) done() bool {
+	return s.pos >= len(s.input)
+}
+
+func (s *tsScanner) peek() byte {
+	if s.done() {
+		return 0
+	}
+	return s.input[s.pos]
+}
+
+func (s *tsScanner) atSpace() bool {
+	r, _ := utf8.DecodeRuneInString(s.input[s.pos:])
+	return unicode.IsSpace(r)
+}
+
+func (s *tsScanner) skipSpaces() {
+	for !s.done() && s.atSpace() {
+		_, n := utf8.DecodeRuneInString(s.input[s.pos:])
+		s.pos += n
+	}
+}
+
+// isQueryOperator returns whether c is a character with a special meaning in
+// the text representation of TSQueries.
+func isQueryOperator(c byte) bool {
+	switch c {
+	case '!', '&', '|', '(', ')', '<':
+		return true
+	}
+	return false
+}
+
+// scanWord scans a lexeme, which is either quoted with single quotes or ends
+// at the next space or colon. In queries, the lexeme also ends at the next
+// operator. A backslash escapes the next character, and two single quotes
+// stand for a single quote in quoted lexemes.
+func (s *tsScanner) scanWord(isQuery bool) (string, bool) {
+	var buf strings.Builder
+	if s.peek() == '\'' {
+		s.pos++
+		for {
+			if s.done() {
+				return "", false
+			}
+			c := s.input[s.pos]
+			s.pos++
+			switch c {
+			case '\\':
+				if s.done() {
+					return "", false
+				}
+				buf.WriteByte(s.input[s.pos])
+				s.pos++
+			case '\'':
+				if s.peek() != '\'' {
+					return buf.String(), true
+				}
+				buf.WriteByte('\'')
+				s.pos++
+			default:
+				buf.WriteByte(c)
+			}
+		}
+	}
+	for !s.done() && !s.atSpace() {
+		c := s.input[s.pos]
+		if c == ':' || (isQuery && isQueryOperator(c)) {
+			break
+		}
+		if c == '\\' {
+			s.pos++
+			if s.done() {
+				return "", false
+			}
+			c = s.input[s.pos]
+		}
+		buf.WriteByte(c)
+		s.pos++
+	}
+	return buf.String(), true
+}
+
+// scanNumber scans a non-negative decimal integer.
+func (s *tsScanner) scanNumber() (int, bool) {
+	start := s.pos
+	for !s.done() && s.input[s.pos] >= '0' && s.input[s.pos] <= '9' {
+		s.pos++
+	}
+	if s.pos == start {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s.input[start:s.pos])
+	if err != nil {
+		// The number overflows; clamp it.
+		n = maxPosition
+	}
+	return n, true
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTSVector(t *testing.T) {
+	testCases := []struct {
+		input string
+		exp   string
+		err   string
+	}{
+		{"", "", ""},
+		{"  ", "", ""},
+		{"a", "'a'", ""},
+		{"b a", "'a' 'b'", ""},
+		{"a b a", "'a' 'b'", ""},
+		{"The Fat Rats", "'Fat' 'Rats' 'The'", ""},
+		{"a:1 b:2", "'a':1 'b':2", ""},
+		{"a:3,1,2 a:2", "'a':1,2,3", ""},
+		{"a:1A,2b,3C,4d", "'a':1A,2B,3C,4", ""},
+		{"a:1A a:1B", "'a':1A", ""},
+		{"a:1 a", "'a':1", ""},
+		{"a:20000", "'a':16383", ""},
+		{`'a b' 'it''s' 'c\'d' e\ f`, `'a b' 'c''d' 'e f' 'it''s'`, ""},
+		{`'back\\slash'`, `'back\\slash'`, ""},
+
+		{"''", "", "syntax error in tsvector"},
+		{"'a", "", "syntax error in tsvector"},
+		{"a:", "", "syntax error in tsvector"},
+		{"a:x", "", "syntax error in tsvector"},
+		{"a:1,", "", "syntax error in tsvector"},
+		{"a:1Ab", "", "syntax error in tsvector"},
+		{"a:0", "", "wrong position info in tsvector"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			v, err := ParseTSVector(tc.input)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, v.String())
+
+			// The text representation round trips.
+			v2, err := ParseTSVector(v.String())
+			require.NoError(t, err)
+			require.Equal(t, 0, v.Compare(v2))
+		})
+	}
+}
+
+func TestDocumentToTSVector(t *testing.T) {
+	testCases := []struct {
+		document string
+		exp      string
+	}{
+		{"", ""},
+		{"The fat rat ate the fat cat!", "'ate':4 'cat':7 'fat':2,6 'rat':3 'the':1,5"},
+		{"It's 2021, ÉTÉ", "'2021':3 'it':1 's':2 'été':4"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.document, func(t *testing.T) {
+			v, err := DocumentToTSVector(DefaultConfig, tc.document)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, v.String())
+		})
+	}
+
+	_, err := DocumentToTSVector("english", "a")
+	require.EqualError(t, err, `text search configuration "english" does not exist`)
+}
+
+func TestTSVectorEncoding(t *testing.T) {
+	for _, input := range []string{"", "a", "a:1A,2 b 'c d':3,16383C"} {
+		v, err := ParseTSVector(input)
+		require.NoError(t, err)
+		decoded, err := DecodeTSVector(EncodeTSVector(nil, v))
+		require.NoError(t, err)
+		require.Equal(t, v.String(), decoded.String())
+	}
+}